@@ -30,6 +30,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type AccessByKeyReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
+	Subtopic             string   `protobuf:"bytes,3,opt,name=subtopic,proto3" json:"subtopic,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,6 +83,13 @@ func (m *AccessByKeyReq) GetChanID() string {
 	return ""
 }
 
+func (m *AccessByKeyReq) GetSubtopic() string {
+	if m != nil {
+		return m.Subtopic
+	}
+	return ""
+}
+
 type ChannelOwnerReq struct {
 	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
@@ -231,9 +239,228 @@ func (m *ChannelID) GetValue() string {
 	return ""
 }
 
+// ChannelSchema carries JSON encoded channel payload schema. Empty value
+// means that the channel accepts every payload.
+type ChannelSchema struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelSchema) Reset()         { *m = ChannelSchema{} }
+func (m *ChannelSchema) String() string { return proto.CompactTextString(m) }
+func (*ChannelSchema) ProtoMessage()    {}
+func (*ChannelSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{4}
+}
+func (m *ChannelSchema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelSchema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelSchema.Merge(m, src)
+}
+func (m *ChannelSchema) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelSchema proto.InternalMessageInfo
+
+func (m *ChannelSchema) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+// ThingCodec carries JSON encoded payload codec of the thing profile. Empty
+// value means that the thing payloads aren't decoded. The content type and
+// transformer are the profile defaults of the payloads published without
+// the content type.
+type ThingCodec struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	ContentType          string   `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Transformer          string   `protobuf:"bytes,3,opt,name=transformer,proto3" json:"transformer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThingCodec) Reset()         { *m = ThingCodec{} }
+func (m *ThingCodec) String() string { return proto.CompactTextString(m) }
+func (*ThingCodec) ProtoMessage()    {}
+func (*ThingCodec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{5}
+}
+func (m *ThingCodec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThingCodec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThingCodec.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThingCodec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThingCodec.Merge(m, src)
+}
+func (m *ThingCodec) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThingCodec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThingCodec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThingCodec proto.InternalMessageInfo
+
+func (m *ThingCodec) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ThingCodec) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *ThingCodec) GetTransformer() string {
+	if m != nil {
+		return m.Transformer
+	}
+	return ""
+}
+
+type GroupChannelsReq struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	GroupID              string   `protobuf:"bytes,2,opt,name=groupID,proto3" json:"groupID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupChannelsReq) Reset()         { *m = GroupChannelsReq{} }
+func (m *GroupChannelsReq) String() string { return proto.CompactTextString(m) }
+func (*GroupChannelsReq) ProtoMessage()    {}
+func (*GroupChannelsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{6}
+}
+func (m *GroupChannelsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GroupChannelsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GroupChannelsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GroupChannelsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupChannelsReq.Merge(m, src)
+}
+func (m *GroupChannelsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *GroupChannelsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupChannelsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupChannelsReq proto.InternalMessageInfo
+
+func (m *GroupChannelsReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *GroupChannelsReq) GetGroupID() string {
+	if m != nil {
+		return m.GroupID
+	}
+	return ""
+}
+
+type ChannelIDs struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelIDs) Reset()         { *m = ChannelIDs{} }
+func (m *ChannelIDs) String() string { return proto.CompactTextString(m) }
+func (*ChannelIDs) ProtoMessage()    {}
+func (*ChannelIDs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{7}
+}
+func (m *ChannelIDs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelIDs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelIDs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelIDs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelIDs.Merge(m, src)
+}
+func (m *ChannelIDs) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelIDs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelIDs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelIDs proto.InternalMessageInfo
+
+func (m *ChannelIDs) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type AccessByIDReq struct {
 	ThingID              string   `protobuf:"bytes,1,opt,name=thingID,proto3" json:"thingID,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
+	Subtopic             string   `protobuf:"bytes,3,opt,name=subtopic,proto3" json:"subtopic,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -243,7 +470,7 @@ func (m *AccessByIDReq) Reset()         { *m = AccessByIDReq{} }
 func (m *AccessByIDReq) String() string { return proto.CompactTextString(m) }
 func (*AccessByIDReq) ProtoMessage()    {}
 func (*AccessByIDReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{4}
+	return fileDescriptor_8bbd6f3875b0e874, []int{8}
 }
 func (m *AccessByIDReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -286,6 +513,13 @@ func (m *AccessByIDReq) GetChanID() string {
 	return ""
 }
 
+func (m *AccessByIDReq) GetSubtopic() string {
+	if m != nil {
+		return m.Subtopic
+	}
+	return ""
+}
+
 // If a token is not carrying any information itself, the type
 // field can be used to determine how to validate the token.
 // Also, different tokens can be encoded in different ways.
@@ -300,7 +534,7 @@ func (m *Token) Reset()         { *m = Token{} }
 func (m *Token) String() string { return proto.CompactTextString(m) }
 func (*Token) ProtoMessage()    {}
 func (*Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{5}
+	return fileDescriptor_8bbd6f3875b0e874, []int{9}
 }
 func (m *Token) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -348,7 +582,7 @@ func (m *UserIdentity) Reset()         { *m = UserIdentity{} }
 func (m *UserIdentity) String() string { return proto.CompactTextString(m) }
 func (*UserIdentity) ProtoMessage()    {}
 func (*UserIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{6}
+	return fileDescriptor_8bbd6f3875b0e874, []int{10}
 }
 func (m *UserIdentity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -404,7 +638,7 @@ func (m *IssueReq) Reset()         { *m = IssueReq{} }
 func (m *IssueReq) String() string { return proto.CompactTextString(m) }
 func (*IssueReq) ProtoMessage()    {}
 func (*IssueReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{7}
+	return fileDescriptor_8bbd6f3875b0e874, []int{11}
 }
 func (m *IssueReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -465,7 +699,7 @@ func (m *AuthorizeReq) Reset()         { *m = AuthorizeReq{} }
 func (m *AuthorizeReq) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeReq) ProtoMessage()    {}
 func (*AuthorizeReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{8}
+	return fileDescriptor_8bbd6f3875b0e874, []int{12}
 }
 func (m *AuthorizeReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -512,7 +746,7 @@ func (m *AuthorizeRes) Reset()         { *m = AuthorizeRes{} }
 func (m *AuthorizeRes) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeRes) ProtoMessage()    {}
 func (*AuthorizeRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{9}
+	return fileDescriptor_8bbd6f3875b0e874, []int{13}
 }
 func (m *AuthorizeRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -560,7 +794,7 @@ func (m *AccessGroupReq) Reset()         { *m = AccessGroupReq{} }
 func (m *AccessGroupReq) String() string { return proto.CompactTextString(m) }
 func (*AccessGroupReq) ProtoMessage()    {}
 func (*AccessGroupReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{10}
+	return fileDescriptor_8bbd6f3875b0e874, []int{14}
 }
 func (m *AccessGroupReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -616,7 +850,7 @@ func (m *Assignment) Reset()         { *m = Assignment{} }
 func (m *Assignment) String() string { return proto.CompactTextString(m) }
 func (*Assignment) ProtoMessage()    {}
 func (*Assignment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{11}
+	return fileDescriptor_8bbd6f3875b0e874, []int{15}
 }
 func (m *Assignment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -681,7 +915,7 @@ func (m *MembersReq) Reset()         { *m = MembersReq{} }
 func (m *MembersReq) String() string { return proto.CompactTextString(m) }
 func (*MembersReq) ProtoMessage()    {}
 func (*MembersReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{12}
+	return fileDescriptor_8bbd6f3875b0e874, []int{16}
 }
 func (m *MembersReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -760,7 +994,7 @@ func (m *MembersRes) Reset()         { *m = MembersRes{} }
 func (m *MembersRes) String() string { return proto.CompactTextString(m) }
 func (*MembersRes) ProtoMessage()    {}
 func (*MembersRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{13}
+	return fileDescriptor_8bbd6f3875b0e874, []int{17}
 }
 func (m *MembersRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -837,7 +1071,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{14}
+	return fileDescriptor_8bbd6f3875b0e874, []int{18}
 }
 func (m *User) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -898,7 +1132,7 @@ func (m *UsersByEmailsReq) Reset()         { *m = UsersByEmailsReq{} }
 func (m *UsersByEmailsReq) String() string { return proto.CompactTextString(m) }
 func (*UsersByEmailsReq) ProtoMessage()    {}
 func (*UsersByEmailsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{15}
+	return fileDescriptor_8bbd6f3875b0e874, []int{19}
 }
 func (m *UsersByEmailsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -945,7 +1179,7 @@ func (m *UsersByIDsReq) Reset()         { *m = UsersByIDsReq{} }
 func (m *UsersByIDsReq) String() string { return proto.CompactTextString(m) }
 func (*UsersByIDsReq) ProtoMessage()    {}
 func (*UsersByIDsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{16}
+	return fileDescriptor_8bbd6f3875b0e874, []int{20}
 }
 func (m *UsersByIDsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -992,7 +1226,7 @@ func (m *UsersRes) Reset()         { *m = UsersRes{} }
 func (m *UsersRes) String() string { return proto.CompactTextString(m) }
 func (*UsersRes) ProtoMessage()    {}
 func (*UsersRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{17}
+	return fileDescriptor_8bbd6f3875b0e874, []int{21}
 }
 func (m *UsersRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1042,7 +1276,7 @@ func (m *Group) Reset()         { *m = Group{} }
 func (m *Group) String() string { return proto.CompactTextString(m) }
 func (*Group) ProtoMessage()    {}
 func (*Group) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{18}
+	return fileDescriptor_8bbd6f3875b0e874, []int{22}
 }
 func (m *Group) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1110,7 +1344,7 @@ func (m *GroupsReq) Reset()         { *m = GroupsReq{} }
 func (m *GroupsReq) String() string { return proto.CompactTextString(m) }
 func (*GroupsReq) ProtoMessage()    {}
 func (*GroupsReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{19}
+	return fileDescriptor_8bbd6f3875b0e874, []int{23}
 }
 func (m *GroupsReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1157,7 +1391,7 @@ func (m *GroupsRes) Reset()         { *m = GroupsRes{} }
 func (m *GroupsRes) String() string { return proto.CompactTextString(m) }
 func (*GroupsRes) ProtoMessage()    {}
 func (*GroupsRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{20}
+	return fileDescriptor_8bbd6f3875b0e874, []int{24}
 }
 func (m *GroupsRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1198,6 +1432,10 @@ func init() {
 	proto.RegisterType((*ChannelOwnerReq)(nil), "mainflux.ChannelOwnerReq")
 	proto.RegisterType((*ThingID)(nil), "mainflux.ThingID")
 	proto.RegisterType((*ChannelID)(nil), "mainflux.ChannelID")
+	proto.RegisterType((*ChannelSchema)(nil), "mainflux.ChannelSchema")
+	proto.RegisterType((*ThingCodec)(nil), "mainflux.ThingCodec")
+	proto.RegisterType((*GroupChannelsReq)(nil), "mainflux.GroupChannelsReq")
+	proto.RegisterType((*ChannelIDs)(nil), "mainflux.ChannelIDs")
 	proto.RegisterType((*AccessByIDReq)(nil), "mainflux.AccessByIDReq")
 	proto.RegisterType((*Token)(nil), "mainflux.Token")
 	proto.RegisterType((*UserIdentity)(nil), "mainflux.UserIdentity")
@@ -1220,60 +1458,69 @@ func init() {
 func init() { proto.RegisterFile("auth.proto", fileDescriptor_8bbd6f3875b0e874) }
 
 var fileDescriptor_8bbd6f3875b0e874 = []byte{
-	// 837 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcd, 0x6e, 0xf3, 0x44,
-	0x14, 0x8d, 0xf3, 0x9f, 0xdb, 0x2f, 0x69, 0x18, 0xaa, 0x60, 0x8c, 0x1a, 0xd2, 0x51, 0x25, 0x2a,
-	0x16, 0x6e, 0x15, 0x8a, 0x60, 0x01, 0x2d, 0x49, 0x5d, 0x55, 0x16, 0x42, 0x48, 0xa6, 0x48, 0x6c,
-	0xdd, 0x64, 0x92, 0x58, 0xc4, 0x76, 0xf0, 0x8c, 0x0b, 0x61, 0xc1, 0x53, 0xb0, 0xe0, 0x91, 0x90,
-	0xd8, 0xf0, 0x08, 0xa8, 0x6c, 0x79, 0x08, 0x34, 0x3f, 0x8e, 0x27, 0xbf, 0x2a, 0xec, 0xe6, 0xcc,
-	0xdc, 0x7b, 0xee, 0xbd, 0xc7, 0x37, 0x27, 0x00, 0x7e, 0xca, 0x66, 0xf6, 0x22, 0x89, 0x59, 0x8c,
-	0xea, 0xa1, 0x1f, 0x44, 0x93, 0x79, 0xfa, 0x93, 0xf5, 0xde, 0x34, 0x8e, 0xa7, 0x73, 0x72, 0x29,
-	0xee, 0x9f, 0xd2, 0xc9, 0x25, 0x09, 0x17, 0x6c, 0x29, 0xc3, 0xf0, 0x0d, 0xb4, 0x06, 0xa3, 0x11,
-	0xa1, 0x74, 0xb8, 0xfc, 0x92, 0x2c, 0x3d, 0xf2, 0x03, 0x3a, 0x81, 0x0a, 0x8b, 0xbf, 0x27, 0x91,
-	0x69, 0xf4, 0x8c, 0x8b, 0x86, 0x27, 0x01, 0xea, 0x40, 0x75, 0x34, 0xf3, 0x23, 0xd7, 0x31, 0x8b,
-	0xe2, 0x5a, 0x21, 0x7c, 0x0b, 0xc7, 0x77, 0x33, 0x3f, 0x8a, 0xc8, 0xfc, 0xeb, 0x1f, 0x23, 0x92,
-	0x28, 0x82, 0x98, 0x9f, 0x33, 0x02, 0x01, 0xf6, 0x12, 0xbc, 0x0f, 0xb5, 0xc7, 0x59, 0x10, 0x4d,
-	0x5d, 0x87, 0x27, 0x3e, 0xfb, 0xf3, 0x94, 0x64, 0x89, 0x02, 0xe0, 0x33, 0x68, 0xa8, 0x0a, 0x7b,
-	0x43, 0x06, 0xd0, 0xcc, 0x86, 0x70, 0x1d, 0xde, 0x82, 0x09, 0x35, 0x26, 0x49, 0x55, 0x60, 0x06,
-	0xf7, 0xb6, 0x71, 0x0a, 0x95, 0x47, 0x31, 0xe8, 0xee, 0x0a, 0xd7, 0xf0, 0xe6, 0x5b, 0x4a, 0x12,
-	0x77, 0x4c, 0x22, 0x16, 0xb0, 0x25, 0x6a, 0x41, 0x31, 0x18, 0xab, 0x90, 0x62, 0x30, 0xe6, 0x59,
-	0x24, 0xf4, 0x83, 0xb9, 0x62, 0x95, 0x00, 0x3b, 0x50, 0x77, 0x29, 0x4d, 0x09, 0x6f, 0xe9, 0x55,
-	0x19, 0x08, 0x41, 0x99, 0x2d, 0x17, 0xc4, 0x2c, 0xf5, 0x8c, 0x8b, 0xa6, 0x27, 0xce, 0xf8, 0x1c,
-	0xde, 0x0c, 0x52, 0x36, 0x8b, 0x93, 0xe0, 0x67, 0xa2, 0xf4, 0x95, 0x99, 0x86, 0x5e, 0xcb, 0x5e,
-	0x8b, 0xa2, 0xa8, 0x2b, 0xb7, 0x41, 0x60, 0x59, 0xb7, 0xee, 0x69, 0x37, 0xf8, 0x8b, 0xec, 0xc3,
-	0x3f, 0x24, 0x71, 0xba, 0xd8, 0xff, 0xe1, 0x4d, 0xa8, 0x4d, 0x79, 0xc4, 0x4a, 0xb1, 0x0c, 0xe2,
-	0xef, 0x00, 0x06, 0x94, 0x06, 0xd3, 0x28, 0x24, 0x11, 0xfb, 0xaf, 0xd9, 0xc8, 0x82, 0x7a, 0x48,
-	0xc2, 0x27, 0x92, 0xb8, 0x8e, 0x98, 0xb6, 0xe1, 0xad, 0x30, 0xfe, 0x05, 0xe0, 0x2b, 0x71, 0xa6,
-	0xff, 0xa3, 0x2f, 0xfe, 0x89, 0xe3, 0xc9, 0x84, 0x12, 0x26, 0x78, 0xcb, 0x9e, 0x42, 0x9c, 0x67,
-	0x1e, 0x84, 0x01, 0x33, 0xcb, 0xe2, 0x5a, 0x82, 0x95, 0xe2, 0x15, 0x41, 0x22, 0x15, 0xd7, 0xeb,
-	0x53, 0x59, 0x9f, 0xf9, 0x52, 0xef, 0xb2, 0x27, 0x81, 0x56, 0xa5, 0xb8, 0xbb, 0x4a, 0x69, 0x57,
-	0x95, 0x72, 0x5e, 0x85, 0x4f, 0x20, 0x27, 0xa6, 0x66, 0xa5, 0x57, 0xe2, 0x13, 0x28, 0x88, 0x1d,
-	0x28, 0xf3, 0x6d, 0x7b, 0xe5, 0xce, 0x74, 0xa0, 0x4a, 0x99, 0xcf, 0x52, 0xaa, 0x74, 0x54, 0x08,
-	0x7f, 0x08, 0x6d, 0xce, 0x42, 0x87, 0xcb, 0x7b, 0x1e, 0x27, 0xb4, 0xec, 0x40, 0x55, 0x24, 0x51,
-	0xd3, 0x10, 0x25, 0x15, 0xc2, 0x67, 0xd0, 0x54, 0xb1, 0xae, 0x23, 0x02, 0xdb, 0x50, 0x0a, 0xc6,
-	0x59, 0x14, 0x3f, 0xe2, 0x2b, 0xa8, 0x8b, 0x10, 0x2e, 0xc9, 0x39, 0x54, 0x52, 0x7e, 0x16, 0xef,
-	0x47, 0xfd, 0x96, 0x9d, 0x99, 0x8d, 0xcd, 0x43, 0x3c, 0xf9, 0x88, 0xa7, 0x50, 0x11, 0xcb, 0xb5,
-	0x35, 0x87, 0x09, 0x35, 0x61, 0x0a, 0xf9, 0xb7, 0x53, 0x90, 0xeb, 0x14, 0xf9, 0x21, 0x51, 0x93,
-	0x88, 0x33, 0xea, 0xc1, 0xd1, 0x98, 0xd0, 0x51, 0x12, 0x2c, 0x58, 0x10, 0x47, 0x4a, 0x42, 0xfd,
-	0x0a, 0x9f, 0x42, 0x43, 0x14, 0xda, 0xd3, 0xf9, 0x75, 0xfe, 0x4c, 0xd1, 0x07, 0x50, 0x15, 0x8b,
-	0x92, 0xf5, 0x7e, 0x9c, 0xf7, 0x2e, 0x7f, 0x09, 0xea, 0xb9, 0xff, 0x47, 0x11, 0x9a, 0xc2, 0x99,
-	0xe8, 0x37, 0x24, 0x79, 0x0e, 0x46, 0x04, 0xdd, 0x42, 0xeb, 0xce, 0x8f, 0x34, 0xbb, 0x44, 0x66,
-	0x9e, 0xbc, 0xee, 0xa2, 0xd6, 0x5b, 0xf9, 0x8b, 0xb2, 0x37, 0x5c, 0x40, 0xf7, 0xd0, 0x72, 0xa9,
-	0x6e, 0x97, 0xe8, 0xdd, 0x3c, 0x6c, 0xc3, 0x46, 0xad, 0x8e, 0x2d, 0x7d, 0xdb, 0xce, 0x7c, 0xdb,
-	0xbe, 0xe7, 0xbe, 0x8d, 0x0b, 0x68, 0x08, 0x4d, 0xad, 0x0f, 0xd7, 0x41, 0xef, 0x6c, 0xb7, 0x21,
-	0x7c, 0xf0, 0x00, 0xc7, 0x15, 0xd4, 0xa5, 0x99, 0x4d, 0x96, 0x48, 0x93, 0x40, 0x78, 0xe0, 0xee,
-	0xe6, 0x3f, 0x83, 0xd6, 0x03, 0x61, 0x52, 0x48, 0xb1, 0x26, 0xe8, 0xed, 0x0d, 0xe9, 0xb8, 0xfc,
-	0xd6, 0x8e, 0x4b, 0x8a, 0x0b, 0xfd, 0x5f, 0x0d, 0xe9, 0xa0, 0x2b, 0x31, 0x6f, 0xa0, 0xf9, 0x40,
-	0x58, 0xbe, 0x74, 0xfa, 0x10, 0x6b, 0xab, 0x68, 0xa1, 0x8d, 0x07, 0x41, 0x88, 0x1c, 0x68, 0xe7,
-	0xf9, 0x72, 0xc1, 0x91, 0xb5, 0x45, 0xb1, 0xda, 0xfc, 0xdd, 0x2c, 0xfd, 0x7f, 0x8a, 0x70, 0xc4,
-	0x6d, 0x33, 0xeb, 0xca, 0x86, 0x8a, 0x70, 0x6c, 0xa4, 0x85, 0x67, 0x16, 0x6e, 0x6d, 0xea, 0x84,
-	0x0b, 0xe8, 0xe3, 0x43, 0x32, 0x76, 0xd6, 0x4b, 0x66, 0x7f, 0x1e, 0xb8, 0x80, 0x3e, 0x87, 0xc6,
-	0xca, 0xac, 0x91, 0x16, 0xa6, 0xfb, 0xfc, 0x81, 0x8f, 0xe7, 0x68, 0x8b, 0x28, 0x7f, 0x61, 0x5b,
-	0x8b, 0x98, 0xb9, 0xfa, 0x01, 0x96, 0x4f, 0xa1, 0x2a, 0xfd, 0x1b, 0x9d, 0x68, 0xd9, 0x2b, 0x47,
-	0x3f, 0x90, 0xf9, 0x09, 0xd4, 0x94, 0x3f, 0xea, 0xa9, 0xb9, 0x65, 0x5b, 0xbb, 0x6e, 0x29, 0x2e,
-	0x0c, 0xdb, 0xbf, 0xbf, 0x74, 0x8d, 0x3f, 0x5f, 0xba, 0xc6, 0x5f, 0x2f, 0x5d, 0xe3, 0xb7, 0xbf,
-	0xbb, 0x85, 0xa7, 0xaa, 0x20, 0xff, 0xe8, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x71, 0x01, 0x53,
-	0xef, 0xbb, 0x08, 0x00, 0x00,
+	// 984 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdb, 0x6e, 0x23, 0x45,
+	0x13, 0xb6, 0x1d, 0x9f, 0x52, 0x89, 0xbd, 0xfe, 0x7b, 0x57, 0xde, 0xf9, 0x07, 0xad, 0x49, 0x5a,
+	0x41, 0x20, 0x2e, 0xbc, 0xab, 0xb0, 0x08, 0x84, 0x80, 0x25, 0xce, 0x84, 0x68, 0x84, 0x10, 0x92,
+	0x37, 0x2b, 0x21, 0x24, 0x84, 0x26, 0xe3, 0xb6, 0x3d, 0xc2, 0x33, 0x63, 0xa6, 0x7b, 0x16, 0xcc,
+	0x05, 0x2f, 0x01, 0x17, 0x3c, 0x12, 0x97, 0x3c, 0x02, 0x0a, 0xb7, 0x3c, 0x04, 0xea, 0xea, 0x6e,
+	0x4f, 0xfb, 0xa8, 0x65, 0xef, 0xba, 0xaa, 0xab, 0xab, 0xaa, 0xbf, 0x3a, 0x7c, 0x00, 0x41, 0x2e,
+	0xa6, 0xfd, 0x79, 0x96, 0x8a, 0x94, 0x34, 0xe3, 0x20, 0x4a, 0xc6, 0xb3, 0xfc, 0x27, 0xf7, 0x8d,
+	0x49, 0x9a, 0x4e, 0x66, 0xec, 0x31, 0xea, 0x6f, 0xf3, 0xf1, 0x63, 0x16, 0xcf, 0xc5, 0x42, 0x99,
+	0xd1, 0x6f, 0xa0, 0x7d, 0x11, 0x86, 0x8c, 0xf3, 0xc1, 0xe2, 0x0b, 0xb6, 0x18, 0xb2, 0x1f, 0xc8,
+	0x03, 0xa8, 0x89, 0xf4, 0x7b, 0x96, 0x38, 0xe5, 0x93, 0xf2, 0x3b, 0x87, 0x43, 0x25, 0x90, 0x2e,
+	0xd4, 0xc3, 0x69, 0x90, 0xf8, 0x9e, 0x53, 0x41, 0xb5, 0x96, 0x88, 0x0b, 0x4d, 0x9e, 0xdf, 0x8a,
+	0x74, 0x1e, 0x85, 0xce, 0x01, 0xde, 0x2c, 0x65, 0xfa, 0x0c, 0xee, 0x5d, 0x4e, 0x83, 0x24, 0x61,
+	0xb3, 0xaf, 0x7e, 0x4c, 0x58, 0xa6, 0x9d, 0xa7, 0xf2, 0x6c, 0x9c, 0xa3, 0xb0, 0xcb, 0x39, 0x7d,
+	0x13, 0x1a, 0x37, 0xd3, 0x28, 0x99, 0xf8, 0x9e, 0x7c, 0xf8, 0x32, 0x98, 0xe5, 0xcc, 0x3c, 0x44,
+	0x81, 0x9e, 0xc2, 0xa1, 0x8e, 0xb0, 0xd3, 0xe4, 0x2d, 0x68, 0x69, 0x93, 0xe7, 0xe1, 0x94, 0xc5,
+	0xc1, 0xaa, 0xd9, 0xb1, 0x31, 0x9b, 0x00, 0x60, 0xa8, 0xcb, 0x74, 0xc4, 0xc2, 0xed, 0x36, 0xe4,
+	0x14, 0x8e, 0xc3, 0x34, 0x11, 0x2c, 0x11, 0xdf, 0x89, 0xc5, 0x9c, 0xe9, 0x64, 0x8f, 0xb4, 0xee,
+	0x66, 0x31, 0x67, 0xe4, 0x04, 0x8e, 0x44, 0x16, 0x24, 0x7c, 0x9c, 0x66, 0x31, 0xcb, 0x34, 0x22,
+	0xb6, 0x8a, 0x0e, 0xa0, 0x73, 0x9d, 0xa5, 0xf9, 0x5c, 0x27, 0xc5, 0x77, 0xa3, 0xe2, 0x40, 0x63,
+	0x22, 0x2d, 0x97, 0xb0, 0x18, 0x91, 0x9e, 0x01, 0x2c, 0xbf, 0xcd, 0x25, 0x7a, 0x98, 0x1f, 0x77,
+	0xca, 0x27, 0x07, 0x12, 0x3d, 0x25, 0xd1, 0x6f, 0xa1, 0x65, 0x4a, 0xeb, 0x7b, 0x32, 0x8c, 0x03,
+	0x0d, 0xa1, 0xe0, 0xd4, 0x81, 0x8c, 0xf8, 0x5a, 0xd5, 0x7d, 0x04, 0xb5, 0x1b, 0x6c, 0x8d, 0xed,
+	0xb8, 0x3f, 0x85, 0xe3, 0x17, 0x9c, 0x65, 0xfe, 0x88, 0x25, 0x22, 0x12, 0x0b, 0xd2, 0x86, 0x4a,
+	0x34, 0xd2, 0x26, 0x95, 0x68, 0x24, 0x5f, 0xb1, 0x38, 0x88, 0x66, 0x3a, 0xa2, 0x12, 0xa8, 0x07,
+	0x4d, 0x9f, 0xf3, 0x9c, 0xc9, 0x74, 0x5f, 0xe9, 0x05, 0x21, 0x50, 0xc5, 0x62, 0xc8, 0xf4, 0x5a,
+	0x43, 0x3c, 0xd3, 0x33, 0x38, 0xbe, 0xc8, 0xc5, 0x34, 0xcd, 0xa2, 0x9f, 0x99, 0xc6, 0x57, 0xbd,
+	0x2c, 0xdb, 0xb1, 0xfa, 0x2b, 0x56, 0x9c, 0xf4, 0xd4, 0xfc, 0xa0, 0xac, 0xe2, 0x36, 0x87, 0x96,
+	0x86, 0x7e, 0x66, 0x46, 0x05, 0xeb, 0xb7, 0x7b, 0x54, 0x76, 0xd7, 0xed, 0x6b, 0x80, 0x0b, 0xce,
+	0xa3, 0x49, 0x12, 0xb3, 0x44, 0xfc, 0xd7, 0xd7, 0xb2, 0x18, 0x31, 0x8b, 0x6f, 0x59, 0xe6, 0x7b,
+	0xa6, 0x18, 0x46, 0xa6, 0xbf, 0x00, 0x7c, 0x89, 0x67, 0xfe, 0x1a, 0x79, 0xc9, 0xf2, 0xa7, 0xe3,
+	0x31, 0x67, 0x02, 0xfd, 0x56, 0x87, 0x5a, 0x92, 0x7e, 0x66, 0x51, 0x1c, 0x09, 0xa7, 0x8a, 0x6a,
+	0x25, 0x2c, 0x11, 0xaf, 0xa1, 0x13, 0x85, 0xb8, 0x1d, 0x9f, 0xab, 0xf8, 0x22, 0x50, 0x78, 0x57,
+	0x87, 0x4a, 0xb0, 0xa2, 0x54, 0xb6, 0x47, 0x39, 0xd8, 0x16, 0xa5, 0x5a, 0x44, 0x91, 0x3f, 0x50,
+	0x3f, 0xe6, 0x4e, 0x0d, 0x5b, 0xdd, 0x88, 0xd4, 0x83, 0xaa, 0xec, 0xb6, 0x57, 0xec, 0x99, 0x2e,
+	0xd4, 0xb9, 0x08, 0x44, 0xce, 0x35, 0x8e, 0x5a, 0xa2, 0xef, 0x42, 0x47, 0x7a, 0xe1, 0x83, 0xc5,
+	0x95, 0xb4, 0x43, 0x2c, 0xbb, 0x50, 0xc7, 0x47, 0xcb, 0xe9, 0x52, 0x12, 0x3d, 0x85, 0x96, 0xb6,
+	0xf5, 0x3d, 0x34, 0xec, 0xc0, 0x41, 0x34, 0x32, 0x56, 0xf2, 0x48, 0x9f, 0x40, 0xf3, 0x05, 0xd7,
+	0x90, 0x9c, 0x41, 0x2d, 0x97, 0x67, 0xbc, 0x3f, 0x3a, 0x6f, 0xf7, 0xcd, 0x7a, 0xee, 0x4b, 0x93,
+	0xa1, 0xba, 0xa4, 0x13, 0xa8, 0x61, 0x73, 0x6d, 0xfc, 0xc3, 0x81, 0x06, 0x2e, 0x85, 0xa2, 0x76,
+	0x5a, 0x94, 0x38, 0x25, 0x41, 0xcc, 0xf4, 0x4f, 0xf0, 0x2c, 0xb7, 0xd0, 0x88, 0xf1, 0x30, 0x8b,
+	0xe6, 0x22, 0x4a, 0x13, 0x0d, 0xa1, 0xad, 0xa2, 0x8f, 0xe0, 0x10, 0x03, 0xed, 0xc8, 0xfc, 0x69,
+	0x71, 0xcd, 0xc9, 0xdb, 0x50, 0xc7, 0x46, 0x31, 0xb9, 0xdf, 0x2b, 0x72, 0x57, 0x93, 0xa0, 0xaf,
+	0xcf, 0x7f, 0xad, 0x42, 0x0b, 0x97, 0x28, 0x7f, 0xce, 0xb2, 0x97, 0x51, 0xc8, 0xc8, 0x33, 0x68,
+	0x5f, 0x06, 0x89, 0x45, 0x30, 0xc4, 0x29, 0x1e, 0xaf, 0xf2, 0x8e, 0xfb, 0xbf, 0xe2, 0x46, 0x2f,
+	0x7d, 0x5a, 0x22, 0x57, 0xd0, 0xf6, 0xb9, 0x4d, 0x22, 0xe4, 0xff, 0x85, 0xd9, 0x1a, 0xb9, 0xb8,
+	0xdd, 0xbe, 0x62, 0xba, 0xbe, 0x61, 0xba, 0xfe, 0x95, 0x64, 0x3a, 0x5a, 0x22, 0x03, 0x68, 0x59,
+	0x79, 0xf8, 0x1e, 0x79, 0xb8, 0x99, 0x86, 0xef, 0xed, 0xf7, 0xf1, 0x04, 0x9a, 0x6a, 0x99, 0x8d,
+	0x17, 0xc4, 0x82, 0x00, 0x77, 0xe0, 0xf6, 0xe4, 0x3f, 0x86, 0xf6, 0x35, 0x13, 0x0a, 0x48, 0x6c,
+	0x13, 0x72, 0x7f, 0x0d, 0x3a, 0x09, 0xbf, 0xbb, 0x45, 0xc9, 0x31, 0xe7, 0xce, 0x35, 0x13, 0xab,
+	0xdc, 0x75, 0x7f, 0xe3, 0xf3, 0xbe, 0xe7, 0x3e, 0xdc, 0x50, 0x2a, 0x6b, 0x5a, 0x22, 0x1f, 0x41,
+	0xeb, 0x9a, 0x09, 0x8b, 0xd8, 0x36, 0xf3, 0x74, 0x1f, 0xac, 0xa9, 0xd0, 0x90, 0x96, 0xc8, 0xe7,
+	0x18, 0x7f, 0x85, 0xab, 0x88, 0xbb, 0x96, 0xaa, 0x45, 0x62, 0xb6, 0x9f, 0x82, 0x9c, 0x68, 0xe9,
+	0xfc, 0xb7, 0xb2, 0x62, 0x82, 0x65, 0x53, 0x7c, 0x8a, 0x49, 0x15, 0xc3, 0x63, 0x17, 0x63, 0x65,
+	0xa4, 0x5c, 0xb2, 0x76, 0xa1, 0x80, 0xf1, 0x30, 0xb1, 0x95, 0x41, 0xb5, 0x13, 0x5b, 0x9f, 0xe0,
+	0xed, 0x5e, 0xce, 0xff, 0xa9, 0xc0, 0x91, 0x5c, 0xff, 0x26, 0xab, 0x3e, 0xd4, 0x90, 0x79, 0x88,
+	0x65, 0x6e, 0xa8, 0xc8, 0x5d, 0xaf, 0x37, 0x2d, 0x91, 0xf7, 0xf7, 0xb5, 0x43, 0x77, 0x35, 0xa4,
+	0x21, 0x41, 0x5a, 0x22, 0x9f, 0xc0, 0xe1, 0x92, 0x74, 0x88, 0x65, 0x66, 0xf3, 0xd5, 0x9e, 0x26,
+	0xf4, 0xac, 0x81, 0x52, 0x9b, 0x62, 0x63, 0xa0, 0x0c, 0x3b, 0xed, 0xf1, 0xf2, 0x21, 0xd4, 0x15,
+	0x0f, 0x11, 0xab, 0x68, 0x05, 0x33, 0xed, 0x79, 0xf9, 0x01, 0x34, 0xf4, 0x9e, 0xb7, 0x9f, 0x16,
+	0xd4, 0xe3, 0x6e, 0xd3, 0x72, 0x5a, 0x1a, 0x74, 0xfe, 0xb8, 0xeb, 0x95, 0xff, 0xbc, 0xeb, 0x95,
+	0xff, 0xba, 0xeb, 0x95, 0x7f, 0xff, 0xbb, 0x57, 0xba, 0xad, 0xa3, 0xf3, 0xf7, 0xfe, 0x1d, 0x00,
+	0xd9, 0xd3, 0xb0, 0x94, 0xb5, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1293,6 +1540,9 @@ type ThingsServiceClient interface {
 	CanAccessByID(ctx context.Context, in *AccessByIDReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Identify(ctx context.Context, in *Token, opts ...grpc.CallOption) (*ThingID, error)
 	GetGroupsByIDs(ctx context.Context, in *GroupsReq, opts ...grpc.CallOption) (*GroupsRes, error)
+	GetChannelSchema(ctx context.Context, in *ChannelID, opts ...grpc.CallOption) (*ChannelSchema, error)
+	GetThingCodec(ctx context.Context, in *ThingID, opts ...grpc.CallOption) (*ThingCodec, error)
+	GetGroupChannels(ctx context.Context, in *GroupChannelsReq, opts ...grpc.CallOption) (*ChannelIDs, error)
 }
 
 type thingsServiceClient struct {
@@ -1348,6 +1598,33 @@ func (c *thingsServiceClient) GetGroupsByIDs(ctx context.Context, in *GroupsReq,
 	return out, nil
 }
 
+func (c *thingsServiceClient) GetChannelSchema(ctx context.Context, in *ChannelID, opts ...grpc.CallOption) (*ChannelSchema, error) {
+	out := new(ChannelSchema)
+	err := c.cc.Invoke(ctx, "/mainflux.ThingsService/GetChannelSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) GetThingCodec(ctx context.Context, in *ThingID, opts ...grpc.CallOption) (*ThingCodec, error) {
+	out := new(ThingCodec)
+	err := c.cc.Invoke(ctx, "/mainflux.ThingsService/GetThingCodec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsServiceClient) GetGroupChannels(ctx context.Context, in *GroupChannelsReq, opts ...grpc.CallOption) (*ChannelIDs, error) {
+	out := new(ChannelIDs)
+	err := c.cc.Invoke(ctx, "/mainflux.ThingsService/GetGroupChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ThingsServiceServer is the server API for ThingsService service.
 type ThingsServiceServer interface {
 	CanAccessByKey(context.Context, *AccessByKeyReq) (*ThingID, error)
@@ -1355,6 +1632,9 @@ type ThingsServiceServer interface {
 	CanAccessByID(context.Context, *AccessByIDReq) (*emptypb.Empty, error)
 	Identify(context.Context, *Token) (*ThingID, error)
 	GetGroupsByIDs(context.Context, *GroupsReq) (*GroupsRes, error)
+	GetChannelSchema(context.Context, *ChannelID) (*ChannelSchema, error)
+	GetThingCodec(context.Context, *ThingID) (*ThingCodec, error)
+	GetGroupChannels(context.Context, *GroupChannelsReq) (*ChannelIDs, error)
 }
 
 // UnimplementedThingsServiceServer can be embedded to have forward compatible implementations.
@@ -1376,6 +1656,15 @@ func (*UnimplementedThingsServiceServer) Identify(ctx context.Context, req *Toke
 func (*UnimplementedThingsServiceServer) GetGroupsByIDs(ctx context.Context, req *GroupsReq) (*GroupsRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetGroupsByIDs not implemented")
 }
+func (*UnimplementedThingsServiceServer) GetChannelSchema(ctx context.Context, req *ChannelID) (*ChannelSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChannelSchema not implemented")
+}
+func (*UnimplementedThingsServiceServer) GetThingCodec(ctx context.Context, req *ThingID) (*ThingCodec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThingCodec not implemented")
+}
+func (*UnimplementedThingsServiceServer) GetGroupChannels(ctx context.Context, req *GroupChannelsReq) (*ChannelIDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupChannels not implemented")
+}
 
 func RegisterThingsServiceServer(s *grpc.Server, srv ThingsServiceServer) {
 	s.RegisterService(&_ThingsService_serviceDesc, srv)
@@ -1471,6 +1760,60 @@ func _ThingsService_GetGroupsByIDs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThingsService_GetChannelSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).GetChannelSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.ThingsService/GetChannelSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).GetChannelSchema(ctx, req.(*ChannelID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_GetThingCodec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThingID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).GetThingCodec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.ThingsService/GetThingCodec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).GetThingCodec(ctx, req.(*ThingID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThingsService_GetGroupChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupChannelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).GetGroupChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.ThingsService/GetGroupChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).GetGroupChannels(ctx, req.(*GroupChannelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ThingsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mainflux.ThingsService",
 	HandlerType: (*ThingsServiceServer)(nil),
@@ -1495,6 +1838,18 @@ var _ThingsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetGroupsByIDs",
 			Handler:    _ThingsService_GetGroupsByIDs_Handler,
 		},
+		{
+			MethodName: "GetChannelSchema",
+			Handler:    _ThingsService_GetChannelSchema_Handler,
+		},
+		{
+			MethodName: "GetThingCodec",
+			Handler:    _ThingsService_GetThingCodec_Handler,
+		},
+		{
+			MethodName: "GetGroupChannels",
+			Handler:    _ThingsService_GetGroupChannels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -1884,6 +2239,13 @@ func (m *AccessByKeyReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Subtopic) > 0 {
+		i -= len(m.Subtopic)
+		copy(dAtA[i:], m.Subtopic)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Subtopic)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ChanID) > 0 {
 		i -= len(m.ChanID)
 		copy(dAtA[i:], m.ChanID)
@@ -2010,7 +2372,7 @@ func (m *ChannelID) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *AccessByIDReq) Marshal() (dAtA []byte, err error) {
+func (m *ChannelSchema) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2020,12 +2382,12 @@ func (m *AccessByIDReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AccessByIDReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChannelSchema) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AccessByIDReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ChannelSchema) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -2034,9 +2396,175 @@ func (m *AccessByIDReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.ChanID) > 0 {
-		i -= len(m.ChanID)
-		copy(dAtA[i:], m.ChanID)
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ThingCodec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThingCodec) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThingCodec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Transformer) > 0 {
+		i -= len(m.Transformer)
+		copy(dAtA[i:], m.Transformer)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Transformer)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ContentType) > 0 {
+		i -= len(m.ContentType)
+		copy(dAtA[i:], m.ContentType)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.ContentType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GroupChannelsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GroupChannelsReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GroupChannelsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.GroupID) > 0 {
+		i -= len(m.GroupID)
+		copy(dAtA[i:], m.GroupID)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.GroupID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelIDs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelIDs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelIDs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintAuth(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccessByIDReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessByIDReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessByIDReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Subtopic) > 0 {
+		i -= len(m.Subtopic)
+		copy(dAtA[i:], m.Subtopic)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Subtopic)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ChanID) > 0 {
+		i -= len(m.ChanID)
+		copy(dAtA[i:], m.ChanID)
 		i = encodeVarintAuth(dAtA, i, uint64(len(m.ChanID)))
 		i--
 		dAtA[i] = 0x12
@@ -2766,6 +3294,10 @@ func (m *AccessByKeyReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
+	l = len(m.Subtopic)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2824,6 +3356,84 @@ func (m *ChannelID) Size() (n int) {
 	return n
 }
 
+func (m *ChannelSchema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ThingCodec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Transformer)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GroupChannelsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.GroupID)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ChannelIDs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *AccessByIDReq) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2838,6 +3448,10 @@ func (m *AccessByIDReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
+	l = len(m.Subtopic)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3280,6 +3894,38 @@ func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
 			}
 			m.ChanID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subtopic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subtopic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -3595,7 +4241,7 @@ func (m *ChannelID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
+func (m *ChannelSchema) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3618,17 +4264,17 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessByIDReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChannelSchema: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessByIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChannelSchema: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThingID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAuth
@@ -3638,7 +4284,451 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThingCodec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThingCodec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThingCodec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Transformer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Transformer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupChannelsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupChannelsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupChannelsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelIDs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelIDs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelIDs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessByIDReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessByIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThingID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -3688,6 +4778,38 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 			}
 			m.ChanID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subtopic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subtopic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -162,6 +162,7 @@ type OrgService interface {
 	ListOrgMembers(ctx context.Context, token, orgID string, pm PageMetadata) (MembersPage, error)
 
 	// AssignGroups adds groups with groupIDs into the org identified by orgID.
+	// Org members gain access to the descendants of the assigned groups as well.
 	AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) error
 
 	// UnassignGroups removes groups with groupIDs from org identified by orgID.
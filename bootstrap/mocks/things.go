@@ -10,6 +10,8 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -223,11 +225,19 @@ func (svc *mainfluxThings) RemoveChannel(context.Context, string, string) error
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string) (string, error) {
+func (svc *mainfluxThings) UpdateSubtopics(context.Context, string, string, string, []string) error {
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByID(context.Context, string, string) error {
+func (svc *mainfluxThings) ViewSubtopics(context.Context, string, string, string) ([]string, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string) (string, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CanAccessByID(context.Context, string, string, string) error {
 	panic("not implemented")
 }
 
@@ -239,6 +249,18 @@ func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) GetChannelSchema(context.Context, string) (schema.Schema, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetThingCodec(context.Context, string) (codec.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetGroupChannels(context.Context, string, string) ([]string, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ShareThing(ctx context.Context, token, thingID string, actions, userIDs []string) error {
 	panic("not implemented")
 }
@@ -273,6 +295,30 @@ func (svc *mainfluxThings) RemoveGroup(ctx context.Context, token, id string) er
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) ListDescendants(context.Context, string, string, things.PageMetadata) (things.GroupPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListDescendantMembers(context.Context, string, string, things.PageMetadata) (things.MemberPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) MoveGroup(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ConnectGroup(context.Context, string, string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) DisconnectGroup(context.Context, string, string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListChannelsByGroup(context.Context, string, string, things.PageMetadata) (things.ChannelsPage, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) UpdateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
 	panic("not implemented")
 }
@@ -292,3 +338,43 @@ func (svc *mainfluxThings) Unassign(ctx context.Context, token string, groupID s
 func (svc *mainfluxThings) ListMemberships(ctx context.Context, token string, memberID string, pm things.PageMetadata) (things.GroupPage, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) CreateProfile(context.Context, string, things.Profile) (things.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateProfile(context.Context, string, things.Profile) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewProfile(context.Context, string, string) (things.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListProfiles(context.Context, string, things.PageMetadata) (things.ProfilesPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveProfile(context.Context, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListThingsByProfile(context.Context, string, string, things.PageMetadata) (things.Page, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateThingsByProfile(context.Context, string, string, things.Metadata) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewThingStatus(context.Context, string, string) (things.Presence, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdatePresence(context.Context, things.Presence) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateLastSeen(context.Context, things.Presence) error {
+	panic("not implemented")
+}
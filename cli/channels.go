@@ -58,6 +58,7 @@ var cmdChannels = []cobra.Command{
 				Offset:   uint64(Offset),
 				Limit:    uint64(Limit),
 				Metadata: metadata,
+				Query:    Query,
 			}
 
 			if args[0] == "all" {
@@ -57,6 +57,7 @@ var cmdThings = []cobra.Command{
 				Offset:   uint64(Offset),
 				Limit:    uint64(Limit),
 				Metadata: metadata,
+				Query:    Query,
 			}
 			if args[0] == "all" {
 				l, err := sdk.Things(args[1], pageMetadata)
@@ -186,6 +186,14 @@ func main() {
 		"Metadata query parameter",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Query,
+		"query",
+		"q",
+		"",
+		"Search query parameter, e.g. \"firmware < 2.3 and site in (A, B)\"",
+	)
+
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,332 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/api"
+	"github.com/MainfluxLabs/mainflux/readers/clickhouse"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "clickhouse-reader"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel          = "error"
+	defPort              = "8916"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defDBHost            = "localhost"
+	defDBPort            = "8123"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "mainflux"
+	defJaegerURL         = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+	defCacheURL          = "localhost:6379"
+	defCachePass         = ""
+	defCacheDB           = "0"
+	defBrokerURL         = "nats://localhost:4222"
+
+	envLogLevel          = "MF_CLICKHOUSE_READER_LOG_LEVEL"
+	envPort              = "MF_CLICKHOUSE_READER_PORT"
+	envClientTLS         = "MF_CLICKHOUSE_READER_CLIENT_TLS"
+	envCACerts           = "MF_CLICKHOUSE_READER_CA_CERTS"
+	envDBHost            = "MF_CLICKHOUSE_READER_DB_HOST"
+	envDBPort            = "MF_CLICKHOUSE_READER_DB_PORT"
+	envDBUser            = "MF_CLICKHOUSE_READER_DB_USER"
+	envDBPass            = "MF_CLICKHOUSE_READER_DB_PASS"
+	envDB                = "MF_CLICKHOUSE_READER_DB"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envCacheURL          = "MF_READERS_CACHE_URL"
+	envCachePass         = "MF_READERS_CACHE_PASS"
+	envCacheDB           = "MF_READERS_CACHE_DB"
+	envBrokerURL         = "MF_BROKER_URL"
+)
+
+type config struct {
+	logLevel          string
+	port              string
+	clientTLS         bool
+	caCerts           string
+	dbConfig          ch.Config
+	jaegerURL         string
+	thingsGRPCURL     string
+	authGRPCURL       string
+	thingsGRPCTimeout time.Duration
+	cacheURL          string
+	cachePass         string
+	cacheDB           string
+	brokerURL         string
+	authGRPCTimeout   time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
+	defer cacheClient.Close()
+	cache := rediscache.New(cacheClient)
+
+	client := connectToDB(cfg.dbConfig, logger)
+
+	repo := newService(client, logger)
+	audit := clickhouse.NewAuditRepository(client)
+
+	pub, err := brokers.NewPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pub.Close()
+
+	replayer := readers.NewReplayer(repo, pub, uuid.New(), logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, repo, cache, audit, replayer, tc, auth, cfg.port, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("ClickHouse reader service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("ClickHouse reader service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	dbConfig := ch.Config{
+		Host: mainflux.Env(envDBHost, defDBHost),
+		Port: mainflux.Env(envDBPort, defDBPort),
+		User: mainflux.Env(envDBUser, defDBUser),
+		Pass: mainflux.Env(envDBPass, defDBPass),
+		Name: mainflux.Env(envDB, defDB),
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	return config{
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		dbConfig:          dbConfig,
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		cacheURL:          mainflux.Env(envCacheURL, defCacheURL),
+		cachePass:         mainflux.Env(envCachePass, defCachePass),
+		cacheDB:           mainflux.Env(envCacheDB, defCacheDB),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+	}
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
+
+func connectToDB(dbConfig ch.Config, logger logger.Logger) *ch.Client {
+	client, err := clickhouse.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to ClickHouse: %s", err))
+		os.Exit(1)
+	}
+	return client
+}
+
+func connectToRedis(cacheURL, cachePass string, cacheDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(cacheDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to cache: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     cacheURL,
+		Password: cachePass,
+		DB:       db,
+	})
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func newService(client *ch.Client, logger logger.Logger) readers.MessageRepository {
+	svc := clickhouse.New(client)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "clickhouse",
+			Subsystem: "message_reader",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "clickhouse",
+			Subsystem: "message_reader",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, repo readers.MessageRepository, cache readers.LatestMessageRepository, audit readers.AuditRepository, replayer readers.Replayer, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, port string, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, cache, audit, replayer, tc, ac, svcName, logger)}
+
+	logger.Info(fmt.Sprintf("ClickHouse reader service started, exposed port %s", port))
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("ClickHouse reader service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("ClickHouse reader service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("ClickHouse reader service  shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
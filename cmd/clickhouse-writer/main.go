@@ -0,0 +1,278 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/clickhouse"
+	"github.com/MainfluxLabs/mainflux/logger"
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "clickhouse-writer"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel        = "error"
+	defClientTLS       = "false"
+	defCACerts         = ""
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+	defBrokerURL       = "nats://localhost:4222"
+	defPort            = "8180"
+	defDBHost          = "localhost"
+	defDBPort          = "8123"
+	defDBUser          = "mainflux"
+	defDBPass          = "mainflux"
+	defDB              = "mainflux"
+	defConfigPath      = "/config.toml"
+	defBatchSize       = "0"
+	defBatchTimeout    = "1s"
+
+	envBrokerURL       = "MF_BROKER_URL"
+	envLogLevel        = "MF_CLICKHOUSE_WRITER_LOG_LEVEL"
+	envClientTLS       = "MF_CLICKHOUSE_WRITER_CLIENT_TLS"
+	envCACerts         = "MF_CLICKHOUSE_WRITER_CA_CERTS"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	envPort            = "MF_CLICKHOUSE_WRITER_PORT"
+	envDBHost          = "MF_CLICKHOUSE_WRITER_DB_HOST"
+	envDBPort          = "MF_CLICKHOUSE_WRITER_DB_PORT"
+	envDBUser          = "MF_CLICKHOUSE_WRITER_DB_USER"
+	envDBPass          = "MF_CLICKHOUSE_WRITER_DB_PASS"
+	envDB              = "MF_CLICKHOUSE_WRITER_DB"
+	envConfigPath      = "MF_CLICKHOUSE_WRITER_CONFIG_PATH"
+	envBatchSize       = "MF_CLICKHOUSE_WRITER_BATCH_SIZE"
+	envBatchTimeout    = "MF_CLICKHOUSE_WRITER_BATCH_TIMEOUT"
+)
+
+type config struct {
+	brokerURL       string
+	logLevel        string
+	port            string
+	configPath      string
+	dbConfig        ch.Config
+	batchCfg        consumers.BatchConfig
+	clientTLS       bool
+	caCerts         string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authGRPCTimeout)
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
+	client := connectToDB(cfg.dbConfig, logger)
+
+	repo := newService(client, logger)
+
+	if cfg.batchCfg.Size > 1 {
+		batcher := consumers.NewBatcher(repo, cfg.batchCfg, logger)
+		defer func() {
+			if err := batcher.Close(); err != nil {
+				logger.Error(fmt.Sprintf("Failed to write buffered messages: %s", err))
+			}
+		}()
+		repo = batcher
+		logger.Info(fmt.Sprintf("ClickHouse writer batches up to %d messages", cfg.batchCfg.Size))
+	}
+
+	dls, err := consumers.Start(svcName, pubSub, repo, dlPubSub, cfg.configPath, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create ClickHouse writer: %s", err))
+	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg.port, dls, auth, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("ClickHouse writer service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("ClickHouse writer service terminated: %s", err))
+	}
+
+}
+
+func loadConfig() config {
+	dbConfig := ch.Config{
+		Host: mainflux.Env(envDBHost, defDBHost),
+		Port: mainflux.Env(envDBPort, defDBPort),
+		User: mainflux.Env(envDBUser, defDBUser),
+		Pass: mainflux.Env(envDBPass, defDBPass),
+		Name: mainflux.Env(envDB, defDB),
+	}
+
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	batchTimeout, err := time.ParseDuration(mainflux.Env(envBatchTimeout, defBatchTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchTimeout, err.Error())
+	}
+
+	clientTLS, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	return config{
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		port:            mainflux.Env(envPort, defPort),
+		configPath:      mainflux.Env(envConfigPath, defConfigPath),
+		clientTLS:       clientTLS,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+		dbConfig:        dbConfig,
+		batchCfg: consumers.BatchConfig{
+			Size:     batchSize,
+			Interval: batchTimeout,
+		},
+	}
+}
+
+func connectToDB(dbConfig ch.Config, logger logger.Logger) *ch.Client {
+	client, err := clickhouse.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to ClickHouse: %s", err))
+		os.Exit(1)
+	}
+	return client
+}
+
+func newService(client *ch.Client, logger logger.Logger) consumers.Consumer {
+	svc := clickhouse.New(client)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "clickhouse",
+			Subsystem: "message_writer",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "clickhouse",
+			Subsystem: "message_writer",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, port string, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, api.MakeHandler(svcName), logger)}
+
+	logger.Info(fmt.Sprintf("ClickHouse writer service started, exposed port %s", port))
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("ClickHouse writer service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("clickhouse writer service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("ClickHouse writer service  shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
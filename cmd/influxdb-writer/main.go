@@ -9,63 +9,96 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/influxdb"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	rapi "github.com/MainfluxLabs/mainflux/consumers/writers/retention/api"
+	rinfluxdb "github.com/MainfluxLabs/mainflux/consumers/writers/retention/influxdb"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName      = "influxdb-writer"
 	stopWaitTime = 5 * time.Second
 
-	defBrokerURL  = "nats://localhost:4222"
-	defLogLevel   = "error"
-	defPort       = "8180"
-	defDBHost     = "localhost"
-	defDBPort     = "8086"
-	defDBUser     = "mainflux"
-	defDBPass     = "mainflux"
-	defConfigPath = "/config.toml"
-	defDBBucket   = "mainflux-bucket"
-	defDBOrg      = "mainflux"
-	defDBToken    = "mainflux-token"
-
-	envBrokerURL  = "MF_BROKER_URL"
-	envLogLevel   = "MF_INFLUX_WRITER_LOG_LEVEL"
-	envPort       = "MF_INFLUX_WRITER_PORT"
-	envDBHost     = "MF_INFLUXDB_HOST"
-	envDBPort     = "MF_INFLUXDB_PORT"
-	envDBUser     = "MF_INFLUXDB_ADMIN_USER"
-	envDBPass     = "MF_INFLUXDB_ADMIN_PASSWORD"
-	envConfigPath = "MF_INFLUX_WRITER_CONFIG_PATH"
-	envDBBucket   = "MF_INFLUXDB_BUCKET"
-	envDBOrg      = "MF_INFLUXDB_ORG"
-	envDBToken    = "MF_INFLUXDB_TOKEN"
+	defBrokerURL         = "nats://localhost:4222"
+	defLogLevel          = "error"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "8086"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defConfigPath        = "/config.toml"
+	defBatchSize         = "0"
+	defBatchTimeout      = "1s"
+	defRetentionInterval = "1h"
+	defAsync             = "false"
+	defDBBucket          = "mainflux-bucket"
+	defDBOrg             = "mainflux"
+	defDBToken           = "mainflux-token"
+
+	envBrokerURL         = "MF_BROKER_URL"
+	envLogLevel          = "MF_INFLUX_WRITER_LOG_LEVEL"
+	envClientTLS         = "MF_INFLUX_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_INFLUX_WRITER_CA_CERTS"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envPort              = "MF_INFLUX_WRITER_PORT"
+	envDBHost            = "MF_INFLUXDB_HOST"
+	envDBPort            = "MF_INFLUXDB_PORT"
+	envDBUser            = "MF_INFLUXDB_ADMIN_USER"
+	envDBPass            = "MF_INFLUXDB_ADMIN_PASSWORD"
+	envConfigPath        = "MF_INFLUX_WRITER_CONFIG_PATH"
+	envBatchSize         = "MF_INFLUX_WRITER_BATCH_SIZE"
+	envBatchTimeout      = "MF_INFLUX_WRITER_BATCH_TIMEOUT"
+	envRetentionInterval = "MF_INFLUX_WRITER_RETENTION_INTERVAL"
+	envAsync             = "MF_INFLUX_WRITER_ASYNC"
+	envDBBucket          = "MF_INFLUXDB_BUCKET"
+	envDBOrg             = "MF_INFLUXDB_ORG"
+	envDBToken           = "MF_INFLUXDB_TOKEN"
 )
 
 type config struct {
-	brokerURL  string
-	logLevel   string
-	port       string
-	dbHost     string
-	dbPort     string
-	dbUser     string
-	dbPass     string
-	configPath string
-	dbBucket   string
-	dbOrg      string
-	dbToken    string
-	dbUrl      string
+	brokerURL         string
+	logLevel          string
+	port              string
+	dbHost            string
+	dbPort            string
+	dbUser            string
+	dbPass            string
+	configPath        string
+	dbBucket          string
+	dbOrg             string
+	dbToken           string
+	dbUrl             string
+	async             bool
+	batchCfg          consumers.BatchConfig
+	retentionInterval time.Duration
+	clientTLS         bool
+	caCerts           string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
 }
 
 func main() {
@@ -78,6 +111,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authGRPCTimeout)
+
 	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
@@ -85,6 +123,13 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
 	client, err := connectToInfluxDB(cfg)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to create InfluxDB client: %s", err))
@@ -93,17 +138,46 @@ func main() {
 	defer client.Close()
 
 	repo := influxdb.New(client, repoCfg)
+	if cfg.async {
+		repo = influxdb.NewAsync(client, repoCfg, logger)
+	}
 	counter, latency := makeMetrics()
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
 
-	if err := consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	if cfg.batchCfg.Size > 1 {
+		batcher := consumers.NewBatcher(repo, cfg.batchCfg, logger)
+		defer func() {
+			if err := batcher.Close(); err != nil {
+				logger.Error(fmt.Sprintf("Failed to write buffered messages: %s", err))
+			}
+		}()
+		repo = batcher
+		logger.Info(fmt.Sprintf("InfluxDB writer batches up to %d messages", cfg.batchCfg.Size))
+	}
+
+	dls, err := consumers.Start(svcName, pubSub, repo, dlPubSub, cfg.configPath, logger)
+	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
+
+	policies, err := rinfluxdb.NewPolicyRepository(client, repoCfg.Org, repoCfg.Bucket)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create retention policy repository: %s", err))
+		os.Exit(1)
+	}
+	rsvc := newRetentionService(policies, influxdb.NewPruner(client, repoCfg), logger)
+	if cfg.retentionInterval > 0 {
+		g.Go(func() error {
+			retention.Run(ctx, rsvc, cfg.retentionInterval, logger)
+			return nil
+		})
+	}
 
 	g.Go(func() error {
-		return startHTTPService(ctx, cfg.port, logger)
+		return startHTTPService(ctx, cfg.port, rsvc, dls, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -126,18 +200,58 @@ func connectToInfluxDB(cfg config) (influxdb2.Client, error) {
 }
 
 func loadConfigs() (config, influxdb.RepoConfig) {
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	batchTimeout, err := time.ParseDuration(mainflux.Env(envBatchTimeout, defBatchTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchTimeout, err.Error())
+	}
+
+	retentionInterval, err := time.ParseDuration(mainflux.Env(envRetentionInterval, defRetentionInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetentionInterval, err.Error())
+	}
+
+	async, err := strconv.ParseBool(mainflux.Env(envAsync, defAsync))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAsync)
+	}
+
+	clientTLS, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
 	cfg := config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		port:       mainflux.Env(envPort, defPort),
-		dbHost:     mainflux.Env(envDBHost, defDBHost),
-		dbPort:     mainflux.Env(envDBPort, defDBPort),
-		dbUser:     mainflux.Env(envDBUser, defDBUser),
-		dbPass:     mainflux.Env(envDBPass, defDBPass),
-		configPath: mainflux.Env(envConfigPath, defConfigPath),
-		dbBucket:   mainflux.Env(envDBBucket, defDBBucket),
-		dbOrg:      mainflux.Env(envDBOrg, defDBOrg),
-		dbToken:    mainflux.Env(envDBToken, defDBToken),
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		port:            mainflux.Env(envPort, defPort),
+		dbHost:          mainflux.Env(envDBHost, defDBHost),
+		dbPort:          mainflux.Env(envDBPort, defDBPort),
+		dbUser:          mainflux.Env(envDBUser, defDBUser),
+		dbPass:          mainflux.Env(envDBPass, defDBPass),
+		configPath:      mainflux.Env(envConfigPath, defConfigPath),
+		clientTLS:       clientTLS,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+		dbBucket:        mainflux.Env(envDBBucket, defDBBucket),
+		dbOrg:           mainflux.Env(envDBOrg, defDBOrg),
+		dbToken:         mainflux.Env(envDBToken, defDBToken),
+		async:           async,
+		batchCfg: consumers.BatchConfig{
+			Size:     batchSize,
+			Interval: batchTimeout,
+		},
+		retentionInterval: retentionInterval,
 	}
 	cfg.dbUrl = fmt.Sprintf("http://%s:%s", cfg.dbHost, cfg.dbPort)
 
@@ -166,10 +280,32 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
-func startHTTPService(ctx context.Context, port string, logger logger.Logger) error {
+func newRetentionService(policies retention.PolicyRepository, pruner retention.Pruner, logger logger.Logger) retention.Service {
+	svc := retention.New(policies, pruner)
+	svc = rapi.LoggingMiddleware(svc, logger)
+	svc = rapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPService(ctx context.Context, port string, rsvc retention.Service, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, rapi.MakeHandler(rsvc, auth, svcName, logger), logger)}
 
 	logger.Info(fmt.Sprintf("InfluxDB writer service started, exposed port %s", p))
 
@@ -191,3 +327,29 @@ func startHTTPService(ctx context.Context, port string, logger logger.Logger) er
 		return err
 	}
 }
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
@@ -19,9 +19,14 @@ import (
 	"github.com/MainfluxLabs/mainflux/lora/mqtt"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	mqttPaho "github.com/eclipse/paho.mqtt.golang"
 	r "github.com/go-redis/redis/v8"
+	opentracing "github.com/opentracing/opentracing-go"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/MainfluxLabs/mainflux/lora/redis"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -35,7 +40,10 @@ const (
 	defHTTPPort       = "8180"
 	defMsgURL         = "tcp://localhost:1883"
 	defBrokerURL      = "nats://localhost:4222"
-	defMsgTopic       = "application/+/device/+/event/up"
+	defMsgTopic       = ""
+	defMsgFormat      = lora.ChirpStackV3
+	defMetaFormat     = ""
+	defMetaSubtopic   = lora.DefMetadataSubtopic
 	defMsgUser        = ""
 	defMsgPass        = ""
 	defMsgTimeout     = "30s"
@@ -46,11 +54,18 @@ const (
 	defRouteMapURL    = "localhost:6379"
 	defRouteMapPass   = ""
 	defRouteMapDB     = "0"
+	defClientTLS      = "false"
+	defCACerts        = ""
+	defThingsURL      = ""
+	defThingsTimeout  = "1s"
 
 	envHTTPPort       = "MF_LORA_ADAPTER_HTTP_PORT"
 	envMsgURL         = "MF_LORA_ADAPTER_MESSAGES_URL"
 	envBrokerURL      = "MF_BROKER_URL"
 	envMsgTopic       = "MF_LORA_ADAPTER_MESSAGES_TOPIC"
+	envMsgFormat      = "MF_LORA_ADAPTER_MESSAGES_FORMAT"
+	envMetaFormat     = "MF_LORA_ADAPTER_METADATA_FORMAT"
+	envMetaSubtopic   = "MF_LORA_ADAPTER_METADATA_SUBTOPIC"
 	envMsgUser        = "MF_LORA_ADAPTER_MESSAGES_USER"
 	envMsgPass        = "MF_LORA_ADAPTER_MESSAGES_PASS"
 	envMsgTimeout     = "MF_LORA_ADAPTER_MESSAGES_TIMEOUT"
@@ -62,10 +77,15 @@ const (
 	envRouteMapURL    = "MF_LORA_ADAPTER_ROUTE_MAP_URL"
 	envRouteMapPass   = "MF_LORA_ADAPTER_ROUTE_MAP_PASS"
 	envRouteMapDB     = "MF_LORA_ADAPTER_ROUTE_MAP_DB"
+	envClientTLS      = "MF_LORA_ADAPTER_CLIENT_TLS"
+	envCACerts        = "MF_LORA_ADAPTER_CA_CERTS"
+	envThingsURL      = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsTimeout  = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 
 	thingsRMPrefix   = "thing"
 	channelsRMPrefix = "channel"
 	connsRMPrefix    = "connection"
+	queue            = "lora"
 )
 
 type config struct {
@@ -75,6 +95,8 @@ type config struct {
 	msgUser        string
 	msgPass        string
 	msgTopic       string
+	msgFormat      string
+	metadata       lora.MetadataConfig
 	msgTimeout     time.Duration
 	logLevel       string
 	esURL          string
@@ -84,6 +106,10 @@ type config struct {
 	routeMapURL    string
 	routeMapPass   string
 	routeMapDB     string
+	clientTLS      bool
+	caCerts        string
+	thingsURL      string
+	thingsTimeout  time.Duration
 }
 
 func main() {
@@ -102,18 +128,41 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pub, err := brokers.NewPublisher(cfg.brokerURL)
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, queue, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
-	defer pub.Close()
+	defer pubSub.Close()
 
 	thingsRM := newRouteMapRepository(rmConn, thingsRMPrefix, logger)
 	chansRM := newRouteMapRepository(rmConn, channelsRMPrefix, logger)
 	connsRM := newRouteMapRepository(rmConn, connsRMPrefix, logger)
 
-	svc := lora.New(pub, thingsRM, chansRM, connsRM)
+	mqttConn := connectToMQTTBroker(cfg.msgURL, cfg.msgUser, cfg.msgPass, cfg.msgTimeout, logger)
+
+	decoder, err := lora.NewDecoder(cfg.msgFormat)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value %q: %s", envMsgFormat, cfg.msgFormat, err))
+		os.Exit(1)
+	}
+
+	var downlinks lora.DownlinkPublisher
+	encoder, err := lora.NewDownlinkEncoder(cfg.msgFormat)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Downlinks are disabled for %s value %q: %s", envMsgFormat, cfg.msgFormat, err))
+	} else {
+		downlinks = mqtt.NewPublisher(mqttConn, encoder, cfg.msgTimeout)
+	}
+
+	var codecs codec.Provider
+	if cfg.thingsURL != "" {
+		conn := connectToThings(cfg, logger)
+		defer conn.Close()
+		codecs = codec.NewProvider(thingsapi.NewClient(conn, opentracing.NoopTracer{}, cfg.thingsTimeout))
+	}
+
+	svc := lora.New(pubSub, downlinks, thingsRM, chansRM, connsRM, cfg.metadata, codecs)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -131,11 +180,21 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	mqttConn := connectToMQTTBroker(cfg.msgURL, cfg.msgUser, cfg.msgPass, cfg.msgTimeout, logger)
+	topic := cfg.msgTopic
+	if topic == "" {
+		topic = decoder.Topic()
+	}
 
-	go subscribeToLoRaBroker(svc, mqttConn, cfg.msgTimeout, cfg.msgTopic, logger)
+	go subscribeToLoRaBroker(svc, decoder, mqttConn, cfg.msgTimeout, topic, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
+	if downlinks != nil {
+		if err := pubSub.Subscribe(queue, brokers.SubjectAllChannels, lora.NewDownlinkHandler(svc)); err != nil {
+			logger.Error(fmt.Sprintf("Failed to subscribe to message broker: %s", err))
+			os.Exit(1)
+		}
+	}
+
 	g.Go(func() error {
 		return startHTTPServer(ctx, cfg, logger)
 	})
@@ -160,11 +219,35 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envMsgTimeout, err.Error())
 	}
 
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsTimeout, err := time.ParseDuration(mainflux.Env(envThingsTimeout, defThingsTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsTimeout, err.Error())
+	}
+
+	metadata := lora.MetadataConfig{
+		Format:   mainflux.Env(envMetaFormat, defMetaFormat),
+		Subtopic: mainflux.Env(envMetaSubtopic, defMetaSubtopic),
+	}
+	if err := metadata.Validate(); err != nil {
+		log.Fatalf("Invalid %s value: %s", envMetaFormat, err.Error())
+	}
+
 	return config{
 		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
 		msgURL:         mainflux.Env(envMsgURL, defMsgURL),
 		brokerURL:      mainflux.Env(envBrokerURL, defBrokerURL),
 		msgTopic:       mainflux.Env(envMsgTopic, defMsgTopic),
+		msgFormat:      mainflux.Env(envMsgFormat, defMsgFormat),
+		metadata:       metadata,
+		clientTLS:      tls,
+		caCerts:        mainflux.Env(envCACerts, defCACerts),
+		thingsURL:      mainflux.Env(envThingsURL, defThingsURL),
+		thingsTimeout:  thingsTimeout,
 		msgUser:        mainflux.Env(envMsgUser, defMsgUser),
 		msgPass:        mainflux.Env(envMsgPass, defMsgPass),
 		msgTimeout:     mqttTimeout,
@@ -216,8 +299,32 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToLoRaBroker(svc lora.Service, mc mqttPaho.Client, timeout time.Duration, topic string, logger logger.Logger) {
-	mqtt := mqtt.NewBroker(svc, mc, timeout, logger)
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func subscribeToLoRaBroker(svc lora.Service, decoder lora.Decoder, mc mqttPaho.Client, timeout time.Duration, topic string, logger logger.Logger) {
+	mqtt := mqtt.NewBroker(svc, decoder, mc, timeout, logger)
 	logger.Info("Subscribed to Lora MQTT broker")
 	if err := mqtt.Subscribe(topic); err != nil {
 		logger.Error(fmt.Sprintf("Failed to subscribe to Lora MQTT broker: %s", err))
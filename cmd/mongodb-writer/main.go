@@ -9,51 +9,81 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/mongodb"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	rapi "github.com/MainfluxLabs/mainflux/consumers/writers/retention/api"
+	rmongodb "github.com/MainfluxLabs/mainflux/consumers/writers/retention/mongodb"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName      = "mongodb-writer"
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel   = "error"
-	defBrokerURL  = "nats://localhost:4222"
-	defPort       = "8180"
-	defDB         = "mainflux"
-	defDBHost     = "localhost"
-	defDBPort     = "27017"
-	defConfigPath = "/config.toml"
-
-	envBrokerURL  = "MF_BROKER_URL"
-	envLogLevel   = "MF_MONGO_WRITER_LOG_LEVEL"
-	envPort       = "MF_MONGO_WRITER_PORT"
-	envDB         = "MF_MONGO_WRITER_DB"
-	envDBHost     = "MF_MONGO_WRITER_DB_HOST"
-	envDBPort     = "MF_MONGO_WRITER_DB_PORT"
-	envConfigPath = "MF_MONGO_WRITER_CONFIG_PATH"
+	defLogLevel          = "error"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defBrokerURL         = "nats://localhost:4222"
+	defPort              = "8180"
+	defDB                = "mainflux"
+	defDBHost            = "localhost"
+	defDBPort            = "27017"
+	defConfigPath        = "/config.toml"
+	defBatchSize         = "0"
+	defBatchTimeout      = "1s"
+	defRetentionInterval = "1h"
+
+	envBrokerURL         = "MF_BROKER_URL"
+	envLogLevel          = "MF_MONGO_WRITER_LOG_LEVEL"
+	envClientTLS         = "MF_MONGO_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_MONGO_WRITER_CA_CERTS"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envPort              = "MF_MONGO_WRITER_PORT"
+	envDB                = "MF_MONGO_WRITER_DB"
+	envDBHost            = "MF_MONGO_WRITER_DB_HOST"
+	envDBPort            = "MF_MONGO_WRITER_DB_PORT"
+	envConfigPath        = "MF_MONGO_WRITER_CONFIG_PATH"
+	envBatchSize         = "MF_MONGO_WRITER_BATCH_SIZE"
+	envBatchTimeout      = "MF_MONGO_WRITER_BATCH_TIMEOUT"
+	envRetentionInterval = "MF_MONGO_WRITER_RETENTION_INTERVAL"
 )
 
 type config struct {
-	brokerURL  string
-	logLevel   string
-	port       string
-	dbName     string
-	dbHost     string
-	dbPort     string
-	configPath string
+	brokerURL         string
+	logLevel          string
+	port              string
+	dbName            string
+	dbHost            string
+	dbPort            string
+	configPath        string
+	batchCfg          consumers.BatchConfig
+	retentionInterval time.Duration
+	clientTLS         bool
+	caCerts           string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
 }
 
 func main() {
@@ -66,6 +96,11 @@ func main() {
 		log.Fatal(err)
 	}
 
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authGRPCTimeout)
+
 	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
@@ -73,6 +108,13 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
 	addr := fmt.Sprintf("mongodb://%s:%s", cfg.dbHost, cfg.dbPort)
 	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
 	if err != nil {
@@ -87,13 +129,34 @@ func main() {
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
 
-	if err := consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	if cfg.batchCfg.Size > 1 {
+		batcher := consumers.NewBatcher(repo, cfg.batchCfg, logger)
+		defer func() {
+			if err := batcher.Close(); err != nil {
+				logger.Error(fmt.Sprintf("Failed to write buffered messages: %s", err))
+			}
+		}()
+		repo = batcher
+		logger.Info(fmt.Sprintf("MongoDB writer batches up to %d messages", cfg.batchCfg.Size))
+	}
+
+	dls, err := consumers.Start(svcName, pubSub, repo, dlPubSub, cfg.configPath, logger)
+	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to start MongoDB writer: %s", err))
 		os.Exit(1)
 	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
+
+	rsvc := newRetentionService(rmongodb.NewPolicyRepository(db), mongodb.NewPruner(db), logger)
+	if cfg.retentionInterval > 0 {
+		g.Go(func() error {
+			retention.Run(ctx, rsvc, cfg.retentionInterval, logger)
+			return nil
+		})
+	}
 
 	g.Go(func() error {
-		return startHTTPService(ctx, cfg.port, logger)
+		return startHTTPService(ctx, cfg.port, rsvc, dls, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -111,14 +174,48 @@ func main() {
 }
 
 func loadConfigs() config {
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	batchTimeout, err := time.ParseDuration(mainflux.Env(envBatchTimeout, defBatchTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchTimeout, err.Error())
+	}
+
+	retentionInterval, err := time.ParseDuration(mainflux.Env(envRetentionInterval, defRetentionInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetentionInterval, err.Error())
+	}
+
+	clientTLS, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
 	return config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		port:       mainflux.Env(envPort, defPort),
-		dbName:     mainflux.Env(envDB, defDB),
-		dbHost:     mainflux.Env(envDBHost, defDBHost),
-		dbPort:     mainflux.Env(envDBPort, defDBPort),
-		configPath: mainflux.Env(envConfigPath, defConfigPath),
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		port:            mainflux.Env(envPort, defPort),
+		dbName:          mainflux.Env(envDB, defDB),
+		dbHost:          mainflux.Env(envDBHost, defDBHost),
+		dbPort:          mainflux.Env(envDBPort, defDBPort),
+		configPath:      mainflux.Env(envConfigPath, defConfigPath),
+		clientTLS:       clientTLS,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+		batchCfg: consumers.BatchConfig{
+			Size:     batchSize,
+			Interval: batchTimeout,
+		},
+		retentionInterval: retentionInterval,
 	}
 }
 
@@ -140,10 +237,32 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
-func startHTTPService(ctx context.Context, port string, logger logger.Logger) error {
+func newRetentionService(policies retention.PolicyRepository, pruner retention.Pruner, logger logger.Logger) retention.Service {
+	svc := retention.New(policies, pruner)
+	svc = rapi.LoggingMiddleware(svc, logger)
+	svc = rapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "mongodb",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "mongodb",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPService(ctx context.Context, port string, rsvc retention.Service, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, rapi.MakeHandler(rsvc, auth, svcName, logger), logger)}
 
 	logger.Info(fmt.Sprintf("MongoDB writer service started, exposed port %s", p))
 
@@ -166,3 +285,29 @@ func startHTTPService(ctx context.Context, port string, logger logger.Logger) er
 	}
 
 }
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
@@ -0,0 +1,283 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/parquet"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "parquet-writer"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel           = "error"
+	defClientTLS          = "false"
+	defCACerts            = ""
+	defAuthGRPCURL        = "localhost:8181"
+	defAuthGRPCTimeout    = "1s"
+	defBrokerURL          = "nats://localhost:4222"
+	defPort               = "8919"
+	defPath               = "/archive"
+	defConfigPath         = "/config.toml"
+	defMaxRows            = "1000000"
+	defMaxAge             = "15m"
+	defRowGroupSize       = "8388608"
+	defMaxOpenFiles       = "100"
+	defCompactionInterval = "5m"
+
+	envBrokerURL          = "MF_BROKER_URL"
+	envLogLevel           = "MF_PARQUET_WRITER_LOG_LEVEL"
+	envClientTLS          = "MF_PARQUET_WRITER_CLIENT_TLS"
+	envCACerts            = "MF_PARQUET_WRITER_CA_CERTS"
+	envAuthGRPCURL        = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout    = "MF_AUTH_GRPC_TIMEOUT"
+	envPort               = "MF_PARQUET_WRITER_PORT"
+	envPath               = "MF_PARQUET_WRITER_PATH"
+	envConfigPath         = "MF_PARQUET_WRITER_CONFIG_PATH"
+	envMaxRows            = "MF_PARQUET_WRITER_MAX_ROWS"
+	envMaxAge             = "MF_PARQUET_WRITER_MAX_AGE"
+	envRowGroupSize       = "MF_PARQUET_WRITER_ROW_GROUP_SIZE"
+	envMaxOpenFiles       = "MF_PARQUET_WRITER_MAX_OPEN_FILES"
+	envCompactionInterval = "MF_PARQUET_WRITER_COMPACTION_INTERVAL"
+)
+
+type config struct {
+	brokerURL          string
+	logLevel           string
+	port               string
+	configPath         string
+	archiveCfg         parquet.Config
+	maxAge             time.Duration
+	compactionInterval time.Duration
+	clientTLS          bool
+	caCerts            string
+	authGRPCURL        string
+	authGRPCTimeout    time.Duration
+}
+
+func main() {
+	cfg := loadConfigs()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authGRPCTimeout)
+
+	// The archive is closed after the subscription, so that the files
+	// aren't reopened by the messages received during shutdown.
+	archive, err := parquet.New(cfg.archiveCfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to open parquet archive: %s", err))
+		os.Exit(1)
+	}
+	defer func() {
+		if err := archive.Close(); err != nil {
+			logger.Error(fmt.Sprintf("Failed to close parquet files: %s", err))
+		}
+	}()
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
+	counter, latency := makeMetrics()
+	repo := api.LoggingMiddleware(archive, logger)
+	repo = api.MetricsMiddleware(repo, counter, latency)
+
+	dls, err := consumers.Start(svcName, pubSub, repo, dlPubSub, cfg.configPath, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to start Parquet writer: %s", err))
+		os.Exit(1)
+	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
+
+	g.Go(func() error {
+		parquet.Run(ctx, archive, cfg.compactionInterval, cfg.maxAge, logger)
+		return nil
+	})
+
+	g.Go(func() error {
+		return startHTTPService(ctx, cfg.port, dls, auth, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Parquet writer service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Parquet writer service terminated: %s", err))
+	}
+
+}
+
+func loadConfigs() config {
+	maxRows, err := strconv.ParseInt(mainflux.Env(envMaxRows, defMaxRows), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxRows, err.Error())
+	}
+
+	rowGroupSize, err := strconv.ParseInt(mainflux.Env(envRowGroupSize, defRowGroupSize), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRowGroupSize, err.Error())
+	}
+
+	maxOpenFiles, err := strconv.Atoi(mainflux.Env(envMaxOpenFiles, defMaxOpenFiles))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxOpenFiles, err.Error())
+	}
+
+	maxAge, err := time.ParseDuration(mainflux.Env(envMaxAge, defMaxAge))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxAge, err.Error())
+	}
+
+	compactionInterval, err := time.ParseDuration(mainflux.Env(envCompactionInterval, defCompactionInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envCompactionInterval, err.Error())
+	}
+
+	clientTLS, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	return config{
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		port:            mainflux.Env(envPort, defPort),
+		configPath:      mainflux.Env(envConfigPath, defConfigPath),
+		clientTLS:       clientTLS,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+		archiveCfg: parquet.Config{
+			Path:         mainflux.Env(envPath, defPath),
+			MaxRows:      maxRows,
+			RowGroupSize: rowGroupSize,
+			MaxOpenFiles: maxOpenFiles,
+		},
+		maxAge:             maxAge,
+		compactionInterval: compactionInterval,
+	}
+}
+
+func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
+	counter := kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "parquet",
+		Subsystem: "message_writer",
+		Name:      "request_count",
+		Help:      "Number of file writes.",
+	}, []string{"method"})
+
+	latency := kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+		Namespace: "parquet",
+		Subsystem: "message_writer",
+		Name:      "request_latency_microseconds",
+		Help:      "Total duration of file writes in microseconds.",
+	}, []string{"method"})
+
+	return counter, latency
+}
+
+func startHTTPService(ctx context.Context, port string, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, api.MakeHandler(svcName), logger)}
+
+	logger.Info(fmt.Sprintf("Parquet writer service started, exposed port %s", p))
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Parquet writer service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("parquet writer service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Parquet writer service  shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
@@ -18,11 +18,15 @@ import (
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/postgres"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -52,6 +56,10 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defCacheURL          = "localhost:6379"
+	defCachePass         = ""
+	defCacheDB           = "0"
+	defBrokerURL         = "nats://localhost:4222"
 	defAuthGRPCURL       = "localhost:8181"
 	defAuthGRPCTimeout   = "1s"
 
@@ -71,6 +79,10 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envCacheURL          = "MF_READERS_CACHE_URL"
+	envCachePass         = "MF_READERS_CACHE_PASS"
+	envCacheDB           = "MF_READERS_CACHE_DB"
+	envBrokerURL         = "MF_BROKER_URL"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
 )
@@ -85,6 +97,10 @@ type config struct {
 	thingsGRPCURL     string
 	authGRPCURL       string
 	thingsGRPCTimeout time.Duration
+	cacheURL          string
+	cachePass         string
+	cacheDB           string
+	brokerURL         string
 	authGRPCTimeout   time.Duration
 }
 
@@ -106,6 +122,10 @@ func main() {
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
 
+	cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
+	defer cacheClient.Close()
+	cache := rediscache.New(cacheClient)
+
 	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
 	defer authCloser.Close()
 
@@ -118,9 +138,19 @@ func main() {
 	defer db.Close()
 
 	repo := newService(db, logger)
+	audit := postgres.NewAuditRepository(db)
+
+	pub, err := brokers.NewPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pub.Close()
+
+	replayer := readers.NewReplayer(repo, pub, uuid.New(), logger)
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, repo, tc, auth, cfg.port, logger)
+		return startHTTPServer(ctx, repo, cache, audit, replayer, tc, auth, cfg.port, logger)
 	})
 
 	g.Go(func() error {
@@ -200,6 +230,10 @@ func loadConfig() config {
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		cacheURL:          mainflux.Env(envCacheURL, defCacheURL),
+		cachePass:         mainflux.Env(envCachePass, defCachePass),
+		cacheDB:           mainflux.Env(envCacheDB, defCacheDB),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		authGRPCTimeout:   authGRPCTimeout,
 	}
 }
@@ -213,6 +247,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToRedis(cacheURL, cachePass string, cacheDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(cacheDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to cache: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     cacheURL,
+		Password: cachePass,
+		DB:       db,
+	})
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -284,10 +332,10 @@ func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, port string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, repo readers.MessageRepository, cache readers.LatestMessageRepository, audit readers.AuditRepository, replayer readers.Replayer, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, port string, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, tc, ac, svcName, logger)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(repo, cache, audit, replayer, tc, ac, svcName, logger)}
 
 	logger.Info(fmt.Sprintf("Postgres reader service started, exposed port %s", port))
 	go func() {
@@ -0,0 +1,339 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/MainfluxLabs/mainflux/scheduler/api"
+	"github.com/MainfluxLabs/mainflux/scheduler/postgres"
+	"github.com/MainfluxLabs/mainflux/scheduler/tracing"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	svcName      = "scheduler"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel          = "error"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "scheduler"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defHTTPPort          = "8914"
+	defServerCert        = ""
+	defServerKey         = ""
+	defJaegerURL         = ""
+	defBrokerURL         = "nats://localhost:4222"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defInterval          = "1s"
+
+	envLogLevel          = "MF_SCHEDULER_LOG_LEVEL"
+	envDBHost            = "MF_SCHEDULER_DB_HOST"
+	envDBPort            = "MF_SCHEDULER_DB_PORT"
+	envDBUser            = "MF_SCHEDULER_DB_USER"
+	envDBPass            = "MF_SCHEDULER_DB_PASS"
+	envDB                = "MF_SCHEDULER_DB"
+	envDBSSLMode         = "MF_SCHEDULER_DB_SSL_MODE"
+	envDBSSLCert         = "MF_SCHEDULER_DB_SSL_CERT"
+	envDBSSLKey          = "MF_SCHEDULER_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_SCHEDULER_DB_SSL_ROOT_CERT"
+	envHTTPPort          = "MF_SCHEDULER_HTTP_PORT"
+	envServerCert        = "MF_SCHEDULER_SERVER_CERT"
+	envServerKey         = "MF_SCHEDULER_SERVER_KEY"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envBrokerURL         = "MF_BROKER_URL"
+	envClientTLS         = "MF_SCHEDULER_CLIENT_TLS"
+	envCACerts           = "MF_SCHEDULER_CA_CERTS"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envInterval          = "MF_SCHEDULER_INTERVAL"
+)
+
+type config struct {
+	logLevel          string
+	dbConfig          postgres.Config
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	jaegerURL         string
+	brokerURL         string
+	clientTLS         bool
+	caCerts           string
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	interval          time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	publisher, err := brokers.NewPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer publisher.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToGRPC(cfg, cfg.thingsGRPCURL, "things", logger)
+	defer thingsConn.Close()
+
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToGRPC(cfg, cfg.authGRPCURL, "auth", logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	tracer, closer := initJaeger(svcName, cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	dbTracer, dbCloser := initJaeger("scheduler_db", cfg.jaegerURL, logger)
+	defer dbCloser.Close()
+
+	database := postgres.NewDatabase(db)
+	schedules := tracing.ScheduleRepositoryMiddleware(postgres.NewScheduleRepository(database), dbTracer)
+	executions := tracing.ExecutionRepositoryMiddleware(postgres.NewExecutionRepository(database), dbTracer)
+
+	svc := newService(auth, tc, schedules, executions, logger)
+	runner := scheduler.NewRunner(tc, publisher, schedules, executions, uuid.New(), logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
+	})
+
+	g.Go(func() error {
+		logger.Info(fmt.Sprintf("Scheduler runner started, checking due schedules every %s", cfg.interval))
+		return runner.Run(ctx, cfg.interval)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Scheduler service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Scheduler service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	interval, err := time.ParseDuration(mainflux.Env(envInterval, defInterval))
+	if err != nil || interval <= 0 {
+		log.Fatalf("Invalid value passed for %s\n", envInterval)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	return config{
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:          dbConfig,
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		interval:          interval,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToGRPC(cfg config, url, name string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", name, err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func newService(ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, schedules scheduler.ScheduleRepository, executions scheduler.ExecutionRepository, logger logger.Logger) scheduler.Service {
+	svc := scheduler.New(ac, tc, schedules, executions, uuid.New())
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "scheduler",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "scheduler",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc scheduler.Service, port string, certFile string, keyFile string, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger)}
+
+	switch {
+	case certFile != "" || keyFile != "":
+		logger.Info(fmt.Sprintf("Scheduler service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		go func() {
+			errCh <- server.ListenAndServeTLS(certFile, keyFile)
+		}()
+	default:
+		logger.Info(fmt.Sprintf("Scheduler service started using http, exposed port %s", port))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Scheduler service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("scheduler service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Scheduler service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
 	"github.com/MainfluxLabs/mainflux/consumers/notifiers"
 	"github.com/MainfluxLabs/mainflux/consumers/notifiers/api"
 	"github.com/MainfluxLabs/mainflux/consumers/notifiers/postgres"
@@ -140,6 +142,13 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
 	authTracer, closer := initJaeger("auth", cfg.jaegerURL, logger)
 	defer closer.Close()
 
@@ -156,12 +165,14 @@ func main() {
 
 	svc := newService(db, dbTracer, auth, cfg, logger)
 
-	if err = consumers.Start(svcName, pubSub, svc, cfg.configPath, logger); err != nil {
+	dls, err := consumers.Start(svcName, pubSub, svc, dlPubSub, cfg.configPath, logger)
+	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
 	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
+		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, dls, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -320,10 +331,10 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceC
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc notifiers.Service, port string, certFile string, keyFile string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc notifiers.Service, port string, certFile string, keyFile string, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger)}
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, api.MakeHandler(svc, tracer, logger), logger)}
 
 	switch {
 	case certFile != "" || keyFile != "":
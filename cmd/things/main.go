@@ -19,6 +19,7 @@ import (
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/api"
@@ -27,6 +28,7 @@ import (
 	thhttpapi "github.com/MainfluxLabs/mainflux/things/api/things/http"
 	"github.com/MainfluxLabs/mainflux/things/postgres"
 	rediscache "github.com/MainfluxLabs/mainflux/things/redis"
+	rediscons "github.com/MainfluxLabs/mainflux/things/redis/consumer"
 	localusers "github.com/MainfluxLabs/mainflux/things/standalone"
 	"github.com/MainfluxLabs/mainflux/things/tracing"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
@@ -42,6 +44,7 @@ import (
 
 const (
 	stopWaitTime = 5 * time.Second
+	svcName      = "things"
 
 	defLogLevel        = "error"
 	defDBHost          = "localhost"
@@ -71,6 +74,9 @@ const (
 	defJaegerURL       = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
+	defBrokerURL       = "nats://localhost:4222"
+	defESConsumerName  = "things"
+	defPresenceIntvl   = "10s"
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -100,6 +106,9 @@ const (
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envauthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	envBrokerURL       = "MF_BROKER_URL"
+	envESConsumerName  = "MF_THINGS_EVENT_CONSUMER"
+	envPresenceIntvl   = "MF_THINGS_PRESENCE_INTERVAL"
 )
 
 type config struct {
@@ -123,6 +132,9 @@ type config struct {
 	jaegerURL       string
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
+	brokerURL       string
+	esConsumerName  string
+	presenceIntvl   time.Duration
 }
 
 func main() {
@@ -161,6 +173,20 @@ func main() {
 
 	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, logger)
 
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, svcName, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	if err := pubSub.Subscribe(svcName, brokers.SubjectAllChannels, things.NewPresenceHandler(svc, cfg.presenceIntvl)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to message broker: %s", err))
+		os.Exit(1)
+	}
+
+	go subscribeToMQTTES(svc, esClient, cfg.esConsumerName, logger)
+
 	g.Go(func() error {
 		return startHTTPServer(ctx, "thing-http", thhttpapi.MakeHandler(thingsTracer, svc, logger), cfg.httpPort, cfg, logger)
 	})
@@ -197,6 +223,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envauthGRPCTimeout, err.Error())
 	}
 
+	presenceIntvl, err := time.ParseDuration(mainflux.Env(envPresenceIntvl, defPresenceIntvl))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPresenceIntvl, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -230,6 +261,9 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
+		presenceIntvl:   presenceIntvl,
 	}
 }
 
@@ -326,6 +360,9 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(dbTracer, groupsRepo)
 
+	profilesRepo := postgres.NewProfileRepository(database)
+	profilesRepo = tracing.ProfileRepositoryMiddleware(dbTracer, profilesRepo)
+
 	chanCache := rediscache.NewChannelCache(cacheClient)
 	chanCache = tracing.ChannelCacheMiddleware(cacheTracer, chanCache)
 
@@ -333,7 +370,7 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
 	idProvider := uuid.New()
 
-	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -435,3 +472,11 @@ func startGRPCServer(ctx context.Context, svc things.Service, tracer opentracing
 		return err
 	}
 }
+
+func subscribeToMQTTES(svc things.Service, client *redis.Client, consumer string, logger logger.Logger) {
+	eventStore := rediscons.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.mqtt"); err != nil {
+		logger.Warn(fmt.Sprintf("Things service failed to subscribe to event sourcing: %s", err))
+	}
+}
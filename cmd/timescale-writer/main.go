@@ -9,60 +9,90 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	dlapi "github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	rapi "github.com/MainfluxLabs/mainflux/consumers/writers/retention/api"
+	rpostgres "github.com/MainfluxLabs/mainflux/consumers/writers/retention/postgres"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/timescale"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName      = "timescaledb-writer"
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel      = "error"
-	defBrokerURL     = "nats://localhost:4222"
-	defPort          = "8180"
-	defDBHost        = "localhost"
-	defDBPort        = "5432"
-	defDBUser        = "mainflux"
-	defDBPass        = "mainflux"
-	defDB            = "mainflux"
-	defDBSSLMode     = "disable"
-	defDBSSLCert     = ""
-	defDBSSLKey      = ""
-	defDBSSLRootCert = ""
-	defConfigPath    = "/config.toml"
-
-	envBrokerURL     = "MF_BROKER_URL"
-	envLogLevel      = "MF_TIMESCALE_WRITER_LOG_LEVEL"
-	envPort          = "MF_TIMESCALE_WRITER_PORT"
-	envDBHost        = "MF_TIMESCALE_WRITER_DB_HOST"
-	envDBPort        = "MF_TIMESCALE_WRITER_DB_PORT"
-	envDBUser        = "MF_TIMESCALE_WRITER_DB_USER"
-	envDBPass        = "MF_TIMESCALE_WRITER_DB_PASS"
-	envDB            = "MF_TIMESCALE_WRITER_DB"
-	envDBSSLMode     = "MF_TIMESCALE_WRITER_DB_SSL_MODE"
-	envDBSSLCert     = "MF_TIMESCALE_WRITER_DB_SSL_CERT"
-	envDBSSLKey      = "MF_TIMESCALE_WRITER_DB_SSL_KEY"
-	envDBSSLRootCert = "MF_TIMESCALE_WRITER_DB_SSL_ROOT_CERT"
-	envConfigPath    = "MF_TIMESCALE_WRITER_CONFIG_PATH"
+	defLogLevel          = "error"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defBrokerURL         = "nats://localhost:4222"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "mainflux"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defConfigPath        = "/config.toml"
+	defBatchSize         = "0"
+	defBatchTimeout      = "1s"
+	defRetentionInterval = "1h"
+
+	envBrokerURL         = "MF_BROKER_URL"
+	envLogLevel          = "MF_TIMESCALE_WRITER_LOG_LEVEL"
+	envClientTLS         = "MF_TIMESCALE_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_TIMESCALE_WRITER_CA_CERTS"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envPort              = "MF_TIMESCALE_WRITER_PORT"
+	envDBHost            = "MF_TIMESCALE_WRITER_DB_HOST"
+	envDBPort            = "MF_TIMESCALE_WRITER_DB_PORT"
+	envDBUser            = "MF_TIMESCALE_WRITER_DB_USER"
+	envDBPass            = "MF_TIMESCALE_WRITER_DB_PASS"
+	envDB                = "MF_TIMESCALE_WRITER_DB"
+	envDBSSLMode         = "MF_TIMESCALE_WRITER_DB_SSL_MODE"
+	envDBSSLCert         = "MF_TIMESCALE_WRITER_DB_SSL_CERT"
+	envDBSSLKey          = "MF_TIMESCALE_WRITER_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_TIMESCALE_WRITER_DB_SSL_ROOT_CERT"
+	envConfigPath        = "MF_TIMESCALE_WRITER_CONFIG_PATH"
+	envBatchSize         = "MF_TIMESCALE_WRITER_BATCH_SIZE"
+	envBatchTimeout      = "MF_TIMESCALE_WRITER_BATCH_TIMEOUT"
+	envRetentionInterval = "MF_TIMESCALE_WRITER_RETENTION_INTERVAL"
 )
 
 type config struct {
-	brokerURL  string
-	logLevel   string
-	port       string
-	configPath string
-	dbConfig   timescale.Config
+	brokerURL         string
+	logLevel          string
+	port              string
+	configPath        string
+	dbConfig          timescale.Config
+	batchCfg          consumers.BatchConfig
+	retentionInterval time.Duration
+	clientTLS         bool
+	caCerts           string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
 }
 
 func main() {
@@ -75,6 +105,11 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authGRPCTimeout)
+
 	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
@@ -82,17 +117,45 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPubSub, err := brokers.NewSubjectPubSub(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPubSub.Close()
+
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
 	repo := newService(db, logger)
 
-	if err = consumers.Start(svcName, pubSub, repo, cfg.configPath, logger); err != nil {
+	if cfg.batchCfg.Size > 1 {
+		batcher := consumers.NewBatcher(repo, cfg.batchCfg, logger)
+		defer func() {
+			if err := batcher.Close(); err != nil {
+				logger.Error(fmt.Sprintf("Failed to write buffered messages: %s", err))
+			}
+		}()
+		repo = batcher
+		logger.Info(fmt.Sprintf("Timescale writer batches up to %d messages", cfg.batchCfg.Size))
+	}
+
+	dls, err := consumers.Start(svcName, pubSub, repo, dlPubSub, cfg.configPath, logger)
+	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Timescale writer: %s", err))
 	}
+	dls = dlapi.LoggingMiddleware(dls, logger)
+
+	rsvc := newRetentionService(rpostgres.NewPolicyRepository(db), timescale.NewPruner(db), logger)
+	if cfg.retentionInterval > 0 {
+		g.Go(func() error {
+			retention.Run(ctx, rsvc, cfg.retentionInterval, logger)
+			return nil
+		})
+	}
 
 	g.Go(func() error {
-		return startHTTPServer(ctx, cfg.port, logger)
+		return startHTTPServer(ctx, cfg.port, rsvc, dls, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -121,12 +184,46 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+
+	batchTimeout, err := time.ParseDuration(mainflux.Env(envBatchTimeout, defBatchTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchTimeout, err.Error())
+	}
+
+	retentionInterval, err := time.ParseDuration(mainflux.Env(envRetentionInterval, defRetentionInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetentionInterval, err.Error())
+	}
+
+	clientTLS, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
 	return config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		port:       mainflux.Env(envPort, defPort),
-		configPath: mainflux.Env(envConfigPath, defConfigPath),
-		dbConfig:   dbConfig,
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		port:            mainflux.Env(envPort, defPort),
+		configPath:      mainflux.Env(envConfigPath, defConfigPath),
+		clientTLS:       clientTLS,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+		dbConfig:        dbConfig,
+		batchCfg: consumers.BatchConfig{
+			Size:     batchSize,
+			Interval: batchTimeout,
+		},
+		retentionInterval: retentionInterval,
 	}
 }
 
@@ -161,10 +258,32 @@ func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, port string, logger logger.Logger) error {
+func newRetentionService(policies retention.PolicyRepository, pruner retention.Pruner, logger logger.Logger) retention.Service {
+	svc := retention.New(policies, pruner)
+	svc = rapi.LoggingMiddleware(svc, logger)
+	svc = rapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "retention",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "retention",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func startHTTPServer(ctx context.Context, port string, rsvc retention.Service, dls deadletters.Service, auth mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: dlapi.MakeHandler(dls, auth, rapi.MakeHandler(rsvc, auth, svcName, logger), logger)}
 
 	logger.Info(fmt.Sprintf("Timescale writer service started, exposed port %s", port))
 	go func() {
@@ -186,3 +305,29 @@ func startHTTPServer(ctx context.Context, port string, logger logger.Logger) err
 	}
 
 }
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 const chansPrefix = "channels"
@@ -39,17 +40,19 @@ var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,
 type adapterService struct {
-	things  mainflux.ThingsServiceClient
-	pubsub  messaging.PubSub
-	obsLock sync.Mutex
+	things    mainflux.ThingsServiceClient
+	validator schema.Validator
+	pubsub    messaging.PubSub
+	obsLock   sync.Mutex
 }
 
 // New instantiates the CoAP adapter implementation.
 func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
 	as := &adapterService{
-		things:  things,
-		pubsub:  pubsub,
-		obsLock: sync.Mutex{},
+		things:    things,
+		validator: schema.NewValidator(things, schema.DefCacheTTL),
+		pubsub:    pubsub,
+		obsLock:   sync.Mutex{},
 	}
 
 	return as
@@ -57,8 +60,9 @@ func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: msg.Channel,
+		Token:    key,
+		ChanID:   msg.Channel,
+		Subtopic: msg.Subtopic,
 	}
 	thid, err := svc.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -66,13 +70,18 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	}
 	msg.Publisher = thid.GetValue()
 
+	if err := svc.validator.Validate(ctx, msg); err != nil {
+		return err
+	}
+
 	return svc.pubsub.Publish(msg.Channel, msg)
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: chanID,
+		Token:    key,
+		ChanID:   chanID,
+		Subtopic: subtopic,
 	}
 	if _, err := svc.things.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
@@ -86,8 +95,9 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  key,
-		ChanID: chanID,
+		Token:    key,
+		ChanID:   chanID,
+		Subtopic: subtopic,
 	}
 	if _, err := svc.things.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
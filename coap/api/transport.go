@@ -20,6 +20,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/coap"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
 	"github.com/plgd-dev/go-coap/v2/mux"
@@ -87,6 +88,8 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		sendResp(w, &resp)
 		return
 	}
+	msg.RemoteAddr = w.Client().RemoteAddr().String()
+
 	key, err := parseKey(m)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
@@ -111,6 +114,8 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		case errors.Contains(err, errors.ErrAuthorization),
 			errors.Contains(err, errors.ErrAuthentication):
 			resp.Code = codes.Unauthorized
+		case errors.Contains(err, schema.ErrInvalidPayload):
+			resp.Code = codes.BadRequest
 		default:
 			resp.Code = codes.InternalServerError
 		}
@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func sendCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sendCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		cmd := commands.Command{
+			ChannelID: req.ChannelID,
+			ThingID:   req.thingID,
+			Payload:   req.Payload,
+		}
+		cmd, err := svc.Send(ctx, req.token, cmd, req.timeout())
+		if err != nil {
+			// The timed out command is returned, so that it can be looked up later.
+			if errors.Contains(err, commands.ErrTimeout) && cmd.ID != "" {
+				res := toCommandRes(cmd)
+				res.timedOut = true
+				return res, nil
+			}
+			return nil, err
+		}
+
+		return toCommandRes(cmd), nil
+	}
+}
+
+func viewCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		cmd, err := svc.ViewCommand(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toCommandRes(cmd), nil
+	}
+}
+
+func listCommandsEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listCommandsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListCommands(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := commandsPageRes{
+			Total:    page.Total,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Commands: []commandRes{},
+		}
+		for _, cmd := range page.Commands {
+			res.Commands = append(res.Commands, toCommandRes(cmd))
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/commands/api"
+	"github.com/MainfluxLabs/mainflux/commands/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thmocks "github.com/MainfluxLabs/mainflux/things/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType  = "application/json"
+	token        = "token"
+	wrongValue   = "wrong-value"
+	email        = "user@example.com"
+	chanID       = "1"
+	thingID      = "2"
+	silentID     = "3"
+	otherChanID  = "4"
+	responseBody = "on"
+	defTimeout   = time.Second
+	shortTimeout = 100 * time.Millisecond
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+// device responds to the commands by publishing the plain text response.
+type device struct {
+	pubsub messaging.PubSub
+}
+
+func (d device) Handle(msg messaging.Message) error {
+	var env commands.Envelope
+	if err := json.Unmarshal(msg.Payload, &env); err != nil {
+		return err
+	}
+
+	res := messaging.Message{
+		Channel:   msg.Channel,
+		Subtopic:  fmt.Sprintf("%s.%s", commands.ResponsesSubtopic, env.ID),
+		Publisher: thingID,
+		Payload:   []byte(responseBody),
+	}
+	return d.pubsub.Publish(msg.Channel, res)
+}
+
+func (d device) Cancel() error {
+	return nil
+}
+
+func newService() commands.Service {
+	auth := thmocks.NewAuthService(map[string]string{token: email})
+	things := pkgmocks.NewThingsService(map[string]string{email: chanID}, nil)
+	pubsub := mocks.NewPubSub()
+	topic := fmt.Sprintf("channels.%s.%s.%s", chanID, commands.CommandsSubtopic, thingID)
+	pubsub.Subscribe(thingID, topic, device{pubsub: pubsub})
+
+	return commands.New(auth, things, pubsub, mocks.NewCommandRepository(), uuid.NewMock())
+}
+
+func newServer(svc commands.Service) *httptest.Server {
+	mux := api.MakeHandler(svc, mocktracer.New(), logger.NewMock())
+	return httptest.NewServer(mux)
+}
+
+type commandRes struct {
+	ID       string          `json:"id"`
+	ThingID  string          `json:"thing_id"`
+	Payload  json.RawMessage `json:"payload"`
+	Response json.RawMessage `json:"response"`
+	Status   string          `json:"status"`
+}
+
+type commandsPageRes struct {
+	Total    uint         `json:"total"`
+	Commands []commandRes `json:"commands"`
+}
+
+func TestSendCommand(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	body := fmt.Sprintf(`{"channel_id":"%s","payload":{"switch":"on"},"timeout":"100ms"}`, chanID)
+
+	cases := []struct {
+		desc        string
+		thingID     string
+		body        string
+		contentType string
+		token       string
+		status      int
+		cmdStatus   string
+		response    string
+	}{
+		{
+			desc:        "send command to responding thing",
+			thingID:     thingID,
+			body:        body,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusOK,
+			cmdStatus:   commands.StatusCompleted,
+			response:    fmt.Sprintf("%q", responseBody),
+		},
+		{
+			desc:        "send command to silent thing",
+			thingID:     silentID,
+			body:        body,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusGatewayTimeout,
+			cmdStatus:   commands.StatusFailed,
+		},
+		{
+			desc:        "send command on other user's channel",
+			thingID:     thingID,
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":"on"}`, otherChanID),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "send command with invalid token",
+			thingID:     thingID,
+			body:        body,
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command with empty token",
+			thingID:     thingID,
+			body:        body,
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command without channel",
+			thingID:     thingID,
+			body:        `{"payload":"on"}`,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command without payload",
+			thingID:     thingID,
+			body:        fmt.Sprintf(`{"channel_id":"%s"}`, chanID),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command with invalid timeout",
+			thingID:     thingID,
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":"on","timeout":"1h"}`, chanID),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command with malformed body",
+			thingID:     thingID,
+			body:        "}",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command without content type",
+			thingID:     thingID,
+			body:        body,
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/commands", ts.URL, tc.thingID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body commandRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.cmdStatus, body.Status, fmt.Sprintf("%s: expected command status %s got %s", tc.desc, tc.cmdStatus, body.Status))
+		assert.Equal(t, tc.response, string(body.Response), fmt.Sprintf("%s: expected response %s got %s", tc.desc, tc.response, body.Response))
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cmd, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: thingID, Payload: []byte(`"on"`)}, defTimeout)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view existing command",
+			id:     cmd.ID,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing command",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view command with invalid token",
+			id:     cmd.ID,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view command with empty token",
+			id:     cmd.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/commands/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: thingID, Payload: []byte(`"on"`)}, defTimeout)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	_, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: silentID, Payload: []byte(`"on"`)}, shortTimeout)
+	require.NotNil(t, err, "expected timeout error")
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+		total  uint
+	}{
+		{
+			desc:   "list all commands",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			size:   4,
+			total:  4,
+		},
+		{
+			desc:   "list commands with limit",
+			query:  "?offset=1&limit=2",
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+			total:  4,
+		},
+		{
+			desc:   "list failed commands",
+			query:  "?status=failed",
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+			total:  1,
+		},
+		{
+			desc:   "list commands of thing",
+			query:  fmt.Sprintf("?thing=%s", silentID),
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+			total:  1,
+		},
+		{
+			desc:   "list commands with invalid status",
+			query:  "?status=sent",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list commands with limit too big",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list commands with invalid offset",
+			query:  "?offset=first",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list commands with invalid token",
+			query:  "",
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/commands%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var page commandsPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.size, len(page.Commands), fmt.Sprintf("%s: expected %d commands got %d", tc.desc, tc.size, len(page.Commands)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
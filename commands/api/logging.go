@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ commands.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    commands.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc commands.Service, logger log.Logger) commands.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Send(ctx context.Context, token string, cmd commands.Command, timeout time.Duration) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method send with id %s to thing %s on channel %s took %s to complete", c.ID, cmd.ThingID, cmd.ChannelID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Send(ctx, token, cmd, timeout)
+}
+
+func (lm *loggingMiddleware) ViewCommand(ctx context.Context, token, id string) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_command for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewCommand(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListCommands(ctx context.Context, token string, pm commands.PageMetadata) (p commands.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_commands took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListCommands(ctx, token, pm)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const (
+	maxLimitSize = 100
+	defTimeout   = 10 * time.Second
+	maxTimeout   = time.Minute
+)
+
+type sendCommandReq struct {
+	token     string
+	thingID   string
+	ChannelID string          `json:"channel_id"`
+	Payload   json.RawMessage `json:"payload"`
+	Timeout   string          `json:"timeout,omitempty"`
+}
+
+func (req sendCommandReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" || req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Payload) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if req.Timeout != "" {
+		timeout, err := time.ParseDuration(req.Timeout)
+		if err != nil || timeout <= 0 || timeout > maxTimeout {
+			return apiutil.ErrInvalidTimeout
+		}
+	}
+
+	return nil
+}
+
+// timeout returns the requested timeout, which is validated beforehand.
+func (req sendCommandReq) timeout() time.Duration {
+	if req.Timeout == "" {
+		return defTimeout
+	}
+
+	timeout, _ := time.ParseDuration(req.Timeout)
+	return timeout
+}
+
+type viewCommandReq struct {
+	token string
+	id    string
+}
+
+func (req viewCommandReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listCommandsReq struct {
+	token        string
+	pageMetadata commands.PageMetadata
+}
+
+func (req listCommandsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	switch req.pageMetadata.Status {
+	case "", commands.StatusPending, commands.StatusCompleted, commands.StatusFailed:
+	default:
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/commands"
+)
+
+var (
+	_ mainflux.Response = (*commandRes)(nil)
+	_ mainflux.Response = (*commandsPageRes)(nil)
+)
+
+type commandRes struct {
+	ID        string          `json:"id"`
+	ChannelID string          `json:"channel_id"`
+	ThingID   string          `json:"thing_id"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	Status    string          `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	Created   time.Time       `json:"created"`
+	Updated   time.Time       `json:"updated"`
+	timedOut  bool
+}
+
+func (res commandRes) Code() int {
+	if res.timedOut {
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusOK
+}
+
+func (res commandRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res commandRes) Empty() bool {
+	return false
+}
+
+type commandsPageRes struct {
+	Total    uint         `json:"total"`
+	Offset   uint         `json:"offset"`
+	Limit    uint         `json:"limit"`
+	Commands []commandRes `json:"commands"`
+}
+
+func (res commandsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res commandsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res commandsPageRes) Empty() bool {
+	return false
+}
+
+func toCommandRes(cmd commands.Command) commandRes {
+	return commandRes{
+		ID:        cmd.ID,
+		ChannelID: cmd.ChannelID,
+		ThingID:   cmd.ThingID,
+		Payload:   toJSON(cmd.Payload),
+		Response:  toJSON(cmd.Response),
+		Status:    cmd.Status,
+		Error:     cmd.Error,
+		Created:   cmd.Created,
+		Updated:   cmd.Updated,
+	}
+}
+
+// toJSON returns the JSON payloads as they are, and the other
+// payloads encoded as JSON strings.
+func toJSON(payload []byte) json.RawMessage {
+	if len(payload) == 0 || json.Valid(payload) {
+		return payload
+	}
+
+	data, err := json.Marshal(string(payload))
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
@@ -0,0 +1,183 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	statusKey   = "status"
+	thingKey    = "thing"
+	channelKey  = "channel"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc commands.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux := bone.New()
+
+	mux.Post("/things/:id/commands", kithttp.NewServer(
+		kitot.TraceServer(tracer, "send_command")(sendCommandEndpoint(svc)),
+		decodeSendCommand,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/commands/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_command")(viewCommandEndpoint(svc)),
+		decodeViewCommand,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/commands", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_commands")(listCommandsEndpoint(svc)),
+		decodeListCommands,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/health", mainflux.Health("commands"))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeSendCommand(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := sendCommandReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeViewCommand(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewCommandReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListCommands(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := apiutil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	thingID, err := apiutil.ReadStringQuery(r, thingKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	chanID, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listCommandsReq{
+		token: apiutil.ExtractBearerToken(r),
+		pageMetadata: commands.PageMetadata{
+			Offset:    uint(offset),
+			Limit:     uint(limit),
+			Status:    status,
+			ThingID:   thingID,
+			ChannelID: chanID,
+		},
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrInvalidTimeout,
+		err == apiutil.ErrLimitSize,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, commands.ErrTimeout):
+		w.WriteHeader(http.StatusGatewayTimeout)
+	case errors.Contains(err, commands.ErrFailedPublish),
+		errors.Contains(err, commands.ErrFailedSubscription):
+		w.WriteHeader(http.StatusBadGateway)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// StatusPending represents the command waiting for the thing response.
+	StatusPending = "pending"
+
+	// StatusCompleted represents the command answered by the thing.
+	StatusCompleted = "completed"
+
+	// StatusFailed represents the command which wasn't delivered or answered in time.
+	StatusFailed = "failed"
+)
+
+// Command represents a command sent to the thing over the channel.
+type Command struct {
+	ID        string
+	OwnerID   string
+	ChannelID string
+	ThingID   string
+	Payload   []byte
+	Response  []byte
+	Status    string
+	Error     string
+	Created   time.Time
+	Updated   time.Time
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint
+	Limit     uint
+	Status    string
+	ThingID   string
+	ChannelID string
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total    uint
+	Commands []Command
+}
+
+// CommandRepository specifies a command persistence API.
+type CommandRepository interface {
+	// Save persists the command.
+	Save(ctx context.Context, cmd Command) error
+
+	// Update updates the command status, response, error and update time.
+	Update(ctx context.Context, cmd Command) error
+
+	// RetrieveByID retrieves the command having the provided ID and owner.
+	RetrieveByID(ctx context.Context, owner, id string) (Command, error)
+
+	// RetrieveAll retrieves the subset of owner's commands, newest first.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (Page, error)
+}
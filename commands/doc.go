@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commands contains the domain concept definitions needed to support
+// Mainflux commands service functionality. Commands service sends commands to
+// things and waits for their responses, correlating them by command ID.
+package commands
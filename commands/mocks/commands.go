@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ commands.CommandRepository = (*commandRepositoryMock)(nil)
+
+type commandRepositoryMock struct {
+	mu       sync.Mutex
+	commands map[string]commands.Command
+}
+
+// NewCommandRepository creates in-memory command repository.
+func NewCommandRepository() commands.CommandRepository {
+	return &commandRepositoryMock{
+		commands: make(map[string]commands.Command),
+	}
+}
+
+func (crm *commandRepositoryMock) Save(_ context.Context, cmd commands.Command) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.commands[cmd.ID]; ok {
+		return errors.ErrConflict
+	}
+	crm.commands[cmd.ID] = cmd
+
+	return nil
+}
+
+func (crm *commandRepositoryMock) Update(_ context.Context, cmd commands.Command) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.commands[cmd.ID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	c.Status = cmd.Status
+	c.Response = cmd.Response
+	c.Error = cmd.Error
+	c.Updated = cmd.Updated
+	crm.commands[cmd.ID] = c
+
+	return nil
+}
+
+func (crm *commandRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (commands.Command, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.commands[id]
+	if !ok || c.OwnerID != owner {
+		return commands.Command{}, errors.ErrNotFound
+	}
+
+	return c, nil
+}
+
+func (crm *commandRepositoryMock) RetrieveAll(_ context.Context, owner string, pm commands.PageMetadata) (commands.Page, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var cmds []commands.Command
+	for _, c := range crm.commands {
+		if c.OwnerID != owner ||
+			(pm.Status != "" && c.Status != pm.Status) ||
+			(pm.ThingID != "" && c.ThingID != pm.ThingID) ||
+			(pm.ChannelID != "" && c.ChannelID != pm.ChannelID) {
+			continue
+		}
+		cmds = append(cmds, c)
+	}
+
+	// Newest first, the mock IDs are sequential.
+	sort.SliceStable(cmds, func(i, j int) bool {
+		return cmds[i].ID > cmds[j].ID
+	})
+
+	page := commands.Page{
+		PageMetadata: pm,
+		Total:        uint(len(cmds)),
+		Commands:     []commands.Command{},
+	}
+
+	start := pm.Offset
+	if start > uint(len(cmds)) {
+		return page, nil
+	}
+	end := uint(len(cmds))
+	if pm.Limit > 0 && start+pm.Limit < end {
+		end = start + pm.Limit
+	}
+	page.Commands = cmds[start:end]
+
+	return page, nil
+}
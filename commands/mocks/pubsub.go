@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var errPublish = errors.New("failed to publish")
+
+var _ messaging.PubSub = (*pubsubMock)(nil)
+
+type pubsubMock struct {
+	mu            sync.Mutex
+	subscriptions map[string]map[string]messaging.MessageHandler
+}
+
+// NewPubSub returns in-memory message publisher-subscriber, which delivers
+// the published messages to the handlers subscribed to the exact subject.
+// Publishing to the channel named "unavailable" fails.
+func NewPubSub() messaging.PubSub {
+	return &pubsubMock{
+		subscriptions: make(map[string]map[string]messaging.MessageHandler),
+	}
+}
+
+func (ps *pubsubMock) Publish(topic string, msg messaging.Message) error {
+	if topic == "unavailable" {
+		return errPublish
+	}
+
+	subject := fmt.Sprintf("channels.%s", topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	var handlers []messaging.MessageHandler
+	for _, h := range ps.subscriptions[subject] {
+		handlers = append(handlers, h)
+	}
+	ps.mu.Unlock()
+
+	for _, h := range handlers {
+		if err := h.Handle(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ps *pubsubMock) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, ok := ps.subscriptions[topic]; !ok {
+		ps.subscriptions[topic] = make(map[string]messaging.MessageHandler)
+	}
+	ps.subscriptions[topic][id] = handler
+
+	return nil
+}
+
+func (ps *pubsubMock) Unsubscribe(id, topic string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	delete(ps.subscriptions[topic], id)
+
+	return nil
+}
+
+func (ps *pubsubMock) Close() error {
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ commands.CommandRepository = (*commandRepository)(nil)
+
+type commandRepository struct {
+	db Database
+}
+
+// New instantiates a PostgreSQL implementation of command repository.
+func New(db Database) commands.CommandRepository {
+	return &commandRepository{
+		db: db,
+	}
+}
+
+func (cr commandRepository) Save(ctx context.Context, cmd commands.Command) error {
+	q := `INSERT INTO commands (id, owner_id, channel_id, thing_id, payload, response, status, error, created, updated)
+		VALUES (:id, :owner_id, :channel_id, :thing_id, :payload, :response, :status, :error, :created, :updated)`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBCommand(cmd)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr commandRepository) Update(ctx context.Context, cmd commands.Command) error {
+	q := `UPDATE commands SET status = :status, response = :response, error = :error, updated = :updated WHERE id = :id`
+
+	res, err := cr.db.NamedExecContext(ctx, q, toDBCommand(cmd))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr commandRepository) RetrieveByID(ctx context.Context, owner, id string) (commands.Command, error) {
+	q := `SELECT id, owner_id, channel_id, thing_id, payload, response, status, error, created, updated
+		FROM commands WHERE id = $1 AND owner_id = $2`
+
+	dbc := dbCommand{}
+	if err := cr.db.QueryRowxContext(ctx, q, id, owner).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return commands.Command{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return commands.Command{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toCommand(dbc), nil
+}
+
+func (cr commandRepository) RetrieveAll(ctx context.Context, owner string, pm commands.PageMetadata) (commands.Page, error) {
+	conditions := []string{"owner_id = :owner_id"}
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"offset":   pm.Offset,
+		"limit":    pm.Limit,
+	}
+	if pm.Status != "" {
+		conditions = append(conditions, "status = :status")
+		params["status"] = pm.Status
+	}
+	if pm.ThingID != "" {
+		conditions = append(conditions, "thing_id = :thing_id")
+		params["thing_id"] = pm.ThingID
+	}
+	if pm.ChannelID != "" {
+		conditions = append(conditions, "channel_id = :channel_id")
+		params["channel_id"] = pm.ChannelID
+	}
+	whereClause := fmt.Sprintf("WHERE %s", strings.Join(conditions, " AND "))
+
+	limitClause := ""
+	if pm.Limit > 0 {
+		limitClause = "LIMIT :limit"
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, channel_id, thing_id, payload, response, status, error, created, updated
+		FROM commands %s ORDER BY created DESC, id DESC %s OFFSET :offset`, whereClause, limitClause)
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return commands.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	cmds := []commands.Command{}
+	for rows.Next() {
+		dbc := dbCommand{}
+		if err := rows.StructScan(&dbc); err != nil {
+			return commands.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		cmds = append(cmds, toCommand(dbc))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM commands %s`, whereClause)
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return commands.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := commands.Page{
+		PageMetadata: pm,
+		Total:        total,
+		Commands:     cmds,
+	}
+
+	return page, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var total uint
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+type dbCommand struct {
+	ID        string         `db:"id"`
+	OwnerID   string         `db:"owner_id"`
+	ChannelID string         `db:"channel_id"`
+	ThingID   string         `db:"thing_id"`
+	Payload   []byte         `db:"payload"`
+	Response  []byte         `db:"response"`
+	Status    string         `db:"status"`
+	Error     sql.NullString `db:"error"`
+	Created   time.Time      `db:"created"`
+	Updated   time.Time      `db:"updated"`
+}
+
+func toDBCommand(cmd commands.Command) dbCommand {
+	return dbCommand{
+		ID:        cmd.ID,
+		OwnerID:   cmd.OwnerID,
+		ChannelID: cmd.ChannelID,
+		ThingID:   cmd.ThingID,
+		Payload:   cmd.Payload,
+		Response:  cmd.Response,
+		Status:    cmd.Status,
+		Error:     sql.NullString{String: cmd.Error, Valid: cmd.Error != ""},
+		Created:   cmd.Created,
+		Updated:   cmd.Updated,
+	}
+}
+
+func toCommand(dbc dbCommand) commands.Command {
+	return commands.Command{
+		ID:        dbc.ID,
+		OwnerID:   dbc.OwnerID,
+		ChannelID: dbc.ChannelID,
+		ThingID:   dbc.ThingID,
+		Payload:   dbc.Payload,
+		Response:  dbc.Response,
+		Status:    dbc.Status,
+		Error:     dbc.Error.String,
+		Created:   dbc.Created,
+		Updated:   dbc.Updated,
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/commands/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	owner       = "owner"
+	chanID      = "channel"
+	thingID     = "thing"
+	numCommands = 10
+)
+
+func newCommand(t *testing.T, created time.Time) commands.Command {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return commands.Command{
+		ID:        id,
+		OwnerID:   owner,
+		ChannelID: chanID,
+		ThingID:   thingID,
+		Payload:   []byte(`{"switch":"on"}`),
+		Status:    commands.StatusPending,
+		Created:   created,
+		Updated:   created,
+	}
+}
+
+func TestSave(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+	cmd := newCommand(t, time.Now().UTC())
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "save command",
+			cmd:  cmd,
+			err:  nil,
+		},
+		{
+			desc: "save existing command",
+			cmd:  cmd,
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+	cmd := newCommand(t, time.Now().UTC())
+	err := repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	completed := cmd
+	completed.Status = commands.StatusCompleted
+	completed.Response = []byte("on")
+
+	unknown := newCommand(t, time.Now().UTC())
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "update command",
+			cmd:  completed,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing command",
+			cmd:  unknown,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.RetrieveByID(context.Background(), owner, cmd.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, completed.Status, saved.Status, fmt.Sprintf("expected status %s got %s\n", completed.Status, saved.Status))
+	assert.Equal(t, completed.Response, saved.Response, fmt.Sprintf("expected response %s got %s\n", completed.Response, saved.Response))
+}
+
+func TestRetrieveByID(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+	cmd := newCommand(t, time.Now().UTC())
+	err := repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve existing command",
+			owner: owner,
+			id:    cmd.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve command of other owner",
+			owner: "other",
+			id:    cmd.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing command",
+			owner: owner,
+			id:    "non-existing",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.RetrieveByID(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	_, err := db.Exec("DELETE FROM commands")
+	require.Nil(t, err, fmt.Sprintf("cleanup must not fail: %s", err))
+
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	now := time.Now().UTC()
+	for i := 0; i < numCommands; i++ {
+		cmd := newCommand(t, now.Add(time.Duration(i)*time.Second))
+		if i%2 == 0 {
+			cmd.Status = commands.StatusFailed
+		}
+		err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		owner string
+		pm    commands.PageMetadata
+		size  int
+		total uint
+	}{
+		{
+			desc:  "retrieve all commands",
+			owner: owner,
+			pm:    commands.PageMetadata{},
+			size:  numCommands,
+			total: numCommands,
+		},
+		{
+			desc:  "retrieve commands page",
+			owner: owner,
+			pm:    commands.PageMetadata{Offset: 2, Limit: 5},
+			size:  5,
+			total: numCommands,
+		},
+		{
+			desc:  "retrieve failed commands",
+			owner: owner,
+			pm:    commands.PageMetadata{Status: commands.StatusFailed},
+			size:  numCommands / 2,
+			total: numCommands / 2,
+		},
+		{
+			desc:  "retrieve commands of other thing",
+			owner: owner,
+			pm:    commands.PageMetadata{ThingID: "other"},
+			size:  0,
+			total: 0,
+		},
+		{
+			desc:  "retrieve commands of other owner",
+			owner: "other",
+			pm:    commands.PageMetadata{},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Commands), fmt.Sprintf("%s: expected %d commands got %d\n", tc.desc, tc.size, len(page.Commands)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "commands_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS commands (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        owner_id    VARCHAR(254) NOT NULL,
+                        channel_id  VARCHAR(254) NOT NULL,
+                        thing_id    VARCHAR(254) NOT NULL,
+                        payload     BYTEA,
+                        response    BYTEA,
+                        status      VARCHAR(16) NOT NULL,
+                        error       TEXT,
+                        created     TIMESTAMPTZ NOT NULL,
+                        updated     TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_commands_owner_created ON commands (owner_id, created DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS commands",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
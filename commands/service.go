@@ -0,0 +1,229 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	// CommandsSubtopic is the subtopic prefix on which the commands are
+	// published, followed by the ID of the addressed thing.
+	CommandsSubtopic = "commands"
+
+	// ResponsesSubtopic is the subtopic prefix on which the things publish
+	// responses, followed by the ID of the answered command.
+	ResponsesSubtopic = "responses"
+
+	// Protocol is the protocol of the published command messages.
+	Protocol = "commands"
+
+	chansPrefix = "channels"
+)
+
+var (
+	// ErrTimeout indicates that the thing didn't respond to the command in time.
+	ErrTimeout = errors.New("timed out waiting for command response")
+
+	// ErrFailedPublish indicates that the command publishing failed.
+	ErrFailedPublish = errors.New("failed to publish command")
+
+	// ErrFailedSubscription indicates that the subscription to the command response failed.
+	ErrFailedSubscription = errors.New("failed to subscribe to command response")
+)
+
+// Envelope represents the message published to the thing, which carries the
+// command ID the thing has to respond to.
+type Envelope struct {
+	ID      string          `json:"id"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// Send publishes the command to the thing connected to the channel and
+	// waits for the thing response until the timeout expires. The command
+	// is returned together with ErrTimeout if the thing didn't respond in time.
+	Send(ctx context.Context, token string, cmd Command, timeout time.Duration) (Command, error)
+
+	// ViewCommand retrieves the command having the provided ID.
+	ViewCommand(ctx context.Context, token, id string) (Command, error)
+
+	// ListCommands retrieves the user's commands matching the page metadata.
+	ListCommands(ctx context.Context, token string, pm PageMetadata) (Page, error)
+}
+
+var _ Service = (*commandsService)(nil)
+
+type commandsService struct {
+	auth     mainflux.AuthServiceClient
+	things   mainflux.ThingsServiceClient
+	pubsub   messaging.PubSub
+	commands CommandRepository
+	idp      mainflux.IDProvider
+}
+
+// New instantiates the commands service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, pubsub messaging.PubSub, commands CommandRepository, idp mainflux.IDProvider) Service {
+	return &commandsService{
+		auth:     auth,
+		things:   things,
+		pubsub:   pubsub,
+		commands: commands,
+		idp:      idp,
+	}
+}
+
+func (cs *commandsService) Send(ctx context.Context, token string, cmd Command, timeout time.Duration) (Command, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := cs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetId(), ChanID: cmd.ChannelID}); err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	cmdSubtopic := fmt.Sprintf("%s.%s", CommandsSubtopic, cmd.ThingID)
+	if _, err := cs.things.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: cmd.ThingID, ChanID: cmd.ChannelID, Subtopic: cmdSubtopic}); err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	if cmd.ID, err = cs.idp.ID(); err != nil {
+		return Command{}, err
+	}
+
+	// The thing must also be allowed to publish the response.
+	resSubtopic := fmt.Sprintf("%s.%s", ResponsesSubtopic, cmd.ID)
+	if _, err := cs.things.CanAccessByID(ctx, &mainflux.AccessByIDReq{ThingID: cmd.ThingID, ChanID: cmd.ChannelID, Subtopic: resSubtopic}); err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	now := time.Now().UTC()
+	cmd.OwnerID = res.GetId()
+	cmd.Status = StatusPending
+	cmd.Created = now
+	cmd.Updated = now
+	if err := cs.commands.Save(ctx, cmd); err != nil {
+		return Command{}, err
+	}
+
+	// Subscribe before publishing, so that the response can't be missed.
+	h := newResponseHandler(cmd.ThingID)
+	topic := responseTopic(cmd.ChannelID, cmd.ID)
+	if err := cs.pubsub.Subscribe(cmd.ID, topic, h); err != nil {
+		return cs.fail(cmd, errors.Wrap(ErrFailedSubscription, err))
+	}
+	defer cs.pubsub.Unsubscribe(cmd.ID, topic)
+
+	payload, err := json.Marshal(Envelope{ID: cmd.ID, Payload: cmd.Payload})
+	if err != nil {
+		return cs.fail(cmd, errors.Wrap(ErrFailedPublish, err))
+	}
+
+	msg := messaging.Message{
+		Channel:   cmd.ChannelID,
+		Subtopic:  cmdSubtopic,
+		Publisher: cmd.OwnerID,
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   now.UnixNano(),
+	}
+	if err := cs.pubsub.Publish(cmd.ChannelID, msg); err != nil {
+		return cs.fail(cmd, errors.Wrap(ErrFailedPublish, err))
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-h.responses:
+		cmd.Status = StatusCompleted
+		cmd.Response = resp.GetPayload()
+		cmd.Updated = time.Now().UTC()
+		if err := cs.commands.Update(context.Background(), cmd); err != nil {
+			return Command{}, err
+		}
+		return cmd, nil
+	case <-timer.C:
+		return cs.fail(cmd, ErrTimeout)
+	case <-ctx.Done():
+		return cs.fail(cmd, ctx.Err())
+	}
+}
+
+func (cs *commandsService) ViewCommand(ctx context.Context, token, id string) (Command, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Command{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return cs.commands.RetrieveByID(ctx, res.GetId(), id)
+}
+
+func (cs *commandsService) ListCommands(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return cs.commands.RetrieveAll(ctx, res.GetId(), pm)
+}
+
+// fail marks the command as failed and returns it together with the cause.
+// The update is not bound to the request context, which may be canceled.
+func (cs *commandsService) fail(cmd Command, cause error) (Command, error) {
+	cmd.Status = StatusFailed
+	cmd.Error = cause.Error()
+	cmd.Updated = time.Now().UTC()
+	if err := cs.commands.Update(context.Background(), cmd); err != nil {
+		return Command{}, errors.Wrap(cause, err)
+	}
+
+	return cmd, cause
+}
+
+func responseTopic(chanID, cmdID string) string {
+	return fmt.Sprintf("%s.%s.%s.%s", chansPrefix, chanID, ResponsesSubtopic, cmdID)
+}
+
+var _ messaging.MessageHandler = (*responseHandler)(nil)
+
+type responseHandler struct {
+	thingID   string
+	responses chan messaging.Message
+}
+
+func newResponseHandler(thingID string) *responseHandler {
+	return &responseHandler{
+		thingID:   thingID,
+		responses: make(chan messaging.Message, 1),
+	}
+}
+
+// Handle accepts the first response published by the commanded thing.
+func (h *responseHandler) Handle(msg messaging.Message) error {
+	if msg.GetPublisher() != h.thingID {
+		return nil
+	}
+
+	select {
+	case h.responses <- msg:
+	default:
+	}
+
+	return nil
+}
+
+func (h *responseHandler) Cancel() error {
+	return nil
+}
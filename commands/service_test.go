@@ -0,0 +1,297 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/commands"
+	"github.com/MainfluxLabs/mainflux/commands/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thmocks "github.com/MainfluxLabs/mainflux/things/mocks"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	token        = "token"
+	otherToken   = "other-token"
+	wrongValue   = "wrong-value"
+	email        = "user@example.com"
+	chanID       = "1"
+	thingID      = "2"
+	silentID     = "3"
+	otherID      = "4"
+	timeout      = 100 * time.Millisecond
+	unavailable  = "unavailable"
+	otherChanID  = "5"
+	otherEmail   = "other@example.com"
+	responseBody = `{"state":"on"}`
+	restrictedID = "6"
+	cmdOnlyID    = "7"
+)
+
+var (
+	payload = []byte(`{"switch":"on"}`)
+
+	// subtopics are the subtopic ACL patterns of the restricted connections.
+	subtopics = map[string][]string{
+		restrictedID: {"commands.#", "responses.#"},
+		cmdOnlyID:    {"commands.#"},
+	}
+)
+
+// thingsMock restricts the connections of the things to their subtopic
+// ACL patterns.
+type thingsMock struct {
+	mainflux.ThingsServiceClient
+}
+
+func (tm thingsMock) CanAccessByID(ctx context.Context, in *mainflux.AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if patterns, ok := subtopics[in.GetThingID()]; ok && !messaging.MatchSubtopic(patterns, in.GetSubtopic()) {
+		return nil, errors.ErrAuthorization
+	}
+
+	return tm.ThingsServiceClient.CanAccessByID(ctx, in, opts...)
+}
+
+// device responds to the commands published to the thing, unless the thing
+// is silent. The response is published by the responder.
+type device struct {
+	pubsub    messaging.PubSub
+	responder string
+}
+
+func (d device) Handle(msg messaging.Message) error {
+	var env commands.Envelope
+	if err := json.Unmarshal(msg.Payload, &env); err != nil {
+		return err
+	}
+
+	res := messaging.Message{
+		Channel:   msg.Channel,
+		Subtopic:  fmt.Sprintf("%s.%s", commands.ResponsesSubtopic, env.ID),
+		Publisher: d.responder,
+		Payload:   []byte(responseBody),
+	}
+	return d.pubsub.Publish(msg.Channel, res)
+}
+
+func (d device) Cancel() error {
+	return nil
+}
+
+func newService() commands.Service {
+	auth := thmocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := thingsMock{pkgmocks.NewThingsService(map[string]string{email: chanID, otherEmail: unavailable}, nil)}
+	pubsub := mocks.NewPubSub()
+	for _, id := range []string{thingID, otherID} {
+		topic := fmt.Sprintf("channels.%s.%s.%s", chanID, commands.CommandsSubtopic, id)
+		pubsub.Subscribe(id, topic, device{pubsub: pubsub, responder: thingID})
+	}
+	for _, id := range []string{restrictedID, cmdOnlyID} {
+		topic := fmt.Sprintf("channels.%s.%s.%s", chanID, commands.CommandsSubtopic, id)
+		pubsub.Subscribe(id, topic, device{pubsub: pubsub, responder: id})
+	}
+
+	return commands.New(auth, things, pubsub, mocks.NewCommandRepository(), uuid.NewMock())
+}
+
+func TestSend(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		token    string
+		cmd      commands.Command
+		status   string
+		response []byte
+		err      error
+	}{
+		{
+			desc:     "send command to responding thing",
+			token:    token,
+			cmd:      commands.Command{ChannelID: chanID, ThingID: thingID, Payload: payload},
+			status:   commands.StatusCompleted,
+			response: []byte(responseBody),
+			err:      nil,
+		},
+		{
+			desc:     "send command to thing restricted to commands subtopics",
+			token:    token,
+			cmd:      commands.Command{ChannelID: chanID, ThingID: restrictedID, Payload: payload},
+			status:   commands.StatusCompleted,
+			response: []byte(responseBody),
+			err:      nil,
+		},
+		{
+			desc:  "send command to thing not allowed to respond",
+			token: token,
+			cmd:   commands.Command{ChannelID: chanID, ThingID: cmdOnlyID, Payload: payload},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:   "send command to silent thing",
+			token:  token,
+			cmd:    commands.Command{ChannelID: chanID, ThingID: silentID, Payload: payload},
+			status: commands.StatusFailed,
+			err:    commands.ErrTimeout,
+		},
+		{
+			desc:   "send command answered by other thing",
+			token:  token,
+			cmd:    commands.Command{ChannelID: chanID, ThingID: otherID, Payload: payload},
+			status: commands.StatusFailed,
+			err:    commands.ErrTimeout,
+		},
+		{
+			desc:   "send command on unavailable broker",
+			token:  otherToken,
+			cmd:    commands.Command{ChannelID: unavailable, ThingID: thingID, Payload: payload},
+			status: commands.StatusFailed,
+			err:    commands.ErrFailedPublish,
+		},
+		{
+			desc:  "send command with invalid token",
+			token: wrongValue,
+			cmd:   commands.Command{ChannelID: chanID, ThingID: thingID, Payload: payload},
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "send command on other user's channel",
+			token: token,
+			cmd:   commands.Command{ChannelID: otherChanID, ThingID: thingID, Payload: payload},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "send command to thing without ID",
+			token: token,
+			cmd:   commands.Command{ChannelID: chanID, Payload: payload},
+			err:   errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		cmd, err := svc.Send(context.Background(), tc.token, tc.cmd, timeout)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.status, cmd.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, cmd.Status))
+		assert.Equal(t, tc.response, cmd.Response, fmt.Sprintf("%s: expected response %s got %s\n", tc.desc, tc.response, cmd.Response))
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	svc := newService()
+
+	cmd, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: thingID, Payload: payload}, timeout)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view existing command",
+			token: token,
+			id:    cmd.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view non-existing command",
+			token: token,
+			id:    wrongValue,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "view command with invalid token",
+			token: wrongValue,
+			id:    cmd.ID,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ViewCommand(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	svc := newService()
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: thingID, Payload: payload}, timeout)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	_, err := svc.Send(context.Background(), token, commands.Command{ChannelID: chanID, ThingID: silentID, Payload: payload}, timeout)
+	require.True(t, errors.Contains(err, commands.ErrTimeout), fmt.Sprintf("expected %s got %s", commands.ErrTimeout, err))
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    commands.PageMetadata
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list all commands",
+			token: token,
+			pm:    commands.PageMetadata{},
+			size:  4,
+			err:   nil,
+		},
+		{
+			desc:  "list commands with limit",
+			token: token,
+			pm:    commands.PageMetadata{Limit: 2},
+			size:  2,
+			err:   nil,
+		},
+		{
+			desc:  "list failed commands",
+			token: token,
+			pm:    commands.PageMetadata{Status: commands.StatusFailed},
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:  "list commands of thing",
+			token: token,
+			pm:    commands.PageMetadata{ThingID: thingID},
+			size:  3,
+			err:   nil,
+		},
+		{
+			desc:  "list pending commands",
+			token: token,
+			pm:    commands.PageMetadata{Status: commands.StatusPending},
+			size:  0,
+			err:   nil,
+		},
+		{
+			desc:  "list commands with invalid token",
+			token: wrongValue,
+			pm:    commands.PageMetadata{},
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListCommands(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Commands), fmt.Sprintf("%s: expected %d commands got %d\n", tc.desc, tc.size, len(page.Commands)))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/commands"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveCommandOp        = "save_command"
+	updateCommandOp      = "update_command"
+	retrieveCommandOp    = "retrieve_command_by_id"
+	retrieveAllCommandOp = "retrieve_all_commands"
+)
+
+var _ commands.CommandRepository = (*commandRepositoryMiddleware)(nil)
+
+type commandRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   commands.CommandRepository
+}
+
+// New instantiates a new command repository that
+// tracks request and their latency, and adds spans to context.
+func New(repo commands.CommandRepository, tracer opentracing.Tracer) commands.CommandRepository {
+	return commandRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (crm commandRepositoryMiddleware) Save(ctx context.Context, cmd commands.Command) error {
+	span := createSpan(ctx, crm.tracer, saveCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Save(ctx, cmd)
+}
+
+func (crm commandRepositoryMiddleware) Update(ctx context.Context, cmd commands.Command) error {
+	span := createSpan(ctx, crm.tracer, updateCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Update(ctx, cmd)
+}
+
+func (crm commandRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (commands.Command, error) {
+	span := createSpan(ctx, crm.tracer, retrieveCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveByID(ctx, owner, id)
+}
+
+func (crm commandRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm commands.PageMetadata) (commands.Page, error) {
+	span := createSpan(ctx, crm.tracer, retrieveAllCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
@@ -0,0 +1,247 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// BatchConfig specifies how the messages are accumulated before they
+// are written.
+type BatchConfig struct {
+	// Size is the number of messages that triggers a write.
+	Size int
+	// Interval is the longest time a message is kept in the buffer.
+	Interval time.Duration
+}
+
+// FailureHandler receives the message whose transformed messages were
+// buffered and failed to be written, along with the error.
+type FailureHandler func(msg messaging.Message, err error)
+
+// Batcher is a Consumer that accumulates the received messages and writes
+// them to the wrapped Consumer in batches.
+type Batcher interface {
+	Consumer
+
+	// ConsumeMessage buffers the messages transformed from the given
+	// message. If they fail to be written, the message is passed to the
+	// failure handler instead of returning the error, since the write
+	// usually happens in a call for another message or in the background.
+	ConsumeMessage(msg messaging.Message, messages interface{}) error
+
+	// OnFailure sets the handler of the messages failed to be written.
+	OnFailure(h FailureHandler)
+
+	// Close writes the buffered messages and stops the periodic writes.
+	Close() error
+}
+
+var _ Batcher = (*batcher)(nil)
+
+type batcher struct {
+	consumer  Consumer
+	cfg       BatchConfig
+	logger    logger.Logger
+	mu        sync.Mutex
+	pending   []part
+	count     int
+	onFailure FailureHandler
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// part is a buffered value passed to Consume, along with the message it's
+// transformed from, if known.
+type part struct {
+	msg      *messaging.Message
+	messages interface{}
+}
+
+// NewBatcher returns a Consumer that buffers up to cfg.Size messages, or
+// the messages received during cfg.Interval, and writes them to the
+// consumer at once. SenML messages are merged into a single slice and JSON
+// messages are merged per format. Consume blocks while the full buffer is
+// written, which bounds the memory used and slows down the subscriber when
+// the database can't keep up. The buffered messages are acknowledged to the
+// message broker before they are written, so they are lost if the process
+// exits without calling Close.
+func NewBatcher(consumer Consumer, cfg BatchConfig, logger logger.Logger) Batcher {
+	b := &batcher{
+		consumer: consumer,
+		cfg:      cfg,
+		logger:   logger,
+		done:     make(chan struct{}),
+	}
+
+	if cfg.Interval > 0 {
+		b.wg.Add(1)
+		go b.tick()
+	}
+
+	return b
+}
+
+func (b *batcher) Consume(messages interface{}) error {
+	return b.consume(part{messages: messages})
+}
+
+func (b *batcher) ConsumeMessage(msg messaging.Message, messages interface{}) error {
+	return b.consume(part{msg: &msg, messages: messages})
+}
+
+func (b *batcher) OnFailure(h FailureHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onFailure = h
+}
+
+func (b *batcher) consume(p part) error {
+	n := 0
+	switch m := p.messages.(type) {
+	case []senml.Message:
+		n = len(m)
+	case json.Messages:
+		n = len(m.Data)
+	default:
+		// Messages of unknown type can't be merged, so they are passed
+		// through as they are.
+		return b.consumer.Consume(p.messages)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending = append(b.pending, p)
+	b.count += n
+	if b.count < b.cfg.Size {
+		return nil
+	}
+
+	return b.flush()
+}
+
+func (b *batcher) Close() error {
+	close(b.done)
+	b.wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.flush()
+}
+
+func (b *batcher) tick() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			if err := b.flush(); err != nil {
+				b.logger.Warn(fmt.Sprintf("Failed to write batch of messages: %s", err))
+			}
+			b.mu.Unlock()
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// flush writes the buffered messages. If a merged batch is rejected, the
+// messages it's made of are written one by one, so that a single invalid
+// message doesn't cause the whole batch to be dropped. The messages failed
+// to be written are passed to the failure handler, and the first error of
+// the ones that can't be handled is returned. Must be called with the lock
+// held.
+func (b *batcher) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	pending := b.pending
+	b.pending = nil
+	b.count = 0
+
+	var ret error
+	for _, bt := range merge(pending) {
+		err := b.consumer.Consume(bt.messages)
+		if err == nil {
+			continue
+		}
+		if len(bt.parts) == 1 {
+			ret = b.fail(bt.parts[0], err, ret)
+			continue
+		}
+		for _, p := range bt.parts {
+			if err := b.consumer.Consume(p.messages); err != nil {
+				ret = b.fail(p, err, ret)
+			}
+		}
+	}
+
+	return ret
+}
+
+// fail passes the message of the failed part to the failure handler. If the
+// part can't be handled, the first of the errors is returned.
+func (b *batcher) fail(p part, err, first error) error {
+	if b.onFailure != nil && p.msg != nil {
+		b.onFailure(*p.msg, err)
+		return first
+	}
+	if first != nil {
+		return first
+	}
+	return err
+}
+
+type batch struct {
+	messages interface{}
+	parts    []part
+}
+
+func merge(pending []part) []batch {
+	var batches []batch
+
+	senmlIdx := -1
+	jsonIdx := map[string]int{}
+	for _, p := range pending {
+		switch m := p.messages.(type) {
+		case []senml.Message:
+			if senmlIdx < 0 {
+				senmlIdx = len(batches)
+				batches = append(batches, batch{messages: []senml.Message{}})
+			}
+			b := &batches[senmlIdx]
+			b.messages = append(b.messages.([]senml.Message), m...)
+			b.parts = append(b.parts, p)
+		case json.Messages:
+			i, ok := jsonIdx[m.Format]
+			if !ok {
+				i = len(batches)
+				jsonIdx[m.Format] = i
+				batches = append(batches, batch{messages: json.Messages{Format: m.Format}})
+			}
+			b := &batches[i]
+			msgs := b.messages.(json.Messages)
+			msgs.Data = append(msgs.Data, m.Data...)
+			b.messages = msgs
+			b.parts = append(b.parts, p)
+		}
+	}
+
+	return batches
+}
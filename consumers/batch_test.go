@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+const invalidName = "invalid"
+
+var errInvalid = errors.New("invalid message")
+
+type consumerMock struct {
+	mu    sync.Mutex
+	calls []interface{}
+}
+
+func (c *consumerMock) Consume(messages interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, messages)
+	if msgs, ok := messages.([]senml.Message); ok {
+		for _, m := range msgs {
+			if m.Name == invalidName {
+				return errInvalid
+			}
+		}
+	}
+	return nil
+}
+
+func (c *consumerMock) Calls() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]interface{}{}, c.calls...)
+}
+
+func senmlMessages(names ...string) []senml.Message {
+	var msgs []senml.Message
+	for _, n := range names {
+		msgs = append(msgs, senml.Message{Name: n})
+	}
+	return msgs
+}
+
+func TestBatcherSize(t *testing.T) {
+	c := &consumerMock{}
+	b := consumers.NewBatcher(c, consumers.BatchConfig{Size: 3}, logger.NewMock())
+
+	err := b.Consume(senmlMessages("a"))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	err = b.Consume(json.Messages{Format: "json", Data: []json.Message{{Subtopic: "b"}}})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Empty(t, c.Calls(), "expected messages to be buffered")
+
+	err = b.Consume(senmlMessages("c"))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	expected := []interface{}{
+		senmlMessages("a", "c"),
+		json.Messages{Format: "json", Data: []json.Message{{Subtopic: "b"}}},
+	}
+	assert.Equal(t, expected, c.Calls(), "expected merged batches to be written")
+
+	err = b.Close()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, c.Calls(), 2, "expected no writes of an empty buffer")
+}
+
+func TestBatcherInterval(t *testing.T) {
+	c := &consumerMock{}
+	b := consumers.NewBatcher(c, consumers.BatchConfig{Size: 100, Interval: 10 * time.Millisecond}, logger.NewMock())
+	defer b.Close()
+
+	err := b.Consume(senmlMessages("a", "b"))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	assert.Eventually(t, func() bool {
+		return len(c.Calls()) == 1
+	}, time.Second, 5*time.Millisecond, "expected buffered messages to be written after interval")
+	assert.Equal(t, senmlMessages("a", "b"), c.Calls()[0])
+}
+
+func TestBatcherClose(t *testing.T) {
+	c := &consumerMock{}
+	b := consumers.NewBatcher(c, consumers.BatchConfig{Size: 100, Interval: time.Hour}, logger.NewMock())
+
+	err := b.Consume(senmlMessages("a"))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	err = b.Close()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, []interface{}{senmlMessages("a")}, c.Calls(), "expected buffered messages to be written on close")
+}
+
+func TestBatcherInvalidMessage(t *testing.T) {
+	c := &consumerMock{}
+	b := consumers.NewBatcher(c, consumers.BatchConfig{Size: 3}, logger.NewMock())
+
+	for _, n := range []string{"a", invalidName} {
+		err := b.Consume(senmlMessages(n))
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	}
+	err := b.Consume(senmlMessages("c"))
+	assert.True(t, errors.Contains(err, errInvalid), fmt.Sprintf("expected %s got %s", errInvalid, err))
+
+	expected := []interface{}{
+		senmlMessages("a", invalidName, "c"),
+		senmlMessages("a"),
+		senmlMessages(invalidName),
+		senmlMessages("c"),
+	}
+	assert.Equal(t, expected, c.Calls(), "expected rejected batch to be written message by message")
+}
+
+func TestBatcherFailureHandler(t *testing.T) {
+	c := &consumerMock{}
+	b := consumers.NewBatcher(c, consumers.BatchConfig{Size: 3}, logger.NewMock())
+
+	failed := map[string]error{}
+	b.OnFailure(func(msg messaging.Message, err error) {
+		failed[msg.Publisher] = err
+	})
+
+	for _, n := range []string{"a", invalidName, "c"} {
+		msg := messaging.Message{Publisher: n}
+		err := b.ConsumeMessage(msg, senmlMessages(n))
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	}
+
+	assert.Len(t, failed, 1, fmt.Sprintf("expected 1 failed message got %d", len(failed)))
+	assert.True(t, errors.Contains(failed[invalidName], errInvalid), fmt.Sprintf("expected %s got %s", errInvalid, failed[invalidName]))
+
+	// The messages consumed without the source message can't be handled,
+	// so the error is returned.
+	err := b.Consume(senmlMessages(invalidName))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	err = b.Close()
+	assert.True(t, errors.Contains(err, errInvalid), fmt.Sprintf("expected %s got %s", errInvalid, err))
+	assert.Len(t, failed, 1, fmt.Sprintf("expected 1 failed message got %d", len(failed)))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listDeadLettersEndpoint(svc deadletters.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeadLettersReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.List(ctx, deadletters.PageMetadata{Offset: req.offset, Limit: req.limit})
+		if err != nil {
+			return nil, err
+		}
+
+		res := deadLettersPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			DeadLetters: []deadLetterRes{},
+		}
+		for _, dl := range page.DeadLetters {
+			res.DeadLetters = append(res.DeadLetters, toDeadLetterRes(dl))
+		}
+
+		return res, nil
+	}
+}
+
+func viewDeadLetterEndpoint(svc deadletters.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		dl, err := svc.View(ctx, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toDeadLetterRes(dl), nil
+	}
+}
+
+func redriveDeadLetterEndpoint(svc deadletters.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Redrive(ctx, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func redriveAllEndpoint(svc deadletters.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(redriveAllReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		n, err := svc.RedriveAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return redriveAllRes{Redriven: n}, nil
+	}
+}
+
+func removeDeadLetterEndpoint(svc deadletters.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Remove(ctx, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
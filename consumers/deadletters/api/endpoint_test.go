@@ -0,0 +1,436 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters/api"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	authmocks "github.com/MainfluxLabs/mainflux/users/mocks"
+	"github.com/go-zoo/bone"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	consumer   = "test-writer"
+	chanID     = "1"
+	failingID  = "2"
+	payload    = `{"temperature":`
+	adminToken = "admin@example.com"
+	userToken  = "user@example.com"
+	invalid    = "invalid"
+)
+
+var errTransform = errors.New("failed to transform message")
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type messageRes struct {
+	Channel string `json:"channel"`
+	Payload []byte `json:"payload"`
+}
+
+type deadLetterRes struct {
+	ID       string     `json:"id"`
+	Consumer string     `json:"consumer"`
+	Error    string     `json:"error"`
+	Attempts uint64     `json:"attempts"`
+	Message  messageRes `json:"message"`
+}
+
+type deadLettersPageRes struct {
+	Total       uint64          `json:"total"`
+	Offset      uint64          `json:"offset"`
+	Limit       uint64          `json:"limit"`
+	DeadLetters []deadLetterRes `json:"dead_letters"`
+}
+
+type redriveAllRes struct {
+	Redriven uint64 `json:"redriven"`
+}
+
+func newService(t *testing.T, handler messaging.MessageHandler) deadletters.Service {
+	cfg := deadletters.Config{
+		Subject:  "deadletters.test-writer",
+		Capacity: 100,
+	}
+
+	svc, err := deadletters.New(consumer, cfg, handler, mocks.NewPublisher(), uuid.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return svc
+}
+
+func newServer(svc deadletters.Service) *httptest.Server {
+	auth := authmocks.NewAuthService(map[string]users.User{
+		adminToken: {ID: "1", Email: adminToken},
+		userToken:  {ID: "2", Email: userToken},
+	})
+	return httptest.NewServer(api.MakeHandler(svc, auth, bone.New(), logger.NewMock()))
+}
+
+func saveDeadLetter(t *testing.T, svc deadletters.Service, channel string) deadletters.DeadLetter {
+	msg := messaging.Message{
+		Channel: channel,
+		Payload: []byte(payload),
+	}
+	err := svc.Save(context.Background(), msg, errTransform)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	page, err := svc.List(context.Background(), deadletters.PageMetadata{Limit: 1})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return page.DeadLetters[0]
+}
+
+func TestListDeadLetters(t *testing.T) {
+	svc := newService(t, mocks.NewHandler())
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < 15; i++ {
+		saveDeadLetter(t, svc, chanID)
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list dead letters",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   10,
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			url:    fmt.Sprintf("%s/deadletters?offset=10&limit=20", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			size:   5,
+		},
+		{
+			desc:   "list dead letters with limit over maximum",
+			url:    fmt.Sprintf("%s/deadletters?limit=1000", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with zero limit",
+			url:    fmt.Sprintf("%s/deadletters?limit=0", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			url:    fmt.Sprintf("%s/deadletters?offset=invalid", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters without token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters with invalid token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters as non-admin user",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page deadLettersPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, uint64(15), page.Total, fmt.Sprintf("%s: expected total 15 got %d", tc.desc, page.Total))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	svc := newService(t, mocks.NewHandler())
+	ts := newServer(svc)
+	defer ts.Close()
+
+	dl := saveDeadLetter(t, svc, chanID)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view dead letter",
+			id:     dl.ID,
+			token:  adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			id:     "non-existing",
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view dead letter without token",
+			id:     dl.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view dead letter with invalid token",
+			id:     dl.ID,
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view dead letter as non-admin user",
+			id:     dl.ID,
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body deadLetterRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		expected := deadLetterRes{
+			ID:       dl.ID,
+			Consumer: consumer,
+			Error:    errTransform.Error(),
+			Attempts: 1,
+			Message: messageRes{
+				Channel: chanID,
+				Payload: []byte(payload),
+			},
+		}
+		assert.Equal(t, expected, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, expected, body))
+	}
+}
+
+func TestRedriveDeadLetter(t *testing.T) {
+	handler := mocks.NewHandler()
+	handler.Fail(failingID)
+	svc := newService(t, handler)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	dl := saveDeadLetter(t, svc, chanID)
+	failing := saveDeadLetter(t, svc, failingID)
+
+	cases := []struct {
+		desc   string
+		id     string
+		status int
+	}{
+		{
+			desc:   "redrive dead letter",
+			id:     dl.ID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "redrive redriven dead letter",
+			id:     dl.ID,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "redrive failing dead letter",
+			id:     failing.ID,
+			status: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/%s/redrive", ts.URL, tc.id),
+			token:  adminToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRedriveAllDeadLetters(t *testing.T) {
+	handler := mocks.NewHandler()
+	handler.Fail(failingID)
+	svc := newService(t, handler)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saveDeadLetter(t, svc, chanID)
+	saveDeadLetter(t, svc, chanID)
+	saveDeadLetter(t, svc, failingID)
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodPost,
+		url:    fmt.Sprintf("%s/deadletters/redrive", ts.URL),
+		token:  adminToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body redriveAllRes
+	err = json.NewDecoder(res.Body).Decode(&body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, uint64(2), body.Redriven, fmt.Sprintf("expected 2 redriven dead letters got %d", body.Redriven))
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	svc := newService(t, mocks.NewHandler())
+	ts := newServer(svc)
+	defer ts.Close()
+
+	dl := saveDeadLetter(t, svc, chanID)
+
+	cases := []struct {
+		desc   string
+		id     string
+		status int
+	}{
+		{
+			desc:   "remove dead letter",
+			id:     dl.ID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed dead letter",
+			id:     dl.ID,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/deadletters/%s", ts.URL, tc.id),
+			token:  adminToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRedriveAllDeadLettersUnauthorized(t *testing.T) {
+	svc := newService(t, mocks.NewHandler())
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saveDeadLetter(t, svc, chanID)
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "redrive all dead letters without token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "redrive all dead letters with invalid token",
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "redrive all dead letters as non-admin user",
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/redrive", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	page, err := svc.List(context.Background(), deadletters.PageMetadata{Limit: 1})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected 1 kept dead letter got %d", page.Total))
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ deadletters.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    deadletters.Service
+}
+
+// LoggingMiddleware adds logging facilities to the dead-letter service.
+func LoggingMiddleware(svc deadletters.Service, logger log.Logger) deadletters.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) Save(ctx context.Context, msg messaging.Message, reason error) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method save for channel %s took %s to complete", msg.Channel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Save(ctx, msg, reason)
+}
+
+func (lm *loggingMiddleware) List(ctx context.Context, pm deadletters.PageMetadata) (page deadletters.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.List(ctx, pm)
+}
+
+func (lm *loggingMiddleware) View(ctx context.Context, id string) (dl deadletters.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.View(ctx, id)
+}
+
+func (lm *loggingMiddleware) Redrive(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method redrive for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Redrive(ctx, id)
+}
+
+func (lm *loggingMiddleware) RedriveAll(ctx context.Context) (n uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method redrive_all redrove %d dead letters and took %s to complete", n, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RedriveAll(ctx)
+}
+
+func (lm *loggingMiddleware) Remove(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove for dead letter %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Remove(ctx, id)
+}
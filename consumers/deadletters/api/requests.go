@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+
+const maxLimitSize = 100
+
+type listDeadLettersReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listDeadLettersReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit < 1 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type deadLetterReq struct {
+	token string
+	id    string
+}
+
+func (req deadLetterReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type redriveAllReq struct {
+	token string
+}
+
+func (req redriveAllReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
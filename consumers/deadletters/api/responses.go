@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+)
+
+var (
+	_ mainflux.Response = (*deadLetterRes)(nil)
+	_ mainflux.Response = (*deadLettersPageRes)(nil)
+	_ mainflux.Response = (*redriveAllRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type messageRes struct {
+	Channel   string `json:"channel"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Payload   []byte `json:"payload,omitempty"`
+	Created   int64  `json:"created"`
+	Replay    bool   `json:"replay,omitempty"`
+}
+
+type deadLetterRes struct {
+	ID       string     `json:"id"`
+	Consumer string     `json:"consumer"`
+	Error    string     `json:"error"`
+	Failed   time.Time  `json:"failed"`
+	Attempts uint64     `json:"attempts"`
+	Message  messageRes `json:"message"`
+}
+
+func (res deadLetterRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLetterRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type deadLettersPageRes struct {
+	pageRes
+	DeadLetters []deadLetterRes `json:"dead_letters"`
+}
+
+func (res deadLettersPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLettersPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLettersPageRes) Empty() bool {
+	return false
+}
+
+type redriveAllRes struct {
+	Redriven uint64 `json:"redriven"`
+}
+
+func (res redriveAllRes) Code() int {
+	return http.StatusOK
+}
+
+func (res redriveAllRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res redriveAllRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct{}
+
+func (res emptyRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
+
+func toDeadLetterRes(dl deadletters.DeadLetter) deadLetterRes {
+	return deadLetterRes{
+		ID:       dl.ID,
+		Consumer: dl.Consumer,
+		Error:    dl.Error,
+		Failed:   dl.Failed,
+		Attempts: dl.Attempts,
+		Message: messageRes{
+			Channel:   dl.Message.Channel,
+			Subtopic:  dl.Message.Subtopic,
+			Publisher: dl.Message.Publisher,
+			Protocol:  dl.Message.Protocol,
+			Payload:   dl.Message.Payload,
+			Created:   dl.Message.Created,
+			Replay:    dl.Message.Replay,
+		},
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler adds the dead-letter endpoints to the consumer's HTTP API
+// handler. The endpoints are restricted to the system admin.
+func MakeHandler(svc deadletters.Service, auth mainflux.AuthServiceClient, mux *bone.Mux, logger logger.Logger) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux.Get("/deadletters", kithttp.NewServer(
+		listDeadLettersEndpoint(svc, auth),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/deadletters/redrive", kithttp.NewServer(
+		redriveAllEndpoint(svc, auth),
+		decodeRedriveAll,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/deadletters/:id", kithttp.NewServer(
+		viewDeadLetterEndpoint(svc, auth),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/deadletters/:id/redrive", kithttp.NewServer(
+		redriveDeadLetterEndpoint(svc, auth),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/deadletters/:id", kithttp.NewServer(
+		removeDeadLetterEndpoint(svc, auth),
+		decodeDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listDeadLettersReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: offset,
+		limit:  limit,
+	}
+
+	return req, nil
+}
+
+func decodeRedriveAll(_ context.Context, r *http.Request) (interface{}, error) {
+	req := redriveAllReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeDeadLetter(_ context.Context, r *http.Request) (interface{}, error) {
+	req := deadLetterReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, apiutil.ErrLimitSize),
+		errors.Contains(err, apiutil.ErrMissingID):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, apiutil.ErrBearerToken),
+		errors.Contains(err, errors.ErrAuthentication):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, deadletters.ErrRedrive):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
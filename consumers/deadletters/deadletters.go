@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletters
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// DeadLetter represents the message the consumer failed to handle. The dead
+// letters are published to the dead-letter subject JSON encoded.
+type DeadLetter struct {
+	ID       string            `json:"id"`
+	Consumer string            `json:"consumer"`
+	Error    string            `json:"error"`
+	Failed   time.Time         `json:"failed"`
+	Attempts uint64            `json:"attempts"`
+	Message  messaging.Message `json:"message"`
+}
+
+// Config specifies where the dead letters are published and how many of them
+// are kept by the consumer.
+type Config struct {
+	// Subject is the subject the dead letters are published to and received
+	// from. The dead letters aren't shared with the other consumer instances
+	// if it's empty.
+	Subject string
+	// Capacity is the number of the dead letters kept in memory by the
+	// consumer instance for the inspection. Once it's reached, the oldest
+	// dead letter is dropped. No dead letters are kept if it's not positive.
+	Capacity int
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset uint64
+	Limit  uint64
+}
+
+// Page contains a page of the dead letters, from the newest one.
+type Page struct {
+	PageMetadata
+	Total       uint64
+	DeadLetters []DeadLetter
+}
@@ -0,0 +1,18 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deadletters contains the domain concept definitions needed to
+// support dead letters in the consumer services. The messages the consumer
+// fails to transform or consume are published to the dead-letter subject,
+// along with the reason of the failure, and kept by the consumer so that
+// they can be inspected and redriven.
+//
+// The consumer instances subscribe to the dead-letter subject, so each of
+// them keeps the dead letters of all the instances sharing its name, and the
+// dead letters can be inspected and redriven through any of them. The updated
+// dead letters are republished to the subject, and the removed ones are
+// announced on the subject with the ".removed" suffix. The kept dead letters
+// live in memory, so an instance keeps only the ones published since it
+// started. They should be collected by a durable subscriber of the
+// dead-letter subject where they mustn't be lost.
+package deadletters
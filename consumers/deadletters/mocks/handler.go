@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// ErrHandle is returned by the mock handler for the failing messages.
+var ErrHandle = errors.New("failed to handle message")
+
+// Handler represents the message handler which fails the messages of the
+// given channel, and keeps the handled ones.
+type Handler interface {
+	messaging.MessageHandler
+
+	// Fail sets the channel whose messages fail.
+	Fail(channel string)
+
+	// Handled returns the handled messages.
+	Handled() []messaging.Message
+}
+
+type handlerMock struct {
+	mu      sync.Mutex
+	fail    string
+	handled []messaging.Message
+}
+
+// NewHandler returns the mock message handler.
+func NewHandler() Handler {
+	return &handlerMock{}
+}
+
+func (h *handlerMock) Handle(msg messaging.Message) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if msg.Channel == h.fail {
+		return ErrHandle
+	}
+	h.handled = append(h.handled, msg)
+
+	return nil
+}
+
+func (h *handlerMock) Cancel() error {
+	return nil
+}
+
+func (h *handlerMock) Fail(channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fail = channel
+}
+
+func (h *handlerMock) Handled() []messaging.Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]messaging.Message{}, h.handled...)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var errPublish = errors.New("failed to publish")
+
+// Publisher represents the subject publisher and subscriber which keeps the
+// published data.
+type Publisher interface {
+	messaging.SubjectPubSub
+
+	// Published returns the published data by subject.
+	Published() map[string][][]byte
+}
+
+type publisherMock struct {
+	mu        sync.Mutex
+	published map[string][][]byte
+	handlers  map[string][]messaging.SubjectHandler
+}
+
+// NewPublisher returns the mock subject publisher, which passes the published
+// data to the subscribers of the subject synchronously. Publishing to the
+// subject named "unavailable" fails.
+func NewPublisher() Publisher {
+	return &publisherMock{
+		published: make(map[string][][]byte),
+		handlers:  make(map[string][]messaging.SubjectHandler),
+	}
+}
+
+func (pub *publisherMock) PublishSubject(subject string, data []byte) error {
+	if subject == "unavailable" {
+		return errPublish
+	}
+
+	pub.mu.Lock()
+	pub.published[subject] = append(pub.published[subject], data)
+	handlers := append([]messaging.SubjectHandler{}, pub.handlers[subject]...)
+	pub.mu.Unlock()
+
+	for _, h := range handlers {
+		h(data)
+	}
+
+	return nil
+}
+
+func (pub *publisherMock) SubscribeSubject(subject string, handler messaging.SubjectHandler) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.handlers[subject] = append(pub.handlers[subject], handler)
+
+	return nil
+}
+
+func (pub *publisherMock) Published() map[string][][]byte {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	published := make(map[string][][]byte)
+	for subject, data := range pub.published {
+		published[subject] = append([][]byte{}, data...)
+	}
+
+	return published
+}
+
+func (pub *publisherMock) Close() error {
+	return nil
+}
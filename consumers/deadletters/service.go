@@ -0,0 +1,300 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletters
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	// ErrPublish indicates that the dead letter couldn't be published to
+	// the dead-letter subject.
+	ErrPublish = errors.New("failed to publish dead letter")
+
+	// ErrRedrive indicates that the consumer failed to handle the redriven
+	// message again.
+	ErrRedrive = errors.New("failed to redrive dead letter")
+
+	// ErrSubscribe indicates that the dead-letter subject couldn't be
+	// subscribed to.
+	ErrSubscribe = errors.New("failed to subscribe to dead-letter subject")
+)
+
+// removedSuffix is appended to the dead-letter subject to get the subject
+// of the removed dead letters.
+const removedSuffix = ".removed"
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics). The
+// dead letters received from the dead-letter subject since the consumer
+// instance started are listed, viewed and redriven, whichever instance of the
+// consumer failed them.
+type Service interface {
+	// Save creates the dead letter of the message which failed with the
+	// given error and publishes it to the dead-letter subject.
+	Save(ctx context.Context, msg messaging.Message, reason error) error
+
+	// List retrieves the page of the kept dead letters, from the newest one.
+	List(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// View retrieves the dead letter.
+	View(ctx context.Context, id string) (DeadLetter, error)
+
+	// Redrive passes the message of the dead letter to the consumer again.
+	// The dead letter is removed once the message is handled, otherwise
+	// its error is updated and it's published again.
+	Redrive(ctx context.Context, id string) error
+
+	// RedriveAll redrives all the kept dead letters and returns the number
+	// of the handled ones. The failed ones are kept.
+	RedriveAll(ctx context.Context) (uint64, error)
+
+	// Remove removes the dead letter from all the consumer instances.
+	Remove(ctx context.Context, id string) error
+}
+
+var _ Service = (*deadLetterService)(nil)
+
+type deadLetterService struct {
+	mu          sync.Mutex
+	consumer    string
+	cfg         Config
+	handler     messaging.MessageHandler
+	pubsub      messaging.SubjectPubSub
+	idProvider  mainflux.IDProvider
+	deadLetters []DeadLetter
+}
+
+// New instantiates the dead-letter service of the consumer, which redrives the
+// messages through the given handler. If the dead-letter subject is set, the
+// service subscribes to it, and keeps the dead letters of the consumer
+// published by any of its instances, as well as their updates and removals.
+// Otherwise, only the dead letters of this instance are kept. The service is
+// returned even if the subscription fails.
+func New(consumer string, cfg Config, handler messaging.MessageHandler, pubsub messaging.SubjectPubSub, idp mainflux.IDProvider) (Service, error) {
+	ds := &deadLetterService{
+		consumer:   consumer,
+		cfg:        cfg,
+		handler:    handler,
+		pubsub:     pubsub,
+		idProvider: idp,
+	}
+
+	if cfg.Subject == "" || pubsub == nil {
+		return ds, nil
+	}
+	if err := pubsub.SubscribeSubject(cfg.Subject, ds.received); err != nil {
+		return ds, errors.Wrap(ErrSubscribe, err)
+	}
+	if err := pubsub.SubscribeSubject(cfg.Subject+removedSuffix, ds.removed); err != nil {
+		return ds, errors.Wrap(ErrSubscribe, err)
+	}
+
+	return ds, nil
+}
+
+func (ds *deadLetterService) Save(_ context.Context, msg messaging.Message, reason error) error {
+	id, err := ds.idProvider.ID()
+	if err != nil {
+		return err
+	}
+
+	dl := DeadLetter{
+		ID:       id,
+		Consumer: ds.consumer,
+		Error:    reason.Error(),
+		Failed:   time.Now().UTC(),
+		Attempts: 1,
+		Message:  msg,
+	}
+
+	ds.keep(dl)
+
+	return ds.publish(ds.cfg.Subject, dl)
+}
+
+func (ds *deadLetterService) List(_ context.Context, pm PageMetadata) (Page, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	total := uint64(len(ds.deadLetters))
+	page := Page{
+		PageMetadata: pm,
+		Total:        total,
+		DeadLetters:  []DeadLetter{},
+	}
+
+	// The dead letters are kept from the oldest one.
+	for i := pm.Offset; i < total && i < pm.Offset+pm.Limit; i++ {
+		page.DeadLetters = append(page.DeadLetters, ds.deadLetters[total-1-i])
+	}
+
+	return page, nil
+}
+
+func (ds *deadLetterService) View(_ context.Context, id string) (DeadLetter, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	i := ds.index(id)
+	if i < 0 {
+		return DeadLetter{}, errors.ErrNotFound
+	}
+
+	return ds.deadLetters[i], nil
+}
+
+func (ds *deadLetterService) Redrive(ctx context.Context, id string) error {
+	dl, err := ds.View(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// The handler isn't called under the lock, since the consumer may take
+	// a while, and the dead letter may be removed in the meantime.
+	handleErr := ds.handler.Handle(dl.Message)
+
+	if handleErr == nil {
+		ds.remove(id)
+		return ds.publish(ds.cfg.Subject+removedSuffix, dl)
+	}
+
+	dl.Error = handleErr.Error()
+	dl.Failed = time.Now().UTC()
+	dl.Attempts++
+	ds.update(dl)
+	if err := ds.publish(ds.cfg.Subject, dl); err != nil {
+		return err
+	}
+
+	return errors.Wrap(ErrRedrive, handleErr)
+}
+
+func (ds *deadLetterService) RedriveAll(ctx context.Context) (uint64, error) {
+	ds.mu.Lock()
+	ids := []string{}
+	for _, dl := range ds.deadLetters {
+		ids = append(ids, dl.ID)
+	}
+	ds.mu.Unlock()
+
+	var n uint64
+	for _, id := range ids {
+		switch err := ds.Redrive(ctx, id); {
+		case err == nil:
+			n++
+		case errors.Contains(err, ErrRedrive), errors.Contains(err, errors.ErrNotFound):
+			continue
+		default:
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+func (ds *deadLetterService) Remove(ctx context.Context, id string) error {
+	dl, err := ds.View(ctx, id)
+	if err != nil {
+		return err
+	}
+	ds.remove(id)
+
+	return ds.publish(ds.cfg.Subject+removedSuffix, dl)
+}
+
+// received keeps the dead letter published to the dead-letter subject, or
+// updates the kept one.
+func (ds *deadLetterService) received(data []byte) {
+	var dl DeadLetter
+	if err := json.Unmarshal(data, &dl); err != nil || dl.Consumer != ds.consumer {
+		return
+	}
+
+	ds.update(dl)
+}
+
+// removed removes the dead letter published to the subject of the removed
+// dead letters.
+func (ds *deadLetterService) removed(data []byte) {
+	var dl DeadLetter
+	if err := json.Unmarshal(data, &dl); err != nil || dl.Consumer != ds.consumer {
+		return
+	}
+
+	ds.remove(dl.ID)
+}
+
+// update replaces the kept dead letter, or keeps it if it isn't kept yet.
+func (ds *deadLetterService) update(dl DeadLetter) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if i := ds.index(dl.ID); i >= 0 {
+		ds.deadLetters[i] = dl
+		return
+	}
+	ds.add(dl)
+}
+
+func (ds *deadLetterService) remove(id string) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if i := ds.index(id); i >= 0 {
+		ds.deadLetters = append(ds.deadLetters[:i], ds.deadLetters[i+1:]...)
+	}
+}
+
+// keep appends the dead letter, dropping the oldest ones over the capacity.
+func (ds *deadLetterService) keep(dl DeadLetter) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.add(dl)
+}
+
+func (ds *deadLetterService) add(dl DeadLetter) {
+	if ds.cfg.Capacity <= 0 {
+		return
+	}
+
+	ds.deadLetters = append(ds.deadLetters, dl)
+	if over := len(ds.deadLetters) - ds.cfg.Capacity; over > 0 {
+		ds.deadLetters = append([]DeadLetter{}, ds.deadLetters[over:]...)
+	}
+}
+
+func (ds *deadLetterService) publish(subject string, dl DeadLetter) error {
+	if ds.cfg.Subject == "" || ds.pubsub == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+	if err := ds.pubsub.PublishSubject(subject, data); err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	return nil
+}
+
+func (ds *deadLetterService) index(id string) int {
+	for i, dl := range ds.deadLetters {
+		if dl.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,289 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletters_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	consumer  = "test-writer"
+	subject   = "deadletters.test-writer"
+	chanID    = "1"
+	failingID = "2"
+	capacity  = 10
+)
+
+var errTransform = errors.New("failed to transform message")
+
+func newService(t *testing.T, subject string, handler messaging.MessageHandler, pub messaging.SubjectPubSub) deadletters.Service {
+	cfg := deadletters.Config{
+		Subject:  subject,
+		Capacity: capacity,
+	}
+
+	svc, err := deadletters.New(consumer, cfg, handler, pub, uuid.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return svc
+}
+
+func saveDeadLetters(t *testing.T, svc deadletters.Service, channel string, n int) []deadletters.DeadLetter {
+	for i := 0; i < n; i++ {
+		msg := messaging.Message{
+			Channel: channel,
+			Payload: []byte(fmt.Sprintf(`{"n":%d}`, i)),
+		}
+		err := svc.Save(context.Background(), msg, errTransform)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	page, err := svc.List(context.Background(), deadletters.PageMetadata{Limit: capacity})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return page.DeadLetters
+}
+
+func TestSave(t *testing.T) {
+	msg := messaging.Message{
+		Channel: chanID,
+		Payload: []byte(`{"temperature":`),
+	}
+
+	cases := []struct {
+		desc      string
+		subject   string
+		published int
+		err       error
+	}{
+		{
+			desc:      "save dead letter",
+			subject:   subject,
+			published: 1,
+			err:       nil,
+		},
+		{
+			desc:      "save dead letter without subject",
+			subject:   "",
+			published: 0,
+			err:       nil,
+		},
+		{
+			desc:      "save dead letter with unavailable subject",
+			subject:   "unavailable",
+			published: 0,
+			err:       deadletters.ErrPublish,
+		},
+	}
+
+	for _, tc := range cases {
+		pub := mocks.NewPublisher()
+		svc := newService(t, tc.subject, mocks.NewHandler(), pub)
+
+		err := svc.Save(context.Background(), msg, errTransform)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		published := pub.Published()[tc.subject]
+		assert.Equal(t, tc.published, len(published), fmt.Sprintf("%s: expected %d published dead letters got %d\n", tc.desc, tc.published, len(published)))
+		for _, data := range published {
+			var dl deadletters.DeadLetter
+			err := json.Unmarshal(data, &dl)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, consumer, dl.Consumer, fmt.Sprintf("%s: expected consumer %s got %s\n", tc.desc, consumer, dl.Consumer))
+			assert.Equal(t, errTransform.Error(), dl.Error, fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, errTransform, dl.Error))
+			assert.Equal(t, msg, dl.Message, fmt.Sprintf("%s: expected message %v got %v\n", tc.desc, msg, dl.Message))
+		}
+
+		// The dead letter is kept even if it couldn't be published.
+		page, err := svc.List(context.Background(), deadletters.PageMetadata{Limit: capacity})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("%s: expected 1 kept dead letter got %d\n", tc.desc, page.Total))
+	}
+}
+
+func TestList(t *testing.T) {
+	svc := newService(t, subject, mocks.NewHandler(), mocks.NewPublisher())
+	saveDeadLetters(t, svc, chanID, capacity+5)
+
+	cases := []struct {
+		desc  string
+		pm    deadletters.PageMetadata
+		size  int
+		first string
+	}{
+		{
+			desc:  "list all dead letters",
+			pm:    deadletters.PageMetadata{Limit: capacity},
+			size:  capacity,
+			first: fmt.Sprintf(`{"n":%d}`, capacity+4),
+		},
+		{
+			desc:  "list dead letters with offset",
+			pm:    deadletters.PageMetadata{Offset: 8, Limit: capacity},
+			size:  2,
+			first: `{"n":6}`,
+		},
+		{
+			desc: "list dead letters with offset past the total",
+			pm:   deadletters.PageMetadata{Offset: capacity, Limit: capacity},
+			size: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.List(context.Background(), tc.pm)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, uint64(capacity), page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, capacity, page.Total))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d\n", tc.desc, tc.size, len(page.DeadLetters)))
+		if tc.size > 0 {
+			first := string(page.DeadLetters[0].Message.Payload)
+			assert.Equal(t, tc.first, first, fmt.Sprintf("%s: expected first payload %s got %s\n", tc.desc, tc.first, first))
+		}
+	}
+}
+
+func TestRedrive(t *testing.T) {
+	handler := mocks.NewHandler()
+	handler.Fail(failingID)
+	svc := newService(t, subject, handler, mocks.NewPublisher())
+
+	dl := saveDeadLetters(t, svc, chanID, 1)[0]
+	failing := saveDeadLetters(t, svc, failingID, 1)[0]
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "redrive dead letter",
+			id:   dl.ID,
+			err:  nil,
+		},
+		{
+			desc: "redrive redriven dead letter",
+			id:   dl.ID,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "redrive failing dead letter",
+			id:   failing.ID,
+			err:  deadletters.ErrRedrive,
+		},
+		{
+			desc: "redrive non-existing dead letter",
+			id:   "non-existing",
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Redrive(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	handled := handler.Handled()
+	assert.Equal(t, []messaging.Message{dl.Message}, handled, fmt.Sprintf("expected handled message %v got %v", dl.Message, handled))
+
+	failed, err := svc.View(context.Background(), failing.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, mocks.ErrHandle.Error(), failed.Error, fmt.Sprintf("expected error %s got %s", mocks.ErrHandle, failed.Error))
+	assert.Equal(t, uint64(2), failed.Attempts, fmt.Sprintf("expected 2 attempts got %d", failed.Attempts))
+}
+
+func TestRedriveAll(t *testing.T) {
+	handler := mocks.NewHandler()
+	handler.Fail(failingID)
+	svc := newService(t, subject, handler, mocks.NewPublisher())
+
+	saveDeadLetters(t, svc, chanID, 3)
+	saveDeadLetters(t, svc, failingID, 2)
+
+	n, err := svc.RedriveAll(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(3), n, fmt.Sprintf("expected 3 redriven dead letters got %d", n))
+
+	page, err := svc.List(context.Background(), deadletters.PageMetadata{Limit: capacity})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(2), page.Total, fmt.Sprintf("expected 2 kept dead letters got %d", page.Total))
+	for _, dl := range page.DeadLetters {
+		assert.Equal(t, failingID, dl.Message.Channel, fmt.Sprintf("expected kept dead letter of channel %s got %s", failingID, dl.Message.Channel))
+	}
+}
+
+func TestRemove(t *testing.T) {
+	svc := newService(t, subject, mocks.NewHandler(), mocks.NewPublisher())
+	dl := saveDeadLetters(t, svc, chanID, 1)[0]
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove dead letter",
+			id:   dl.ID,
+			err:  nil,
+		},
+		{
+			desc: "remove removed dead letter",
+			id:   dl.ID,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Remove(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSharedDeadLetters(t *testing.T) {
+	handler := mocks.NewHandler()
+	handler.Fail(failingID)
+	pub := mocks.NewPublisher()
+	svc := newService(t, subject, handler, pub)
+	other := newService(t, subject, handler, pub)
+
+	dls := saveDeadLetters(t, svc, chanID, 2)
+	failing := saveDeadLetters(t, svc, failingID, 1)[0]
+
+	// The dead letters of another consumer published to the same subject
+	// are ignored.
+	foreign, err := json.Marshal(deadletters.DeadLetter{ID: "foreign", Consumer: "other-writer"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = pub.PublishSubject(subject, foreign)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	page, err := other.List(context.Background(), deadletters.PageMetadata{Limit: capacity})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(3), page.Total, fmt.Sprintf("expected 3 dead letters of other instance got %d", page.Total))
+
+	err = other.Redrive(context.Background(), dls[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("redrive dead letter of other instance: expected no error got %s", err))
+	_, err = svc.View(context.Background(), dls[0].ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view redriven dead letter: expected %s got %s", errors.ErrNotFound, err))
+
+	err = other.Redrive(context.Background(), failing.ID)
+	assert.True(t, errors.Contains(err, deadletters.ErrRedrive), fmt.Sprintf("redrive failing dead letter: expected %s got %s", deadletters.ErrRedrive, err))
+	failed, err := svc.View(context.Background(), failing.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(2), failed.Attempts, fmt.Sprintf("expected 2 attempts got %d", failed.Attempts))
+
+	err = svc.Remove(context.Background(), dls[1].ID)
+	assert.Nil(t, err, fmt.Sprintf("remove dead letter: expected no error got %s", err))
+	_, err = other.View(context.Background(), dls[1].ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view removed dead letter: expected %s got %s", errors.ErrNotFound, err))
+}
@@ -4,25 +4,36 @@
 package consumers
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml"
 
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
-	defContentType = "application/senml+json"
-	defFormat      = "senml"
+	defContentType   = "application/senml+json"
+	defFormat        = "senml"
+	defThingsTimeout = "1s"
+	defDeadLetters   = 1000
 )
 
 var (
@@ -32,25 +43,51 @@ var (
 
 // Start method starts consuming messages received from Message broker.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(id string, sub messaging.Subscriber, consumer Consumer, configPath string, logger logger.Logger) error {
+// using MessageRepository to store them. The messages which fail to be
+// transformed or consumed are passed to the returned dead-letter service,
+// which shares them with the other consumer instances through the given
+// pub/sub and redrives them to the consumer. If the consumer is a Batcher,
+// the buffered messages which fail to be written become dead letters as
+// well. The dead-letter service is returned even if the subscription fails.
+func Start(id string, sub messaging.Subscriber, consumer Consumer, pub messaging.SubjectPubSub, configPath string, logger logger.Logger) (deadletters.Service, error) {
 	cfg, err := loadConfig(configPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
 	}
 
 	transformer := makeTransformer(cfg.TransformerCfg, logger)
+	h := handle(transformer, consumer, cfg.SubscriberCfg.Replays)
+
+	dlCfg := deadletters.Config{
+		Subject:  cfg.DeadLettersCfg.Subject,
+		Capacity: cfg.DeadLettersCfg.Capacity,
+	}
+	dls, err := deadletters.New(id, dlCfg, h, pub, uuid.New())
+	if err != nil {
+		return dls, err
+	}
+	if b, ok := consumer.(Batcher); ok {
+		b.OnFailure(func(msg messaging.Message, err error) {
+			saveDeadLetter(dls, msg, err, logger)
+		})
+	}
 
 	for _, subject := range cfg.SubscriberCfg.Subjects {
-		if err := sub.Subscribe(id, subject, handle(transformer, consumer)); err != nil {
-			return err
+		if err := sub.Subscribe(id, subject, handleDeadLetters(h, dls, logger)); err != nil {
+			return dls, err
 		}
 	}
-	return nil
+	return dls, nil
 }
 
-func handle(t transformers.Transformer, c Consumer) handleFunc {
+func handle(t transformers.Transformer, c Consumer, replays bool) handleFunc {
 	return func(msg messaging.Message) error {
+		// The replayed messages were already consumed, unless the consumer
+		// is being backfilled.
+		if msg.Replay && !replays {
+			return nil
+		}
+
 		m := interface{}(msg)
 		var err error
 		if t != nil {
@@ -59,10 +96,33 @@ func handle(t transformers.Transformer, c Consumer) handleFunc {
 				return err
 			}
 		}
+		if b, ok := c.(Batcher); ok {
+			return b.ConsumeMessage(msg, m)
+		}
 		return c.Consume(m)
 	}
 }
 
+// handleDeadLetters passes the messages failed by the handler to the
+// dead-letter service. The error is still returned to the subscriber.
+func handleDeadLetters(h handleFunc, dls deadletters.Service, logger logger.Logger) handleFunc {
+	return func(msg messaging.Message) error {
+		err := h(msg)
+		if err == nil {
+			return nil
+		}
+
+		saveDeadLetter(dls, msg, err, logger)
+		return err
+	}
+}
+
+func saveDeadLetter(dls deadletters.Service, msg messaging.Message, err error, logger logger.Logger) {
+	if err := dls.Save(context.Background(), msg, err); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to save dead letter of channel %s: %s", msg.Channel, err))
+	}
+}
+
 type handleFunc func(msg messaging.Message) error
 
 func (h handleFunc) Handle(msg messaging.Message) error {
@@ -76,17 +136,37 @@ func (h handleFunc) Cancel() error {
 
 type subscriberConfig struct {
 	Subjects []string `toml:"subjects"`
+	Replays  bool     `toml:"replays"`
 }
 
 type transformerConfig struct {
 	Format      string           `toml:"format"`
 	ContentType string           `toml:"content_type"`
 	TimeFields  []json.TimeField `toml:"time_fields"`
+	Codecs      codecsConfig     `toml:"codecs"`
+}
+
+// codecsConfig specifies the things service the thing codecs are retrieved
+// from. The binary payloads aren't decoded if the things URL isn't set.
+type codecsConfig struct {
+	ThingsURL     string `toml:"things_url"`
+	ThingsTimeout string `toml:"things_timeout"`
+	ClientTLS     bool   `toml:"client_tls"`
+	CACerts       string `toml:"ca_certs"`
+}
+
+// deadLettersConfig specifies the subject the dead letters are published to,
+// and the number of the dead letters kept for the inspection. The dead
+// letters aren't published if the subject isn't set.
+type deadLettersConfig struct {
+	Subject  string `toml:"subject"`
+	Capacity int    `toml:"capacity"`
 }
 
 type config struct {
 	SubscriberCfg  subscriberConfig  `toml:"subscriber"`
 	TransformerCfg transformerConfig `toml:"transformer"`
+	DeadLettersCfg deadLettersConfig `toml:"dead_letters"`
 }
 
 func loadConfig(configPath string) (config, error) {
@@ -97,6 +177,12 @@ func loadConfig(configPath string) (config, error) {
 		TransformerCfg: transformerConfig{
 			Format:      defFormat,
 			ContentType: defContentType,
+			Codecs: codecsConfig{
+				ThingsTimeout: defThingsTimeout,
+			},
+		},
+		DeadLettersCfg: deadLettersConfig{
+			Capacity: defDeadLetters,
 		},
 	}
 
@@ -113,16 +199,57 @@ func loadConfig(configPath string) (config, error) {
 }
 
 func makeTransformer(cfg transformerConfig, logger logger.Logger) transformers.Transformer {
+	var t transformers.Transformer
 	switch strings.ToUpper(cfg.Format) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
-		return senml.New(cfg.ContentType)
+		t = senml.New(cfg.ContentType)
 	case "JSON":
 		logger.Info("Using JSON transformer")
-		return json.New(cfg.TimeFields)
+		t = json.New(cfg.TimeFields)
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.Format))
 		os.Exit(1)
 		return nil
 	}
+
+	if cfg.Codecs.ThingsURL == "" {
+		return t
+	}
+
+	timeout, err := time.ParseDuration(cfg.Codecs.ThingsTimeout)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Can't create transformer: invalid things timeout %s", cfg.Codecs.ThingsTimeout))
+		os.Exit(1)
+	}
+
+	logger.Info("Using thing profile codecs and payload defaults")
+	conn := connectToThings(cfg.Codecs, logger)
+	codecs := codec.NewProvider(thingsapi.NewClient(conn, opentracing.NoopTracer{}, timeout))
+
+	return codec.New(codecs, t, json.New(cfg.TimeFields))
+}
+
+func connectToThings(cfg codecsConfig, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.ClientTLS {
+		if cfg.CACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.CACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.ThingsURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
 }
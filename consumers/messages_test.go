@@ -0,0 +1,207 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters"
+	"github.com/MainfluxLabs/mainflux/consumers/deadletters/mocks"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subscriberMock struct {
+	handlers map[string]messaging.MessageHandler
+}
+
+func (s *subscriberMock) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	s.handlers[topic] = handler
+	return nil
+}
+
+func (s *subscriberMock) Unsubscribe(id, topic string) error {
+	delete(s.handlers, topic)
+	return nil
+}
+
+func (s *subscriberMock) Close() error {
+	return nil
+}
+
+func TestStartReplays(t *testing.T) {
+	msg := messaging.Message{
+		Channel:   "channel",
+		Publisher: "publisher",
+		Payload:   []byte(`[{"n":"temperature","v":21.5,"t":1700000000}]`),
+	}
+	replayed := msg
+	replayed.Replay = true
+
+	cases := []struct {
+		desc     string
+		config   string
+		msg      messaging.Message
+		consumed int
+	}{
+		{
+			desc:     "consume message",
+			config:   "[subscriber]\nsubjects = [\"channels.>\"]\n",
+			msg:      msg,
+			consumed: 1,
+		},
+		{
+			desc:     "skip replayed message",
+			config:   "[subscriber]\nsubjects = [\"channels.>\"]\n",
+			msg:      replayed,
+			consumed: 0,
+		},
+		{
+			desc:     "consume replayed message",
+			config:   "[subscriber]\nsubjects = [\"channels.>\"]\nreplays = true\n",
+			msg:      replayed,
+			consumed: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		err := os.WriteFile(path, []byte(tc.config), 0o644)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		sub := &subscriberMock{handlers: map[string]messaging.MessageHandler{}}
+		c := &consumerMock{}
+		_, err = consumers.Start("test", sub, c, nil, path, logger.NewMock())
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		err = sub.handlers["channels.>"].Handle(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.consumed, len(c.Calls()), fmt.Sprintf("%s: expected %d consumed messages got %d", tc.desc, tc.consumed, len(c.Calls())))
+	}
+}
+
+func TestStartDeadLetters(t *testing.T) {
+	const subject = "deadletters.test"
+	config := fmt.Sprintf("[subscriber]\nsubjects = [\"channels.>\"]\n\n[dead_letters]\nsubject = %q\n", subject)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(path, []byte(config), 0o644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	sub := &subscriberMock{handlers: map[string]messaging.MessageHandler{}}
+	pub := mocks.NewPublisher()
+	c := &consumerMock{}
+	dls, err := consumers.Start("test", sub, c, pub, path, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc        string
+		payload     string
+		deadLetters int
+	}{
+		{
+			desc:        "consume valid message",
+			payload:     `[{"n":"temperature","v":21.5}]`,
+			deadLetters: 0,
+		},
+		{
+			desc:        "consume message with invalid payload",
+			payload:     `[{"n":"temperature","v":`,
+			deadLetters: 1,
+		},
+		{
+			desc:        "consume message rejected by consumer",
+			payload:     fmt.Sprintf(`[{"n":"%s","v":21.5}]`, invalidName),
+			deadLetters: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := messaging.Message{
+			Channel: "channel",
+			Payload: []byte(tc.payload),
+		}
+		err := sub.handlers["channels.>"].Handle(msg)
+		published := pub.Published()[subject]
+		assert.Equal(t, tc.deadLetters, len(published), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.deadLetters, len(published)))
+		if tc.deadLetters == 0 {
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			continue
+		}
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error got nil", tc.desc))
+
+		var dl deadletters.DeadLetter
+		err = json.Unmarshal(published[len(published)-1], &dl)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, "test", dl.Consumer, fmt.Sprintf("%s: expected consumer test got %s", tc.desc, dl.Consumer))
+		assert.Equal(t, msg, dl.Message, fmt.Sprintf("%s: expected message %v got %v", tc.desc, msg, dl.Message))
+		assert.NotEmpty(t, dl.Error, fmt.Sprintf("%s: expected error reason", tc.desc))
+	}
+
+	page, err := dls.List(context.Background(), deadletters.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Equal(t, uint64(2), page.Total, fmt.Sprintf("expected 2 kept dead letters got %d", page.Total))
+
+	// The redriven message fails again, so its dead letter is updated and
+	// republished rather than dead lettered twice.
+	err = dls.Redrive(context.Background(), page.DeadLetters[0].ID)
+	assert.True(t, errors.Contains(err, deadletters.ErrRedrive), fmt.Sprintf("expected %s got %s", deadletters.ErrRedrive, err))
+	published := pub.Published()[subject]
+	require.Equal(t, 3, len(published), fmt.Sprintf("expected 3 published dead letters got %d", len(published)))
+
+	var dl deadletters.DeadLetter
+	err = json.Unmarshal(published[2], &dl)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, page.DeadLetters[0].ID, dl.ID, fmt.Sprintf("expected dead letter %s got %s", page.DeadLetters[0].ID, dl.ID))
+	assert.Equal(t, uint64(2), dl.Attempts, fmt.Sprintf("expected 2 attempts got %d", dl.Attempts))
+
+	page, err = dls.List(context.Background(), deadletters.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, uint64(2), page.Total, fmt.Sprintf("expected 2 kept dead letters got %d", page.Total))
+}
+
+func TestStartBatchDeadLetters(t *testing.T) {
+	const subject = "deadletters.test"
+	config := fmt.Sprintf("[subscriber]\nsubjects = [\"channels.>\"]\n\n[dead_letters]\nsubject = %q\n", subject)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(path, []byte(config), 0o644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	sub := &subscriberMock{handlers: map[string]messaging.MessageHandler{}}
+	pub := mocks.NewPublisher()
+	b := consumers.NewBatcher(&consumerMock{}, consumers.BatchConfig{Size: 3}, logger.NewMock())
+	_, err = consumers.Start("test", sub, b, pub, path, logger.NewMock())
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	var msgs []messaging.Message
+	for _, n := range []string{invalidName, "humidity", "temperature"} {
+		msg := messaging.Message{
+			Channel: "channel",
+			Payload: []byte(fmt.Sprintf(`[{"n":"%s","v":21.5}]`, n)),
+		}
+		msgs = append(msgs, msg)
+		err := sub.handlers["channels.>"].Handle(msg)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	}
+
+	// The invalid message is dead lettered even though the batch is
+	// written while consuming the last message.
+	published := pub.Published()[subject]
+	require.Equal(t, 1, len(published), fmt.Sprintf("expected 1 dead letter got %d", len(published)))
+
+	var dl deadletters.DeadLetter
+	err = json.Unmarshal(published[0], &dl)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, msgs[0], dl.Message, fmt.Sprintf("expected message %v got %v", msgs[0], dl.Message))
+}
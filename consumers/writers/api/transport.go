@@ -4,15 +4,13 @@
 package api
 
 import (
-	"net/http"
-
 	"github.com/go-zoo/bone"
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // MakeHandler returns a HTTP API handler with health check and metrics.
-func MakeHandler(svcName string) http.Handler {
+func MakeHandler(svcName string) *bone.Mux {
 	r := bone.New()
 	r.GetFunc("/health", mainflux.Health(svcName))
 	r.Handle("/metrics", promhttp.Handler())
@@ -0,0 +1,195 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/gocql/gocql"
+)
+
+const (
+	defTable = "messages"
+
+	// SenML time is expressed in seconds and JSON created in nanoseconds,
+	// so the partitions of both formats span a single day.
+	senmlBucket = 24 * 60 * 60
+	jsonBucket  = senmlBucket * 1e9
+
+	// maxBatchSize keeps the batches under the Cassandra batch size limits.
+	maxBatchSize = 100
+)
+
+var (
+	errSaveMessage = errors.New("failed to save message to cassandra database")
+	errNoTable     = errors.New("table does not exist")
+)
+
+var _ consumers.Consumer = (*cassandraRepository)(nil)
+
+type cassandraRepository struct {
+	session *gocql.Session
+}
+
+// New instantiates Cassandra message repository.
+func New(session *gocql.Session) consumers.Consumer {
+	return &cassandraRepository{session}
+}
+
+func (cr *cassandraRepository) Consume(message interface{}) error {
+	switch m := message.(type) {
+	case mfjson.Messages:
+		return cr.saveJSON(m)
+	default:
+		return cr.saveSenml(m)
+	}
+}
+
+func (cr *cassandraRepository) saveSenml(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+
+	q := `INSERT INTO messages (id, channel, bucket, subtopic, publisher, protocol, name, unit,
+        value, string_value, bool_value, data_value, sum, time, update_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	parts := map[partition][][]interface{}{}
+	var keys []partition
+	for _, msg := range msgs {
+		p := partition{channel: msg.Channel, bucket: bucket(msg.Time, senmlBucket)}
+		if _, ok := parts[p]; !ok {
+			keys = append(keys, p)
+		}
+		parts[p] = append(parts[p], []interface{}{
+			gocql.TimeUUID(), msg.Channel, p.bucket, msg.Subtopic, msg.Publisher, msg.Protocol,
+			msg.Name, msg.Unit, msg.Value, msg.StringValue, msg.BoolValue, msg.DataValue,
+			msg.Sum, msg.Time, msg.UpdateTime,
+		})
+	}
+
+	if err := cr.insert(defTable, q, keys, parts); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (cr *cassandraRepository) saveJSON(msgs mfjson.Messages) error {
+	if err := cr.insertJSON(msgs); err != nil {
+		if err == errNoTable {
+			if err := cr.createTable(msgs.Format); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			return cr.insertJSON(msgs)
+		}
+		return err
+	}
+	return nil
+}
+
+func (cr *cassandraRepository) insertJSON(msgs mfjson.Messages) error {
+	q := fmt.Sprintf(`INSERT INTO %s (id, channel, bucket, created, subtopic, publisher, protocol, payload)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, msgs.Format)
+
+	parts := map[partition][][]interface{}{}
+	var keys []partition
+	for _, msg := range msgs.Data {
+		pld := []byte("{}")
+		if msg.Payload != nil {
+			b, err := json.Marshal(msg.Payload)
+			if err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			pld = b
+		}
+
+		p := partition{channel: msg.Channel, bucket: bucket(float64(msg.Created), jsonBucket)}
+		if _, ok := parts[p]; !ok {
+			keys = append(keys, p)
+		}
+		parts[p] = append(parts[p], []interface{}{
+			gocql.TimeUUID(), msg.Channel, p.bucket, msg.Created, msg.Subtopic,
+			msg.Publisher, msg.Protocol, string(pld),
+		})
+	}
+
+	if err := cr.insert(msgs.Format, q, keys, parts); err != nil {
+		if isUnknownTable(err) {
+			return errNoTable
+		}
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+// insert writes the rows of each partition in unlogged batches, which are
+// applied by a single replica set, and records the written partitions in
+// the buckets table so that the readers can find them.
+func (cr *cassandraRepository) insert(table, q string, keys []partition, parts map[partition][][]interface{}) error {
+	for _, p := range keys {
+		rows := parts[p]
+		for len(rows) > 0 {
+			n := len(rows)
+			if n > maxBatchSize {
+				n = maxBatchSize
+			}
+
+			b := cr.session.NewBatch(gocql.UnloggedBatch)
+			for _, row := range rows[:n] {
+				b.Query(q, row...)
+			}
+			if err := cr.session.ExecuteBatch(b); err != nil {
+				return err
+			}
+			rows = rows[n:]
+		}
+
+		bq := `INSERT INTO buckets (table_name, channel, bucket) VALUES (?, ?, ?)`
+		if err := cr.session.Query(bq, table, p.channel, p.bucket).Exec(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cr *cassandraRepository) createTable(name string) error {
+	q := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+        id uuid,
+        channel text,
+        bucket bigint,
+        created bigint,
+        subtopic text,
+        publisher text,
+        protocol text,
+        payload text,
+        PRIMARY KEY ((channel, bucket), created, id)
+    ) WITH CLUSTERING ORDER BY (created DESC, id DESC)`, name)
+
+	return cr.session.Query(q).Exec()
+}
+
+type partition struct {
+	channel string
+	bucket  int64
+}
+
+func bucket(ts, size float64) int64 {
+	return int64(math.Floor(ts / size))
+}
+
+func isUnknownTable(err error) bool {
+	e, ok := err.(gocql.RequestError)
+	return ok && e.Code() == gocql.ErrCodeInvalid && strings.Contains(e.Message(), "unconfigured table")
+}
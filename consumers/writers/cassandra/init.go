@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"github.com/gocql/gocql"
+)
+
+const (
+	senmlTable = `CREATE TABLE IF NOT EXISTS messages (
+        id uuid,
+        channel text,
+        bucket bigint,
+        subtopic text,
+        publisher text,
+        protocol text,
+        name text,
+        unit text,
+        value double,
+        string_value text,
+        bool_value boolean,
+        data_value text,
+        sum double,
+        time double,
+        update_time double,
+        PRIMARY KEY ((channel, bucket), time, id)
+    ) WITH CLUSTERING ORDER BY (time DESC, id DESC)`
+
+	bucketsTable = `CREATE TABLE IF NOT EXISTS buckets (
+        table_name text,
+        channel text,
+        bucket bigint,
+        PRIMARY KEY ((table_name, channel), bucket)
+    ) WITH CLUSTERING ORDER BY (bucket DESC)`
+)
+
+// Config contains Cassandra DB specific parameters.
+type Config struct {
+	Hosts    []string
+	Keyspace string
+	User     string
+	Pass     string
+	Port     int
+}
+
+// Connect establishes connection to the Cassandra cluster and creates the
+// messages tables if they don't exist. The keyspace must already exist.
+func Connect(cfg Config) (*gocql.Session, error) {
+	cluster := gocql.NewCluster(cfg.Hosts...)
+	cluster.Keyspace = cfg.Keyspace
+	cluster.Consistency = gocql.Quorum
+	cluster.Authenticator = gocql.PasswordAuthenticator{
+		Username: cfg.User,
+		Password: cfg.Pass,
+	}
+	cluster.Port = cfg.Port
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range []string{senmlTable, bucketsTable} {
+		if err := session.Query(q).Exec(); err != nil {
+			session.Close()
+			return nil, err
+		}
+	}
+
+	return session, nil
+}
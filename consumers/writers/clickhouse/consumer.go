@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+const senmlTable = "messages"
+
+var errSaveMessage = errors.New("failed to save message to clickhouse database")
+
+var _ consumers.Consumer = (*clickhouseRepo)(nil)
+
+type clickhouseRepo struct {
+	client *ch.Client
+}
+
+// New returns new ClickHouse writer.
+func New(client *ch.Client) consumers.Consumer {
+	return &clickhouseRepo{client: client}
+}
+
+func (cr clickhouseRepo) Consume(message interface{}) error {
+	switch m := message.(type) {
+	case mfjson.Messages:
+		return cr.saveJSON(m)
+	default:
+		return cr.saveSenml(m)
+	}
+}
+
+func (cr clickhouseRepo) saveSenml(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+
+	rows := make([]interface{}, 0, len(msgs))
+	for _, msg := range msgs {
+		rows = append(rows, msg)
+	}
+
+	if err := cr.client.Insert(context.Background(), senmlTable, rows); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (cr clickhouseRepo) saveJSON(msgs mfjson.Messages) error {
+	if err := cr.insertJSON(msgs); err != nil {
+		if err == ch.ErrUnknownTable {
+			if err := cr.createTable(msgs.Format); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+			return cr.insertJSON(msgs)
+		}
+		return err
+	}
+	return nil
+}
+
+func (cr clickhouseRepo) insertJSON(msgs mfjson.Messages) error {
+	rows := make([]interface{}, 0, len(msgs.Data))
+	for _, m := range msgs.Data {
+		row, err := toJSONMessage(m)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		rows = append(rows, row)
+	}
+
+	if err := cr.client.Insert(context.Background(), msgs.Format, rows); err != nil {
+		if err == ch.ErrUnknownTable {
+			return err
+		}
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func (cr clickhouseRepo) createTable(name string) error {
+	q := `CREATE TABLE IF NOT EXISTS %s (
+            created       Int64,
+            channel       String,
+            subtopic      String,
+            publisher     String,
+            protocol      String,
+            payload       String
+        ) ENGINE = MergeTree
+        ORDER BY (channel, created)`
+	q = fmt.Sprintf(q, ch.Identifier(name))
+
+	return cr.client.Exec(context.Background(), q, nil)
+}
+
+type jsonMessage struct {
+	Channel   string `json:"channel"`
+	Created   int64  `json:"created"`
+	Subtopic  string `json:"subtopic"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   string `json:"payload"`
+}
+
+func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, err
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   string(data),
+	}
+
+	return m, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+)
+
+// Connect creates a client of the ClickHouse instance and creates the SenML
+// messages table if it doesn't exist. A non-nil error is returned to
+// indicate failure.
+func Connect(cfg ch.Config) (*ch.Client, error) {
+	client := ch.New(cfg)
+
+	ctx := context.Background()
+	if err := client.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	q := `CREATE TABLE IF NOT EXISTS messages (
+            channel       String,
+            subtopic      String,
+            publisher     String,
+            protocol      String,
+            name          String,
+            unit          String,
+            value         Nullable(Float64),
+            string_value  Nullable(String),
+            bool_value    Nullable(Bool),
+            data_value    Nullable(String),
+            sum           Nullable(Float64),
+            time          Float64,
+            update_time   Float64
+        ) ENGINE = MergeTree
+        ORDER BY (channel, time)`
+	if err := client.Exec(ctx, q, nil); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
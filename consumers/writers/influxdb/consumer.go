@@ -5,15 +5,18 @@ package influxdb
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	influxdb2write "github.com/influxdata/influxdb-client-go/v2/api/write"
 )
@@ -31,6 +34,8 @@ type RepoConfig struct {
 type influxRepo struct {
 	client influxdb2.Client
 	cfg    RepoConfig
+	writer api.WriteAPI
+	logger logger.Logger
 }
 
 // New returns new InfluxDB writer.
@@ -41,6 +46,21 @@ func New(client influxdb2.Client, config RepoConfig) consumers.Consumer {
 	}
 }
 
+// NewAsync returns new InfluxDB writer with non-blocking writes. Points are
+// buffered and written by the client in the background. Since a failed
+// background write can't be attributed to the consumed message anymore, it
+// is logged rather than returned.
+func NewAsync(client influxdb2.Client, config RepoConfig, logger logger.Logger) consumers.Consumer {
+	repo := &influxRepo{
+		client: client,
+		cfg:    config,
+		writer: client.WriteAPI(config.Org, config.Bucket),
+		logger: logger,
+	}
+	go repo.collectErrors(repo.writer.Errors())
+	return repo
+}
+
 func (repo *influxRepo) Consume(message interface{}) error {
 	var err error
 	var pts []*influxdb2write.Point
@@ -53,11 +73,28 @@ func (repo *influxRepo) Consume(message interface{}) error {
 	if err != nil {
 		return err
 	}
+	if repo.writer != nil {
+		repo.writeAsync(pts)
+		return nil
+	}
 	writeAPI := repo.client.WriteAPIBlocking(repo.cfg.Org, repo.cfg.Bucket)
 	err = writeAPI.WritePoint(context.Background(), pts...)
 	return err
 }
 
+// writeAsync hands the points over to the non-blocking write API.
+func (repo *influxRepo) writeAsync(pts []*influxdb2write.Point) {
+	for _, pt := range pts {
+		repo.writer.WritePoint(pt)
+	}
+}
+
+func (repo *influxRepo) collectErrors(errs <-chan error) {
+	for err := range errs {
+		repo.logger.Error(fmt.Sprintf("%s: %s", errSaveMessage, err))
+	}
+}
+
 func (repo *influxRepo) senmlPoints(messages interface{}) ([]*influxdb2write.Point, error) {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
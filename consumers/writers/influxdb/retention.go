@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
+)
+
+var epoch = time.Unix(0, 0)
+
+var _ retention.Pruner = (*pruner)(nil)
+
+type pruner struct {
+	client influxdb2.Client
+	cfg    RepoConfig
+}
+
+// NewPruner returns the pruner expiring the messages by the bucket retention
+// rule, and deleting the messages the rule doesn't cover.
+func NewPruner(client influxdb2.Client, config RepoConfig) retention.Pruner {
+	return &pruner{
+		client: client,
+		cfg:    config,
+	}
+}
+
+func (pr pruner) Prune(ctx context.Context, policies []retention.Policy) error {
+	def, ok, chans := retention.Split(policies)
+
+	// The bucket retention applies to all the channels, so it's set to the
+	// longest period if the default policy is set, and it's infinite otherwise.
+	var every time.Duration
+	if ok {
+		every = def.Period
+		if longest := retention.Longest(chans); longest > every {
+			every = longest
+		}
+	}
+	if err := pr.syncBucketRetention(ctx, every); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, p := range chans {
+		if every != 0 && p.Period >= every {
+			continue
+		}
+		if err := pr.deleteBefore(ctx, p.Channel, now.Add(-p.Period)); err != nil {
+			return err
+		}
+	}
+
+	if !ok || every == def.Period {
+		return nil
+	}
+
+	channels, err := pr.channels(ctx)
+	if err != nil {
+		return err
+	}
+	set := map[string]bool{}
+	for _, ch := range retention.Channels(chans) {
+		set[ch] = true
+	}
+	for _, ch := range channels {
+		if set[ch] {
+			continue
+		}
+		if err := pr.deleteBefore(ctx, ch, now.Add(-def.Period)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pr pruner) syncBucketRetention(ctx context.Context, every time.Duration) error {
+	bucket, err := pr.client.BucketsAPI().FindBucketByName(ctx, pr.cfg.Bucket)
+	if err != nil {
+		return err
+	}
+
+	secs := int64(every / time.Second)
+	var current int64
+	for _, r := range bucket.RetentionRules {
+		if r.Type == domain.RetentionRuleTypeExpire {
+			current = r.EverySeconds
+		}
+	}
+	if current == secs {
+		return nil
+	}
+
+	bucket.RetentionRules = domain.RetentionRules{
+		{Type: domain.RetentionRuleTypeExpire, EverySeconds: secs},
+	}
+	_, err = pr.client.BucketsAPI().UpdateBucket(ctx, bucket)
+	return err
+}
+
+func (pr pruner) deleteBefore(ctx context.Context, chanID string, before time.Time) error {
+	predicate := fmt.Sprintf(`channel="%s"`, chanID)
+	return pr.client.DeleteAPI().DeleteWithName(ctx, pr.cfg.Org, pr.cfg.Bucket, epoch, before, predicate)
+}
+
+// channels returns the channels having messages in the bucket.
+func (pr pruner) channels(ctx context.Context) ([]string, error) {
+	q := fmt.Sprintf(`import "influxdata/influxdb/schema"
+
+schema.tagValues(bucket: "%s", tag: "channel", start: time(v: 0))`, pr.cfg.Bucket)
+
+	res, err := pr.client.QueryAPI(pr.cfg.Org).Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var channels []string
+	for res.Next() {
+		if ch, ok := res.Record().Value().(string); ok {
+			channels = append(channels, ch)
+		}
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
@@ -5,8 +5,11 @@ package mongodb
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -18,6 +21,10 @@ const senmlCollection string = "messages"
 
 var errSaveMessage = errors.New("failed to save message to mongodb database")
 
+// Unordered inserts let the server write the documents of a batch in parallel
+// and keep storing the remaining documents if one of them fails.
+var insertOpts = options.InsertMany().SetOrdered(false)
+
 var _ consumers.Consumer = (*mongoRepo)(nil)
 
 type mongoRepo struct {
@@ -47,10 +54,13 @@ func (repo *mongoRepo) saveSenml(messages interface{}) error {
 	coll := repo.db.Collection(senmlCollection)
 	var dbMsgs []interface{}
 	for _, msg := range msgs {
-		dbMsgs = append(dbMsgs, msg)
+		dbMsgs = append(dbMsgs, senmlDoc{
+			Message:       msg,
+			RetentionTime: secondsTime(msg.Time),
+		})
 	}
 
-	_, err := coll.InsertMany(context.Background(), dbMsgs)
+	_, err := coll.InsertMany(context.Background(), dbMsgs, insertOpts)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
 	}
@@ -61,15 +71,35 @@ func (repo *mongoRepo) saveSenml(messages interface{}) error {
 func (repo *mongoRepo) saveJSON(msgs json.Messages) error {
 	m := []interface{}{}
 	for _, msg := range msgs.Data {
-		m = append(m, msg)
+		m = append(m, jsonDoc{
+			Message:       msg,
+			RetentionTime: time.Unix(0, msg.Created),
+		})
 	}
 
 	coll := repo.db.Collection(msgs.Format)
 
-	_, err := coll.InsertMany(context.Background(), m)
+	_, err := coll.InsertMany(context.Background(), m, insertOpts)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
 	}
 
 	return nil
 }
+
+// The documents carry the message time as a date, which is required by the
+// retention TTL index.
+type senmlDoc struct {
+	senml.Message `bson:",inline"`
+	RetentionTime time.Time `bson:"retention_time"`
+}
+
+type jsonDoc struct {
+	json.Message  `bson:",inline"`
+	RetentionTime time.Time `bson:"retention_time"`
+}
+
+func secondsTime(t float64) time.Time {
+	sec, frac := math.Modf(t)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	rmongo "github.com/MainfluxLabs/mainflux/consumers/writers/retention/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ttlIndex = "retention_ttl"
+
+	// deletionsCollection has to match the audit trail collection used by
+	// the MongoDB reader, which mustn't expire with the messages.
+	deletionsCollection = "deletions"
+)
+
+var _ retention.Pruner = (*pruner)(nil)
+
+type pruner struct {
+	db *mongo.Database
+}
+
+// NewPruner returns the pruner expiring the messages by the TTL index on
+// their retention time, and deleting the messages the index doesn't cover.
+func NewPruner(db *mongo.Database) retention.Pruner {
+	return &pruner{db: db}
+}
+
+func (pr pruner) Prune(ctx context.Context, policies []retention.Policy) error {
+	names, err := pr.db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return err
+	}
+
+	def, ok, chans := retention.Split(policies)
+
+	// The TTL index expires the messages of all the channels, so it's used
+	// only if no channel keeps its messages longer than the default.
+	var ttl time.Duration
+	if ok && retention.Longest(chans) <= def.Period {
+		ttl = def.Period
+	}
+
+	now := time.Now()
+	for _, name := range names {
+		if name == rmongo.PoliciesCollection || name == deletionsCollection || strings.HasPrefix(name, "system.") {
+			continue
+		}
+
+		coll := pr.db.Collection(name)
+		if err := pr.syncTTLIndex(ctx, coll, ttl); err != nil {
+			return err
+		}
+
+		for _, p := range chans {
+			filter := bson.M{"channel": p.Channel}
+			if err := deleteBefore(ctx, coll, filter, now.Add(-p.Period)); err != nil {
+				return err
+			}
+		}
+
+		if ok && ttl == 0 {
+			filter := bson.M{"channel": bson.M{"$nin": retention.Channels(chans)}}
+			if err := deleteBefore(ctx, coll, filter, now.Add(-def.Period)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// syncTTLIndex sets the expiration of the TTL index, creating the index if
+// it doesn't exist. The index is dropped if the TTL is not set.
+func (pr pruner) syncTTLIndex(ctx context.Context, coll *mongo.Collection, ttl time.Duration) error {
+	cursor, err := coll.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var indexes []struct {
+		Name               string `bson:"name"`
+		ExpireAfterSeconds *int64 `bson:"expireAfterSeconds"`
+	}
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+
+	var current *int64
+	for _, idx := range indexes {
+		if idx.Name == ttlIndex {
+			current = idx.ExpireAfterSeconds
+			if current == nil {
+				current = new(int64)
+			}
+		}
+	}
+
+	secs := int64(ttl / time.Second)
+	if secs > math.MaxInt32 {
+		secs = math.MaxInt32
+	}
+
+	switch {
+	case secs == 0 && current == nil:
+		return nil
+	case secs == 0:
+		_, err := coll.Indexes().DropOne(ctx, ttlIndex)
+		return err
+	case current == nil:
+		idx := mongo.IndexModel{
+			Keys:    bson.D{{Key: "retention_time", Value: 1}},
+			Options: options.Index().SetName(ttlIndex).SetExpireAfterSeconds(int32(secs)),
+		}
+		_, err := coll.Indexes().CreateOne(ctx, idx)
+		return err
+	case *current != secs:
+		cmd := bson.D{
+			{Key: "collMod", Value: coll.Name()},
+			{Key: "index", Value: bson.D{
+				{Key: "name", Value: ttlIndex},
+				{Key: "expireAfterSeconds", Value: secs},
+			}},
+		}
+		return pr.db.RunCommand(ctx, cmd).Err()
+	default:
+		return nil
+	}
+}
+
+// deleteBefore deletes the matching messages older than the given time. The
+// message time is used instead of the retention time, so that the messages
+// written before the retention time was introduced are deleted as well. SenML
+// time is expressed in seconds and JSON created in nanoseconds.
+func deleteBefore(ctx context.Context, coll *mongo.Collection, filter bson.M, before time.Time) error {
+	switch coll.Name() {
+	case senmlCollection:
+		filter["time"] = bson.M{"$lt": float64(before.Unix())}
+	default:
+		filter["created"] = bson.M{"$lt": before.UnixNano()}
+	}
+
+	_, err := coll.DeleteMany(ctx, filter)
+	return err
+}
@@ -0,0 +1,346 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/xitongsys/parquet-go-source/local"
+	pq "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	senmlFormat = "messages"
+	filePrefix  = "part-"
+	fileExt     = ".parquet"
+	tmpExt      = ".tmp"
+	dateLayout  = "2006-01-02"
+	hourLayout  = "15"
+)
+
+var (
+	errSaveMessage      = errors.New("failed to save message to parquet file")
+	errRotateFile       = errors.New("failed to rotate parquet file")
+	errInvalidPartition = errors.New("invalid partition name")
+)
+
+// Config defines the options of the archive files.
+type Config struct {
+	// Path is the root directory of the archive.
+	Path string
+	// MaxRows is the number of rows after which the file is rotated. The
+	// files are rotated only by age if it's not positive.
+	MaxRows int64
+	// RowGroupSize is the size of the row group buffered in memory for each
+	// open file, in bytes.
+	RowGroupSize int64
+	// MaxOpenFiles is the number of files kept open at once. Once reached,
+	// the least recently written file is rotated before opening a new one.
+	// The number of open files isn't limited if it's not positive.
+	MaxOpenFiles int
+}
+
+// Archive writes the messages into the Parquet files partitioned by channel
+// and hour, as <path>/<format>/channel=<id>/date=<date>/hour=<hour>/part-<n>.parquet.
+// The SenML messages are stored under the "messages" format. The files being
+// written are hidden until they are rotated, so the readers of the archive
+// see only the complete files. The messages written to the open files are
+// lost if the writer stops without closing the archive.
+type Archive interface {
+	consumers.Consumer
+
+	// Rotate closes the files opened before the given time.
+	Rotate(before time.Time) error
+
+	// Compact merges the rotated files of each partition of the hours ended
+	// before the given time into a single file.
+	Compact(before time.Time) error
+
+	// Close closes all the open files.
+	Close() error
+}
+
+var _ Archive = (*archive)(nil)
+
+type partition struct {
+	format  string
+	channel string
+	hour    time.Time
+}
+
+func (p partition) dir(root string) string {
+	return filepath.Join(root, p.format, "channel="+p.channel, "date="+p.hour.Format(dateLayout), "hour="+p.hour.Format(hourLayout))
+}
+
+type file struct {
+	path    string
+	tmp     string
+	source  source.ParquetFile
+	writer  *writer.ParquetWriter
+	opened  time.Time
+	written time.Time
+	rows    int64
+}
+
+type archive struct {
+	mu    sync.Mutex
+	cfg   Config
+	files map[partition]*file
+}
+
+// New returns new Parquet archive writer. The hidden files left behind by a
+// writer that stopped without closing the archive can't be read, since they
+// lack the footer, so they are removed.
+func New(cfg Config) (Archive, error) {
+	if err := removeTemp(cfg.Path); err != nil {
+		return nil, err
+	}
+
+	return &archive{
+		cfg:   cfg,
+		files: make(map[partition]*file),
+	}, nil
+}
+
+func (a *archive) Consume(message interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch m := message.(type) {
+	case json.Messages:
+		return a.saveJSON(m)
+	default:
+		return a.saveSenml(m)
+	}
+}
+
+func (a *archive) saveSenml(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+
+	for _, msg := range msgs {
+		sec, frac := math.Modf(msg.Time)
+		p := partition{
+			format:  senmlFormat,
+			channel: msg.Channel,
+			hour:    time.Unix(int64(sec), int64(frac*1e9)).UTC().Truncate(time.Hour),
+		}
+		if err := a.write(p, newSenmlRecord(msg)); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *archive) saveJSON(msgs json.Messages) error {
+	for _, msg := range msgs.Data {
+		rec, err := newJSONRecord(msg)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+
+		p := partition{
+			format:  msgs.Format,
+			channel: msg.Channel,
+			hour:    time.Unix(0, msg.Created).UTC().Truncate(time.Hour),
+		}
+		if err := a.write(p, rec); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *archive) write(p partition, rec interface{}) error {
+	f, ok := a.files[p]
+	if !ok {
+		if a.cfg.MaxOpenFiles > 0 && len(a.files) >= a.cfg.MaxOpenFiles {
+			if err := a.rotateLRU(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if f, err = a.open(p); err != nil {
+			return err
+		}
+		a.files[p] = f
+	}
+
+	if err := f.writer.Write(rec); err != nil {
+		return err
+	}
+
+	f.rows++
+	f.written = time.Now()
+
+	if a.cfg.MaxRows > 0 && f.rows >= a.cfg.MaxRows {
+		delete(a.files, p)
+		return f.close()
+	}
+
+	return nil
+}
+
+// rotateLRU closes the least recently written file.
+func (a *archive) rotateLRU() error {
+	var (
+		lru *file
+		key partition
+	)
+	for p, f := range a.files {
+		if lru == nil || f.written.Before(lru.written) {
+			lru, key = f, p
+		}
+	}
+	if lru == nil {
+		return nil
+	}
+
+	delete(a.files, key)
+	if err := lru.close(); err != nil {
+		return errors.Wrap(errRotateFile, err)
+	}
+
+	return nil
+}
+
+func (a *archive) open(p partition) (*file, error) {
+	if !validName(p.format) || !validName(p.channel) {
+		return nil, errInvalidPartition
+	}
+
+	dir := p.dir(a.cfg.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return create(dir, p.format, a.cfg.RowGroupSize)
+}
+
+func (a *archive) Rotate(before time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.rotate(func(f *file) bool {
+		return f.opened.Before(before)
+	})
+}
+
+func (a *archive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.rotate(func(f *file) bool {
+		return true
+	})
+}
+
+// rotate closes the open files matching the given condition, and keeps
+// closing the remaining ones if some of them fail.
+func (a *archive) rotate(match func(f *file) bool) error {
+	var errs []string
+	for p, f := range a.files {
+		if !match(f) {
+			continue
+		}
+		delete(a.files, p)
+		if err := f.close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrap(errRotateFile, errors.New(strings.Join(errs, ", ")))
+	}
+
+	return nil
+}
+
+// create opens the hidden file in the given directory, which is named after
+// the opening time.
+func create(dir, format string, rowGroupSize int64) (*file, error) {
+	now := time.Now()
+	name := fmt.Sprintf("%s%d%s", filePrefix, now.UnixNano(), fileExt)
+	f := &file{
+		path:   filepath.Join(dir, name),
+		tmp:    filepath.Join(dir, "."+name+tmpExt),
+		opened: now,
+	}
+
+	src, err := local.NewLocalFileWriter(f.tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := writer.NewParquetWriter(src, schema(format), 1)
+	if err != nil {
+		src.Close()
+		os.Remove(f.tmp)
+		return nil, err
+	}
+	if rowGroupSize > 0 {
+		w.RowGroupSize = rowGroupSize
+	}
+	w.CompressionType = pq.CompressionCodec_SNAPPY
+
+	f.source = src
+	f.writer = w
+
+	return f, nil
+}
+
+// close writes the footer of the file and makes it visible.
+func (f *file) close() error {
+	if err := f.writer.WriteStop(); err != nil {
+		f.source.Close()
+		return err
+	}
+	if err := f.source.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.tmp, f.path)
+}
+
+// removeTemp removes the hidden files under the root directory of the archive.
+func removeTemp(root string) error {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasPrefix(d.Name(), ".") && strings.HasSuffix(d.Name(), tmpExt) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// validName checks that the partition name is a single path element, since
+// the format comes from the message subtopic.
+func validName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`)
+}
@@ -0,0 +1,360 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/parquet"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+const (
+	msgsNum     = 100
+	valueFields = 5
+	subtopic    = "topic"
+	format      = "some-json"
+)
+
+var (
+	idProvider = uuid.New()
+
+	v   float64 = 5
+	vs          = "stringValue"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+
+	// The messages start in the middle of the hour.
+	start = time.Date(2023, time.March, 1, 10, 30, 0, 0, time.UTC)
+)
+
+type senmlRow struct {
+	Channel     string   `parquet:"name=channel, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Subtopic    string   `parquet:"name=subtopic, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Publisher   string   `parquet:"name=publisher, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Protocol    string   `parquet:"name=protocol, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name        string   `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Unit        string   `parquet:"name=unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Time        float64  `parquet:"name=time, type=DOUBLE"`
+	UpdateTime  float64  `parquet:"name=update_time, type=DOUBLE"`
+	Value       *float64 `parquet:"name=value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	StringValue *string  `parquet:"name=string_value, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	DataValue   *string  `parquet:"name=data_value, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	BoolValue   *bool    `parquet:"name=bool_value, type=BOOLEAN, repetitiontype=OPTIONAL"`
+	Sum         *float64 `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+}
+
+type jsonRow struct {
+	Channel   string `parquet:"name=channel, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Created   int64  `parquet:"name=created, type=INT64"`
+	Subtopic  string `parquet:"name=subtopic, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Publisher string `parquet:"name=publisher, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Protocol  string `parquet:"name=protocol, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Payload   string `parquet:"name=payload, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+func TestSaveSenml(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := parquet.New(parquet.Config{Path: dir})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  "mqtt",
+			Name:      "temperature",
+			Unit:      "C",
+			Time:      float64(start.Unix() + int64(i*60)),
+		}
+
+		// Mix possible values as well as value sum.
+		switch i % valueFields {
+		case 0:
+			msg.Subtopic = subtopic
+			msg.Value = &v
+		case 1:
+			msg.BoolValue = &vb
+		case 2:
+			msg.StringValue = &vs
+		case 3:
+			msg.DataValue = &vd
+		case 4:
+			msg.Sum = &sum
+		}
+		msgs = append(msgs, msg)
+	}
+
+	err = archive.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Empty(t, files(t, dir, "*/*/*/*"), "expected no visible files before rotation")
+
+	err = archive.Close()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	cases := []struct {
+		desc string
+		hour string
+		rows int
+	}{
+		{
+			desc: "save messages of first hour",
+			hour: "date=2023-03-01/hour=10",
+			rows: 30,
+		},
+		{
+			desc: "save messages of second hour",
+			hour: "date=2023-03-01/hour=11",
+			rows: 60,
+		},
+		{
+			desc: "save messages of third hour",
+			hour: "date=2023-03-01/hour=12",
+			rows: 10,
+		},
+	}
+
+	for _, tc := range cases {
+		paths := files(t, dir, filepath.Join("messages", "channel="+chanID, tc.hour))
+		require.Len(t, paths, 1, fmt.Sprintf("%s: expected 1 file got %d", tc.desc, len(paths)))
+
+		rows := readSenml(t, paths[0])
+		assert.Len(t, rows, tc.rows, fmt.Sprintf("%s: expected %d rows got %d", tc.desc, tc.rows, len(rows)))
+	}
+
+	rows := readSenml(t, files(t, dir, filepath.Join("messages", "channel="+chanID, "date=2023-03-01/hour=10"))[0])
+	for i, row := range rows {
+		msg := msgs[i]
+		assert.Equal(t, msg.Time, row.Time, fmt.Sprintf("expected time %f got %f", msg.Time, row.Time))
+		assert.Equal(t, msg.Publisher, row.Publisher, fmt.Sprintf("expected publisher %s got %s", msg.Publisher, row.Publisher))
+		assert.Equal(t, msg.Subtopic, row.Subtopic, fmt.Sprintf("expected subtopic %s got %s", msg.Subtopic, row.Subtopic))
+		assert.Equal(t, msg.Value, row.Value, fmt.Sprintf("expected value %v got %v", msg.Value, row.Value))
+		assert.Equal(t, msg.BoolValue, row.BoolValue, fmt.Sprintf("expected bool value %v got %v", msg.BoolValue, row.BoolValue))
+		assert.Equal(t, msg.StringValue, row.StringValue, fmt.Sprintf("expected string value %v got %v", msg.StringValue, row.StringValue))
+		assert.Equal(t, msg.DataValue, row.DataValue, fmt.Sprintf("expected data value %v got %v", msg.DataValue, row.DataValue))
+		assert.Equal(t, msg.Sum, row.Sum, fmt.Sprintf("expected sum %v got %v", msg.Sum, row.Sum))
+	}
+}
+
+func TestSaveJSON(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := parquet.New(parquet.Config{Path: dir})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msgs := mfjson.Messages{Format: format}
+	for i := 0; i < msgsNum; i++ {
+		msgs.Data = append(msgs.Data, mfjson.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Created:   start.UnixNano() + int64(i)*int64(time.Second),
+			Subtopic:  subtopic,
+			Protocol:  "mqtt",
+			Payload: map[string]interface{}{
+				"field_1": 123.0,
+				"field_2": "value",
+				"field_3": map[string]interface{}{"field_4": float64(i)},
+			},
+		})
+	}
+
+	cases := []struct {
+		desc string
+		msgs mfjson.Messages
+		err  bool
+	}{
+		{
+			desc: "save JSON messages",
+			msgs: msgs,
+		},
+		{
+			desc: "save JSON messages of invalid format",
+			msgs: mfjson.Messages{Format: "..", Data: msgs.Data[:1]},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := archive.Consume(tc.msgs)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+	}
+
+	err = archive.Close()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	paths := files(t, dir, filepath.Join(format, "channel="+chanID, "date=2023-03-01", "hour=10"))
+	require.Len(t, paths, 1, fmt.Sprintf("expected 1 file got %d", len(paths)))
+
+	rows := readJSON(t, paths[0])
+	require.Len(t, rows, msgsNum, fmt.Sprintf("expected %d rows got %d", msgsNum, len(rows)))
+	for i, row := range rows {
+		msg := msgs.Data[i]
+		assert.Equal(t, msg.Created, row.Created, fmt.Sprintf("expected created %d got %d", msg.Created, row.Created))
+
+		var payload map[string]interface{}
+		err := json.Unmarshal([]byte(row.Payload), &payload)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, map[string]interface{}(msg.Payload), payload, fmt.Sprintf("expected payload %v got %v", msg.Payload, payload))
+	}
+}
+
+func TestRotateAndCompact(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := parquet.New(parquet.Config{Path: dir, MaxRows: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var msgs []senml.Message
+	for i := 0; i < 25; i++ {
+		msgs = append(msgs, senml.Message{
+			Channel: chanID,
+			Name:    "temperature",
+			Time:    float64(start.Unix() + int64(i)),
+			Value:   &v,
+		})
+	}
+	partition := filepath.Join("messages", "channel="+chanID, "date=2023-03-01", "hour=10")
+
+	err = archive.Consume(msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, files(t, dir, partition), 2, "expected full files to be rotated")
+
+	err = archive.Rotate(time.Now().Add(-time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, files(t, dir, partition), 2, "expected recent file to be kept open")
+
+	err = archive.Rotate(time.Now().Add(time.Second))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, files(t, dir, partition), 3, "expected open file to be rotated")
+
+	err = archive.Compact(start.Truncate(time.Hour).Add(30 * time.Minute))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, files(t, dir, partition), 3, "expected hour in progress not to be compacted")
+
+	err = archive.Compact(start.Truncate(time.Hour).Add(time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	paths := files(t, dir, partition)
+	require.Len(t, paths, 1, "expected files to be compacted")
+
+	rows := readSenml(t, paths[0])
+	assert.Len(t, rows, len(msgs), fmt.Sprintf("expected %d rows got %d", len(msgs), len(rows)))
+	for i, row := range rows {
+		assert.Equal(t, msgs[i].Time, row.Time, fmt.Sprintf("expected time %f got %f", msgs[i].Time, row.Time))
+	}
+}
+
+func TestMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := parquet.New(parquet.Config{Path: dir, MaxOpenFiles: 2})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Each message is written to the partition of another hour.
+	for i := 0; i < 3; i++ {
+		msg := senml.Message{
+			Channel: chanID,
+			Name:    "temperature",
+			Time:    float64(start.Add(time.Duration(i) * time.Hour).Unix()),
+			Value:   &v,
+		}
+		err := archive.Consume([]senml.Message{msg})
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	}
+
+	pattern := filepath.Join("messages", "channel="+chanID, "date=2023-03-01", "hour=*")
+	rotated := files(t, dir, pattern)
+	require.Len(t, rotated, 1, "expected least recently written file to be rotated")
+	assert.Contains(t, rotated[0], "hour=10", fmt.Sprintf("expected file of the first hour to be rotated got %s", rotated[0]))
+
+	err = archive.Close()
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Len(t, files(t, dir, pattern), 3, "expected open files to be rotated")
+}
+
+func TestRemoveTemp(t *testing.T) {
+	dir := t.TempDir()
+	partition := filepath.Join(dir, "messages", "channel=1", "date=2023-03-01", "hour=10")
+	err := os.MkdirAll(partition, 0o755)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	tmp := filepath.Join(partition, ".part-1.parquet.tmp")
+	complete := filepath.Join(partition, "part-2.parquet")
+	for _, path := range []string{tmp, complete} {
+		err := os.WriteFile(path, []byte("data"), 0o644)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	_, err = parquet.New(parquet.Config{Path: dir})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	_, err = os.Stat(tmp)
+	assert.True(t, os.IsNotExist(err), fmt.Sprintf("expected stale file to be removed got %s", err))
+	_, err = os.Stat(complete)
+	assert.Nil(t, err, fmt.Sprintf("expected complete file to be kept got %s", err))
+
+	_, err = parquet.New(parquet.Config{Path: filepath.Join(dir, "missing")})
+	assert.Nil(t, err, fmt.Sprintf("expected no error for missing archive got %s", err))
+}
+
+// files returns the visible files of the archive matching the pattern.
+func files(t *testing.T, dir, pattern string) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, pattern, "*.parquet"))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return paths
+}
+
+func readSenml(t *testing.T, path string) []senmlRow {
+	src, err := local.NewLocalFileReader(path)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer src.Close()
+
+	r, err := reader.NewParquetReader(src, new(senmlRow), 1)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer r.ReadStop()
+
+	rows := make([]senmlRow, r.GetNumRows())
+	err = r.Read(&rows)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return rows
+}
+
+func readJSON(t *testing.T, path string) []jsonRow {
+	src, err := local.NewLocalFileReader(path)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer src.Close()
+
+	r, err := reader.NewParquetReader(src, new(jsonRow), 1)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer r.ReadStop()
+
+	rows := make([]jsonRow, r.GetNumRows())
+	err = r.Read(&rows)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return rows
+}
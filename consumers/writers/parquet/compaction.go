@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// compactBatch is the number of the rows copied at once during compaction.
+const compactBatch = 1000
+
+var errCompactFiles = errors.New("failed to compact parquet files")
+
+// The rotated files are never modified, so the compaction doesn't block
+// writing the messages. Late messages of a compacted hour end up in a new
+// file, which is merged by the following compaction.
+func (a *archive) Compact(before time.Time) error {
+	dirs, err := filepath.Glob(filepath.Join(a.cfg.Path, "*", "channel=*", "date=*", "hour=*"))
+	if err != nil {
+		return errors.Wrap(errCompactFiles, err)
+	}
+
+	var errs []string
+	for _, dir := range dirs {
+		hour, err := partitionHour(dir)
+		if err != nil || hour.Add(time.Hour).After(before) {
+			continue
+		}
+
+		format := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(dir))))
+		if err := compact(dir, format, a.cfg.RowGroupSize); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", dir, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrap(errCompactFiles, errors.New(strings.Join(errs, ", ")))
+	}
+
+	return nil
+}
+
+// compact merges the files of the partition directory. The merged file is
+// made visible before the original files are removed, so the failure in
+// between leaves the rows duplicated rather than lost.
+func compact(dir, format string, rowGroupSize int64) error {
+	paths, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileExt))
+	if err != nil {
+		return err
+	}
+	if len(paths) < 2 {
+		return nil
+	}
+
+	out, err := create(dir, format, rowGroupSize)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := copyRows(out, path, format); err != nil {
+			out.source.Close()
+			os.Remove(out.tmp)
+			return err
+		}
+	}
+
+	if err := out.close(); err != nil {
+		os.Remove(out.tmp)
+		return err
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyRows(out *file, path, format string) error {
+	src, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	r, err := reader.NewParquetReader(src, schema(format), 1)
+	if err != nil {
+		return err
+	}
+	defer r.ReadStop()
+
+	for n := r.GetNumRows(); n > 0; n -= compactBatch {
+		size := compactBatch
+		if n < compactBatch {
+			size = int(n)
+		}
+
+		rows, err := r.ReadByNumber(size)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := out.writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// partitionHour returns the hour of the partition directory.
+func partitionHour(dir string) (time.Time, error) {
+	hour := strings.TrimPrefix(filepath.Base(dir), "hour=")
+	date := strings.TrimPrefix(filepath.Base(filepath.Dir(dir)), "date=")
+
+	return time.Parse(dateLayout+" "+hourLayout, date+" "+hour)
+}
+
+// Run rotates the files opened for longer than the maximum age and compacts
+// the partitions of the hours ended before, every interval, until the
+// context is canceled.
+func Run(ctx context.Context, a Archive, interval, maxAge time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			before := time.Now().Add(-maxAge)
+			if err := a.Rotate(before); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to rotate parquet files: %s", err))
+			}
+			if err := a.Compact(before); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to compact parquet files: %s", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
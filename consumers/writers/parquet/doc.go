@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parquet contains the domain concept definitions needed to
+// support Mainflux Parquet writer service functionality.
+package parquet
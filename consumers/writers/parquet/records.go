@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"encoding/json"
+
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// senmlRecord is the row of the SenML messages file. The values missing from
+// the record are stored as nulls.
+type senmlRecord struct {
+	Channel     string   `parquet:"name=channel, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Subtopic    string   `parquet:"name=subtopic, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Publisher   string   `parquet:"name=publisher, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Protocol    string   `parquet:"name=protocol, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Name        string   `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Unit        string   `parquet:"name=unit, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Time        float64  `parquet:"name=time, type=DOUBLE"`
+	UpdateTime  float64  `parquet:"name=update_time, type=DOUBLE"`
+	Value       *float64 `parquet:"name=value, type=DOUBLE, repetitiontype=OPTIONAL"`
+	StringValue *string  `parquet:"name=string_value, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	DataValue   *string  `parquet:"name=data_value, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	BoolValue   *bool    `parquet:"name=bool_value, type=BOOLEAN, repetitiontype=OPTIONAL"`
+	Sum         *float64 `parquet:"name=sum, type=DOUBLE, repetitiontype=OPTIONAL"`
+}
+
+// jsonRecord is the row of the JSON messages file. The payload is stored as
+// JSON text, since its structure varies between the messages.
+type jsonRecord struct {
+	Channel   string `parquet:"name=channel, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Created   int64  `parquet:"name=created, type=INT64"`
+	Subtopic  string `parquet:"name=subtopic, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Publisher string `parquet:"name=publisher, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Protocol  string `parquet:"name=protocol, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	Payload   string `parquet:"name=payload, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+func newSenmlRecord(msg senml.Message) senmlRecord {
+	return senmlRecord{
+		Channel:     msg.Channel,
+		Subtopic:    msg.Subtopic,
+		Publisher:   msg.Publisher,
+		Protocol:    msg.Protocol,
+		Name:        msg.Name,
+		Unit:        msg.Unit,
+		Time:        msg.Time,
+		UpdateTime:  msg.UpdateTime,
+		Value:       msg.Value,
+		StringValue: msg.StringValue,
+		DataValue:   msg.DataValue,
+		BoolValue:   msg.BoolValue,
+		Sum:         msg.Sum,
+	}
+}
+
+func newJSONRecord(msg mfjson.Message) (jsonRecord, error) {
+	payload, err := json.Marshal(msg.Payload)
+	if err != nil {
+		return jsonRecord{}, err
+	}
+
+	return jsonRecord{
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   string(payload),
+	}, nil
+}
+
+// schema returns the record type of the files of the given format.
+func schema(format string) interface{} {
+	if format == senmlFormat {
+		return new(senmlRecord)
+	}
+	return new(jsonRecord)
+}
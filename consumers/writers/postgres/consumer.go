@@ -14,15 +14,27 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx" // required for DB access
 )
 
+const senmlTable = "messages"
+
 var (
 	errInvalidMessage = errors.New("invalid message representation")
 	errSaveMessage    = errors.New("failed to save message to postgres database")
-	errTransRollback  = errors.New("failed to rollback transaction")
 	errNoTable        = errors.New("relation does not exist")
+	errDriver         = errors.New("unsupported database driver")
+)
+
+var (
+	senmlColumns = []string{"id", "channel", "subtopic", "publisher", "protocol",
+		"name", "unit", "value", "string_value", "bool_value", "data_value", "sum",
+		"time", "update_time"}
+	jsonColumns = []string{"id", "channel", "created", "subtopic", "publisher", "protocol", "payload"}
 )
 
 var _ consumers.Consumer = (*postgresRepo)(nil)
@@ -45,54 +57,34 @@ func (pr postgresRepo) Consume(message interface{}) (err error) {
 	}
 }
 
-func (pr postgresRepo) saveSenml(messages interface{}) (err error) {
+func (pr postgresRepo) saveSenml(messages interface{}) error {
 	msgs, ok := messages.([]senml.Message)
 	if !ok {
 		return errSaveMessage
 	}
-	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
-          name, unit, value, string_value, bool_value, data_value, sum,
-          time, update_time)
-          VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
-          :value, :string_value, :bool_value, :data_value, :sum,
-          :time, :update_time);`
-
-	tx, err := pr.db.BeginTxx(context.Background(), nil)
-	if err != nil {
-		return errors.Wrap(errSaveMessage, err)
-	}
-	defer func() {
-		if err != nil {
-			if txErr := tx.Rollback(); txErr != nil {
-				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
-			}
-			return
-		}
-
-		if err = tx.Commit(); err != nil {
-			err = errors.Wrap(errSaveMessage, err)
-		}
-	}()
 
+	rows := make([][]interface{}, 0, len(msgs))
 	for _, msg := range msgs {
-		id, err := uuid.NewV4()
+		row, err := senmlRow(msg)
 		if err != nil {
-			return err
+			return errors.Wrap(errSaveMessage, err)
 		}
-		m := senmlMessage{Message: msg, ID: id.String()}
-		if _, err := tx.NamedExec(q, m); err != nil {
-			pgErr, ok := err.(*pgconn.PgError)
-			if ok {
-				switch pgErr.Code {
-				case pgerrcode.InvalidTextRepresentation:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
-				}
-			}
+		rows = append(rows, row)
+	}
 
-			return errors.Wrap(errSaveMessage, err)
+	if err := pr.copy(senmlTable, senmlColumns, rows); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errSaveMessage, errInvalidMessage)
+			}
 		}
+
+		return errors.Wrap(errSaveMessage, err)
 	}
-	return err
+
+	return nil
 }
 
 func (pr postgresRepo) saveJSON(msgs mfjson.Messages) error {
@@ -109,50 +101,51 @@ func (pr postgresRepo) saveJSON(msgs mfjson.Messages) error {
 }
 
 func (pr postgresRepo) insertJSON(msgs mfjson.Messages) error {
-	tx, err := pr.db.BeginTxx(context.Background(), nil)
-	if err != nil {
-		return errors.Wrap(errSaveMessage, err)
-	}
-	defer func() {
-		if err != nil {
-			if txErr := tx.Rollback(); txErr != nil {
-				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
-			}
-			return
-		}
-
-		if err = tx.Commit(); err != nil {
-			err = errors.Wrap(errSaveMessage, err)
-		}
-	}()
-
-	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
-          VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
-	q = fmt.Sprintf(q, msgs.Format)
-
+	rows := make([][]interface{}, 0, len(msgs.Data))
 	for _, m := range msgs.Data {
-		var dbmsg jsonMessage
-		dbmsg, err = toJSONMessage(m)
+		row, err := jsonRow(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
+		rows = append(rows, row)
+	}
 
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
-			pgErr, ok := err.(*pgconn.PgError)
-			if ok {
-				switch pgErr.Code {
-				case pgerrcode.InvalidTextRepresentation:
-					return errors.Wrap(errSaveMessage, errInvalidMessage)
-				case pgerrcode.UndefinedTable:
-					return errNoTable
-				}
+	if err := pr.copy(msgs.Format, jsonColumns, rows); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errSaveMessage, errInvalidMessage)
+			case pgerrcode.UndefinedTable:
+				return errNoTable
 			}
-			return err
 		}
+		return errors.Wrap(errSaveMessage, err)
 	}
+
 	return nil
 }
 
+// copy writes rows to the table using the COPY protocol, so the whole
+// slice of messages is stored in a single round-trip.
+func (pr postgresRepo) copy(table string, columns []string, rows [][]interface{}) error {
+	ctx := context.Background()
+	conn, err := pr.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errDriver
+		}
+		_, err := c.Conn().CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+		return err
+	})
+}
+
 func (pr postgresRepo) createTable(name string) error {
 	q := `CREATE TABLE IF NOT EXISTS %s (
             id            UUID,
@@ -170,45 +163,73 @@ func (pr postgresRepo) createTable(name string) error {
 	return err
 }
 
-type senmlMessage struct {
-	senml.Message
-	ID string `db:"id"`
-}
+func senmlRow(msg senml.Message) ([]interface{}, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	channel, err := toUUID(msg.Channel)
+	if err != nil {
+		return nil, err
+	}
+	publisher, err := toUUID(msg.Publisher)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if msg.DataValue != nil {
+		data = []byte(*msg.DataValue)
+	}
 
-type jsonMessage struct {
-	ID        string `db:"id"`
-	Channel   string `db:"channel"`
-	Created   int64  `db:"created"`
-	Subtopic  string `db:"subtopic"`
-	Publisher string `db:"publisher"`
-	Protocol  string `db:"protocol"`
-	Payload   []byte `db:"payload"`
+	return []interface{}{
+		pgtype.UUID{Bytes: id, Valid: true},
+		channel,
+		msg.Subtopic,
+		publisher,
+		msg.Protocol,
+		msg.Name,
+		msg.Unit,
+		msg.Value,
+		msg.StringValue,
+		msg.BoolValue,
+		data,
+		msg.Sum,
+		msg.Time,
+		msg.UpdateTime,
+	}, nil
 }
 
-func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
+func jsonRow(msg mfjson.Message) ([]interface{}, error) {
 	id, err := uuid.NewV4()
 	if err != nil {
-		return jsonMessage{}, err
+		return nil, err
 	}
 
 	data := []byte("{}")
 	if msg.Payload != nil {
 		b, err := json.Marshal(msg.Payload)
 		if err != nil {
-			return jsonMessage{}, errors.Wrap(errSaveMessage, err)
+			return nil, err
 		}
 		data = b
 	}
 
-	m := jsonMessage{
-		ID:        id.String(),
-		Channel:   msg.Channel,
-		Created:   msg.Created,
-		Subtopic:  msg.Subtopic,
-		Publisher: msg.Publisher,
-		Protocol:  msg.Protocol,
-		Payload:   data,
-	}
+	return []interface{}{
+		pgtype.UUID{Bytes: id, Valid: true},
+		msg.Channel,
+		msg.Created,
+		msg.Subtopic,
+		msg.Publisher,
+		msg.Protocol,
+		data,
+	}, nil
+}
 
-	return m, nil
+func toUUID(s string) (pgtype.UUID, error) {
+	id, err := uuid.FromString(s)
+	if err != nil {
+		return pgtype.UUID{}, errInvalidMessage
+	}
+	return pgtype.UUID{Bytes: id, Valid: true}, nil
 }
@@ -70,6 +70,39 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS retention_policies (
+                        channel       TEXT,
+                        period        BIGINT NOT NULL,
+                        PRIMARY KEY (channel)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS messages_time_idx ON messages (time)`,
+				},
+				Down: []string{
+					"DROP INDEX messages_time_idx",
+					"DROP TABLE retention_policies",
+				},
+			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS deletions (
+                        channel       TEXT NOT NULL,
+                        actor         TEXT NOT NULL,
+                        subtopic      TEXT,
+                        publisher     TEXT,
+                        format        TEXT,
+                        from_time     FLOAT,
+                        to_time       FLOAT,
+                        time          TIMESTAMPTZ NOT NULL
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE deletions",
+				},
+			},
 		},
 	}
 
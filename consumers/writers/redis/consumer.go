@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "latest"
+
+// saveScript stores the message unless the cache already contains a
+// newer message of the same publisher and name.
+var saveScript = redis.NewScript(`
+local cur = redis.call('HGET', KEYS[1], ARGV[1])
+if cur then
+	local t = cjson.decode(cur)['time']
+	if t and tonumber(t) > tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+return 1
+`)
+
+var errSaveMessage = errors.New("failed to save message to redis cache")
+
+var _ consumers.Consumer = (*redisRepo)(nil)
+
+type redisRepo struct {
+	client *redis.Client
+}
+
+// New returns new Redis writer which keeps the latest message per channel,
+// publisher and SenML name.
+func New(client *redis.Client) consumers.Consumer {
+	return &redisRepo{client: client}
+}
+
+func (rr *redisRepo) Consume(message interface{}) error {
+	switch m := message.(type) {
+	case mfjson.Messages:
+		// JSON messages carry no SenML names, so there is no value to cache.
+		return nil
+	default:
+		return rr.saveSenml(m)
+	}
+}
+
+func (rr *redisRepo) saveSenml(messages interface{}) error {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+
+	// Keep only the newest message of each publisher and name in the batch.
+	latest := map[string]senml.Message{}
+	var order []string
+	for _, msg := range msgs {
+		k := fmt.Sprintf("%s\x00%s\x00%s", msg.Channel, msg.Publisher, msg.Name)
+		cur, ok := latest[k]
+		if !ok {
+			order = append(order, k)
+		}
+		if !ok || msg.Time >= cur.Time {
+			latest[k] = msg
+		}
+	}
+
+	ctx := context.Background()
+	pipe := rr.client.Pipeline()
+	for _, k := range order {
+		msg := latest[k]
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		saveScript.Eval(ctx, pipe, []string{key(msg.Channel)}, field(msg.Publisher, msg.Name), msg.Time, data)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+func key(chanID string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, chanID)
+}
+
+func field(publisher, name string) string {
+	return fmt.Sprintf("%s:%s", publisher, name)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	rediscache "github.com/MainfluxLabs/mainflux/readers/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID    = "45"
+	publisher = "2580"
+	msgName   = "temperature"
+)
+
+var v float64 = 5
+
+func TestSaveSenML(t *testing.T) {
+	repo := redis.New(redisClient)
+	cache := rediscache.New(redisClient)
+
+	newer := senml.Message{Channel: chanID, Publisher: publisher, Name: msgName, Time: 20, Value: &v}
+	older := senml.Message{Channel: chanID, Publisher: publisher, Name: msgName, Time: 10, Value: &v}
+	other := senml.Message{Channel: chanID, Publisher: publisher, Name: "humidity", Time: 5, Value: &v}
+
+	cases := []struct {
+		desc     string
+		msgs     interface{}
+		expected []readers.Message
+	}{
+		{
+			desc:     "save batch of messages",
+			msgs:     []senml.Message{older, newer, other},
+			expected: []readers.Message{other, newer},
+		},
+		{
+			desc:     "save message older than the cached one",
+			msgs:     []senml.Message{older},
+			expected: []readers.Message{other, newer},
+		},
+		{
+			desc:     "save JSON messages",
+			msgs:     json.Messages{Format: "some_json"},
+			expected: []readers.Message{other, newer},
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Consume(tc.msgs)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		page, err := cache.ListLatestMessages(chanID, readers.PageMetadata{})
+		require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.expected, page.Messages, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expected, page.Messages))
+	}
+}
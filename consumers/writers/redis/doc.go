@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the latest value writer which keeps the last
+// received message of each channel publisher and SenML name in Redis.
+package redis
@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func savePolicyEndpoint(svc retention.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(savePolicyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		p := retention.Policy{
+			Channel: req.chanID,
+			Period:  req.period(),
+		}
+		if err := svc.SavePolicy(ctx, p); err != nil {
+			return nil, err
+		}
+
+		return toPolicyRes(p), nil
+	}
+}
+
+func viewPolicyEndpoint(svc retention.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		p, err := svc.ViewPolicy(ctx, req.chanID)
+		if err != nil {
+			return nil, err
+		}
+
+		return toPolicyRes(p), nil
+	}
+}
+
+func listPoliciesEndpoint(svc retention.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPoliciesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		policies, err := svc.ListPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := policiesRes{
+			Policies: []policyRes{},
+		}
+		for _, p := range policies {
+			res.Policies = append(res.Policies, toPolicyRes(p))
+		}
+
+		return res, nil
+	}
+}
+
+func removePolicyEndpoint(svc retention.Service, auth mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := apiutil.AuthorizeAdmin(ctx, auth, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemovePolicy(ctx, req.chanID); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention/api"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/users"
+	authmocks "github.com/MainfluxLabs/mainflux/users/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	svcName     = "test-writer"
+	chanID      = "1"
+	otherID     = "2"
+	adminToken  = "admin@example.com"
+	userToken   = "user@example.com"
+	invalid     = "invalid"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type policyRes struct {
+	Channel string `json:"channel"`
+	Period  string `json:"period"`
+}
+
+type policiesRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func newServer() *httptest.Server {
+	svc := retention.New(mocks.NewPolicyRepository(), mocks.NewPruner(nil))
+	auth := authmocks.NewAuthService(map[string]users.User{
+		adminToken: {ID: "1", Email: adminToken},
+		userToken:  {ID: "2", Email: userToken},
+	})
+	return httptest.NewServer(api.MakeHandler(svc, auth, svcName, logger.NewMock()))
+}
+
+func TestSavePolicy(t *testing.T) {
+	ts := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		contentType string
+		token       string
+		body        string
+		status      int
+		res         policyRes
+	}{
+		{
+			desc:        "save channel policy",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: contentType,
+			token:       adminToken,
+			body:        `{"period":"24h"}`,
+			status:      http.StatusOK,
+			res:         policyRes{Channel: chanID, Period: "24h0m0s"},
+		},
+		{
+			desc:        "save default policy",
+			url:         fmt.Sprintf("%s/retention/default", ts.URL),
+			contentType: contentType,
+			token:       adminToken,
+			body:        `{"period":"720h"}`,
+			status:      http.StatusOK,
+			res:         policyRes{Period: "720h0m0s"},
+		},
+		{
+			desc:        "save policy with period shorter than minimum",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: contentType,
+			token:       adminToken,
+			body:        `{"period":"10m"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with invalid period",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: contentType,
+			token:       adminToken,
+			body:        `{"period":"month"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with malformed body",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: contentType,
+			token:       adminToken,
+			body:        `{"period":`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "save policy with invalid content type",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: "text/plain",
+			token:       adminToken,
+			body:        `{"period":"24h"}`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "save default policy without token",
+			url:         fmt.Sprintf("%s/retention/default", ts.URL),
+			contentType: contentType,
+			body:        `{"period":"1h"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save default policy with invalid token",
+			url:         fmt.Sprintf("%s/retention/default", ts.URL),
+			contentType: contentType,
+			token:       invalid,
+			body:        `{"period":"1h"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "save default policy as non-admin user",
+			url:         fmt.Sprintf("%s/retention/default", ts.URL),
+			contentType: contentType,
+			token:       userToken,
+			body:        `{"period":"1h"}`,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "save channel policy as non-admin user",
+			url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			contentType: contentType,
+			token:       userToken,
+			body:        `{"period":"1h"}`,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         tc.url,
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status == http.StatusOK {
+			var body policyRes
+			err := json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+		}
+	}
+}
+
+func TestViewAndRemovePolicy(t *testing.T) {
+	ts := newServer()
+	defer ts.Close()
+
+	for _, url := range []string{fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID), fmt.Sprintf("%s/retention/default", ts.URL)} {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         url,
+			contentType: contentType,
+			token:       adminToken,
+			body:        strings.NewReader(`{"period":"48h"}`),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		require.Equal(t, http.StatusOK, res.StatusCode, "unexpected status code")
+	}
+
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view channel policy without token",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove channel policy with invalid token",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove channel policy as non-admin user",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove default policy as non-admin user",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/retention/default", ts.URL),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view channel policy",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view default policy",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/retention/default", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing channel policy",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, otherID),
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "remove channel policy",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "view removed channel policy",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "remove removed channel policy",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: tc.method,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListPolicies(t *testing.T) {
+	ts := newServer()
+	defer ts.Close()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/retention", ts.URL),
+		token:  adminToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	var body policiesRes
+	err = json.NewDecoder(res.Body).Decode(&body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, policiesRes{Policies: []policyRes{}}, body, "expected empty policy list")
+
+	req = testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPut,
+		url:         fmt.Sprintf("%s/channels/%s/retention", ts.URL, chanID),
+		contentType: contentType,
+		token:       adminToken,
+		body:        strings.NewReader(`{"period":"24h"}`),
+	}
+	_, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	req = testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/retention", ts.URL),
+		token:  adminToken,
+	}
+	res, err = req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	body = policiesRes{}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, policiesRes{Policies: []policyRes{{Channel: chanID, Period: "24h0m0s"}}}, body, "expected channel policy list")
+}
+
+func TestListPoliciesUnauthorized(t *testing.T) {
+	ts := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "list policies without token",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list policies with invalid token",
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list policies as non-admin user",
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/retention", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ retention.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    retention.Service
+}
+
+// LoggingMiddleware adds logging facilities to the retention service.
+func LoggingMiddleware(svc retention.Service, logger log.Logger) retention.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) SavePolicy(ctx context.Context, p retention.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method save_policy for channel %s took %s to complete", chanName(p.Channel), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SavePolicy(ctx, p)
+}
+
+func (lm *loggingMiddleware) ViewPolicy(ctx context.Context, chanID string) (p retention.Policy, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_policy for channel %s took %s to complete", chanName(chanID), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewPolicy(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) ListPolicies(ctx context.Context) (ps []retention.Policy, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_policies took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListPolicies(ctx)
+}
+
+func (lm *loggingMiddleware) RemovePolicy(ctx context.Context, chanID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_policy for channel %s took %s to complete", chanName(chanID), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemovePolicy(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) Prune(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method prune took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Prune(ctx)
+}
+
+// chanName returns the name the default policy is logged with.
+func chanName(chanID string) string {
+	if chanID == "" {
+		return "default"
+	}
+	return chanID
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ retention.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     retention.Service
+}
+
+// MetricsMiddleware instruments retention service by tracking request count and latency.
+func MetricsMiddleware(svc retention.Service, counter metrics.Counter, latency metrics.Histogram) retention.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) SavePolicy(ctx context.Context, p retention.Policy) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "save_policy").Add(1)
+		ms.latency.With("method", "save_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SavePolicy(ctx, p)
+}
+
+func (ms *metricsMiddleware) ViewPolicy(ctx context.Context, chanID string) (retention.Policy, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_policy").Add(1)
+		ms.latency.With("method", "view_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewPolicy(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) ListPolicies(ctx context.Context) ([]retention.Policy, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_policies").Add(1)
+		ms.latency.With("method", "list_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListPolicies(ctx)
+}
+
+func (ms *metricsMiddleware) RemovePolicy(ctx context.Context, chanID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_policy").Add(1)
+		ms.latency.With("method", "remove_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemovePolicy(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) Prune(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "prune").Add(1)
+		ms.latency.With("method", "prune").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Prune(ctx)
+}
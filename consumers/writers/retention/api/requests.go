@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+// The requests having the empty channel ID address the default policy.
+
+type savePolicyReq struct {
+	token  string
+	chanID string
+	Period string `json:"period"`
+}
+
+func (req savePolicyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	period, err := time.ParseDuration(req.Period)
+	if err != nil || period < retention.MinPeriod {
+		return retention.ErrInvalidPeriod
+	}
+
+	return nil
+}
+
+// period returns the requested period, which is validated beforehand.
+func (req savePolicyReq) period() time.Duration {
+	period, _ := time.ParseDuration(req.Period)
+	return period
+}
+
+type policyReq struct {
+	token  string
+	chanID string
+}
+
+func (req policyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type listPoliciesReq struct {
+	token string
+}
+
+func (req listPoliciesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+)
+
+var (
+	_ mainflux.Response = (*policyRes)(nil)
+	_ mainflux.Response = (*policiesRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type policyRes struct {
+	Channel string `json:"channel,omitempty"`
+	Period  string `json:"period"`
+}
+
+func (res policyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res policyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res policyRes) Empty() bool {
+	return false
+}
+
+type policiesRes struct {
+	Policies []policyRes `json:"policies"`
+}
+
+func (res policiesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res policiesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res policiesRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+func toPolicyRes(p retention.Policy) policyRes {
+	return policyRes{
+		Channel: p.Channel,
+		Period:  p.Period.String(),
+	}
+}
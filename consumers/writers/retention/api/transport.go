@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const contentType = "application/json"
+
+// MakeHandler returns a HTTP API handler with health check, metrics and the
+// retention policy endpoints. The default policy is addressed by the
+// /retention/default path and the channel policies by /channels/:id/retention.
+// The retention policy endpoints are restricted to the system admin.
+func MakeHandler(svc retention.Service, auth mainflux.AuthServiceClient, svcName string, logger logger.Logger) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	r := bone.New()
+
+	r.Get("/retention", kithttp.NewServer(
+		listPoliciesEndpoint(svc, auth),
+		decodeListPolicies,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/retention/default", kithttp.NewServer(
+		savePolicyEndpoint(svc, auth),
+		decodeSavePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/retention/default", kithttp.NewServer(
+		viewPolicyEndpoint(svc, auth),
+		decodePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/retention/default", kithttp.NewServer(
+		removePolicyEndpoint(svc, auth),
+		decodePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/channels/:id/retention", kithttp.NewServer(
+		savePolicyEndpoint(svc, auth),
+		decodeSavePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/channels/:id/retention", kithttp.NewServer(
+		viewPolicyEndpoint(svc, auth),
+		decodePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/channels/:id/retention", kithttp.NewServer(
+		removePolicyEndpoint(svc, auth),
+		decodePolicy,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health(svcName))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeListPolicies(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listPoliciesReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeSavePolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := savePolicyReq{
+		token:  apiutil.ExtractBearerToken(r),
+		chanID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodePolicy(_ context.Context, r *http.Request) (interface{}, error) {
+	req := policyReq{
+		token:  apiutil.ExtractBearerToken(r),
+		chanID: bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, retention.ErrInvalidPeriod):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, apiutil.ErrBearerToken),
+		errors.Contains(err, errors.ErrAuthentication):
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package retention contains the domain concept definitions needed to support
+// message retention in the writer services. Retention policies are set per
+// channel or per writer and the messages that outlive them are periodically
+// pruned from the writer's database.
+package retention
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package influxdb contains retention policy repository implementation using
+// InfluxDB as the underlying database.
+package influxdb
@@ -0,0 +1,151 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+const (
+	measurement = "retention_policies"
+
+	// defChannel tags the default policy, since the tag values can't be empty.
+	defChannel = "_default"
+)
+
+// The policies are written at the same time, so that saving a policy
+// overwrites the existing policy of the channel.
+var epoch = time.Unix(0, 0)
+
+var _ retention.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	client influxdb2.Client
+	org    string
+	bucket string
+}
+
+// NewPolicyRepository instantiates an InfluxDB implementation of retention
+// policy repository. The policies are stored in a bucket of their own, named
+// after the messages bucket, so that they aren't expired with the messages.
+// The bucket is created if it doesn't exist.
+func NewPolicyRepository(client influxdb2.Client, org, bucket string) (retention.PolicyRepository, error) {
+	name := fmt.Sprintf("%s-retention", bucket)
+
+	ctx := context.Background()
+	if _, err := client.BucketsAPI().FindBucketByName(ctx, name); err != nil {
+		o, err := client.OrganizationsAPI().FindOrganizationByName(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := client.BucketsAPI().CreateBucketWithName(ctx, o, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &policyRepository{
+		client: client,
+		org:    org,
+		bucket: name,
+	}, nil
+}
+
+func (pr policyRepository) Save(ctx context.Context, p retention.Policy) error {
+	tags := map[string]string{"channel": toTag(p.Channel)}
+	fields := map[string]interface{}{"period": int64(p.Period / time.Second)}
+	pt := influxdb2.NewPoint(measurement, tags, fields, epoch)
+
+	if err := pr.client.WriteAPIBlocking(pr.org, pr.bucket).WritePoint(ctx, pt); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) Retrieve(ctx context.Context, chanID string) (retention.Policy, error) {
+	filter := fmt.Sprintf(`r._measurement == "%s" and r.channel == %s`, measurement, strconv.Quote(toTag(chanID)))
+	policies, err := pr.query(ctx, filter)
+	if err != nil {
+		return retention.Policy{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	if len(policies) == 0 {
+		return retention.Policy{}, errors.ErrNotFound
+	}
+
+	return policies[0], nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	filter := fmt.Sprintf(`r._measurement == "%s"`, measurement)
+	policies, err := pr.query(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return policies, nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, chanID string) error {
+	if _, err := pr.Retrieve(ctx, chanID); err != nil {
+		return err
+	}
+
+	predicate := fmt.Sprintf(`_measurement="%s" AND channel="%s"`, measurement, toTag(chanID))
+	if err := pr.client.DeleteAPI().DeleteWithName(ctx, pr.org, pr.bucket, epoch, epoch.Add(time.Second), predicate); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) query(ctx context.Context, filter string) ([]retention.Policy, error) {
+	q := fmt.Sprintf(`from(bucket: "%s")
+  |> range(start: 0)
+  |> filter(fn: (r) => %s)
+  |> last()`, pr.bucket, filter)
+
+	res, err := pr.client.QueryAPI(pr.org).Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var policies []retention.Policy
+	for res.Next() {
+		rec := res.Record()
+		ch, _ := rec.ValueByKey("channel").(string)
+		period, _ := rec.Value().(int64)
+		policies = append(policies, retention.Policy{
+			Channel: fromTag(ch),
+			Period:  time.Duration(period) * time.Second,
+		})
+	}
+	if err := res.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func toTag(chanID string) string {
+	if chanID == "" {
+		return defChannel
+	}
+	return chanID
+}
+
+func fromTag(tag string) string {
+	if tag == defChannel {
+		return ""
+	}
+	return tag
+}
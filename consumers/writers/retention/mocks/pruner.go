@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+)
+
+var _ retention.Pruner = (*PrunerMock)(nil)
+
+// PrunerMock records the policies it's called with.
+type PrunerMock struct {
+	mu       sync.Mutex
+	policies []retention.Policy
+	err      error
+}
+
+// NewPruner creates the pruner mock failing with the given error.
+func NewPruner(err error) *PrunerMock {
+	return &PrunerMock{err: err}
+}
+
+func (pm *PrunerMock) Prune(_ context.Context, policies []retention.Policy) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.policies = policies
+
+	return pm.err
+}
+
+// Policies returns the policies of the last Prune call.
+func (pm *PrunerMock) Policies() []retention.Policy {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	return pm.policies
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mongodb contains retention policy repository implementation using
+// MongoDB as the underlying database.
+package mongodb
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PoliciesCollection is the collection the retention policies are stored in.
+const PoliciesCollection = "retention_policies"
+
+var _ retention.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	db *mongo.Database
+}
+
+// NewPolicyRepository instantiates a MongoDB implementation of retention
+// policy repository.
+func NewPolicyRepository(db *mongo.Database) retention.PolicyRepository {
+	return &policyRepository{
+		db: db,
+	}
+}
+
+func (pr policyRepository) Save(ctx context.Context, p retention.Policy) error {
+	coll := pr.db.Collection(PoliciesCollection)
+
+	filter := bson.M{"_id": p.Channel}
+	opts := options.Replace().SetUpsert(true)
+	if _, err := coll.ReplaceOne(ctx, filter, toDBPolicy(p), opts); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) Retrieve(ctx context.Context, chanID string) (retention.Policy, error) {
+	coll := pr.db.Collection(PoliciesCollection)
+
+	var dbp dbPolicy
+	if err := coll.FindOne(ctx, bson.M{"_id": chanID}).Decode(&dbp); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return retention.Policy{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return retention.Policy{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toPolicy(dbp), nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	coll := pr.db.Collection(PoliciesCollection)
+
+	cursor, err := coll.Find(ctx, bson.D{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer cursor.Close(ctx)
+
+	var policies []retention.Policy
+	for cursor.Next(ctx) {
+		var dbp dbPolicy
+		if err := cursor.Decode(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		policies = append(policies, toPolicy(dbp))
+	}
+
+	return policies, nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, chanID string) error {
+	coll := pr.db.Collection(PoliciesCollection)
+
+	res, err := coll.DeleteOne(ctx, bson.M{"_id": chanID})
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if res.DeletedCount == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// dbPolicy stores the period in seconds.
+type dbPolicy struct {
+	Channel string `bson:"_id"`
+	Period  int64  `bson:"period"`
+}
+
+func toDBPolicy(p retention.Policy) dbPolicy {
+	return dbPolicy{
+		Channel: p.Channel,
+		Period:  int64(p.Period / time.Second),
+	}
+}
+
+func toPolicy(dbp dbPolicy) retention.Policy {
+	return retention.Policy{
+		Channel: dbp.Channel,
+		Period:  time.Duration(dbp.Period) * time.Second,
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package postgres contains retention policy repository implementation using
+// PostgreSQL as the underlying database. It's shared by the PostgreSQL and
+// TimescaleDB writers, whose migrations create the retention_policies table.
+package postgres
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ retention.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	db *sqlx.DB
+}
+
+// NewPolicyRepository instantiates a PostgreSQL implementation of retention
+// policy repository.
+func NewPolicyRepository(db *sqlx.DB) retention.PolicyRepository {
+	return &policyRepository{
+		db: db,
+	}
+}
+
+func (pr policyRepository) Save(ctx context.Context, p retention.Policy) error {
+	q := `INSERT INTO retention_policies (channel, period) VALUES (:channel, :period)
+		ON CONFLICT (channel) DO UPDATE SET period = EXCLUDED.period`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr policyRepository) Retrieve(ctx context.Context, chanID string) (retention.Policy, error) {
+	q := `SELECT channel, period FROM retention_policies WHERE channel = $1`
+
+	dbp := dbPolicy{}
+	if err := pr.db.QueryRowxContext(ctx, q, chanID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return retention.Policy{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return retention.Policy{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toPolicy(dbp), nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context) ([]retention.Policy, error) {
+	q := `SELECT channel, period FROM retention_policies ORDER BY channel`
+
+	rows, err := pr.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var policies []retention.Policy
+	for rows.Next() {
+		dbp := dbPolicy{}
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		policies = append(policies, toPolicy(dbp))
+	}
+
+	return policies, nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, chanID string) error {
+	q := `DELETE FROM retention_policies WHERE channel = $1`
+
+	res, err := pr.db.ExecContext(ctx, q, chanID)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// dbPolicy stores the period in seconds.
+type dbPolicy struct {
+	Channel string `db:"channel"`
+	Period  int64  `db:"period"`
+}
+
+func toDBPolicy(p retention.Policy) dbPolicy {
+	return dbPolicy{
+		Channel: p.Channel,
+		Period:  int64(p.Period / time.Second),
+	}
+}
+
+func toPolicy(dbp dbPolicy) retention.Policy {
+	return retention.Policy{
+		Channel: dbp.Channel,
+		Period:  time.Duration(dbp.Period) * time.Second,
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"time"
+)
+
+// MinPeriod is the shortest retention period a policy can be set to.
+const MinPeriod = time.Hour
+
+// Policy specifies how long the messages of a channel are kept. The policy
+// with the empty channel is the writer's default policy, which applies to
+// the channels without a policy of their own.
+type Policy struct {
+	Channel string
+	Period  time.Duration
+}
+
+// PolicyRepository specifies a retention policy persistence API.
+type PolicyRepository interface {
+	// Save persists the policy, replacing the existing policy of the channel.
+	Save(ctx context.Context, p Policy) error
+
+	// Retrieve retrieves the policy of the channel.
+	Retrieve(ctx context.Context, chanID string) (Policy, error)
+
+	// RetrieveAll retrieves all the policies, including the default one.
+	RetrieveAll(ctx context.Context) ([]Policy, error)
+
+	// Remove removes the policy of the channel.
+	Remove(ctx context.Context, chanID string) error
+}
+
+// Pruner specifies an API for deleting the messages that outlived their
+// retention policies.
+type Pruner interface {
+	// Prune deletes the messages older than the policies of their channels,
+	// and the messages of the remaining channels older than the default
+	// policy. The messages are kept if there is no policy applying to them.
+	Prune(ctx context.Context, policies []Policy) error
+}
+
+// Split returns the default policy, if it's set, and the channel policies.
+func Split(policies []Policy) (Policy, bool, []Policy) {
+	var def Policy
+	var ok bool
+	var chans []Policy
+	for _, p := range policies {
+		if p.Channel == "" {
+			def, ok = p, true
+			continue
+		}
+		chans = append(chans, p)
+	}
+
+	return def, ok, chans
+}
+
+// Longest returns the longest period of the policies.
+func Longest(policies []Policy) time.Duration {
+	var longest time.Duration
+	for _, p := range policies {
+		if p.Period > longest {
+			longest = p.Period
+		}
+	}
+
+	return longest
+}
+
+// Channels returns the channels of the policies.
+func Channels(policies []Policy) []string {
+	chans := []string{}
+	for _, p := range policies {
+		chans = append(chans, p.Channel)
+	}
+
+	return chans
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	// ErrInvalidPeriod indicates a retention period shorter than MinPeriod.
+	ErrInvalidPeriod = errors.New("invalid retention period")
+
+	// ErrPrune indicates that the pruning of the expired messages failed.
+	ErrPrune = errors.New("failed to prune expired messages")
+)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// SavePolicy sets the retention policy of the channel, or the default
+	// policy if the channel is empty.
+	SavePolicy(ctx context.Context, p Policy) error
+
+	// ViewPolicy retrieves the retention policy of the channel.
+	ViewPolicy(ctx context.Context, chanID string) (Policy, error)
+
+	// ListPolicies retrieves all the retention policies.
+	ListPolicies(ctx context.Context) ([]Policy, error)
+
+	// RemovePolicy removes the retention policy of the channel.
+	RemovePolicy(ctx context.Context, chanID string) error
+
+	// Prune deletes the messages that outlived their retention policies.
+	Prune(ctx context.Context) error
+}
+
+var _ Service = (*retentionService)(nil)
+
+type retentionService struct {
+	policies PolicyRepository
+	pruner   Pruner
+}
+
+// New instantiates the retention service implementation.
+func New(policies PolicyRepository, pruner Pruner) Service {
+	return &retentionService{
+		policies: policies,
+		pruner:   pruner,
+	}
+}
+
+func (rs *retentionService) SavePolicy(ctx context.Context, p Policy) error {
+	if p.Period < MinPeriod {
+		return ErrInvalidPeriod
+	}
+
+	return rs.policies.Save(ctx, p)
+}
+
+func (rs *retentionService) ViewPolicy(ctx context.Context, chanID string) (Policy, error) {
+	return rs.policies.Retrieve(ctx, chanID)
+}
+
+func (rs *retentionService) ListPolicies(ctx context.Context) ([]Policy, error) {
+	return rs.policies.RetrieveAll(ctx)
+}
+
+func (rs *retentionService) RemovePolicy(ctx context.Context, chanID string) error {
+	return rs.policies.Remove(ctx, chanID)
+}
+
+func (rs *retentionService) Prune(ctx context.Context) error {
+	policies, err := rs.policies.RetrieveAll(ctx)
+	if err != nil {
+		return errors.Wrap(ErrPrune, err)
+	}
+
+	if err := rs.pruner.Prune(ctx, policies); err != nil {
+		return errors.Wrap(ErrPrune, err)
+	}
+
+	return nil
+}
+
+// Run prunes the expired messages every interval, until the context is
+// canceled.
+func Run(ctx context.Context, svc Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := svc.Prune(ctx); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to prune expired messages: %s", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package retention_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID  = "1"
+	otherID = "2"
+	day     = 24 * time.Hour
+)
+
+func newService(pruner retention.Pruner) retention.Service {
+	return retention.New(mocks.NewPolicyRepository(), pruner)
+}
+
+func TestSavePolicy(t *testing.T) {
+	svc := newService(mocks.NewPruner(nil))
+
+	cases := []struct {
+		desc   string
+		policy retention.Policy
+		err    error
+	}{
+		{
+			desc:   "save channel policy",
+			policy: retention.Policy{Channel: chanID, Period: day},
+			err:    nil,
+		},
+		{
+			desc:   "save default policy",
+			policy: retention.Policy{Period: 30 * day},
+			err:    nil,
+		},
+		{
+			desc:   "save existing channel policy",
+			policy: retention.Policy{Channel: chanID, Period: 2 * day},
+			err:    nil,
+		},
+		{
+			desc:   "save policy with period shorter than minimum",
+			policy: retention.Policy{Channel: chanID, Period: time.Minute},
+			err:    retention.ErrInvalidPeriod,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SavePolicy(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	p, err := svc.ViewPolicy(context.Background(), chanID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 2*day, p.Period, fmt.Sprintf("expected the replaced policy period got %s", p.Period))
+}
+
+func TestViewPolicy(t *testing.T) {
+	svc := newService(mocks.NewPruner(nil))
+	policy := retention.Policy{Channel: chanID, Period: day}
+	err := svc.SavePolicy(context.Background(), policy)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		policy retention.Policy
+		err    error
+	}{
+		{
+			desc:   "view channel policy",
+			chanID: chanID,
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "view non-existing channel policy",
+			chanID: otherID,
+			policy: retention.Policy{},
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "view non-existing default policy",
+			chanID: "",
+			policy: retention.Policy{},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := svc.ViewPolicy(context.Background(), tc.chanID)
+		assert.Equal(t, tc.policy, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, p))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemovePolicy(t *testing.T) {
+	svc := newService(mocks.NewPruner(nil))
+	err := svc.SavePolicy(context.Background(), retention.Policy{Channel: chanID, Period: day})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		err    error
+	}{
+		{
+			desc:   "remove channel policy",
+			chanID: chanID,
+			err:    nil,
+		},
+		{
+			desc:   "remove removed channel policy",
+			chanID: chanID,
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemovePolicy(context.Background(), tc.chanID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	pruner := mocks.NewPruner(nil)
+	svc := newService(pruner)
+
+	policies := []retention.Policy{
+		{Period: 30 * day},
+		{Channel: chanID, Period: day},
+		{Channel: otherID, Period: 60 * day},
+	}
+	for _, p := range policies {
+		err := svc.SavePolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	err := svc.Prune(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.ElementsMatch(t, policies, pruner.Policies(), "expected pruner to be called with all the policies")
+
+	failing := newService(mocks.NewPruner(errors.New("pruning failed")))
+	err = failing.Prune(context.Background())
+	assert.True(t, errors.Contains(err, retention.ErrPrune), fmt.Sprintf("expected %s got %s\n", retention.ErrPrune, err))
+}
+
+func TestSplit(t *testing.T) {
+	def := retention.Policy{Period: 30 * day}
+	chans := []retention.Policy{
+		{Channel: chanID, Period: day},
+		{Channel: otherID, Period: 60 * day},
+	}
+
+	d, ok, cp := retention.Split(append([]retention.Policy{chans[0], def}, chans[1:]...))
+	assert.True(t, ok, "expected default policy to be found")
+	assert.Equal(t, def, d, fmt.Sprintf("expected default policy %v got %v", def, d))
+	assert.Equal(t, chans, cp, fmt.Sprintf("expected channel policies %v got %v", chans, cp))
+	assert.Equal(t, 60*day, retention.Longest(cp), "expected the longest channel policy period")
+	assert.Equal(t, []string{chanID, otherID}, retention.Channels(cp), "expected the channels of the policies")
+
+	_, ok, _ = retention.Split(chans)
+	assert.False(t, ok, "expected default policy not to be found")
+}
@@ -70,6 +70,40 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS retention_policies (
+                        channel       TEXT,
+                        period        BIGINT NOT NULL,
+                        PRIMARY KEY (channel)
+                    )`,
+					// The time is stored in seconds, so the chunks of the new
+					// data span a day and can be dropped by the retention.
+					`SELECT set_chunk_time_interval('messages', 86400)`,
+				},
+				Down: []string{
+					"DROP TABLE retention_policies",
+				},
+			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS deletions (
+                        channel       TEXT NOT NULL,
+                        actor         TEXT NOT NULL,
+                        subtopic      TEXT,
+                        publisher     TEXT,
+                        format        TEXT,
+                        from_time     FLOAT,
+                        to_time       FLOAT,
+                        time          TIMESTAMPTZ NOT NULL
+                    )`,
+				},
+				Down: []string{
+					"DROP TABLE deletions",
+				},
+			},
 		},
 	}
 
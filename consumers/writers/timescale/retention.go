@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/retention"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// deleteBatchSize limits the number of rows deleted by a single statement,
+// so that the pruning of large tables doesn't hold long running transactions.
+const deleteBatchSize = 10000
+
+// The primary keys of the SenML and JSON messages tables.
+const (
+	senmlKey = "time, publisher, subtopic, name"
+	jsonKey  = "created, publisher, subtopic"
+)
+
+var _ retention.Pruner = (*pruner)(nil)
+
+type pruner struct {
+	db *sqlx.DB
+}
+
+// NewPruner returns the pruner dropping the expired chunks of the messages
+// hypertable and deleting the remaining expired messages in batches.
+func NewPruner(db *sqlx.DB) retention.Pruner {
+	return &pruner{db: db}
+}
+
+func (pr pruner) Prune(ctx context.Context, policies []retention.Policy) error {
+	tables, err := jsonTables(ctx, pr.db)
+	if err != nil {
+		return err
+	}
+
+	def, ok, chans := retention.Split(policies)
+	now := time.Now()
+
+	// The chunks hold the messages of all the channels, so they can be
+	// dropped only if no channel keeps its messages longer than the default.
+	if ok && retention.Longest(chans) <= def.Period {
+		q := `SELECT drop_chunks('messages', older_than => $1::bigint)`
+		if _, err := pr.db.ExecContext(ctx, q, now.Add(-def.Period).Unix()); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range chans {
+		if err := pr.prune(ctx, tables, `channel::text = $1`, p.Channel, now.Add(-p.Period)); err != nil {
+			return err
+		}
+	}
+
+	if ok {
+		return pr.prune(ctx, tables, `channel::text <> ALL($1::text[])`, retention.Channels(chans), now.Add(-def.Period))
+	}
+
+	return nil
+}
+
+// prune deletes the messages matching the channel condition, written before
+// the given time. SenML time is expressed in seconds and JSON created in
+// nanoseconds.
+func (pr pruner) prune(ctx context.Context, tables []string, cond string, arg interface{}, before time.Time) error {
+	if err := pr.deleteBatches(ctx, senmlTable, senmlKey, "time", cond, arg, before.Unix()); err != nil {
+		return err
+	}
+
+	for _, t := range tables {
+		if err := pr.deleteBatches(ctx, pgx.Identifier{t}.Sanitize(), jsonKey, "created", cond, arg, before.UnixNano()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteBatches deletes the rows by their primary key, since the row
+// locations aren't unique across the chunks of a hypertable.
+func (pr pruner) deleteBatches(ctx context.Context, table, key, timeColumn, cond string, arg, before interface{}) error {
+	q := fmt.Sprintf(`DELETE FROM %s WHERE (%s) IN (SELECT %s FROM %s WHERE %s AND %s < $2 LIMIT %d)`,
+		table, key, key, table, cond, timeColumn, deleteBatchSize)
+
+	for {
+		res, err := pr.db.ExecContext(ctx, q, arg, before)
+		if err != nil {
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n < deleteBatchSize {
+			return nil
+		}
+	}
+}
+
+// jsonTables returns the tables of the JSON messages, which are created per
+// message format.
+func jsonTables(ctx context.Context, db *sqlx.DB) ([]string, error) {
+	q := `SELECT table_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND column_name = 'created'`
+
+	var tables []string
+	if err := db.SelectContext(ctx, &tables, q); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
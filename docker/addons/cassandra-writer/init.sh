@@ -0,0 +1,12 @@
+#!/bin/bash
+
+# Copyright (c) Mainflux
+# SPDX-License-Identifier: Apache-2.0
+
+until printf "" 2>>/dev/null >>/dev/tcp/mainfluxlabs-cassandra/9042; do
+    sleep 5;
+    echo "Waiting for Cassandra...";
+done
+
+echo "Creating keyspace..."
+cqlsh mainfluxlabs-cassandra -e "CREATE KEYSPACE IF NOT EXISTS ${MF_CASSANDRA_WRITER_DB_KEYSPACE} WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1};"
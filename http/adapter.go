@@ -10,6 +10,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 // Service specifies coap service API.
@@ -23,6 +24,7 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	publisher messaging.Publisher
 	things    mainflux.ThingsServiceClient
+	validator schema.Validator
 }
 
 // New instantiates the HTTP adapter implementation.
@@ -30,13 +32,15 @@ func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Ser
 	return &adapterService{
 		publisher: publisher,
 		things:    things,
+		validator: schema.NewValidator(things, schema.DefCacheTTL),
 	}
 }
 
 func (as *adapterService) Publish(ctx context.Context, token string, msg messaging.Message) error {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  token,
-		ChanID: msg.Channel,
+		Token:    token,
+		ChanID:   msg.Channel,
+		Subtopic: msg.Subtopic,
 	}
 	thid, err := as.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -44,5 +48,9 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	}
 	msg.Publisher = thid.GetValue()
 
+	if err := as.validator.Validate(ctx, msg); err != nil {
+		return err
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
@@ -150,6 +150,20 @@ func TestPublish(t *testing.T) {
 			key:         thingKey,
 			status:      http.StatusBadRequest,
 		},
+		"publish message conforming to the channel schema": {
+			chanID:      mocks.SchemaChanID,
+			msg:         `[{"n":"current","u":"A","v":1.6}]`,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+		},
+		"publish message not conforming to the channel schema": {
+			chanID:      mocks.SchemaChanID,
+			msg:         `[{"n":"voltage","u":"V","v":230}]`,
+			contentType: ctSenmlJSON,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
 		"publish message unable to authorize": {
 			chanID:      chanID,
 			msg:         msg,
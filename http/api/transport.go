@@ -19,6 +19,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -130,11 +131,13 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 
 	req := publishReq{
 		msg: messaging.Message{
-			Protocol: protocol,
-			Channel:  bone.GetValue(r, "id"),
-			Subtopic: subtopic,
-			Payload:  payload,
-			Created:  time.Now().UnixNano(),
+			Protocol:    protocol,
+			Channel:     bone.GetValue(r, "id"),
+			Subtopic:    subtopic,
+			Payload:     payload,
+			Created:     time.Now().UnixNano(),
+			RemoteAddr:  r.RemoteAddr,
+			ContentType: ct,
 		},
 		token: token,
 	}
@@ -157,7 +160,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, errMalformedSubtopic),
-		errors.Contains(err, apiutil.ErrMalformedEntity):
+		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, schema.ErrInvalidPayload):
 		w.WriteHeader(http.StatusBadRequest)
 
 	default:
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// AuthorizeAdmin checks that the user identified by the token is the system
+// admin, for the services that have no users of their own.
+func AuthorizeAdmin(ctx context.Context, auth mainflux.AuthServiceClient, token string) error {
+	if token == "" {
+		return ErrBearerToken
+	}
+
+	user, err := auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := auth.Authorize(ctx, &mainflux.AuthorizeReq{Email: user.Email}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
@@ -101,4 +101,7 @@ var (
 
 	// ErrMalformedEntity indicates a malformed entity specification.
 	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrInvalidTimeout indicates an invalid timeout.
+	ErrInvalidTimeout = errors.New("invalid timeout")
 )
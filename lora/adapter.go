@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	mfsenml "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 )
 
 const protocol = "lora"
@@ -25,6 +27,9 @@ var (
 
 	// ErrNotConnected indicates a non-existent route map for a connection.
 	ErrNotConnected = errors.New("route map not found for this connection")
+
+	// ErrMalformedDownlink indicates malformed downlink subtopic or payload.
+	ErrMalformedDownlink = errors.New("malformed downlink message")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -56,24 +61,35 @@ type Service interface {
 
 	// Publish forwards messages from the LoRa MQTT broker to Mainflux Message Broker
 	Publish(ctx context.Context, msg Message) error
+
+	// Downlink forwards messages from Mainflux Message Broker to the LoRa
+	// device the message is addressed to
+	Downlink(ctx context.Context, msg messaging.Message) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
 	publisher  messaging.Publisher
+	downlinks  DownlinkPublisher
 	thingsRM   RouteMapRepository
 	channelsRM RouteMapRepository
 	connectRM  RouteMapRepository
+	metadata   MetadataConfig
+	codecs     codec.Provider
 }
 
-// New instantiates the LoRa adapter implementation.
-func New(publisher messaging.Publisher, thingsRM, channelsRM, connectRM RouteMapRepository) Service {
+// New instantiates the LoRa adapter implementation. The raw payloads are
+// decoded using the codecs of the things if the codecs provider is set.
+func New(publisher messaging.Publisher, downlinks DownlinkPublisher, thingsRM, channelsRM, connectRM RouteMapRepository, metadata MetadataConfig, codecs codec.Provider) Service {
 	return &adapterService{
 		publisher:  publisher,
+		downlinks:  downlinks,
 		thingsRM:   thingsRM,
 		channelsRM: channelsRM,
 		connectRM:  connectRM,
+		metadata:   metadata,
+		codecs:     codecs,
 	}
 }
 
@@ -96,35 +112,123 @@ func (as *adapterService) Publish(ctx context.Context, m Message) error {
 		return ErrNotConnected
 	}
 
+	created := time.Now().UnixNano()
+
 	// Use the SenML message decoded on LoRa Server application if
-	// field Object isn't empty. Otherwise, decode standard field Data.
+	// field Object isn't empty. Otherwise, decode standard field Data,
+	// using the thing codec if there is one. The content type of the raw
+	// payload is left empty, so that the consumers decode it.
 	var payload []byte
+	var contentType string
 	switch m.Object {
 	case nil:
 		payload, err = base64.StdEncoding.DecodeString(m.Data)
 		if err != nil {
 			return ErrMalformedMessage
 		}
+		if payload, contentType, err = as.decode(ctx, thingID, payload, created); err != nil {
+			return err
+		}
 	default:
 		jo, err := json.Marshal(m.Object)
 		if err != nil {
 			return err
 		}
 		payload = []byte(jo)
+		contentType = mfsenml.JSON
 	}
 
 	// Publish on Mainflux Message broker
 	msg := messaging.Message{
-		Publisher: thingID,
-		Protocol:  protocol,
-		Channel:   chanID,
-		Payload:   payload,
-		Created:   time.Now().UnixNano(),
+		Publisher:   thingID,
+		Protocol:    protocol,
+		Channel:     chanID,
+		Payload:     payload,
+		Created:     created,
+		ContentType: contentType,
+	}
+
+	if err := as.publisher.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+
+	if as.metadata.Format == "" {
+		return nil
+	}
+
+	// Publish radio metadata on the metadata subtopic of the same channel
+	meta, err := metadataPayload(as.metadata.Format, m, float64(msg.Created)/float64(time.Second))
+	if err != nil {
+		return err
 	}
+	msg.Subtopic = as.metadata.Subtopic
+	msg.Payload = meta
+	msg.ContentType = metadataContentType(as.metadata.Format)
 
 	return as.publisher.Publish(msg.Channel, msg)
 }
 
+// decode decodes the raw payload into SenML using the thing codec, and
+// returns it along with its content type. The payload is returned as it is,
+// without the content type, if the thing has no codec.
+func (as *adapterService) decode(ctx context.Context, thingID string, payload []byte, created int64) ([]byte, string, error) {
+	if as.codecs == nil {
+		return payload, "", nil
+	}
+
+	p, err := as.codecs.Profile(ctx, thingID)
+	if err != nil {
+		return nil, "", err
+	}
+	if p.Codec.Empty() {
+		return payload, "", nil
+	}
+
+	decoded, err := p.Codec.Encode(payload, float64(created)/float64(time.Second))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return decoded, mfsenml.JSON, nil
+}
+
+// Downlink forwards messages from Mainflux Message broker to Lora MQTT broker
+func (as *adapterService) Downlink(ctx context.Context, msg messaging.Message) error {
+	thingID, fPort, confirmed, err := parseDownlinkSubtopic(msg.Subtopic)
+	if err != nil {
+		return err
+	}
+
+	// Get route map of mainflux channel
+	appID, err := as.channelsRM.Get(ctx, msg.Channel)
+	if err != nil {
+		return ErrNotFoundApp
+	}
+
+	// Get route map of mainflux thing
+	devEUI, err := as.thingsRM.Get(ctx, thingID)
+	if err != nil {
+		return ErrNotFoundDev
+	}
+
+	c := fmt.Sprintf("%s:%s", msg.Channel, thingID)
+	if _, err := as.connectRM.Get(ctx, c); err != nil {
+		return ErrNotConnected
+	}
+
+	d := Downlink{
+		ApplicationID: appID,
+		DevEUI:        devEUI,
+		FPort:         fPort,
+		Confirmed:     confirmed,
+	}
+	if err := d.setPayload(msg.Payload); err != nil {
+		return err
+	}
+
+	return as.downlinks.Publish(ctx, d)
+}
+
 func (as *adapterService) CreateThing(ctx context.Context, thingID string, devEUI string) error {
 	return as.thingsRM.Save(ctx, thingID, devEUI)
 }
@@ -4,6 +4,7 @@
 package lora_test
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"testing"
@@ -11,7 +12,11 @@ import (
 	"github.com/MainfluxLabs/mainflux/lora"
 	"github.com/MainfluxLabs/mainflux/lora/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	mfsenml "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/senml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,12 +34,18 @@ const (
 )
 
 func newService() lora.Service {
+	svc, _ := newServiceWithDownlinks()
+	return svc
+}
+
+func newServiceWithDownlinks() (lora.Service, mocks.DownlinkPublisher) {
 	pub := pubmocks.NewPublisher()
+	downlinks := mocks.NewDownlinkPublisher()
 	thingsRM := mocks.NewRouteMap()
 	channelsRM := mocks.NewRouteMap()
 	connsRM := mocks.NewRouteMap()
 
-	return lora.New(pub, thingsRM, channelsRM, connsRM)
+	return lora.New(pub, downlinks, thingsRM, channelsRM, connsRM, lora.MetadataConfig{}, nil), downlinks
 }
 
 func TestPublish(t *testing.T) {
@@ -111,3 +122,178 @@ func TestPublish(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestDownlink(t *testing.T) {
+	svc, downlinks := newServiceWithDownlinks()
+	handler := lora.NewDownlinkHandler(svc)
+
+	err := svc.CreateChannel(context.Background(), chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(context.Background(), thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(context.Background(), chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(context.Background(), thingID2, devEUI2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	raw := []byte{0x01, 0x02}
+	rawBase64 := base64.StdEncoding.EncodeToString(raw)
+
+	cases := []struct {
+		desc     string
+		msg      messaging.Message
+		err      error
+		downlink *lora.Downlink
+	}{
+		{
+			desc:     "downlink raw payload with default fPort",
+			msg:      messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID, Payload: raw},
+			err:      nil,
+			downlink: &lora.Downlink{ApplicationID: appID, DevEUI: devEUI, FPort: 1, Data: rawBase64},
+		},
+		{
+			desc:     "downlink raw payload with fPort and confirmed flag in subtopic",
+			msg:      messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID + ".10.confirmed", Payload: raw},
+			err:      nil,
+			downlink: &lora.Downlink{ApplicationID: appID, DevEUI: devEUI, FPort: 10, Confirmed: true, Data: rawBase64},
+		},
+		{
+			desc:     "downlink payload with fPort and confirmed flag in metadata",
+			msg:      messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID + ".10", Payload: []byte(fmt.Sprintf(`{"fPort":20,"confirmed":true,"data":"%s"}`, rawBase64))},
+			err:      nil,
+			downlink: &lora.Downlink{ApplicationID: appID, DevEUI: devEUI, FPort: 20, Confirmed: true, Data: rawBase64},
+		},
+		{
+			desc:     "downlink JSON payload",
+			msg:      messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID, Payload: []byte(`{"valve":"open"}`)},
+			err:      nil,
+			downlink: &lora.Downlink{ApplicationID: appID, DevEUI: devEUI, FPort: 1, Data: base64.StdEncoding.EncodeToString([]byte(`{"valve":"open"}`))},
+		},
+		{
+			desc: "downlink message on other subtopic",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "uplink." + thingID, Payload: raw},
+			err:  nil,
+		},
+		{
+			desc: "downlink message with invalid fPort",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID + ".300", Payload: raw},
+			err:  lora.ErrMalformedDownlink,
+		},
+		{
+			desc: "downlink message with invalid data",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID, Payload: []byte(`{"data":"wrong"}`)},
+			err:  lora.ErrMalformedDownlink,
+		},
+		{
+			desc: "downlink message without thing",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "downlink", Payload: raw},
+			err:  lora.ErrMalformedDownlink,
+		},
+		{
+			desc: "downlink message with non existing channel route-map",
+			msg:  messaging.Message{Channel: chanID2, Subtopic: "downlink." + thingID, Payload: raw},
+			err:  lora.ErrNotFoundApp,
+		},
+		{
+			desc: "downlink message with non existing thing route-map",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "downlink.wrong", Payload: raw},
+			err:  lora.ErrNotFoundDev,
+		},
+		{
+			desc: "downlink message with non existing connection route-map",
+			msg:  messaging.Message{Channel: chanID, Subtopic: "downlink." + thingID2, Payload: raw},
+			err:  lora.ErrNotConnected,
+		},
+	}
+
+	for _, tc := range cases {
+		before := len(downlinks.Downlinks())
+		err := handler.Handle(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		published := downlinks.Downlinks()[before:]
+		if tc.downlink == nil {
+			assert.Empty(t, published, fmt.Sprintf("%s: expected no downlinks got %v\n", tc.desc, published))
+			continue
+		}
+		require.Len(t, published, 1, fmt.Sprintf("%s: expected a single downlink\n", tc.desc))
+		assert.Equal(t, *tc.downlink, published[0], fmt.Sprintf("%s: expected %v got %v\n", tc.desc, *tc.downlink, published[0]))
+	}
+}
+
+func TestPublishWithCodec(t *testing.T) {
+	pub := mocks.NewPublisher()
+	codecs := codec.NewProvider(pubmocks.NewThingsService(nil, nil))
+	svc := lora.New(pub, mocks.NewDownlinkPublisher(), mocks.NewRouteMap(), mocks.NewRouteMap(), mocks.NewRouteMap(), lora.MetadataConfig{}, codecs)
+
+	for _, thID := range []string{pubmocks.CodecThingID, thingID} {
+		err := svc.CreateThing(context.Background(), thID, thID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+	err := svc.CreateChannel(context.Background(), chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	for _, thID := range []string{pubmocks.CodecThingID, thingID} {
+		err := svc.ConnectThing(context.Background(), chanID, thID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	frame := base64.StdEncoding.EncodeToString([]byte{0xf8, 0x30})
+
+	cases := []struct {
+		desc    string
+		msg     lora.Message
+		decoded bool
+		payload string
+		err     error
+	}{
+		{
+			desc:    "publish frame of device with codec",
+			msg:     lora.Message{ApplicationID: appID, DevEUI: pubmocks.CodecThingID, Data: frame},
+			decoded: true,
+			err:     nil,
+		},
+		{
+			desc: "publish too short frame of device with codec",
+			msg:  lora.Message{ApplicationID: appID, DevEUI: pubmocks.CodecThingID, Data: base64.StdEncoding.EncodeToString([]byte{0xf8})},
+			err:  codec.ErrDecode,
+		},
+		{
+			desc:    "publish frame of device without codec",
+			msg:     lora.Message{ApplicationID: appID, DevEUI: thingID, Data: frame},
+			payload: string([]byte{0xf8, 0x30}),
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		n := len(pub.Messages())
+		err := svc.Publish(context.Background(), tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+
+		msgs := pub.Messages()
+		require.Len(t, msgs, n+1, tc.desc)
+		payload := msgs[n].Payload
+		if !tc.decoded {
+			assert.Equal(t, tc.payload, string(payload), fmt.Sprintf("%s: expected payload %v got %v\n", tc.desc, tc.payload, payload))
+			assert.Empty(t, msgs[n].ContentType, fmt.Sprintf("%s: expected no content type got %s\n", tc.desc, msgs[n].ContentType))
+			continue
+		}
+
+		assert.Equal(t, mfsenml.JSON, msgs[n].ContentType, fmt.Sprintf("%s: expected content type %s got %s\n", tc.desc, mfsenml.JSON, msgs[n].ContentType))
+
+		pack, err := senml.Decode(payload, senml.JSON)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		require.Len(t, pack.Records, 1, tc.desc)
+		rec := pack.Records[0]
+		assert.Equal(t, "temperature", rec.Name, fmt.Sprintf("%s: unexpected record name %s\n", tc.desc, rec.Name))
+		assert.Equal(t, "Cel", rec.Unit, fmt.Sprintf("%s: unexpected record unit %s\n", tc.desc, rec.Unit))
+		require.NotNil(t, rec.Value, tc.desc)
+		assert.Equal(t, -20.0, *rec.Value, fmt.Sprintf("%s: expected value -20 got %v\n", tc.desc, *rec.Value))
+	}
+}
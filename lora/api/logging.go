@@ -12,6 +12,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*loggingMiddleware)(nil)
@@ -145,3 +146,16 @@ func (lm loggingMiddleware) Publish(ctx context.Context, msg lora.Message) (err
 
 	return lm.svc.Publish(ctx, msg)
 }
+
+func (lm loggingMiddleware) Downlink(ctx context.Context, msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("downlink channels/%s/messages/%s took %s to complete", msg.Channel, msg.Subtopic, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Downlink(ctx, msg)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
 var _ lora.Service = (*metricsMiddleware)(nil)
@@ -110,3 +111,12 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, msg lora.Message) erro
 
 	return mm.svc.Publish(ctx, msg)
 }
+
+func (mm *metricsMiddleware) Downlink(ctx context.Context, msg messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "downlink").Add(1)
+		mm.latency.With("method", "downlink").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Downlink(ctx, msg)
+}
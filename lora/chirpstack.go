@@ -0,0 +1,213 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// chirpStackV4Event represents the ChirpStack v4 uplink event in JSON
+// (https://www.chirpstack.io/docs/chirpstack/integrations/events.html).
+type chirpStackV4Event struct {
+	DeviceInfo struct {
+		ApplicationID   string `json:"applicationId"`
+		ApplicationName string `json:"applicationName"`
+		DeviceName      string `json:"deviceName"`
+		DevEUI          string `json:"devEui"`
+	} `json:"deviceInfo"`
+	FCnt   int         `json:"fCnt"`
+	FPort  int         `json:"fPort"`
+	Data   string      `json:"data"`
+	Object interface{} `json:"object"`
+	RxInfo []struct {
+		GatewayID string  `json:"gatewayId"`
+		Time      string  `json:"gwTime"`
+		Rssi      float64 `json:"rssi"`
+		SNR       float64 `json:"snr"`
+		Location  struct {
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+			Altitude  float64 `json:"altitude"`
+		} `json:"location"`
+	} `json:"rxInfo"`
+	TxInfo struct {
+		Frequency  float64 `json:"frequency"`
+		Modulation struct {
+			LoRa struct {
+				Bandwidth       float64 `json:"bandwidth"`
+				SpreadingFactor int64   `json:"spreadingFactor"`
+				CodeRate        string  `json:"codeRate"`
+			} `json:"lora"`
+		} `json:"modulation"`
+	} `json:"txInfo"`
+}
+
+type chirpStackV4Decoder struct{}
+
+// Decode decodes the JSON event, or the protobuf one if the payload
+// isn't a JSON object.
+func (chirpStackV4Decoder) Decode(payload []byte) (Message, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(payload), []byte("{")) {
+		return decodeChirpStackV4Proto(payload)
+	}
+
+	var ev chirpStackV4Event
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return Message{}, ErrMalformedMessage
+	}
+
+	m := Message{
+		ApplicationID:   ev.DeviceInfo.ApplicationID,
+		ApplicationName: ev.DeviceInfo.ApplicationName,
+		DeviceName:      ev.DeviceInfo.DeviceName,
+		DevEUI:          ev.DeviceInfo.DevEUI,
+		FCnt:            ev.FCnt,
+		FPort:           ev.FPort,
+		Data:            ev.Data,
+		Object:          ev.Object,
+		TxInfo: TxInfo{
+			Frequency: ev.TxInfo.Frequency,
+			DataRate: DataRate{
+				Modulation:   loraModulation,
+				Bandwith:     ev.TxInfo.Modulation.LoRa.Bandwidth,
+				SpreadFactor: ev.TxInfo.Modulation.LoRa.SpreadingFactor,
+			},
+			CodeRate: ev.TxInfo.Modulation.LoRa.CodeRate,
+		},
+	}
+	for _, rx := range ev.RxInfo {
+		m.RxInfo = append(m.RxInfo, Receiver{
+			Mac:       rx.GatewayID,
+			Time:      rx.Time,
+			Rssi:      rx.Rssi,
+			LoRaSNR:   rx.SNR,
+			Latitude:  rx.Location.Latitude,
+			Longitude: rx.Location.Longitude,
+			Altitude:  rx.Location.Altitude,
+		})
+	}
+
+	return m, nil
+}
+
+func (chirpStackV4Decoder) Topic() string {
+	return "application/+/device/+/event/up"
+}
+
+const loraModulation = "LORA"
+
+// Field numbers of the ChirpStack v4 integration.UplinkEvent protobuf
+// message and its nested messages.
+const (
+	upDeviceInfo protowire.Number = 3
+	upFCnt       protowire.Number = 7
+	upFPort      protowire.Number = 8
+	upData       protowire.Number = 10
+	upObject     protowire.Number = 11
+	upRxInfo     protowire.Number = 12
+	upTxInfo     protowire.Number = 13
+
+	devAppID   protowire.Number = 3
+	devAppName protowire.Number = 4
+	devName    protowire.Number = 7
+	devEUI     protowire.Number = 8
+
+	rxGatewayID protowire.Number = 1
+	rxRssi      protowire.Number = 6
+	rxSNR       protowire.Number = 7
+
+	txFrequency  protowire.Number = 1
+	txModulation protowire.Number = 2
+	modLoRa      protowire.Number = 3
+	loraBW       protowire.Number = 1
+	loraSF       protowire.Number = 2
+)
+
+func decodeChirpStackV4Proto(payload []byte) (Message, error) {
+	var m Message
+	err := walkProto(payload, func(num protowire.Number, v protoValue) error {
+		switch num {
+		case upDeviceInfo:
+			return walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+				switch num {
+				case devAppID:
+					m.ApplicationID = string(v.bytes)
+				case devAppName:
+					m.ApplicationName = string(v.bytes)
+				case devName:
+					m.DeviceName = string(v.bytes)
+				case devEUI:
+					m.DevEUI = string(v.bytes)
+				}
+				return nil
+			})
+		case upFCnt:
+			m.FCnt = int(v.varint)
+		case upFPort:
+			m.FPort = int(v.varint)
+		case upData:
+			m.Data = base64.StdEncoding.EncodeToString(v.bytes)
+		case upObject:
+			obj, err := decodeProtoStruct(v.bytes)
+			if err != nil {
+				return err
+			}
+			m.Object = obj
+		case upRxInfo:
+			var rx Receiver
+			if err := walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+				switch num {
+				case rxGatewayID:
+					rx.Mac = string(v.bytes)
+				case rxRssi:
+					rx.Rssi = float64(int32(v.varint))
+				case rxSNR:
+					rx.LoRaSNR = float64(math.Float32frombits(uint32(v.fixed)))
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			m.RxInfo = append(m.RxInfo, rx)
+		case upTxInfo:
+			return walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+				switch num {
+				case txFrequency:
+					m.TxInfo.Frequency = float64(v.varint)
+				case txModulation:
+					return walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+						if num != modLoRa {
+							return nil
+						}
+						m.TxInfo.DataRate.Modulation = loraModulation
+						return walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+							switch num {
+							case loraBW:
+								m.TxInfo.DataRate.Bandwith = float64(v.varint)
+							case loraSF:
+								m.TxInfo.DataRate.SpreadFactor = int64(v.varint)
+							}
+							return nil
+						})
+					})
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return Message{}, err
+	}
+	if m.DevEUI == "" {
+		return Message{}, ErrMalformedMessage
+	}
+
+	return m, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	// ChirpStackV3 is the ChirpStack v3 application event JSON format.
+	ChirpStackV3 = "chirpstack_v3"
+
+	// ChirpStackV4 is the ChirpStack v4 integration event format, either
+	// JSON or protobuf encoded.
+	ChirpStackV4 = "chirpstack_v4"
+
+	// TTSV3 is The Things Stack v3 application uplink JSON format.
+	TTSV3 = "tts_v3"
+)
+
+// ErrUnknownFormat indicates an unsupported LoRa network server format.
+var ErrUnknownFormat = errors.New("unknown LoRa network server format")
+
+// Decoder decodes the uplink events of the LoRa network server into messages.
+type Decoder interface {
+	// Decode decodes the uplink event payload.
+	Decode(payload []byte) (Message, error)
+
+	// Topic returns the default MQTT topic of the uplink events.
+	Topic() string
+}
+
+// NewDecoder returns the uplink decoder of the given LoRa network server
+// format.
+func NewDecoder(format string) (Decoder, error) {
+	switch format {
+	case ChirpStackV3:
+		return chirpStackV3Decoder{}, nil
+	case ChirpStackV4:
+		return chirpStackV4Decoder{}, nil
+	case TTSV3:
+		return ttsV3Decoder{}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type chirpStackV3Decoder struct{}
+
+func (chirpStackV3Decoder) Decode(payload []byte) (Message, error) {
+	var m Message
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Message{}, ErrMalformedMessage
+	}
+
+	return m, nil
+}
+
+func (chirpStackV3Decoder) Topic() string {
+	return "application/+/device/+/event/up"
+}
@@ -0,0 +1,229 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	chirpStackV4Msg = `{
+		"deviceInfo": {"applicationId": "appID-1", "applicationName": "app", "deviceName": "dev", "devEui": "devEUI-1"},
+		"fCnt": 10,
+		"fPort": 5,
+		"data": "AQI=",
+		"object": {"temperature": 17},
+		"rxInfo": [{"gatewayId": "0016c001ff10a235", "rssi": -57, "snr": 10.5}],
+		"txInfo": {"frequency": 868100000, "modulation": {"lora": {"bandwidth": 125000, "spreadingFactor": 7, "codeRate": "CR_4_5"}}}
+	}`
+	ttsV3Msg = `{
+		"end_device_ids": {"device_id": "dev", "application_ids": {"application_id": "appID-1"}, "dev_eui": "devEUI-1"},
+		"uplink_message": {
+			"f_port": 5,
+			"f_cnt": 10,
+			"frm_payload": "AQI=",
+			"decoded_payload": {"temperature": 17},
+			"rx_metadata": [{"gateway_ids": {"gateway_id": "gw", "eui": "0016C001FF10A235"}, "rssi": -57, "snr": 10.5}],
+			"settings": {"data_rate": {"lora": {"bandwidth": 125000, "spreading_factor": 7, "coding_rate": "4/5"}}, "frequency": "868100000"}
+		}
+	}`
+)
+
+func TestNewDecoder(t *testing.T) {
+	cases := []struct {
+		desc   string
+		format string
+		topic  string
+		err    error
+	}{
+		{
+			desc:   "create ChirpStack v3 decoder",
+			format: lora.ChirpStackV3,
+			topic:  "application/+/device/+/event/up",
+			err:    nil,
+		},
+		{
+			desc:   "create ChirpStack v4 decoder",
+			format: lora.ChirpStackV4,
+			topic:  "application/+/device/+/event/up",
+			err:    nil,
+		},
+		{
+			desc:   "create The Things Stack v3 decoder",
+			format: lora.TTSV3,
+			topic:  "v3/+/devices/+/up",
+			err:    nil,
+		},
+		{
+			desc:   "create decoder of unknown format",
+			format: "unknown",
+			err:    lora.ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		dec, err := lora.NewDecoder(tc.format)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.topic, dec.Topic(), fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, tc.topic, dec.Topic()))
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	chirpStackV4, err := lora.NewDecoder(lora.ChirpStackV4)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ttsV3, err := lora.NewDecoder(lora.TTSV3)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	expected := lora.Message{
+		ApplicationID: appID,
+		DeviceName:    "dev",
+		DevEUI:        devEUI,
+		FCnt:          10,
+		FPort:         5,
+		Data:          "AQI=",
+		Object:        map[string]interface{}{"temperature": float64(17)},
+		TxInfo: lora.TxInfo{
+			Frequency: 868100000,
+			DataRate: lora.DataRate{
+				Modulation:   "LORA",
+				Bandwith:     125000,
+				SpreadFactor: 7,
+			},
+		},
+	}
+
+	csJSON := expected
+	csJSON.ApplicationName = "app"
+	csJSON.TxInfo.CodeRate = "CR_4_5"
+	csJSON.RxInfo = lora.RxInfo{{Mac: "0016c001ff10a235", Rssi: -57, LoRaSNR: 10.5}}
+
+	csProto := csJSON
+	csProto.TxInfo.CodeRate = ""
+
+	tts := expected
+	tts.TxInfo.CodeRate = "4/5"
+	tts.RxInfo = lora.RxInfo{{Mac: "0016C001FF10A235", Name: "gw", Rssi: -57, LoRaSNR: 10.5}}
+
+	cases := []struct {
+		desc    string
+		decoder lora.Decoder
+		payload []byte
+		msg     lora.Message
+		err     error
+	}{
+		{
+			desc:    "decode ChirpStack v4 JSON message",
+			decoder: chirpStackV4,
+			payload: []byte(chirpStackV4Msg),
+			msg:     csJSON,
+			err:     nil,
+		},
+		{
+			desc:    "decode ChirpStack v4 protobuf message",
+			decoder: chirpStackV4,
+			payload: chirpStackV4Proto(),
+			msg:     csProto,
+			err:     nil,
+		},
+		{
+			desc:    "decode malformed ChirpStack v4 JSON message",
+			decoder: chirpStackV4,
+			payload: []byte(`{"deviceInfo":`),
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode malformed ChirpStack v4 protobuf message",
+			decoder: chirpStackV4,
+			payload: []byte{0x1a, 0xff},
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode The Things Stack v3 message",
+			decoder: ttsV3,
+			payload: []byte(ttsV3Msg),
+			msg:     tts,
+			err:     nil,
+		},
+		{
+			desc:    "decode The Things Stack v3 message without uplink",
+			decoder: ttsV3,
+			payload: []byte(`{"end_device_ids": {"dev_eui": "devEUI-1"}}`),
+			err:     lora.ErrMalformedMessage,
+		},
+		{
+			desc:    "decode malformed The Things Stack v3 message",
+			decoder: ttsV3,
+			payload: []byte(`{"uplink_message"`),
+			err:     lora.ErrMalformedMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		msg, err := tc.decoder.Decode(tc.payload)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.msg, msg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.msg, msg))
+	}
+}
+
+// chirpStackV4Proto returns the ChirpStack v4 uplink event protobuf encoded.
+func chirpStackV4Proto() []byte {
+	bytesField := func(b []byte, num protowire.Number, v []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, v)
+	}
+	varintField := func(b []byte, num protowire.Number, v uint64) []byte {
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, v)
+	}
+
+	var dev []byte
+	dev = bytesField(dev, 3, []byte(appID))
+	dev = bytesField(dev, 4, []byte("app"))
+	dev = bytesField(dev, 7, []byte("dev"))
+	dev = bytesField(dev, 8, []byte(devEUI))
+
+	// google.protobuf.Struct {"temperature": 17}
+	var val []byte
+	val = protowire.AppendTag(val, 2, protowire.Fixed64Type)
+	val = protowire.AppendFixed64(val, math.Float64bits(17))
+	var entry []byte
+	entry = bytesField(entry, 1, []byte("temperature"))
+	entry = bytesField(entry, 2, val)
+	obj := bytesField(nil, 1, entry)
+
+	rssi := int64(-57)
+	var rx []byte
+	rx = bytesField(rx, 1, []byte("0016c001ff10a235"))
+	rx = varintField(rx, 6, uint64(rssi))
+	rx = protowire.AppendTag(rx, 7, protowire.Fixed32Type)
+	rx = protowire.AppendFixed32(rx, math.Float32bits(10.5))
+
+	var lr []byte
+	lr = varintField(lr, 1, 125000)
+	lr = varintField(lr, 2, 7)
+	mod := bytesField(nil, 3, lr)
+	var tx []byte
+	tx = varintField(tx, 1, 868100000)
+	tx = bytesField(tx, 2, mod)
+
+	var ev []byte
+	ev = bytesField(ev, 3, dev)
+	ev = varintField(ev, 7, 10)
+	ev = varintField(ev, 8, 5)
+	ev = bytesField(ev, 10, []byte{0x01, 0x02})
+	ev = bytesField(ev, 11, obj)
+	ev = bytesField(ev, 12, rx)
+	ev = bytesField(ev, 13, tx)
+
+	return ev
+}
@@ -0,0 +1,220 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	// DownlinkSubtopic is the channel subtopic of the messages addressed to
+	// the LoRa devices, followed by the thing ID and optionally by the
+	// fPort and the "confirmed" flag, e.g. "downlink.<thing_id>.10.confirmed".
+	DownlinkSubtopic = "downlink"
+
+	confirmedFlag = "confirmed"
+	defFPort      = 1
+	maxFPort      = 223
+
+	// chirpStackDownlinkTopic is the ChirpStack topic of the device downlink
+	// queue, which is the same in v3 and v4.
+	chirpStackDownlinkTopic = "application/%s/device/%s/command/down"
+)
+
+// ErrUnsupportedDownlink indicates that the downlinks can't be sent to the
+// LoRa network server of the given format.
+var ErrUnsupportedDownlink = errors.New("downlinks are not supported by the LoRa network server format")
+
+// Downlink represents the message enqueued to the LoRa device.
+type Downlink struct {
+	ApplicationID string
+	DevEUI        string
+	Confirmed     bool
+	FPort         int
+	Data          string
+	Object        interface{}
+}
+
+// DownlinkEncoder encodes the downlinks in the LoRa network server format.
+type DownlinkEncoder interface {
+	// Encode returns the MQTT topic and the payload of the downlink.
+	Encode(d Downlink) (string, []byte, error)
+}
+
+// NewDownlinkEncoder returns the downlink encoder of the given LoRa network
+// server format. The Things Stack devices are addressed by their device ID,
+// which isn't mapped to the things, so its downlinks aren't supported.
+func NewDownlinkEncoder(format string) (DownlinkEncoder, error) {
+	switch format {
+	case ChirpStackV3:
+		return chirpStackV3Encoder{}, nil
+	case ChirpStackV4:
+		return chirpStackV4Encoder{}, nil
+	case TTSV3:
+		return nil, ErrUnsupportedDownlink
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type chirpStackV3Downlink struct {
+	DevEUI    string      `json:"devEUI"`
+	Confirmed bool        `json:"confirmed"`
+	FPort     int         `json:"fPort"`
+	Data      string      `json:"data,omitempty"`
+	Object    interface{} `json:"object,omitempty"`
+}
+
+type chirpStackV3Encoder struct{}
+
+func (chirpStackV3Encoder) Encode(d Downlink) (string, []byte, error) {
+	payload, err := json.Marshal(chirpStackV3Downlink{
+		DevEUI:    d.DevEUI,
+		Confirmed: d.Confirmed,
+		FPort:     d.FPort,
+		Data:      d.Data,
+		Object:    d.Object,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf(chirpStackDownlinkTopic, d.ApplicationID, d.DevEUI), payload, nil
+}
+
+// chirpStackV4Downlink represents the ChirpStack v4 device queue item.
+type chirpStackV4Downlink struct {
+	DevEUI    string      `json:"devEui"`
+	Confirmed bool        `json:"confirmed"`
+	FPort     int         `json:"fPort"`
+	Data      string      `json:"data,omitempty"`
+	Object    interface{} `json:"object,omitempty"`
+}
+
+type chirpStackV4Encoder struct{}
+
+func (chirpStackV4Encoder) Encode(d Downlink) (string, []byte, error) {
+	payload, err := json.Marshal(chirpStackV4Downlink{
+		DevEUI:    d.DevEUI,
+		Confirmed: d.Confirmed,
+		FPort:     d.FPort,
+		Data:      d.Data,
+		Object:    d.Object,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf(chirpStackDownlinkTopic, d.ApplicationID, d.DevEUI), payload, nil
+}
+
+// DownlinkPublisher enqueues the downlink messages on the LoRa server.
+type DownlinkPublisher interface {
+	// Publish enqueues the downlink message to the device.
+	Publish(ctx context.Context, d Downlink) error
+}
+
+// downlinkReq represents the message payload carrying the downlink
+// metadata together with the data, in the LoRa server downlink format.
+type downlinkReq struct {
+	Confirmed *bool           `json:"confirmed"`
+	FPort     *int            `json:"fPort"`
+	Data      *string         `json:"data"`
+	Object    json.RawMessage `json:"object"`
+}
+
+var _ messaging.MessageHandler = (*downlinkHandler)(nil)
+
+type downlinkHandler struct {
+	svc Service
+}
+
+// NewDownlinkHandler returns the message handler which forwards the
+// messages published on the downlink subtopic to the LoRa devices.
+func NewDownlinkHandler(svc Service) messaging.MessageHandler {
+	return downlinkHandler{svc: svc}
+}
+
+func (dh downlinkHandler) Handle(msg messaging.Message) error {
+	if msg.Subtopic != DownlinkSubtopic && !strings.HasPrefix(msg.Subtopic, DownlinkSubtopic+".") {
+		return nil
+	}
+
+	return dh.svc.Downlink(context.Background(), msg)
+}
+
+func (dh downlinkHandler) Cancel() error {
+	return nil
+}
+
+// parseDownlinkSubtopic returns the thing ID, fPort and confirmed flag
+// encoded in the downlink subtopic.
+func parseDownlinkSubtopic(subtopic string) (string, int, bool, error) {
+	levels := strings.Split(subtopic, ".")
+	if len(levels) < 2 || len(levels) > 4 || levels[0] != DownlinkSubtopic || levels[1] == "" {
+		return "", 0, false, ErrMalformedDownlink
+	}
+
+	thingID, fPort, confirmed := levels[1], 0, false
+	for _, l := range levels[2:] {
+		switch {
+		case l == confirmedFlag && !confirmed:
+			confirmed = true
+		case fPort == 0:
+			p, err := strconv.Atoi(l)
+			if err != nil || p < 1 || p > maxFPort {
+				return "", 0, false, ErrMalformedDownlink
+			}
+			fPort = p
+		default:
+			return "", 0, false, ErrMalformedDownlink
+		}
+	}
+	if fPort == 0 {
+		fPort = defFPort
+	}
+
+	return thingID, fPort, confirmed, nil
+}
+
+// setPayload sets the downlink data from the message payload. The payload
+// carrying data or object is used as the downlink request, overriding the
+// fPort and confirmed flag. Other payloads are sent as they are.
+func (d *Downlink) setPayload(payload []byte) error {
+	var req downlinkReq
+	if err := json.Unmarshal(payload, &req); err != nil || (req.Data == nil && req.Object == nil) {
+		d.Data = base64.StdEncoding.EncodeToString(payload)
+		return nil
+	}
+
+	if req.FPort != nil {
+		if *req.FPort < 1 || *req.FPort > maxFPort {
+			return ErrMalformedDownlink
+		}
+		d.FPort = *req.FPort
+	}
+	if req.Confirmed != nil {
+		d.Confirmed = *req.Confirmed
+	}
+
+	switch {
+	case req.Data != nil:
+		if _, err := base64.StdEncoding.DecodeString(*req.Data); err != nil {
+			return ErrMalformedDownlink
+		}
+		d.Data = *req.Data
+	default:
+		d.Object = req.Object
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDownlink(t *testing.T) {
+	d := lora.Downlink{
+		ApplicationID: "appID-1",
+		DevEUI:        "devEUI-1",
+		Confirmed:     true,
+		FPort:         10,
+		Data:          "AQI=",
+	}
+
+	cases := []struct {
+		desc     string
+		format   string
+		downlink lora.Downlink
+		topic    string
+		payload  string
+		err      error
+	}{
+		{
+			desc:     "encode ChirpStack v3 downlink",
+			format:   lora.ChirpStackV3,
+			downlink: d,
+			topic:    "application/appID-1/device/devEUI-1/command/down",
+			payload:  `{"devEUI":"devEUI-1","confirmed":true,"fPort":10,"data":"AQI="}`,
+			err:      nil,
+		},
+		{
+			desc:     "encode ChirpStack v3 downlink with object",
+			format:   lora.ChirpStackV3,
+			downlink: lora.Downlink{ApplicationID: "appID-1", DevEUI: "devEUI-1", FPort: 1, Object: map[string]interface{}{"open": true}},
+			topic:    "application/appID-1/device/devEUI-1/command/down",
+			payload:  `{"devEUI":"devEUI-1","confirmed":false,"fPort":1,"object":{"open":true}}`,
+			err:      nil,
+		},
+		{
+			desc:     "encode ChirpStack v4 downlink",
+			format:   lora.ChirpStackV4,
+			downlink: d,
+			topic:    "application/appID-1/device/devEUI-1/command/down",
+			payload:  `{"devEui":"devEUI-1","confirmed":true,"fPort":10,"data":"AQI="}`,
+			err:      nil,
+		},
+		{
+			desc:   "encode The Things Stack v3 downlink",
+			format: lora.TTSV3,
+			err:    lora.ErrUnsupportedDownlink,
+		},
+		{
+			desc:   "encode downlink of unknown format",
+			format: "unknown",
+			err:    lora.ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		enc, err := lora.NewDownlinkEncoder(tc.format)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		topic, payload, err := enc.Encode(tc.downlink)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.topic, topic, fmt.Sprintf("%s: expected topic %s got %s\n", tc.desc, tc.topic, topic))
+		assert.JSONEq(t, tc.payload, string(payload), fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, payload))
+	}
+}
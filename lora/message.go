@@ -1,7 +1,10 @@
 package lora
 
 // RxInfo receiver parameters
-type RxInfo []struct {
+type RxInfo []Receiver
+
+// Receiver parameters of the single gateway
+type Receiver struct {
 	Mac       string  `json:"mac"`
 	Name      string  `json:"name"`
 	Latitude  float64 `json:"latitude"`
@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	mfsenml "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	// MetadataSenML publishes the radio metadata as SenML records.
+	MetadataSenML = "senml"
+
+	// MetadataJSON publishes the radio metadata as the JSON envelope.
+	MetadataJSON = "json"
+
+	// DefMetadataSubtopic is the default subtopic of the radio metadata.
+	DefMetadataSubtopic = "metadata"
+)
+
+// ErrUnknownMetadataFormat indicates an unsupported radio metadata format.
+var ErrUnknownMetadataFormat = errors.New("unknown radio metadata format")
+
+// MetadataConfig specifies whether and how the radio metadata of the uplinks
+// (RSSI, SNR, gateways, frequency, frame counter and battery) is published
+// alongside the uplink payload.
+type MetadataConfig struct {
+	// Format is MetadataSenML or MetadataJSON. The radio metadata isn't
+	// published if the format is empty.
+	Format string
+
+	// Subtopic is the subtopic of the channel the radio metadata is
+	// published to.
+	Subtopic string
+}
+
+// Validate returns an error if the radio metadata config is invalid.
+func (mc MetadataConfig) Validate() error {
+	switch mc.Format {
+	case "":
+		return nil
+	case MetadataSenML, MetadataJSON:
+	default:
+		return ErrUnknownMetadataFormat
+	}
+	if mc.Subtopic == "" {
+		return ErrUnknownMetadataFormat
+	}
+
+	return nil
+}
+
+// Metadata represents the radio metadata JSON envelope.
+type Metadata struct {
+	DevEUI  string   `json:"devEUI"`
+	FCnt    int      `json:"fCnt"`
+	FPort   int      `json:"fPort"`
+	Battery *float64 `json:"battery,omitempty"`
+	Margin  *float64 `json:"margin,omitempty"`
+	RxInfo  RxInfo   `json:"rxInfo"`
+	TxInfo  TxInfo   `json:"txInfo"`
+}
+
+// metadataPayload returns the radio metadata of the message in the given
+// format, taken at the given time in seconds.
+func metadataPayload(format string, m Message, t float64) ([]byte, error) {
+	switch format {
+	case MetadataJSON:
+		return json.Marshal(Metadata{
+			DevEUI:  m.DevEUI,
+			FCnt:    m.FCnt,
+			FPort:   m.FPort,
+			Battery: parseStatus(m.DeviceStatusBattery),
+			Margin:  parseStatus(m.DeviceStatusMrgin),
+			RxInfo:  m.RxInfo,
+			TxInfo:  m.TxInfo,
+		})
+	case MetadataSenML:
+		return senml.Encode(metadataPack(m, t), senml.JSON)
+	default:
+		return nil, ErrUnknownMetadataFormat
+	}
+}
+
+// metadataContentType returns the content type of the radio metadata payload.
+func metadataContentType(format string) string {
+	if format == MetadataJSON {
+		return mfjson.ContentType
+	}
+	return mfsenml.JSON
+}
+
+// metadataPack returns the radio metadata as SenML pack. The record names
+// are prefixed by the device EUI, and the gateway records by the gateway ID.
+func metadataPack(m Message, t float64) senml.Pack {
+	var recs []senml.Record
+	add := func(name, unit string, v float64) {
+		recs = append(recs, senml.Record{Name: name, Unit: unit, Value: &v})
+	}
+
+	add("fcnt", "", float64(m.FCnt))
+	add("fport", "", float64(m.FPort))
+	if b := parseStatus(m.DeviceStatusBattery); b != nil {
+		add("battery", "", *b)
+	}
+	if mg := parseStatus(m.DeviceStatusMrgin); mg != nil {
+		add("margin", "dB", *mg)
+	}
+	if m.TxInfo.Frequency != 0 {
+		add("frequency", "Hz", m.TxInfo.Frequency)
+	}
+	if m.TxInfo.DataRate.SpreadFactor != 0 {
+		add("spreading_factor", "", float64(m.TxInfo.DataRate.SpreadFactor))
+	}
+	if m.TxInfo.DataRate.Bandwith != 0 {
+		add("bandwidth", "Hz", m.TxInfo.DataRate.Bandwith)
+	}
+
+	for _, rx := range m.RxInfo {
+		gw := rx.Mac
+		if gw == "" {
+			gw = rx.Name
+		}
+		if gw == "" {
+			continue
+		}
+		add(fmt.Sprintf("%s:rssi", gw), "dBm", rx.Rssi)
+		add(fmt.Sprintf("%s:snr", gw), "dB", rx.LoRaSNR)
+		if rx.Latitude != 0 || rx.Longitude != 0 {
+			add(fmt.Sprintf("%s:lat", gw), "lat", rx.Latitude)
+			add(fmt.Sprintf("%s:lon", gw), "lon", rx.Longitude)
+			add(fmt.Sprintf("%s:alt", gw), "m", rx.Altitude)
+		}
+	}
+
+	recs[0].BaseName = fmt.Sprintf("%s:", m.DevEUI)
+	recs[0].BaseTime = t
+
+	return senml.Pack{Records: recs}
+}
+
+// parseStatus parses the device status value, which is a number in the
+// JSON formats of the LoRa network servers.
+func parseStatus(s string) *float64 {
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &v
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/MainfluxLabs/mainflux/lora/mocks"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gatewayID = "0016c001ff10a235"
+
+var radioMsg = lora.Message{
+	ApplicationID:       appID,
+	DevEUI:              devEUI,
+	DeviceStatusBattery: "200",
+	FCnt:                10,
+	FPort:               5,
+	Data:                base64.StdEncoding.EncodeToString([]byte(msg)),
+	RxInfo:              lora.RxInfo{{Mac: gatewayID, Rssi: -57, LoRaSNR: 10.5}},
+	TxInfo: lora.TxInfo{
+		Frequency: 868100000,
+		DataRate:  lora.DataRate{Modulation: "LORA", Bandwith: 125, SpreadFactor: 7},
+	},
+}
+
+func newMetadataService(t *testing.T, mc lora.MetadataConfig) (lora.Service, mocks.Publisher) {
+	pub := mocks.NewPublisher()
+	svc := lora.New(pub, mocks.NewDownlinkPublisher(), mocks.NewRouteMap(), mocks.NewRouteMap(), mocks.NewRouteMap(), mc, nil)
+
+	err := svc.CreateChannel(context.Background(), chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CreateThing(context.Background(), thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectThing(context.Background(), chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	return svc, pub
+}
+
+func TestValidateMetadataConfig(t *testing.T) {
+	cases := []struct {
+		desc string
+		mc   lora.MetadataConfig
+		err  error
+	}{
+		{
+			desc: "validate disabled metadata",
+			mc:   lora.MetadataConfig{},
+			err:  nil,
+		},
+		{
+			desc: "validate SenML metadata",
+			mc:   lora.MetadataConfig{Format: lora.MetadataSenML, Subtopic: lora.DefMetadataSubtopic},
+			err:  nil,
+		},
+		{
+			desc: "validate JSON metadata",
+			mc:   lora.MetadataConfig{Format: lora.MetadataJSON, Subtopic: lora.DefMetadataSubtopic},
+			err:  nil,
+		},
+		{
+			desc: "validate metadata of unknown format",
+			mc:   lora.MetadataConfig{Format: "xml", Subtopic: lora.DefMetadataSubtopic},
+			err:  lora.ErrUnknownMetadataFormat,
+		},
+		{
+			desc: "validate metadata without subtopic",
+			mc:   lora.MetadataConfig{Format: lora.MetadataSenML},
+			err:  lora.ErrUnknownMetadataFormat,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.mc.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublishMetadataDisabled(t *testing.T) {
+	svc, pub := newMetadataService(t, lora.MetadataConfig{})
+
+	err := svc.Publish(context.Background(), radioMsg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msgs := pub.Messages()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "", msgs[0].Subtopic, fmt.Sprintf("expected empty subtopic got %s\n", msgs[0].Subtopic))
+	assert.Equal(t, msg, string(msgs[0].Payload), fmt.Sprintf("expected payload %s got %s\n", msg, msgs[0].Payload))
+}
+
+func TestPublishMetadataSenML(t *testing.T) {
+	svc, pub := newMetadataService(t, lora.MetadataConfig{Format: lora.MetadataSenML, Subtopic: lora.DefMetadataSubtopic})
+
+	err := svc.Publish(context.Background(), radioMsg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msgs := pub.Messages()
+	require.Len(t, msgs, 2)
+	meta := msgs[1]
+	assert.Equal(t, lora.DefMetadataSubtopic, meta.Subtopic, fmt.Sprintf("expected subtopic %s got %s\n", lora.DefMetadataSubtopic, meta.Subtopic))
+	assert.Equal(t, chanID, meta.Channel, fmt.Sprintf("expected channel %s got %s\n", chanID, meta.Channel))
+	assert.Equal(t, thingID, meta.Publisher, fmt.Sprintf("expected publisher %s got %s\n", thingID, meta.Publisher))
+
+	pack, err := senml.Decode(meta.Payload, senml.JSON)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	pack, err = senml.Normalize(pack)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	values := map[string]float64{}
+	for _, r := range pack.Records {
+		require.NotNil(t, r.Value)
+		values[r.Name] = *r.Value
+		assert.Equal(t, float64(msgs[0].Created)/1e9, r.Time, fmt.Sprintf("%s: unexpected record time", r.Name))
+	}
+
+	expected := map[string]float64{
+		devEUI + ":fcnt":                   10,
+		devEUI + ":fport":                  5,
+		devEUI + ":battery":                200,
+		devEUI + ":frequency":              868100000,
+		devEUI + ":spreading_factor":       7,
+		devEUI + ":bandwidth":              125,
+		devEUI + ":" + gatewayID + ":rssi": -57,
+		devEUI + ":" + gatewayID + ":snr":  10.5,
+	}
+	assert.Equal(t, expected, values, fmt.Sprintf("expected records %v got %v\n", expected, values))
+}
+
+func TestPublishMetadataJSON(t *testing.T) {
+	svc, pub := newMetadataService(t, lora.MetadataConfig{Format: lora.MetadataJSON, Subtopic: "radio"})
+
+	err := svc.Publish(context.Background(), radioMsg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msgs := pub.Messages()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "radio", msgs[1].Subtopic, fmt.Sprintf("expected subtopic radio got %s\n", msgs[1].Subtopic))
+
+	var meta lora.Metadata
+	err = json.Unmarshal(msgs[1].Payload, &meta)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	battery := float64(200)
+	expected := lora.Metadata{
+		DevEUI:  devEUI,
+		FCnt:    10,
+		FPort:   5,
+		Battery: &battery,
+		RxInfo:  radioMsg.RxInfo,
+		TxInfo:  radioMsg.TxInfo,
+	}
+	assert.Equal(t, expected, meta, fmt.Sprintf("expected metadata %v got %v\n", expected, meta))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+)
+
+// DownlinkPublisher is the downlink publisher mock which keeps the
+// published downlinks.
+type DownlinkPublisher interface {
+	lora.DownlinkPublisher
+
+	// Downlinks returns the published downlinks.
+	Downlinks() []lora.Downlink
+}
+
+type downlinkPublisherMock struct {
+	mu        sync.Mutex
+	downlinks []lora.Downlink
+}
+
+// NewDownlinkPublisher returns mock downlink publisher instance.
+func NewDownlinkPublisher() DownlinkPublisher {
+	return &downlinkPublisherMock{}
+}
+
+func (dpm *downlinkPublisherMock) Publish(_ context.Context, d lora.Downlink) error {
+	dpm.mu.Lock()
+	defer dpm.mu.Unlock()
+
+	dpm.downlinks = append(dpm.downlinks, d)
+	return nil
+}
+
+func (dpm *downlinkPublisherMock) Downlinks() []lora.Downlink {
+	dpm.mu.Lock()
+	defer dpm.mu.Unlock()
+
+	return append([]lora.Downlink{}, dpm.downlinks...)
+}
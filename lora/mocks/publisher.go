@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Publisher is the message publisher mock which keeps the published
+// messages.
+type Publisher interface {
+	messaging.Publisher
+
+	// Messages returns the published messages.
+	Messages() []messaging.Message
+}
+
+type publisherMock struct {
+	mu       sync.Mutex
+	messages []messaging.Message
+}
+
+// NewPublisher returns mock message publisher instance.
+func NewPublisher() Publisher {
+	return &publisherMock{}
+}
+
+func (pm *publisherMock) Publish(_ string, msg messaging.Message) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.messages = append(pm.messages, msg)
+	return nil
+}
+
+func (pm *publisherMock) Close() error {
+	return nil
+}
+
+func (pm *publisherMock) Messages() []messaging.Message {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	return append([]messaging.Message{}, pm.messages...)
+}
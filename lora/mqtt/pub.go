@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+var _ lora.DownlinkPublisher = (*publisher)(nil)
+
+type publisher struct {
+	client  mqtt.Client
+	encoder lora.DownlinkEncoder
+	timeout time.Duration
+}
+
+// NewPublisher returns new LoRa MQTT downlink publisher instance, which
+// encodes the downlinks using the given encoder.
+func NewPublisher(client mqtt.Client, encoder lora.DownlinkEncoder, timeout time.Duration) lora.DownlinkPublisher {
+	return publisher{
+		client:  client,
+		encoder: encoder,
+		timeout: timeout,
+	}
+}
+
+// Publish enqueues the downlink message using the LoRa MQTT message broker
+func (p publisher) Publish(_ context.Context, d lora.Downlink) error {
+	topic, payload, err := p.encoder.Encode(d)
+	if err != nil {
+		return err
+	}
+
+	token := p.client.Publish(topic, 0, false, payload)
+	if !token.WaitTimeout(p.timeout) {
+		return fmt.Errorf("timed out publishing downlink to %s", topic)
+	}
+
+	return token.Error()
+}
@@ -3,7 +3,6 @@ package mqtt
 // LoraSubscribe subscribe to lora server messages
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -21,15 +20,17 @@ type Subscriber interface {
 
 type broker struct {
 	svc     lora.Service
+	decoder lora.Decoder
 	client  mqtt.Client
 	logger  logger.Logger
 	timeout time.Duration
 }
 
 // NewBroker returns new MQTT broker instance.
-func NewBroker(svc lora.Service, client mqtt.Client, t time.Duration, log logger.Logger) Subscriber {
+func NewBroker(svc lora.Service, decoder lora.Decoder, client mqtt.Client, t time.Duration, log logger.Logger) Subscriber {
 	return broker{
 		svc:     svc,
+		decoder: decoder,
 		client:  client,
 		logger:  log,
 		timeout: t,
@@ -48,9 +49,9 @@ func (b broker) Subscribe(subject string) error {
 
 // handleMsg triggered when new message is received on Lora MQTT broker
 func (b broker) handleMsg(c mqtt.Client, msg mqtt.Message) {
-	m := lora.Message{}
-	if err := json.Unmarshal(msg.Payload(), &m); err != nil {
-		b.logger.Warn(fmt.Sprintf("Failed to unmarshal message: %s", err.Error()))
+	m, err := b.decoder.Decode(msg.Payload())
+	if err != nil {
+		b.logger.Warn(fmt.Sprintf("Failed to decode message: %s", err.Error()))
 		return
 	}
 
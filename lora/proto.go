@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protoValue holds the raw value of the protobuf field, depending on its
+// wire type.
+type protoValue struct {
+	varint uint64
+	fixed  uint64
+	bytes  []byte
+}
+
+// walkProto calls the handler for each field of the protobuf encoded message.
+func walkProto(b []byte, handle func(num protowire.Number, v protoValue) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return ErrMalformedMessage
+		}
+		b = b[n:]
+
+		var v protoValue
+		switch typ {
+		case protowire.VarintType:
+			v.varint, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var f uint32
+			f, n = protowire.ConsumeFixed32(b)
+			v.fixed = uint64(f)
+		case protowire.Fixed64Type:
+			v.fixed, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v.bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return ErrMalformedMessage
+		}
+		b = b[n:]
+
+		if err := handle(num, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Field numbers of the google.protobuf.Struct, Value and ListValue messages.
+const (
+	structFields protowire.Number = 1
+	entryKey     protowire.Number = 1
+	entryValue   protowire.Number = 2
+
+	valueNumber protowire.Number = 2
+	valueString protowire.Number = 3
+	valueBool   protowire.Number = 4
+	valueStruct protowire.Number = 5
+	valueList   protowire.Number = 6
+
+	listValues protowire.Number = 1
+)
+
+// decodeProtoStruct decodes google.protobuf.Struct into the JSON object.
+func decodeProtoStruct(b []byte) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	err := walkProto(b, func(num protowire.Number, v protoValue) error {
+		if num != structFields {
+			return nil
+		}
+
+		var key string
+		var val interface{}
+		if err := walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+			switch num {
+			case entryKey:
+				key = string(v.bytes)
+			case entryValue:
+				var err error
+				val, err = decodeProtoValue(v.bytes)
+				return err
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		obj[key] = val
+		return nil
+	})
+
+	return obj, err
+}
+
+// decodeProtoValue decodes google.protobuf.Value into the JSON value.
+func decodeProtoValue(b []byte) (interface{}, error) {
+	var val interface{}
+	err := walkProto(b, func(num protowire.Number, v protoValue) error {
+		var err error
+		switch num {
+		case valueNumber:
+			val = math.Float64frombits(v.fixed)
+		case valueString:
+			val = string(v.bytes)
+		case valueBool:
+			val = v.varint != 0
+		case valueStruct:
+			val, err = decodeProtoStruct(v.bytes)
+		case valueList:
+			list := []interface{}{}
+			err = walkProto(v.bytes, func(num protowire.Number, v protoValue) error {
+				if num != listValues {
+					return nil
+				}
+				item, err := decodeProtoValue(v.bytes)
+				if err != nil {
+					return err
+				}
+				list = append(list, item)
+				return nil
+			})
+			val = list
+		}
+		return err
+	})
+
+	return val, err
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ttsV3Event represents The Things Stack v3 uplink message
+// (https://www.thethingsindustries.com/docs/reference/data-formats/#uplink-messages).
+type ttsV3Event struct {
+	EndDeviceIDs struct {
+		DeviceID       string `json:"device_id"`
+		DevEUI         string `json:"dev_eui"`
+		ApplicationIDs struct {
+			ApplicationID string `json:"application_id"`
+		} `json:"application_ids"`
+	} `json:"end_device_ids"`
+	UplinkMessage *struct {
+		FCnt           int         `json:"f_cnt"`
+		FPort          int         `json:"f_port"`
+		FRMPayload     string      `json:"frm_payload"`
+		DecodedPayload interface{} `json:"decoded_payload"`
+		RxMetadata     []struct {
+			GatewayIDs struct {
+				GatewayID string `json:"gateway_id"`
+				EUI       string `json:"eui"`
+			} `json:"gateway_ids"`
+			Time     string  `json:"time"`
+			Rssi     float64 `json:"rssi"`
+			SNR      float64 `json:"snr"`
+			Location struct {
+				Latitude  float64 `json:"latitude"`
+				Longitude float64 `json:"longitude"`
+				Altitude  float64 `json:"altitude"`
+			} `json:"location"`
+		} `json:"rx_metadata"`
+		Settings struct {
+			DataRate struct {
+				LoRa struct {
+					Bandwidth       float64 `json:"bandwidth"`
+					SpreadingFactor int64   `json:"spreading_factor"`
+					CodingRate      string  `json:"coding_rate"`
+				} `json:"lora"`
+			} `json:"data_rate"`
+			// Frequency is a string, as 64-bit integers are in protobuf JSON.
+			Frequency string `json:"frequency"`
+		} `json:"settings"`
+	} `json:"uplink_message"`
+}
+
+type ttsV3Decoder struct{}
+
+func (ttsV3Decoder) Decode(payload []byte) (Message, error) {
+	var ev ttsV3Event
+	if err := json.Unmarshal(payload, &ev); err != nil || ev.UplinkMessage == nil {
+		return Message{}, ErrMalformedMessage
+	}
+	up := ev.UplinkMessage
+
+	m := Message{
+		ApplicationID: ev.EndDeviceIDs.ApplicationIDs.ApplicationID,
+		DeviceName:    ev.EndDeviceIDs.DeviceID,
+		DevEUI:        ev.EndDeviceIDs.DevEUI,
+		FCnt:          up.FCnt,
+		FPort:         up.FPort,
+		Data:          up.FRMPayload,
+		Object:        up.DecodedPayload,
+		TxInfo: TxInfo{
+			DataRate: DataRate{
+				Modulation:   loraModulation,
+				Bandwith:     up.Settings.DataRate.LoRa.Bandwidth,
+				SpreadFactor: up.Settings.DataRate.LoRa.SpreadingFactor,
+			},
+			CodeRate: up.Settings.DataRate.LoRa.CodingRate,
+		},
+	}
+	if up.Settings.Frequency != "" {
+		freq, err := strconv.ParseFloat(up.Settings.Frequency, 64)
+		if err != nil {
+			return Message{}, ErrMalformedMessage
+		}
+		m.TxInfo.Frequency = freq
+	}
+	for _, rx := range up.RxMetadata {
+		m.RxInfo = append(m.RxInfo, Receiver{
+			Mac:       rx.GatewayIDs.EUI,
+			Name:      rx.GatewayIDs.GatewayID,
+			Time:      rx.Time,
+			Rssi:      rx.Rssi,
+			LoRaSNR:   rx.SNR,
+			Latitude:  rx.Location.Latitude,
+			Longitude: rx.Location.Longitude,
+			Altitude:  rx.Location.Altitude,
+		})
+	}
+
+	return m, nil
+}
+
+func (ttsV3Decoder) Topic() string {
+	return "v3/+/devices/+/up"
+}
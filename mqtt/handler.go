@@ -11,11 +11,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/mqtt/redis"
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 )
 
@@ -42,6 +44,7 @@ const (
 	logErrFailedParseSubtopic          = "failed to parse subtopic: "
 	LogErrFailedPublishConnectEvent    = "failed to publish connect event: "
 	LogErrFailedPublishToMsgBroker     = "failed to publish to mainflux message broker: "
+	LogErrFailedValidatePayload        = "failed to validate payload: "
 )
 
 var (
@@ -60,6 +63,7 @@ var (
 type handler struct {
 	publishers []messaging.Publisher
 	auth       auth.Client
+	validator  schema.Validator
 	logger     logger.Logger
 	es         redis.EventStore
 	service    Service
@@ -67,12 +71,13 @@ type handler struct {
 
 // NewHandler creates new Handler entity
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client, svc Service) session.Handler {
+	logger logger.Logger, auth auth.Client, things mainflux.ThingsServiceClient, svc Service) session.Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
+		validator:  schema.NewValidator(things, schema.DefCacheTTL),
 		service:    svc,
 	}
 }
@@ -181,6 +186,11 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 		Created:   time.Now().UnixNano(),
 	}
 
+	if err := h.validator.Validate(context.Background(), msg); err != nil {
+		h.logger.Error(LogErrFailedValidatePayload + err.Error())
+		return
+	}
+
 	for _, pub := range h.publishers {
 		if err := pub.Publish(msg.Channel, msg); err != nil {
 			h.logger.Error(LogErrFailedPublishToMsgBroker + err.Error())
@@ -282,7 +292,12 @@ func (h *handler) authAccess(username string, topic string) error {
 	}
 
 	chanID := channelParts[1]
-	return h.auth.Authorize(context.Background(), chanID, username)
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return err
+	}
+
+	return h.auth.Authorize(context.Background(), chanID, username, subtopic)
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 	"github.com/stretchr/testify/assert"
 )
@@ -230,6 +231,7 @@ func TestPublish(t *testing.T) {
 	malformedSubtopics := topic + "/" + subtopic + "%"
 	wrongCharSubtopics := topic + "/" + subtopic + ">"
 	validSubtopic := topic + "/" + subtopic
+	schemaTopic := fmt.Sprintf(topicMsg, pubmocks.SchemaChanID)
 
 	cases := []struct {
 		desc    string
@@ -287,6 +289,13 @@ func TestPublish(t *testing.T) {
 			payload: payload,
 			logMsg:  "",
 		},
+		{
+			desc:    "publish payload that doesn't conform to the channel schema",
+			client:  &sessionClient,
+			topic:   schemaTopic,
+			payload: []byte(`[{"n":"voltage","u":"V","v":230}]`),
+			logMsg:  mqtt.LogErrFailedValidatePayload + schema.ErrInvalidPayload.Error(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -393,5 +402,6 @@ func newHandler() session.Handler {
 
 	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string]interface{}{chanID: thingID})
 	eventStore := mocks.NewEventStore()
-	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService())
+	thingsClient := pubmocks.NewThingsService(nil, nil)
+	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, thingsClient, newService())
 }
@@ -21,7 +21,7 @@ func NewClient(key map[string]string, conns map[string]interface{}) auth.Client
 	return MockClient{key: key, conns: conns}
 }
 
-func (cli MockClient) Authorize(ctx context.Context, chanID, thingID string) error {
+func (cli MockClient) Authorize(ctx context.Context, chanID, thingID, subtopic string) error {
 	for k, v := range cli.conns {
 		if k == chanID && v == thingID {
 			return nil
@@ -5,20 +5,24 @@ package auth
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/go-redis/redis/v8"
 )
 
 // Client represents Auth cache.
 type Client interface {
-	Authorize(ctx context.Context, chanID, thingID string) error
+	Authorize(ctx context.Context, chanID, thingID, subtopic string) error
 	Identify(ctx context.Context, thingKey string) (string, error)
 }
 
 const (
-	chanPrefix = "channel"
-	keyPrefix  = "thing_key"
+	chanPrefix      = "channel"
+	keyPrefix       = "thing_key"
+	subtopicsSuffix = "subtopics"
 )
 
 type client struct {
@@ -51,15 +55,28 @@ func (c client) Identify(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) Authorize(ctx context.Context, chanID, thingID string) error {
-	if c.redisClient.SIsMember(ctx, chanPrefix+":"+chanID, thingID).Val() {
+func (c client) Authorize(ctx context.Context, chanID, thingID, subtopic string) error {
+	// Connection and its subtopic patterns are read atomically, since
+	// things service invalidates them together.
+	var connected *redis.BoolCmd
+	var subtopics *redis.StringSliceCmd
+	_, err := c.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		connected = pipe.SIsMember(ctx, chanPrefix+":"+chanID, thingID)
+		subtopics = pipe.SMembers(ctx, fmt.Sprintf("%s:%s:%s:%s", chanPrefix, chanID, thingID, subtopicsSuffix))
 		return nil
+	})
+	if err == nil && connected.Val() {
+		if messaging.MatchSubtopic(subtopics.Val(), subtopic) {
+			return nil
+		}
+		return errors.ErrAuthorization
 	}
 
 	ar := &mainflux.AccessByIDReq{
-		ThingID: thingID,
-		ChanID:  chanID,
+		ThingID:  thingID,
+		ChanID:   chanID,
+		Subtopic: subtopic,
 	}
-	_, err := c.things.CanAccessByID(ctx, ar)
+	_, err = c.things.CanAccessByID(ctx, ar)
 	return err
 }
@@ -0,0 +1,192 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clickhouse contains a minimal client for the ClickHouse HTTP
+// interface, used by the ClickHouse writer and reader.
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// unknownTableCode is the ClickHouse UNKNOWN_TABLE exception code.
+const unknownTableCode = "60"
+
+var (
+	// ErrUnknownTable indicates that the queried table doesn't exist.
+	ErrUnknownTable = errors.New("unknown table")
+
+	// ErrQuery indicates that ClickHouse failed to execute the query.
+	ErrQuery = errors.New("failed to execute clickhouse query")
+)
+
+// Config defines the options that are used when connecting to a ClickHouse
+// instance.
+type Config struct {
+	Host string
+	Port string
+	User string
+	Pass string
+	Name string
+}
+
+// Client executes queries using the ClickHouse HTTP interface. Query
+// parameters are passed as `{name:Type}` placeholders and bound by the
+// server, so the values are never interpolated into the query text.
+type Client struct {
+	url    string
+	cfg    Config
+	client *http.Client
+}
+
+// New returns a new ClickHouse client.
+func New(cfg Config) *Client {
+	return &Client{
+		url:    fmt.Sprintf("http://%s:%s/", cfg.Host, cfg.Port),
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+// Ping checks whether the ClickHouse instance is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.Exec(ctx, "SELECT 1", nil)
+}
+
+// Exec executes the query that doesn't return any rows.
+func (c *Client) Exec(ctx context.Context, query string, params map[string]interface{}) error {
+	body, err := c.do(ctx, bindParams(params), strings.NewReader(query))
+	if err != nil {
+		return err
+	}
+	return body.Close()
+}
+
+// Insert writes the rows to the table in a single request. The rows are
+// encoded to JSON and their fields are matched to the table columns by name.
+func (c *Client) Insert(ctx context.Context, table string, rows []interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return errors.Wrap(ErrQuery, err)
+		}
+	}
+
+	values := url.Values{}
+	values.Set("query", fmt.Sprintf("INSERT INTO %s FORMAT JSONEachRow", Identifier(table)))
+	body, err := c.do(ctx, values, &buf)
+	if err != nil {
+		return err
+	}
+	return body.Close()
+}
+
+// Query executes the query and calls scan with each of the returned rows
+// encoded as JSON object.
+func (c *Client) Query(ctx context.Context, query string, params map[string]interface{}, scan func(row json.RawMessage) error) error {
+	values := bindParams(params)
+	values.Set("output_format_json_quote_64bit_integers", "0")
+
+	body, err := c.do(ctx, values, strings.NewReader(query+" FORMAT JSONEachRow"))
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	for {
+		var row json.RawMessage
+		if err := dec.Decode(&row); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(ErrQuery, err)
+		}
+		if err := scan(row); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, values url.Values, body io.Reader) (io.ReadCloser, error) {
+	if c.cfg.Name != "" {
+		values.Set("database", c.cfg.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"?"+values.Encode(), body)
+	if err != nil {
+		return nil, errors.Wrap(ErrQuery, err)
+	}
+	if c.cfg.User != "" {
+		req.Header.Set("X-ClickHouse-User", c.cfg.User)
+		req.Header.Set("X-ClickHouse-Key", c.cfg.Pass)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(ErrQuery, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		if res.Header.Get("X-ClickHouse-Exception-Code") == unknownTableCode {
+			return nil, ErrUnknownTable
+		}
+		msg, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, errors.Wrap(ErrQuery, err)
+		}
+		return nil, errors.Wrap(ErrQuery, errors.New(strings.TrimSpace(string(msg))))
+	}
+
+	return res.Body, nil
+}
+
+// Identifier quotes the name so that it can be safely used as a table or
+// column name.
+func Identifier(name string) string {
+	name = strings.ReplaceAll(name, `\`, `\\`)
+	return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+}
+
+func bindParams(params map[string]interface{}) url.Values {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set("param_"+k, formatParam(v))
+	}
+	return values
+}
+
+// formatParam formats the value the way ClickHouse parses query parameters.
+func formatParam(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		r := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`)
+		return r.Replace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []string:
+		r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\t", `\t`, "\n", `\n`)
+		elems := make([]string, len(v))
+		for i, e := range v {
+			elems[i] = "'" + r.Replace(e) + "'"
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	user = "user"
+	pass = "pass"
+	db   = "mainflux"
+)
+
+type request struct {
+	query url.Values
+	body  string
+	user  string
+	key   string
+}
+
+func newServer(t *testing.T, status int, header map[string]string, res string) (*httptest.Server, *request) {
+	var req request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		req = request{
+			query: r.URL.Query(),
+			body:  string(body),
+			user:  r.Header.Get("X-ClickHouse-User"),
+			key:   r.Header.Get("X-ClickHouse-Key"),
+		}
+		for k, v := range header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(res))
+	}))
+	return ts, &req
+}
+
+func newClient(ts *httptest.Server) *clickhouse.Client {
+	u, _ := url.Parse(ts.URL)
+	return clickhouse.New(clickhouse.Config{
+		Host: u.Hostname(),
+		Port: u.Port(),
+		User: user,
+		Pass: pass,
+		Name: db,
+	})
+}
+
+func TestExec(t *testing.T) {
+	ts, req := newServer(t, http.StatusOK, nil, "")
+	defer ts.Close()
+
+	c := newClient(ts)
+	params := map[string]interface{}{
+		"name":  "a\tb",
+		"value": 1.5,
+		"flag":  true,
+		"limit": uint64(10),
+		"ids":   []string{"a", `b'c`},
+	}
+	err := c.Exec(context.Background(), "SELECT {name:String}", params)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	assert.Equal(t, "SELECT {name:String}", req.body)
+	assert.Equal(t, db, req.query.Get("database"))
+	assert.Equal(t, user, req.user)
+	assert.Equal(t, pass, req.key)
+	assert.Equal(t, `a\tb`, req.query.Get("param_name"))
+	assert.Equal(t, "1.5", req.query.Get("param_value"))
+	assert.Equal(t, "true", req.query.Get("param_flag"))
+	assert.Equal(t, "10", req.query.Get("param_limit"))
+	assert.Equal(t, `['a','b\'c']`, req.query.Get("param_ids"))
+}
+
+func TestInsert(t *testing.T) {
+	ts, req := newServer(t, http.StatusOK, nil, "")
+	defer ts.Close()
+
+	c := newClient(ts)
+	rows := []interface{}{
+		map[string]interface{}{"name": "a"},
+		map[string]interface{}{"name": "b"},
+	}
+	err := c.Insert(context.Background(), "messages", rows)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	assert.Equal(t, "INSERT INTO `messages` FORMAT JSONEachRow", req.query.Get("query"))
+	assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n", req.body)
+}
+
+func TestQuery(t *testing.T) {
+	ts, req := newServer(t, http.StatusOK, nil, "{\"name\":\"a\",\"value\":1}\n{\"name\":\"b\",\"value\":2}\n")
+	defer ts.Close()
+
+	c := newClient(ts)
+
+	type row struct {
+		Name  string `json:"name"`
+		Value int64  `json:"value"`
+	}
+	var rows []row
+	err := c.Query(context.Background(), "SELECT name, value FROM messages", nil, func(r json.RawMessage) error {
+		var dst row
+		if err := json.Unmarshal(r, &dst); err != nil {
+			return err
+		}
+		rows = append(rows, dst)
+		return nil
+	})
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, []row{{"a", 1}, {"b", 2}}, rows)
+	assert.True(t, strings.HasSuffix(req.body, "FORMAT JSONEachRow"), fmt.Sprintf("expected JSONEachRow output format in %s", req.body))
+}
+
+func TestErrors(t *testing.T) {
+	cases := []struct {
+		desc   string
+		header map[string]string
+		res    string
+		err    error
+	}{
+		{
+			desc:   "query unknown table",
+			header: map[string]string{"X-ClickHouse-Exception-Code": "60"},
+			res:    "Code: 60. DB::Exception: Table mainflux.unknown doesn't exist.",
+			err:    clickhouse.ErrUnknownTable,
+		},
+		{
+			desc:   "query with syntax error",
+			header: map[string]string{"X-ClickHouse-Exception-Code": "62"},
+			res:    "Code: 62. DB::Exception: Syntax error.",
+			err:    clickhouse.ErrQuery,
+		},
+	}
+
+	for _, tc := range cases {
+		ts, _ := newServer(t, http.StatusBadRequest, tc.header, tc.res)
+		c := newClient(ts)
+		err := c.Query(context.Background(), "SELECT * FROM unknown", nil, func(json.RawMessage) error { return nil })
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		ts.Close()
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "`messages`", clickhouse.Identifier("messages"))
+	assert.Equal(t, "`a\\`; DROP TABLE b`", clickhouse.Identifier("a`; DROP TABLE b"))
+}
@@ -30,6 +30,14 @@ func NewPublisher(url string) (messaging.Publisher, error) {
 
 }
 
+func NewSubjectPubSub(url string) (messaging.SubjectPubSub, error) {
+	pb, err := nats.NewSubjectPubSub(url)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
 func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error) {
 	pb, err := nats.NewPubSub(url, queue, logger)
 	if err != nil {
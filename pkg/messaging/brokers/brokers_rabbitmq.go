@@ -29,6 +29,14 @@ func NewPublisher(url string) (messaging.Publisher, error) {
 	return pb, nil
 }
 
+func NewSubjectPubSub(url string) (messaging.SubjectPubSub, error) {
+	pb, err := rabbitmq.NewSubjectPubSub(url)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
 func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error) {
 	pb, err := rabbitmq.NewPubSub(url, queue, logger)
 	if err != nil {
@@ -30,6 +30,9 @@ type Message struct {
 	Protocol             string   `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
 	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
 	Created              int64    `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty"`
+	RemoteAddr           string   `protobuf:"bytes,7,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	Replay               bool     `protobuf:"varint,8,opt,name=replay,proto3" json:"replay,omitempty"`
+	ContentType          string   `protobuf:"bytes,9,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -110,6 +113,27 @@ func (m *Message) GetCreated() int64 {
 	return 0
 }
 
+func (m *Message) GetRemoteAddr() string {
+	if m != nil {
+		return m.RemoteAddr
+	}
+	return ""
+}
+
+func (m *Message) GetReplay() bool {
+	if m != nil {
+		return m.Replay
+	}
+	return false
+}
+
+func (m *Message) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "messaging.Message")
 }
@@ -117,19 +141,23 @@ func init() {
 func init() { proto.RegisterFile("pkg/messaging/message.proto", fileDescriptor_e5e29d24c44e4762) }
 
 var fileDescriptor_e5e29d24c44e4762 = []byte{
-	// 187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2e, 0xc8, 0x4e, 0xd7,
-	0xcf, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0xcf, 0xcc, 0x83, 0xb1, 0x52, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b,
-	0xf2, 0x85, 0x38, 0xe1, 0x12, 0x4a, 0x6b, 0x19, 0xb9, 0xd8, 0x7d, 0x21, 0x92, 0x42, 0x12, 0x5c,
-	0xec, 0xc9, 0x19, 0x89, 0x79, 0x79, 0xa9, 0x39, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0x30,
-	0xae, 0x90, 0x14, 0x17, 0x47, 0x71, 0x69, 0x52, 0x49, 0x7e, 0x41, 0x66, 0xb2, 0x04, 0x13, 0x58,
-	0x0a, 0xce, 0x17, 0x92, 0xe1, 0xe2, 0x2c, 0x28, 0x4d, 0xca, 0xc9, 0x2c, 0xce, 0x48, 0x2d, 0x92,
-	0x60, 0x06, 0x4b, 0x22, 0x04, 0x40, 0x3a, 0xc1, 0x76, 0x26, 0xe7, 0xe7, 0x48, 0xb0, 0x40, 0x74,
-	0xc2, 0xf8, 0x20, 0xfb, 0x0a, 0x12, 0x2b, 0x73, 0xf2, 0x13, 0x53, 0x24, 0x58, 0x15, 0x18, 0x35,
-	0x78, 0x82, 0x60, 0x5c, 0xb0, 0x4b, 0x8a, 0x52, 0x13, 0x4b, 0x52, 0x53, 0x24, 0xd8, 0x14, 0x18,
-	0x35, 0x98, 0x83, 0x60, 0x5c, 0x27, 0x81, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c,
-	0xf0, 0x48, 0x8e, 0x71, 0xc6, 0x63, 0x39, 0x86, 0x24, 0x36, 0xb0, 0x79, 0xc6, 0x80, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xa9, 0x1e, 0x4a, 0xea, 0xf2, 0x00, 0x00, 0x00,
+	// 247 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x8e, 0x3f, 0x4e, 0xc3, 0x30,
+	0x14, 0xc6, 0x71, 0x0b, 0xf9, 0xf3, 0xda, 0x01, 0x79, 0x40, 0x4f, 0x80, 0x42, 0x60, 0xca, 0x04,
+	0x03, 0x27, 0x80, 0x9d, 0x25, 0x62, 0xaf, 0x9c, 0xf8, 0xa9, 0x8d, 0x70, 0x6d, 0xcb, 0x71, 0x87,
+	0x1c, 0x81, 0x1b, 0x70, 0x24, 0x46, 0x8e, 0x80, 0xc2, 0x45, 0x50, 0x9d, 0xba, 0xdd, 0xfc, 0xfb,
+	0x7e, 0xfa, 0xfc, 0x3e, 0xb8, 0xb1, 0x1f, 0xeb, 0xa7, 0x2d, 0xf5, 0xbd, 0x58, 0x77, 0x3a, 0xbe,
+	0xe8, 0xd1, 0x3a, 0xe3, 0x0d, 0xcf, 0x8f, 0xe2, 0xe1, 0x73, 0x06, 0xe9, 0xdb, 0x24, 0x39, 0x42,
+	0xda, 0x6e, 0x84, 0xd6, 0xa4, 0x90, 0x95, 0xac, 0xca, 0xeb, 0x88, 0xfc, 0x1a, 0xb2, 0x7e, 0xd7,
+	0x78, 0x63, 0xbb, 0x16, 0x67, 0x41, 0x1d, 0x99, 0xdf, 0x42, 0x6e, 0x77, 0x8d, 0xea, 0xfa, 0x0d,
+	0x39, 0x9c, 0x07, 0x79, 0x0a, 0xf6, 0xcd, 0x70, 0xb3, 0x35, 0x0a, 0xcf, 0xa7, 0x66, 0xe4, 0xfd,
+	0x3d, 0x2b, 0x06, 0x65, 0x84, 0xc4, 0x8b, 0x92, 0x55, 0xcb, 0x3a, 0x62, 0x58, 0xe2, 0x48, 0x78,
+	0x92, 0x98, 0x94, 0xac, 0x9a, 0xd7, 0x11, 0xf9, 0x1d, 0x2c, 0x1c, 0x6d, 0x8d, 0xa7, 0x95, 0x90,
+	0xd2, 0x61, 0x1a, 0xbe, 0x84, 0x29, 0x7a, 0x91, 0xd2, 0xf1, 0x2b, 0x48, 0x1c, 0x59, 0x25, 0x06,
+	0xcc, 0x4a, 0x56, 0x65, 0xf5, 0x81, 0xf8, 0x3d, 0x2c, 0x5b, 0xa3, 0x3d, 0x69, 0xbf, 0xf2, 0x83,
+	0x25, 0xcc, 0x43, 0x73, 0x71, 0xc8, 0xde, 0x07, 0x4b, 0xaf, 0x97, 0xdf, 0x63, 0xc1, 0x7e, 0xc6,
+	0x82, 0xfd, 0x8e, 0x05, 0xfb, 0xfa, 0x2b, 0xce, 0x9a, 0x24, 0x6c, 0x7d, 0xfe, 0x1f, 0x00, 0x50,
+	0x69, 0x17, 0xb4, 0x4e, 0x01, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -156,6 +184,30 @@ func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ContentType) > 0 {
+		i -= len(m.ContentType)
+		copy(dAtA[i:], m.ContentType)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.ContentType)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Replay {
+		i--
+		if m.Replay {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.RemoteAddr) > 0 {
+		i -= len(m.RemoteAddr)
+		copy(dAtA[i:], m.RemoteAddr)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.RemoteAddr)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if m.Created != 0 {
 		i = encodeVarintMessage(dAtA, i, uint64(m.Created))
 		i--
@@ -239,6 +291,17 @@ func (m *Message) Size() (n int) {
 	if m.Created != 0 {
 		n += 1 + sovMessage(uint64(m.Created))
 	}
+	l = len(m.RemoteAddr)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Replay {
+		n += 2
+	}
+	l = len(m.ContentType)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -461,6 +524,90 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoteAddr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemoteAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Replay = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContentType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContentType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
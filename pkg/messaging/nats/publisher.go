@@ -16,7 +16,10 @@ import (
 // will never give up on retrying to re-establish connection to NATS server.
 const maxReconnects = -1
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher     = (*publisher)(nil)
+	_ messaging.SubjectPubSub = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *broker.Conn
@@ -37,6 +40,18 @@ func NewPublisher(url string) (messaging.Publisher, error) {
 	return ret, nil
 }
 
+// NewSubjectPubSub returns NATS raw data publisher and subscriber.
+func NewSubjectPubSub(url string) (messaging.SubjectPubSub, error) {
+	conn, err := broker.Connect(url, broker.MaxReconnects(maxReconnects))
+	if err != nil {
+		return nil, err
+	}
+	ret := &publisher{
+		conn: conn,
+	}
+	return ret, nil
+}
+
 func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 	if topic == "" {
 		return ErrEmptyTopic
@@ -57,6 +72,26 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 	return nil
 }
 
+func (pub *publisher) PublishSubject(subject string, data []byte) error {
+	if subject == "" {
+		return ErrEmptyTopic
+	}
+
+	return pub.conn.Publish(subject, data)
+}
+
+func (pub *publisher) SubscribeSubject(subject string, handler messaging.SubjectHandler) error {
+	if subject == "" {
+		return ErrEmptyTopic
+	}
+
+	_, err := pub.conn.Subscribe(subject, func(m *broker.Msg) {
+		handler(m.Data)
+	})
+
+	return err
+}
+
 func (pub *publisher) Close() error {
 	pub.conn.Close()
 	return nil
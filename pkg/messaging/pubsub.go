@@ -12,6 +12,37 @@ type Publisher interface {
 	Close() error
 }
 
+// SubjectPublisher specifies publishing API for the raw data, which is sent
+// to the subject as is, outside of the subjects of the channels.
+type SubjectPublisher interface {
+	// PublishSubject publishes the data to the subject.
+	PublishSubject(subject string, data []byte) error
+
+	// Close gracefully closes publisher's connection.
+	Close() error
+}
+
+// SubjectHandler handles the raw data received from the subject.
+type SubjectHandler func(data []byte)
+
+// SubjectSubscriber specifies subscription API for the raw data published to
+// the subject by the SubjectPublisher.
+type SubjectSubscriber interface {
+	// SubscribeSubject subscribes to the subject. Each of the subscribers
+	// receives all the data published to the subject.
+	SubscribeSubject(subject string, handler SubjectHandler) error
+
+	// Close gracefully closes subscriber's connection.
+	Close() error
+}
+
+// SubjectPubSub represents aggregation interface for subject publisher and
+// subscriber.
+type SubjectPubSub interface {
+	SubjectPublisher
+	SubjectSubscriber
+}
+
 // MessageHandler represents Message handler for Subscriber.
 type MessageHandler interface {
 	// Handle handles messages passed by underlying implementation.
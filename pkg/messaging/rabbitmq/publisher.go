@@ -13,7 +13,10 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher     = (*publisher)(nil)
+	_ messaging.SubjectPubSub = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *amqp.Connection
@@ -22,6 +25,15 @@ type publisher struct {
 
 // NewPublisher returns RabbitMQ message Publisher.
 func NewPublisher(url string) (messaging.Publisher, error) {
+	return newPublisher(url)
+}
+
+// NewSubjectPubSub returns RabbitMQ raw data publisher and subscriber.
+func NewSubjectPubSub(url string) (messaging.SubjectPubSub, error) {
+	return newPublisher(url)
+}
+
+func newPublisher(url string) (*publisher, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
 		return nil, err
@@ -75,6 +87,53 @@ func (pub *publisher) Publish(topic string, msg messaging.Message) error {
 	return nil
 }
 
+func (pub *publisher) PublishSubject(subject string, data []byte) error {
+	if subject == "" {
+		return ErrEmptyTopic
+	}
+
+	return pub.ch.PublishWithContext(
+		context.Background(),
+		exchangeName,
+		formatTopic(subject),
+		false,
+		false,
+		amqp.Publishing{
+			Headers:     amqp.Table{},
+			ContentType: "application/octet-stream",
+			AppId:       "mainflux-publisher",
+			Body:        data,
+		})
+}
+
+// SubscribeSubject binds the exclusive queue of the subscriber to the subject,
+// so that each of the subscribers receives all the published data.
+func (pub *publisher) SubscribeSubject(subject string, handler messaging.SubjectHandler) error {
+	if subject == "" {
+		return ErrEmptyTopic
+	}
+
+	q, err := pub.ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return err
+	}
+	if err := pub.ch.QueueBind(q.Name, formatTopic(subject), exchangeName, false, nil); err != nil {
+		return err
+	}
+	msgs, err := pub.ch.Consume(q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for msg := range msgs {
+			handler(msg.Body)
+		}
+	}()
+
+	return nil
+}
+
 func (pub *publisher) Close() error {
 	if err := pub.ch.Close(); err != nil {
 		return err
@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// SingleLevelWildcard matches exactly one subtopic level.
+	SingleLevelWildcard = "+"
+	// MultiLevelWildcard matches any number of trailing subtopic levels.
+	MultiLevelWildcard = "#"
+
+	subtopicSep = "."
+)
+
+// ErrMalformedSubtopicPattern indicates an invalid subtopic ACL pattern.
+var ErrMalformedSubtopicPattern = errors.New("malformed subtopic pattern")
+
+// NormalizeSubtopicPattern converts an MQTT-style subtopic pattern, which
+// uses "/" as a level separator, to the dot-separated form used in
+// messages and validates wildcard placement.
+func NormalizeSubtopicPattern(pattern string) (string, error) {
+	pattern = strings.Trim(strings.Replace(pattern, "/", subtopicSep, -1), subtopicSep)
+	if pattern == "" {
+		return "", ErrMalformedSubtopicPattern
+	}
+
+	levels := strings.Split(pattern, subtopicSep)
+	for i, l := range levels {
+		switch {
+		case l == "":
+			return "", ErrMalformedSubtopicPattern
+		case l == MultiLevelWildcard && i != len(levels)-1:
+			return "", ErrMalformedSubtopicPattern
+		case len(l) > 1 && strings.ContainsAny(l, "+#*>"):
+			return "", ErrMalformedSubtopicPattern
+		case l == "*" || l == ">":
+			return "", ErrMalformedSubtopicPattern
+		}
+	}
+
+	return pattern, nil
+}
+
+// MatchSubtopic determines whether the subtopic is covered by at least one
+// of the given patterns. An empty list of patterns grants access to every
+// subtopic. Wildcards contained in the subtopic itself (e.g. subscriptions
+// to "a.*" or "a.>") are only covered by patterns that are at least as wide.
+func MatchSubtopic(patterns []string, subtopic string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, p := range patterns {
+		if matchSubtopic(p, subtopic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchSubtopic(pattern, subtopic string) bool {
+	pl := strings.Split(pattern, subtopicSep)
+	var sl []string
+	if subtopic != "" {
+		sl = strings.Split(subtopic, subtopicSep)
+	}
+
+	for i, p := range pl {
+		if p == MultiLevelWildcard {
+			return true
+		}
+		if i >= len(sl) {
+			return false
+		}
+
+		s := sl[i]
+		switch s {
+		case "*":
+			s = SingleLevelWildcard
+		case ">":
+			s = MultiLevelWildcard
+		}
+
+		switch {
+		case s == MultiLevelWildcard:
+			return false
+		case p == SingleLevelWildcard:
+			continue
+		case p != s:
+			return false
+		}
+	}
+
+	return len(pl) == len(sl)
+}
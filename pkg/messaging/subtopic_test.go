@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSubtopicPattern(t *testing.T) {
+	cases := map[string]struct {
+		pattern  string
+		expected string
+		err      error
+	}{
+		"normalize slash separated pattern": {
+			pattern:  "/tenant1/sensors/#",
+			expected: "tenant1.sensors.#",
+			err:      nil,
+		},
+		"normalize dot separated pattern": {
+			pattern:  "tenant1.+.temp",
+			expected: "tenant1.+.temp",
+			err:      nil,
+		},
+		"normalize empty pattern": {
+			pattern: "",
+			err:     messaging.ErrMalformedSubtopicPattern,
+		},
+		"normalize pattern with multi-level wildcard in the middle": {
+			pattern: "tenant1/#/temp",
+			err:     messaging.ErrMalformedSubtopicPattern,
+		},
+		"normalize pattern with partial level wildcard": {
+			pattern: "tenant1/sens+",
+			err:     messaging.ErrMalformedSubtopicPattern,
+		},
+		"normalize pattern with empty level": {
+			pattern: "tenant1//temp",
+			err:     messaging.ErrMalformedSubtopicPattern,
+		},
+		"normalize pattern with broker wildcard": {
+			pattern: "tenant1.>",
+			err:     messaging.ErrMalformedSubtopicPattern,
+		},
+	}
+
+	for desc, tc := range cases {
+		p, err := messaging.NormalizeSubtopicPattern(tc.pattern)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.expected, p, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.expected, p))
+	}
+}
+
+func TestMatchSubtopic(t *testing.T) {
+	cases := map[string]struct {
+		patterns []string
+		subtopic string
+		match    bool
+	}{
+		"match without patterns": {
+			patterns: nil,
+			subtopic: "tenant1.temp",
+			match:    true,
+		},
+		"match exact subtopic": {
+			patterns: []string{"tenant1.temp"},
+			subtopic: "tenant1.temp",
+			match:    true,
+		},
+		"match single-level wildcard": {
+			patterns: []string{"tenant1.+.temp"},
+			subtopic: "tenant1.s1.temp",
+			match:    true,
+		},
+		"match single-level wildcard with missing level": {
+			patterns: []string{"tenant1.+.temp"},
+			subtopic: "tenant1.temp",
+			match:    false,
+		},
+		"match multi-level wildcard": {
+			patterns: []string{"tenant1.#"},
+			subtopic: "tenant1.s1.temp",
+			match:    true,
+		},
+		"match multi-level wildcard parent level": {
+			patterns: []string{"tenant1.#"},
+			subtopic: "tenant1",
+			match:    true,
+		},
+		"match empty subtopic": {
+			patterns: []string{"tenant1.#"},
+			subtopic: "",
+			match:    false,
+		},
+		"match empty subtopic with multi-level wildcard": {
+			patterns: []string{"#"},
+			subtopic: "",
+			match:    true,
+		},
+		"match other tenant": {
+			patterns: []string{"tenant1.#", "shared.+"},
+			subtopic: "tenant2.temp",
+			match:    false,
+		},
+		"match any of the patterns": {
+			patterns: []string{"tenant1.#", "shared.+"},
+			subtopic: "shared.temp",
+			match:    true,
+		},
+		"match subscription wildcard covered by pattern": {
+			patterns: []string{"tenant1.#"},
+			subtopic: "tenant1.>",
+			match:    true,
+		},
+		"match subscription wildcard wider than pattern": {
+			patterns: []string{"tenant1.+"},
+			subtopic: "tenant1.>",
+			match:    false,
+		},
+		"match single-level subscription wildcard against literal pattern": {
+			patterns: []string{"tenant1.temp"},
+			subtopic: "tenant1.*",
+			match:    false,
+		},
+	}
+
+	for desc, tc := range cases {
+		match := messaging.MatchSubtopic(tc.patterns, tc.subtopic)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.match, match))
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
@@ -15,6 +17,18 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// SchemaChanID represents ID of the channel that accepts SenML payloads
+// containing "current" records measured in amperes only.
+const SchemaChanID = "schema"
+
+// CodecThingID represents ID of the thing that sends "temperature" in
+// hundredths of degree Celsius as big endian 16-bit integer.
+const CodecThingID = "codec"
+
+// JSONThingID represents ID of the thing whose profile defaults its payloads
+// to JSON.
+const JSONThingID = "json"
+
 var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
 
 type thingsServiceMock struct {
@@ -52,8 +66,17 @@ func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.Ac
 	return &mainflux.ThingID{Value: token}, nil
 }
 
-func (svc thingsServiceMock) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
-	panic("not implemented")
+// CanAccessByID allows access of any thing to the channels known to the mock.
+func (svc thingsServiceMock) CanAccessByID(ctx context.Context, in *mainflux.AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if in.GetThingID() == "" {
+		return nil, errors.ErrAuthorization
+	}
+	for _, id := range svc.channels {
+		if id == in.GetChanID() {
+			return nil, nil
+		}
+	}
+	return nil, errors.ErrAuthorization
 }
 
 func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -79,3 +102,56 @@ func (svc thingsServiceMock) GetGroupsByIDs(ctx context.Context, req *mainflux.G
 
 	return &mainflux.GroupsRes{Groups: groups}, nil
 }
+
+func (svc thingsServiceMock) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID, opts ...grpc.CallOption) (*mainflux.ChannelSchema, error) {
+	if req.GetValue() != SchemaChanID {
+		return &mainflux.ChannelSchema{}, nil
+	}
+
+	value, err := schema.Marshal(schema.Schema{
+		SenML: []schema.SenMLRecord{{Name: "current", Unit: "A"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.ChannelSchema{Value: value}, nil
+}
+
+func (svc thingsServiceMock) GetThingCodec(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.ThingCodec, error) {
+	switch req.GetValue() {
+	case CodecThingID:
+	case JSONThingID:
+		return &mainflux.ThingCodec{ContentType: "application/json", Transformer: "json"}, nil
+	default:
+		return &mainflux.ThingCodec{}, nil
+	}
+
+	value, err := codec.Marshal(codec.Codec{
+		Fields: []codec.Field{{Name: "temperature", Unit: "Cel", Type: codec.Int16, Scale: 0.01}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.ThingCodec{Value: value}, nil
+}
+
+// GetGroupChannels returns the channel of the user known to the mock, provided
+// the user owns the group.
+func (svc thingsServiceMock) GetGroupChannels(ctx context.Context, req *mainflux.GroupChannelsReq, opts ...grpc.CallOption) (*mainflux.ChannelIDs, error) {
+	group, ok := svc.groups[req.GetGroupID()]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	if group.OwnerID != req.GetOwner() {
+		return nil, errors.ErrAuthorization
+	}
+
+	var chIDs []string
+	if id, ok := svc.channels[req.GetOwner()]; ok {
+		chIDs = append(chIDs, id)
+	}
+
+	return &mainflux.ChannelIDs{Values: chIDs}, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOperator
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+var keywords = map[string]bool{
+	"and":          true,
+	"or":           true,
+	"not":          true,
+	string(In):     true,
+	string(Exists): true,
+	string(Prefix): true,
+	string(Match):  true,
+}
+
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t token) isKeyword(kw string) bool {
+	return t.kind == tokIdent && strings.ToLower(t.text) == kw
+}
+
+func (t token) isReserved() bool {
+	return t.kind == tokIdent && keywords[strings.ToLower(t.text)]
+}
+
+func lex(q string) ([]token, error) {
+	var tokens []token
+	runes := []rune(q)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")"})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ","})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			i += len(op)
+			switch op {
+			case "!":
+				return nil, errors.New("unexpected \"!\"")
+			case "==":
+				op = string(Eq)
+			}
+			tokens = append(tokens, token{kind: tokOperator, text: op})
+		case r == '"' || r == '\'':
+			s, n, err := lexString(runes[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: s})
+			i += n
+		case r == '-' || unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			i = j
+			// Values such as version numbers are compared as strings.
+			if n, err := strconv.ParseFloat(text, 64); err == nil {
+				tokens = append(tokens, token{kind: tokNumber, text: text, number: n})
+				continue
+			}
+			tokens = append(tokens, token{kind: tokString, text: text})
+		case isIdentRune(r):
+			j := i + 1
+			for j < len(runes) && (isIdentRune(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '.' || runes[j] == '-') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[i:j])})
+			i = j
+		default:
+			return nil, errors.New(fmt.Sprintf("unexpected %q", r))
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+func lexString(runes []rune) (string, int, error) {
+	quote := runes[0]
+	var b strings.Builder
+	for i := 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 == len(runes) {
+				return "", 0, errors.New("unterminated string")
+			}
+			i++
+			b.WriteRune(runes[i])
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, errors.New("unterminated string")
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
@@ -0,0 +1,343 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package query contains the parser of the query language used to filter
+// entities by their name and metadata.
+//
+// A query consists of conditions combined with `and`, `or` and `not`
+// operators, and grouped with parentheses. A condition compares a field
+// with a value:
+//
+//	firmware < 2.3 and site in (A, B)
+//	location.floor >= 2 or not name startswith "test-"
+//	serial exists and name match "boiler room"
+//
+// Field `name` refers to the entity name, while every other field refers
+// to a metadata key. Nested metadata keys are separated by dots, and a
+// metadata key called `name` can be referred to as `metadata.name`.
+// Supported operators are `=`, `!=`, `<`, `<=`, `>`, `>=`, `in`, `exists`,
+// `startswith` (prefix match) and `match` (full-text match). Values are
+// numbers, quoted or bare strings, `true`, `false` and `null`.
+package query
+
+import (
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// NameField is the field referring to the entity name.
+	NameField = "name"
+
+	metadataPrefix = "metadata."
+	maxQueryLen    = 2048
+	maxDepth       = 32
+)
+
+// ErrInvalidQuery indicates malformed query.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Operator represents the condition operator.
+type Operator string
+
+const (
+	// Eq matches values equal to the condition value.
+	Eq Operator = "="
+	// Neq matches values different from the condition value.
+	Neq Operator = "!="
+	// Lt matches values lower than the condition value.
+	Lt Operator = "<"
+	// Lte matches values lower than or equal to the condition value.
+	Lte Operator = "<="
+	// Gt matches values greater than the condition value.
+	Gt Operator = ">"
+	// Gte matches values greater than or equal to the condition value.
+	Gte Operator = ">="
+	// In matches values equal to any of the condition values.
+	In Operator = "in"
+	// Exists matches entities having the field set.
+	Exists Operator = "exists"
+	// Prefix matches string values starting with the condition value.
+	Prefix Operator = "startswith"
+	// Match matches string values containing all the words of the condition value.
+	Match Operator = "match"
+)
+
+// Expr represents a node of the parsed query.
+type Expr interface {
+	expr()
+}
+
+// And matches entities matching both expressions.
+type And struct {
+	Left  Expr
+	Right Expr
+}
+
+// Or matches entities matching at least one of the expressions.
+type Or struct {
+	Left  Expr
+	Right Expr
+}
+
+// Not matches entities not matching the expression.
+type Not struct {
+	Expr Expr
+}
+
+// Field represents the field a condition refers to.
+type Field struct {
+	// Metadata is set if the field refers to a metadata key, and unset
+	// if it refers to the entity name.
+	Metadata bool
+
+	// Path contains the keys of the nested metadata field.
+	Path []string
+}
+
+// Condition compares the field with values. Values are of type float64,
+// string, bool or nil. Operator In takes any number of values, Exists
+// takes none, and all the other operators take exactly one.
+type Condition struct {
+	Field    Field
+	Operator Operator
+	Values   []interface{}
+}
+
+func (And) expr()       {}
+func (Or) expr()        {}
+func (Not) expr()       {}
+func (Condition) expr() {}
+
+// Parse parses the query.
+func Parse(q string) (Expr, error) {
+	if len(q) > maxQueryLen {
+		return nil, errors.Wrap(ErrInvalidQuery, errors.New("query too long"))
+	}
+
+	tokens, err := lex(q)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidQuery, err)
+	}
+
+	p := parser{tokens: tokens}
+	e, err := p.parseOr(0)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidQuery, err)
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errors.Wrap(ErrInvalidQuery, errors.New("unexpected "+t.String()))
+	}
+
+	return e, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary(depth int) (Expr, error) {
+	if depth > maxDepth {
+		return nil, errors.New("query nested too deep")
+	}
+
+	t := p.peek()
+	switch {
+	case t.isKeyword("not"):
+		p.next()
+		e, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: e}, nil
+	case t.kind == tokLParen:
+		p.next()
+		e, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, errors.New("expected ) got " + t.String())
+		}
+		return e, nil
+	default:
+		return p.parseCondition()
+	}
+}
+
+func (p *parser) parseCondition() (Expr, error) {
+	t := p.next()
+	if t.kind != tokIdent || t.isReserved() {
+		return nil, errors.New("expected field got " + t.String())
+	}
+	field, err := parseField(t.text)
+	if err != nil {
+		return nil, err
+	}
+
+	c := Condition{Field: field}
+	op := p.next()
+	switch {
+	case op.kind == tokOperator:
+		c.Operator = Operator(op.text)
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.Values = []interface{}{v}
+	case op.isKeyword(string(Exists)):
+		c.Operator = Exists
+	case op.isKeyword(string(In)):
+		c.Operator = In
+		if c.Values, err = p.parseList(); err != nil {
+			return nil, err
+		}
+	case op.isKeyword(string(Prefix)), op.isKeyword(string(Match)):
+		c.Operator = Operator(strings.ToLower(op.text))
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.Values = []interface{}{v}
+	default:
+		return nil, errors.New("expected operator got " + op.String())
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (p *parser) parseList() ([]interface{}, error) {
+	if t := p.next(); t.kind != tokLParen {
+		return nil, errors.New("expected ( got " + t.String())
+	}
+
+	var values []interface{}
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		t := p.next()
+		if t.kind == tokRParen {
+			return values, nil
+		}
+		if t.kind != tokComma {
+			return nil, errors.New("expected , or ) got " + t.String())
+		}
+	}
+}
+
+func (p *parser) parseValue() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return t.text, nil
+	case tokNumber:
+		return t.number, nil
+	case tokIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		if t.isReserved() {
+			return nil, errors.New("expected value got " + t.String())
+		}
+		return t.text, nil
+	default:
+		return nil, errors.New("expected value got " + t.String())
+	}
+}
+
+func parseField(f string) (Field, error) {
+	if f == NameField {
+		return Field{Path: []string{NameField}}, nil
+	}
+
+	f = strings.TrimPrefix(f, metadataPrefix)
+	path := strings.Split(f, ".")
+	for _, k := range path {
+		if k == "" {
+			return Field{}, errors.New("empty key in field " + f)
+		}
+	}
+
+	return Field{Metadata: true, Path: path}, nil
+}
+
+func (c Condition) validate() error {
+	switch c.Operator {
+	case Lt, Lte, Gt, Gte:
+		switch c.Values[0].(type) {
+		case float64, string:
+			return nil
+		}
+		return errors.New("operator " + string(c.Operator) + " requires a number or a string")
+	case Prefix, Match:
+		if _, ok := c.Values[0].(string); !ok {
+			return errors.New("operator " + string(c.Operator) + " requires a string")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package query_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func meta(path ...string) query.Field {
+	return query.Field{Metadata: true, Path: path}
+}
+
+func TestParse(t *testing.T) {
+	name := query.Field{Path: []string{query.NameField}}
+
+	cases := []struct {
+		desc  string
+		query string
+		expr  query.Expr
+		err   error
+	}{
+		{
+			desc:  "parse comparison and list",
+			query: "firmware < 2.3 and site in (A, B)",
+			expr: query.And{
+				Left:  query.Condition{Field: meta("firmware"), Operator: query.Lt, Values: []interface{}{2.3}},
+				Right: query.Condition{Field: meta("site"), Operator: query.In, Values: []interface{}{"A", "B"}},
+			},
+		},
+		{
+			desc:  "parse nested path with quoted string",
+			query: `location.room = "boiler room"`,
+			expr:  query.Condition{Field: meta("location", "room"), Operator: query.Eq, Values: []interface{}{"boiler room"}},
+		},
+		{
+			desc:  "parse metadata prefixed name",
+			query: "metadata.name != null",
+			expr:  query.Condition{Field: meta("name"), Operator: query.Neq, Values: []interface{}{nil}},
+		},
+		{
+			desc:  "parse name prefix",
+			query: "name startswith 'test-'",
+			expr:  query.Condition{Field: name, Operator: query.Prefix, Values: []interface{}{"test-"}},
+		},
+		{
+			desc:  "parse full-text match",
+			query: "name MATCH 'boiler'",
+			expr:  query.Condition{Field: name, Operator: query.Match, Values: []interface{}{"boiler"}},
+		},
+		{
+			desc:  "parse version as string",
+			query: "firmware >= 2.3.1",
+			expr:  query.Condition{Field: meta("firmware"), Operator: query.Gte, Values: []interface{}{"2.3.1"}},
+		},
+		{
+			desc:  "parse precedence",
+			query: "a exists or b = true and not c == -1",
+			expr: query.Or{
+				Left: query.Condition{Field: meta("a"), Operator: query.Exists},
+				Right: query.And{
+					Left:  query.Condition{Field: meta("b"), Operator: query.Eq, Values: []interface{}{true}},
+					Right: query.Not{Expr: query.Condition{Field: meta("c"), Operator: query.Eq, Values: []interface{}{float64(-1)}}},
+				},
+			},
+		},
+		{
+			desc:  "parse parentheses",
+			query: "(a = 1 or b = 2) and c > 'x'",
+			expr: query.And{
+				Left: query.Or{
+					Left:  query.Condition{Field: meta("a"), Operator: query.Eq, Values: []interface{}{float64(1)}},
+					Right: query.Condition{Field: meta("b"), Operator: query.Eq, Values: []interface{}{float64(2)}},
+				},
+				Right: query.Condition{Field: meta("c"), Operator: query.Gt, Values: []interface{}{"x"}},
+			},
+		},
+		{
+			desc:  "parse empty query",
+			query: "",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse missing value",
+			query: "firmware <",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse unbalanced parentheses",
+			query: "(a = 1",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse unterminated string",
+			query: "a = 'x",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse empty list",
+			query: "a in ()",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse empty key",
+			query: "a..b exists",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse ordering of boolean",
+			query: "a < true",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse prefix of number",
+			query: "a startswith 1",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse trailing tokens",
+			query: "a = 1 b",
+			err:   query.ErrInvalidQuery,
+		},
+		{
+			desc:  "parse unknown character",
+			query: "a ~ 1",
+			err:   query.ErrInvalidQuery,
+		},
+	}
+
+	for _, tc := range cases {
+		expr, err := query.Parse(tc.query)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.expr, expr, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.expr, expr))
+	}
+}
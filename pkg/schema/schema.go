@@ -0,0 +1,174 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema contains the definition of payload schemas that can be
+// attached to channels and used to validate messages before they are
+// published to the message broker.
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	// ErrMalformedSchema indicates an invalid payload schema.
+	ErrMalformedSchema = errors.New("malformed payload schema")
+
+	// ErrInvalidPayload indicates that the payload doesn't conform to the schema.
+	ErrInvalidPayload = errors.New("payload doesn't conform to the channel schema")
+)
+
+// Schema describes the payloads accepted on a channel. At most one of
+// JSON and SenML can be set. An empty schema accepts every payload.
+type Schema struct {
+	// JSON contains a JSON Schema document the payloads are validated against.
+	JSON map[string]interface{} `json:"json,omitempty"`
+
+	// SenML contains the whitelist of SenML record names and units.
+	SenML []SenMLRecord `json:"senml,omitempty"`
+}
+
+// SenMLRecord represents a whitelisted SenML record. An empty unit allows
+// the record to be sent with any unit.
+type SenMLRecord struct {
+	Name string `json:"name"`
+	Unit string `json:"unit,omitempty"`
+}
+
+// Empty determines whether the schema accepts every payload.
+func (s Schema) Empty() bool {
+	return len(s.JSON) == 0 && len(s.SenML) == 0
+}
+
+// Validate checks whether the schema itself is well-formed.
+func (s Schema) Validate() error {
+	if len(s.JSON) > 0 && len(s.SenML) > 0 {
+		return ErrMalformedSchema
+	}
+
+	for _, r := range s.SenML {
+		if strings.TrimSpace(r.Name) == "" {
+			return ErrMalformedSchema
+		}
+	}
+
+	_, err := s.compile()
+	return err
+}
+
+// ValidatePayload checks whether the payload conforms to the schema.
+func (s Schema) ValidatePayload(payload []byte) error {
+	c, err := s.compile()
+	if err != nil {
+		return err
+	}
+
+	return c.validatePayload(payload)
+}
+
+// compiled is the schema prepared for the validation of the payloads, which
+// spares parsing the JSON Schema document for each payload.
+type compiled struct {
+	schema Schema
+	json   *gojsonschema.Schema
+}
+
+func (s Schema) compile() (compiled, error) {
+	c := compiled{schema: s}
+	if len(s.JSON) == 0 {
+		return c, nil
+	}
+
+	js, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(s.JSON))
+	if err != nil {
+		return compiled{}, errors.Wrap(ErrMalformedSchema, err)
+	}
+	c.json = js
+
+	return c, nil
+}
+
+func (c compiled) validatePayload(payload []byte) error {
+	switch {
+	case c.json != nil:
+		return c.validateJSON(payload)
+	case len(c.schema.SenML) > 0:
+		return c.schema.validateSenML(payload)
+	default:
+		return nil
+	}
+}
+
+func (c compiled) validateJSON(payload []byte) error {
+	res, err := c.json.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	if !res.Valid() {
+		return errors.Wrap(ErrInvalidPayload, errors.New(res.Errors()[0].String()))
+	}
+
+	return nil
+}
+
+func (s Schema) validateSenML(payload []byte) error {
+	pack, err := senml.Decode(payload, senml.JSON)
+	if err != nil {
+		if pack, err = senml.Decode(payload, senml.CBOR); err != nil {
+			return errors.Wrap(ErrInvalidPayload, err)
+		}
+	}
+
+	normalized, err := senml.Normalize(pack)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	for _, r := range normalized.Records {
+		if !s.allowed(r.Name, r.Unit) {
+			return errors.Wrap(ErrInvalidPayload, errors.New("record "+r.Name+" is not allowed"))
+		}
+	}
+
+	return nil
+}
+
+func (s Schema) allowed(name, unit string) bool {
+	for _, r := range s.SenML {
+		if r.Name == name && (r.Unit == "" || r.Unit == unit) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Marshal encodes the schema in the form used to transfer it between services.
+// An empty schema is encoded as nil.
+func Marshal(s Schema) ([]byte, error) {
+	if s.Empty() {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
+// Unmarshal decodes the schema encoded using Marshal.
+func Unmarshal(data []byte) (Schema, error) {
+	var s Schema
+	if len(data) == 0 {
+		return s, nil
+	}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Schema{}, errors.Wrap(ErrMalformedSchema, err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jsonSchema = schema.Schema{
+		JSON: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"temperature"},
+			"properties": map[string]interface{}{
+				"temperature": map[string]interface{}{"type": "number"},
+			},
+		},
+	}
+	senmlSchema = schema.Schema{
+		SenML: []schema.SenMLRecord{
+			{Name: "current", Unit: "A"},
+			{Name: "dev1:status"},
+		},
+	}
+)
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		schema schema.Schema
+		err    error
+	}{
+		"validate empty schema": {
+			schema: schema.Schema{},
+			err:    nil,
+		},
+		"validate JSON schema": {
+			schema: jsonSchema,
+			err:    nil,
+		},
+		"validate SenML whitelist": {
+			schema: senmlSchema,
+			err:    nil,
+		},
+		"validate schema with both JSON schema and SenML whitelist": {
+			schema: schema.Schema{JSON: jsonSchema.JSON, SenML: senmlSchema.SenML},
+			err:    schema.ErrMalformedSchema,
+		},
+		"validate SenML whitelist with empty name": {
+			schema: schema.Schema{SenML: []schema.SenMLRecord{{Unit: "A"}}},
+			err:    schema.ErrMalformedSchema,
+		},
+		"validate invalid JSON schema": {
+			schema: schema.Schema{JSON: map[string]interface{}{"type": 1}},
+			err:    schema.ErrMalformedSchema,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := tc.schema.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestValidatePayload(t *testing.T) {
+	cases := map[string]struct {
+		schema  schema.Schema
+		payload string
+		err     error
+	}{
+		"validate payload without schema": {
+			schema:  schema.Schema{},
+			payload: "not a JSON",
+			err:     nil,
+		},
+		"validate valid JSON payload": {
+			schema:  jsonSchema,
+			payload: `{"temperature": 21.5}`,
+			err:     nil,
+		},
+		"validate JSON payload with missing field": {
+			schema:  jsonSchema,
+			payload: `{"humidity": 40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate JSON payload with wrong field type": {
+			schema:  jsonSchema,
+			payload: `{"temperature": "hot"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate malformed JSON payload": {
+			schema:  jsonSchema,
+			payload: `{"temperature":`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate whitelisted SenML payload": {
+			schema:  senmlSchema,
+			payload: `[{"n":"current","u":"A","v":1.6}]`,
+			err:     nil,
+		},
+		"validate whitelisted SenML payload with base name": {
+			schema:  senmlSchema,
+			payload: `[{"bn":"dev1:","n":"status","vs":"ok"}]`,
+			err:     nil,
+		},
+		"validate SenML payload with unknown name": {
+			schema:  senmlSchema,
+			payload: `[{"n":"voltage","u":"V","v":230}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate SenML payload with wrong unit": {
+			schema:  senmlSchema,
+			payload: `[{"n":"current","u":"mA","v":1600}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		"validate malformed SenML payload": {
+			schema:  senmlSchema,
+			payload: `{"n":"current"`,
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := tc.schema.ValidatePayload([]byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := schema.Marshal(schema.Schema{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Nil(t, data, "expected empty schema to be encoded as nil")
+
+	data, err = schema.Marshal(senmlSchema)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	s, err := schema.Unmarshal(data)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, senmlSchema, s, fmt.Sprintf("expected %v got %v", senmlSchema, s))
+
+	_, err = schema.Unmarshal([]byte("{"))
+	assert.True(t, errors.Contains(err, schema.ErrMalformedSchema), fmt.Sprintf("expected %s got %s", schema.ErrMalformedSchema, err))
+}
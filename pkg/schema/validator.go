@@ -0,0 +1,117 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// DefCacheTTL is the default time a channel schema retrieved from the things
+// service is used before it's retrieved again.
+const DefCacheTTL = 10 * time.Second
+
+// Validator validates messages against the schemas of their channels.
+type Validator interface {
+	// Validate returns ErrInvalidPayload if the message payload doesn't
+	// conform to the schema of the channel the message is published to.
+	Validate(ctx context.Context, msg messaging.Message) error
+}
+
+// version identifies the encoded schema the compiled one was built from.
+type version [sha256.Size]byte
+
+type entry struct {
+	version  version
+	compiled compiled
+	expires  time.Time
+}
+
+type validator struct {
+	things mainflux.ThingsServiceClient
+	ttl    time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]entry
+}
+
+// NewValidator returns validator that retrieves channel schemas from
+// the things service. The compiled schemas are kept per channel and used
+// for the given TTL without asking the things service again, so a schema
+// change applies to the published messages at most TTL later. Once the TTL
+// expires, the schema is retrieved again and compiled only if it changed.
+func NewValidator(things mainflux.ThingsServiceClient, ttl time.Duration) Validator {
+	return &validator{
+		things:  things,
+		ttl:     ttl,
+		entries: make(map[string]entry),
+	}
+}
+
+func (v *validator) Validate(ctx context.Context, msg messaging.Message) error {
+	c, ok := v.cached(msg.Channel)
+	if !ok {
+		res, err := v.things.GetChannelSchema(ctx, &mainflux.ChannelID{Value: msg.Channel})
+		if err != nil {
+			return err
+		}
+
+		if c, err = v.compiled(msg.Channel, res.GetValue()); err != nil {
+			return err
+		}
+	}
+
+	return c.validatePayload(msg.Payload)
+}
+
+// cached returns the compiled schema of the channel if it hasn't expired.
+func (v *validator) cached(chanID string) (compiled, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	e, ok := v.entries[chanID]
+	if !ok || !time.Now().Before(e.expires) {
+		return compiled{}, false
+	}
+
+	return e.compiled, true
+}
+
+// compiled returns the compiled schema of the channel, compiling it only if
+// the channel has no compiled schema of the same version.
+func (v *validator) compiled(chanID string, data []byte) (compiled, error) {
+	ver := version(sha256.Sum256(data))
+	expires := time.Now().Add(v.ttl)
+
+	v.mu.Lock()
+	e, ok := v.entries[chanID]
+	if ok && e.version == ver {
+		e.expires = expires
+		v.entries[chanID] = e
+		v.mu.Unlock()
+		return e.compiled, nil
+	}
+	v.mu.Unlock()
+
+	s, err := Unmarshal(data)
+	if err != nil {
+		return compiled{}, err
+	}
+
+	c, err := s.compile()
+	if err != nil {
+		return compiled{}, err
+	}
+
+	v.mu.Lock()
+	v.entries[chanID] = entry{version: ver, compiled: c, expires: expires}
+	v.mu.Unlock()
+
+	return c, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+const chanID = "1"
+
+// thingsMock serves the schema of a single channel, which can be changed
+// between the validations.
+type thingsMock struct {
+	mainflux.ThingsServiceClient
+	schema schema.Schema
+	calls  int
+}
+
+func (tm *thingsMock) GetChannelSchema(_ context.Context, _ *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelSchema, error) {
+	tm.calls++
+	value, err := schema.Marshal(tm.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.ChannelSchema{Value: value}, nil
+}
+
+func TestValidatorValidate(t *testing.T) {
+	things := &thingsMock{schema: jsonSchema}
+	validator := schema.NewValidator(things, 0)
+
+	humidity := schema.Schema{
+		JSON: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"humidity"},
+		},
+	}
+
+	cases := []struct {
+		desc    string
+		schema  schema.Schema
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate valid payload",
+			schema:  jsonSchema,
+			payload: `{"temperature": 21.5}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate invalid payload",
+			schema:  jsonSchema,
+			payload: `{"humidity": 40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload against changed schema",
+			schema:  humidity,
+			payload: `{"humidity": 40}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate payload invalid against changed schema",
+			schema:  humidity,
+			payload: `{"temperature": 21.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload against removed schema",
+			schema:  schema.Schema{},
+			payload: "not a JSON",
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		things.schema = tc.schema
+		msg := messaging.Message{
+			Channel: chanID,
+			Payload: []byte(tc.payload),
+		}
+		err := validator.Validate(context.Background(), msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidatorCache(t *testing.T) {
+	things := &thingsMock{schema: jsonSchema}
+	validator := schema.NewValidator(things, time.Minute)
+	msg := messaging.Message{
+		Channel: chanID,
+		Payload: []byte(`{"humidity": 40}`),
+	}
+
+	err := validator.Validate(context.Background(), msg)
+	assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("validate invalid payload: expected %s got %s\n", schema.ErrInvalidPayload, err))
+
+	things.schema = schema.Schema{}
+	err = validator.Validate(context.Background(), msg)
+	assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("validate payload against cached schema: expected %s got %s\n", schema.ErrInvalidPayload, err))
+	assert.Equal(t, 1, things.calls, fmt.Sprintf("expected %d schema retrievals got %d\n", 1, things.calls))
+
+	expiring := schema.NewValidator(things, time.Millisecond)
+	err = expiring.Validate(context.Background(), msg)
+	assert.Nil(t, err, fmt.Sprintf("validate payload against removed schema: expected no error got %s\n", err))
+
+	things.schema = jsonSchema
+	time.Sleep(2 * time.Millisecond)
+	err = expiring.Validate(context.Background(), msg)
+	assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("validate payload against expired schema: expected %s got %s\n", schema.ErrInvalidPayload, err))
+	assert.Equal(t, 3, things.calls, fmt.Sprintf("expected %d schema retrievals got %d\n", 3, things.calls))
+}
@@ -98,6 +98,7 @@ type PageMetadata struct {
 	Name     string                 `json:"name,omitempty"`
 	Type     string                 `json:"type,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Query    string                 `json:"query,omitempty"`
 }
 
 // Group represents mainflux users group.
@@ -393,5 +394,8 @@ func (pm PageMetadata) query() (string, error) {
 		}
 		q.Add("metadata", string(md))
 	}
+	if pm.Query != "" {
+		q.Add("query", pm.Query)
+	}
 	return q.Encode(), nil
 }
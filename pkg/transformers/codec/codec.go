@@ -0,0 +1,220 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package codec contains the payload codecs that decode the compact binary
+// frames sent by the devices into SenML records. The codec is defined by the
+// declarative byte layout of the frame and referenced by the thing profile.
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+)
+
+// Supported field types.
+const (
+	Uint8   = "uint8"
+	Int8    = "int8"
+	Uint16  = "uint16"
+	Int16   = "int16"
+	Uint32  = "uint32"
+	Int32   = "int32"
+	Uint64  = "uint64"
+	Int64   = "int64"
+	Float32 = "float32"
+	Float64 = "float64"
+	Bool    = "bool"
+)
+
+// maxFrameSize is the size of the largest binary frame the codec describes,
+// which bounds the field offsets.
+const maxFrameSize = math.MaxUint16
+
+// Supported field byte orders.
+const (
+	BigEndian    = "big"
+	LittleEndian = "little"
+)
+
+var (
+	// ErrMalformedCodec indicates an invalid codec definition.
+	ErrMalformedCodec = errors.New("malformed payload codec")
+
+	// ErrDecode indicates that the payload doesn't match the codec layout.
+	ErrDecode = errors.New("failed to decode payload using codec")
+)
+
+var sizes = map[string]int{
+	Uint8:   1,
+	Int8:    1,
+	Uint16:  2,
+	Int16:   2,
+	Uint32:  4,
+	Int32:   4,
+	Uint64:  8,
+	Int64:   8,
+	Float32: 4,
+	Float64: 8,
+	Bool:    1,
+}
+
+// Codec describes the byte layout of the binary payloads. An empty codec
+// doesn't decode payloads.
+type Codec struct {
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Field describes the single value of the binary payload, decoded into
+// the SenML record of the same name and unit.
+type Field struct {
+	Name string `json:"name"`
+	Unit string `json:"unit,omitempty"`
+
+	// Type is one of the supported field types.
+	Type string `json:"type"`
+
+	// Offset is the position of the first byte of the field.
+	Offset int `json:"offset"`
+
+	// Endianness is the byte order of the multi-byte fields, big endian
+	// by default.
+	Endianness string `json:"endianness,omitempty"`
+
+	// Scale multiplies the numeric value, 1 by default.
+	Scale float64 `json:"scale,omitempty"`
+
+	// Bit is the position of the boolean field bit in the byte, starting
+	// from the least significant one.
+	Bit uint `json:"bit,omitempty"`
+}
+
+// Empty determines whether the codec decodes payloads.
+func (c Codec) Empty() bool {
+	return len(c.Fields) == 0
+}
+
+// Validate checks whether the codec itself is well-formed.
+func (c Codec) Validate() error {
+	names := map[string]bool{}
+	for _, f := range c.Fields {
+		if strings.TrimSpace(f.Name) == "" || names[f.Name] {
+			return ErrMalformedCodec
+		}
+		names[f.Name] = true
+
+		if _, ok := sizes[f.Type]; !ok {
+			return ErrMalformedCodec
+		}
+
+		switch f.Endianness {
+		case "", BigEndian, LittleEndian:
+		default:
+			return ErrMalformedCodec
+		}
+
+		if f.Offset < 0 || f.Offset > maxFrameSize || f.Bit > 7 {
+			return ErrMalformedCodec
+		}
+	}
+
+	return nil
+}
+
+// Decode decodes the payload into the SenML pack, with records at the given
+// time in seconds.
+func (c Codec) Decode(payload []byte, t float64) (senml.Pack, error) {
+	recs := make([]senml.Record, len(c.Fields))
+	for i, f := range c.Fields {
+		// The offset is compared with the room left for the field, so that
+		// it can't overflow even if the codec wasn't validated.
+		size := sizes[f.Type]
+		if size == 0 || f.Offset < 0 || f.Offset > len(payload)-size {
+			return senml.Pack{}, ErrDecode
+		}
+		b := payload[f.Offset : f.Offset+size]
+
+		recs[i] = senml.Record{Name: f.Name, Unit: f.Unit, Time: t}
+		if f.Type == Bool {
+			v := b[0]&(1<<f.Bit) != 0
+			recs[i].BoolValue = &v
+			continue
+		}
+
+		v := f.value(b)
+		if f.Scale != 0 {
+			v *= f.Scale
+		}
+		recs[i].Value = &v
+	}
+
+	return senml.Pack{Records: recs}, nil
+}
+
+// Encode decodes the payload and encodes the result as SenML JSON.
+func (c Codec) Encode(payload []byte, t float64) ([]byte, error) {
+	pack, err := c.Decode(payload, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return senml.Encode(pack, senml.JSON)
+}
+
+func (f Field) value(b []byte) float64 {
+	var order binary.ByteOrder = binary.BigEndian
+	if f.Endianness == LittleEndian {
+		order = binary.LittleEndian
+	}
+
+	switch f.Type {
+	case Uint8:
+		return float64(b[0])
+	case Int8:
+		return float64(int8(b[0]))
+	case Uint16:
+		return float64(order.Uint16(b))
+	case Int16:
+		return float64(int16(order.Uint16(b)))
+	case Uint32:
+		return float64(order.Uint32(b))
+	case Int32:
+		return float64(int32(order.Uint32(b)))
+	case Uint64:
+		return float64(order.Uint64(b))
+	case Int64:
+		return float64(int64(order.Uint64(b)))
+	case Float32:
+		return float64(math.Float32frombits(order.Uint32(b)))
+	default:
+		return math.Float64frombits(order.Uint64(b))
+	}
+}
+
+// Marshal encodes the codec in the form used to transfer it between services.
+// An empty codec is encoded as nil.
+func Marshal(c Codec) ([]byte, error) {
+	if c.Empty() {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
+}
+
+// Unmarshal decodes the codec encoded using Marshal.
+func Unmarshal(data []byte) (Codec, error) {
+	var c Codec
+	if len(data) == 0 {
+		return c, nil
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Codec{}, errors.Wrap(ErrMalformedCodec, err)
+	}
+
+	return c, nil
+}
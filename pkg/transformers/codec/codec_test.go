@@ -0,0 +1,192 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc  string
+		codec codec.Codec
+		err   error
+	}{
+		{
+			desc:  "validate empty codec",
+			codec: codec.Codec{},
+			err:   nil,
+		},
+		{
+			desc: "validate codec",
+			codec: codec.Codec{Fields: []codec.Field{
+				{Name: "temperature", Type: codec.Int16, Endianness: codec.LittleEndian, Scale: 0.01},
+				{Name: "door", Type: codec.Bool, Offset: 2, Bit: 7},
+			}},
+			err: nil,
+		},
+		{
+			desc:  "validate codec with field without name",
+			codec: codec.Codec{Fields: []codec.Field{{Type: codec.Uint8}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with duplicated field",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: codec.Uint8}, {Name: "a", Type: codec.Uint8, Offset: 1}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with unknown field type",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: "int12"}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with unknown endianness",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: codec.Uint16, Endianness: "middle"}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with negative offset",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: codec.Uint8, Offset: -1}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with offset past the largest frame",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: codec.Uint8, Offset: math.MaxUint16 + 1}}},
+			err:   codec.ErrMalformedCodec,
+		},
+		{
+			desc:  "validate codec with invalid bit",
+			codec: codec.Codec{Fields: []codec.Field{{Name: "a", Type: codec.Bool, Bit: 8}}},
+			err:   codec.ErrMalformedCodec,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.codec.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	num := func(v float64) *float64 { return &v }
+	flag := func(v bool) *bool { return &v }
+
+	cases := []struct {
+		desc    string
+		field   codec.Field
+		payload []byte
+		rec     senml.Record
+		err     error
+	}{
+		{
+			desc:    "decode uint8",
+			field:   codec.Field{Name: "a", Type: codec.Uint8, Offset: 1},
+			payload: []byte{0x00, 0xff},
+			rec:     senml.Record{Name: "a", Value: num(255)},
+		},
+		{
+			desc:    "decode int8",
+			field:   codec.Field{Name: "a", Type: codec.Int8},
+			payload: []byte{0xff},
+			rec:     senml.Record{Name: "a", Value: num(-1)},
+		},
+		{
+			desc:    "decode big endian int16 with scale",
+			field:   codec.Field{Name: "temperature", Unit: "Cel", Type: codec.Int16, Scale: 0.01},
+			payload: []byte{0xf8, 0x30},
+			rec:     senml.Record{Name: "temperature", Unit: "Cel", Value: num(-20)},
+		},
+		{
+			desc:    "decode little endian uint16",
+			field:   codec.Field{Name: "a", Type: codec.Uint16, Endianness: codec.LittleEndian},
+			payload: []byte{0x01, 0x02},
+			rec:     senml.Record{Name: "a", Value: num(513)},
+		},
+		{
+			desc:    "decode uint32",
+			field:   codec.Field{Name: "a", Type: codec.Uint32},
+			payload: []byte{0x00, 0x01, 0x00, 0x00},
+			rec:     senml.Record{Name: "a", Value: num(65536)},
+		},
+		{
+			desc:    "decode int64",
+			field:   codec.Field{Name: "a", Type: codec.Int64},
+			payload: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe},
+			rec:     senml.Record{Name: "a", Value: num(-2)},
+		},
+		{
+			desc:    "decode float32",
+			field:   codec.Field{Name: "a", Type: codec.Float32},
+			payload: []byte{0x3f, 0xc0, 0x00, 0x00},
+			rec:     senml.Record{Name: "a", Value: num(1.5)},
+		},
+		{
+			desc:    "decode little endian float64",
+			field:   codec.Field{Name: "a", Type: codec.Float64, Endianness: codec.LittleEndian},
+			payload: []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f},
+			rec:     senml.Record{Name: "a", Value: num(1.5)},
+		},
+		{
+			desc:    "decode set bool bit",
+			field:   codec.Field{Name: "door", Type: codec.Bool, Bit: 2},
+			payload: []byte{0x04},
+			rec:     senml.Record{Name: "door", BoolValue: flag(true)},
+		},
+		{
+			desc:    "decode unset bool bit",
+			field:   codec.Field{Name: "door", Type: codec.Bool, Bit: 1},
+			payload: []byte{0x04},
+			rec:     senml.Record{Name: "door", BoolValue: flag(false)},
+		},
+		{
+			desc:    "decode too short payload",
+			field:   codec.Field{Name: "a", Type: codec.Uint32, Offset: 1},
+			payload: []byte{0x00, 0x01, 0x00, 0x00},
+			err:     codec.ErrDecode,
+		},
+		{
+			desc:    "decode field with overflowing offset",
+			field:   codec.Field{Name: "a", Type: codec.Uint64, Offset: math.MaxInt},
+			payload: []byte{0x00, 0x01, 0x00, 0x00},
+			err:     codec.ErrDecode,
+		},
+	}
+
+	for _, tc := range cases {
+		c := codec.Codec{Fields: []codec.Field{tc.field}}
+		pack, err := c.Decode(tc.payload, 0)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		require.Len(t, pack.Records, 1, tc.desc)
+		assert.Equal(t, tc.rec, pack.Records[0], fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rec, pack.Records[0]))
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	c := codec.Codec{Fields: []codec.Field{{Name: "temperature", Unit: "Cel", Type: codec.Int16, Scale: 0.01}}}
+
+	data, err := codec.Marshal(c)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	res, err := codec.Unmarshal(data)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, c, res, fmt.Sprintf("expected %v got %v\n", c, res))
+
+	data, err = codec.Marshal(codec.Codec{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Nil(t, data, "expected empty codec to be encoded as nil")
+
+	_, err = codec.Unmarshal([]byte("{"))
+	assert.True(t, errors.Contains(err, codec.ErrMalformedCodec), fmt.Sprintf("expected %s got %s\n", codec.ErrMalformedCodec, err))
+}
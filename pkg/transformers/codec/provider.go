@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+// Profile contains the payload settings of the profile the thing was created
+// from. The content type and transformer are the defaults of the payloads
+// published without the content type.
+type Profile struct {
+	Codec       Codec  `json:"codec"`
+	ContentType string `json:"content_type,omitempty"`
+	Transformer string `json:"transformer,omitempty"`
+}
+
+// Provider provides the codecs of the things.
+type Provider interface {
+	// Profile returns the payload settings of the profile the thing was
+	// created from. An empty profile is returned if the thing has no profile.
+	Profile(ctx context.Context, thingID string) (Profile, error)
+}
+
+type provider struct {
+	things mainflux.ThingsServiceClient
+}
+
+// NewProvider returns provider that retrieves thing codecs from the things
+// service, which caches them per thing.
+func NewProvider(things mainflux.ThingsServiceClient) Provider {
+	return provider{things: things}
+}
+
+func (p provider) Profile(ctx context.Context, thingID string) (Profile, error) {
+	res, err := p.things.GetThingCodec(ctx, &mainflux.ThingID{Value: thingID})
+	if err != nil {
+		return Profile{}, err
+	}
+
+	c, err := Unmarshal(res.GetValue())
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return Profile{
+		Codec:       c,
+		ContentType: res.GetContentType(),
+		Transformer: res.GetTransformer(),
+	}, nil
+}
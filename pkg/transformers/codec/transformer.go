@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	mfsenml "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/senml"
+)
+
+type transformer struct {
+	codecs   Provider
+	fallback transformers.Transformer
+	json     transformers.Transformer
+	senml    map[string]transformers.Transformer
+}
+
+// New returns transformer that decodes the binary payloads of the things
+// having a codec into SenML messages. The other payloads are transformed
+// according to the message content type or, if the message has none, the
+// default content type and transformer of the thing profile, using the given
+// JSON transformer for the JSON payloads. The payloads of unknown format are
+// transformed by the fallback transformer.
+func New(codecs Provider, fallback, jsonTransformer transformers.Transformer) transformers.Transformer {
+	return transformer{
+		codecs:   codecs,
+		fallback: fallback,
+		json:     jsonTransformer,
+		senml: map[string]transformers.Transformer{
+			mfsenml.JSON: mfsenml.New(mfsenml.JSON),
+			mfsenml.CBOR: mfsenml.New(mfsenml.CBOR),
+		},
+	}
+}
+
+func (t transformer) Transform(msg messaging.Message) (interface{}, error) {
+	if msg.ContentType != "" {
+		return t.transformer(msg.ContentType, "").Transform(msg)
+	}
+
+	// The payloads already decoded by the adapter, e.g. LoRa adapter, have
+	// the content type, so the codec is applied only to the payloads without
+	// it, regardless of whether they look like JSON.
+	p, err := t.codecs.Profile(context.Background(), msg.Publisher)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Codec.Empty() {
+		return t.transformer(p.ContentType, p.Transformer).Transform(msg)
+	}
+
+	// Convert the Unix timestamp in nanoseconds to float64
+	pack, err := p.Codec.Decode(msg.Payload, float64(msg.Created)/float64(1e9))
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := senml.Normalize(pack)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]mfsenml.Message, len(normalized.Records))
+	for i, v := range normalized.Records {
+		msgs[i] = mfsenml.Message{
+			Channel:   msg.Channel,
+			Subtopic:  msg.Subtopic,
+			Publisher: msg.Publisher,
+			Protocol:  msg.Protocol,
+			Name:      v.Name,
+			Unit:      v.Unit,
+			Time:      v.Time,
+			Value:     v.Value,
+			BoolValue: v.BoolValue,
+		}
+	}
+
+	return msgs, nil
+}
+
+// transformer returns the transformer of the given format, which is derived
+// from the content type if it's not set.
+func (t transformer) transformer(contentType, format string) transformers.Transformer {
+	if format == "" {
+		switch contentType {
+		case mfsenml.JSON, mfsenml.CBOR:
+			format = transformers.SenML
+		case mfjson.ContentType:
+			format = transformers.JSON
+		}
+	}
+
+	switch format {
+	case transformers.SenML:
+		if tr, ok := t.senml[contentType]; ok {
+			return tr
+		}
+		return t.senml[mfsenml.JSON]
+	case transformers.JSON:
+		return t.json
+	default:
+		return t.fallback
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package codec_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransform(t *testing.T) {
+	tr := codec.New(codec.NewProvider(mocks.NewThingsService(nil, nil)), senml.New(senml.JSON), json.New(nil))
+
+	temp := -20.0
+	value := 22.0
+	scale := 0.01
+	digitsTemp := 0x3132 * scale
+	msg := messaging.Message{
+		Channel:   "channel",
+		Publisher: mocks.CodecThingID,
+		Protocol:  "lora",
+		Created:   1500000000000000000,
+	}
+
+	binary := msg
+	binary.Payload = []byte{0xf8, 0x30}
+
+	// The frame of ASCII digits "12" is valid JSON.
+	digits := msg
+	digits.Payload = []byte("12")
+
+	decoded := msg
+	decoded.Payload = []byte(`[{"n":"temperature","u":"Cel","v":22}]`)
+	decoded.ContentType = senml.JSON
+
+	short := msg
+	short.Payload = []byte{0xf8}
+
+	other := msg
+	other.Publisher = "publisher"
+	other.Payload = []byte(`[{"n":"temperature","u":"Cel","v":22}]`)
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		msgs interface{}
+		err  error
+	}{
+		{
+			desc: "transform binary payload of thing with codec",
+			msg:  binary,
+			msgs: []senml.Message{{Channel: "channel", Publisher: mocks.CodecThingID, Protocol: "lora", Name: "temperature", Unit: "Cel", Time: 1500000000, Value: &temp}},
+			err:  nil,
+		},
+		{
+			desc: "transform digit-only binary payload of thing with codec",
+			msg:  digits,
+			msgs: []senml.Message{{Channel: "channel", Publisher: mocks.CodecThingID, Protocol: "lora", Name: "temperature", Unit: "Cel", Time: 1500000000, Value: &digitsTemp}},
+			err:  nil,
+		},
+		{
+			desc: "transform SenML payload of thing with codec",
+			msg:  decoded,
+			msgs: []senml.Message{{Channel: "channel", Publisher: mocks.CodecThingID, Protocol: "lora", Name: "temperature", Unit: "Cel", Time: 1500000000, Value: &value}},
+			err:  nil,
+		},
+		{
+			desc: "transform too short binary payload of thing with codec",
+			msg:  short,
+			msgs: nil,
+			err:  codec.ErrDecode,
+		},
+		{
+			desc: "transform SenML payload of thing without codec",
+			msg:  other,
+			msgs: []senml.Message{{Channel: "channel", Publisher: "publisher", Protocol: "lora", Name: "temperature", Unit: "Cel", Time: 1500000000, Value: &value}},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		msgs, err := tr.Transform(tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.msgs, msgs))
+	}
+}
+
+// providerMock counts the codec lookups.
+type providerMock struct {
+	codec.Provider
+	lookups int
+}
+
+func (pm *providerMock) Profile(ctx context.Context, thingID string) (codec.Profile, error) {
+	pm.lookups++
+	return pm.Provider.Profile(ctx, thingID)
+}
+
+func TestTransformLookups(t *testing.T) {
+	cases := []struct {
+		desc        string
+		payload     []byte
+		contentType string
+		lookups     int
+	}{
+		{
+			desc:    "transform digit-only binary payload with codec lookup",
+			payload: []byte("12"),
+			lookups: 1,
+		},
+		{
+			desc:    "transform binary payload with codec lookup",
+			payload: []byte{0xf8, 0x30},
+			lookups: 1,
+		},
+		{
+			desc:        "transform SenML payload with content type without codec lookup",
+			payload:     []byte(`[{"n":"temperature","u":"Cel","v":22}]`),
+			contentType: senml.JSON,
+			lookups:     0,
+		},
+	}
+
+	for _, tc := range cases {
+		provider := &providerMock{Provider: codec.NewProvider(mocks.NewThingsService(nil, nil))}
+		tr := codec.New(provider, senml.New(senml.JSON), json.New(nil))
+
+		msg := messaging.Message{
+			Channel:     "channel",
+			Publisher:   mocks.CodecThingID,
+			Payload:     tc.payload,
+			ContentType: tc.contentType,
+		}
+		_, err := tr.Transform(msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.lookups, provider.lookups, fmt.Sprintf("%s: expected %d codec lookups got %d\n", tc.desc, tc.lookups, provider.lookups))
+	}
+}
+
+func TestTransformDefaults(t *testing.T) {
+	tr := codec.New(codec.NewProvider(mocks.NewThingsService(nil, nil)), senml.New(senml.JSON), json.New(nil))
+
+	cases := []struct {
+		desc        string
+		publisher   string
+		contentType string
+		payload     []byte
+		msgs        interface{}
+	}{
+		{
+			desc:      "transform payload using profile defaults",
+			publisher: mocks.JSONThingID,
+			payload:   []byte(`{"temperature":22}`),
+			msgs:      json.Messages{},
+		},
+		{
+			desc:      "transform payload of thing without profile using fallback",
+			publisher: "publisher",
+			payload:   []byte(`[{"n":"temperature","v":22}]`),
+			msgs:      []senml.Message{},
+		},
+		{
+			desc:        "transform payload using message content type",
+			publisher:   "publisher",
+			contentType: json.ContentType,
+			payload:     []byte(`{"temperature":22}`),
+			msgs:        json.Messages{},
+		},
+		{
+			desc:        "transform payload using message content type over profile defaults",
+			publisher:   mocks.JSONThingID,
+			contentType: senml.JSON,
+			payload:     []byte(`[{"n":"temperature","v":22}]`),
+			msgs:        []senml.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		// The JSON message format is the subtopic.
+		msg := messaging.Message{
+			Channel:     "channel",
+			Subtopic:    "engines",
+			Publisher:   tc.publisher,
+			ContentType: tc.contentType,
+			Payload:     tc.payload,
+		}
+		msgs, err := tr.Transform(msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.IsType(t, tc.msgs, msgs, fmt.Sprintf("%s: expected %T got %T\n", tc.desc, tc.msgs, msgs))
+	}
+}
@@ -12,6 +12,9 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/transformers"
 )
 
+// ContentType represents JSON format content type.
+const ContentType = "application/json"
+
 const sep = "/"
 
 var (
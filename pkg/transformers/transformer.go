@@ -5,6 +5,12 @@ package transformers
 
 import "github.com/MainfluxLabs/mainflux/pkg/messaging"
 
+// Supported transformer formats.
+const (
+	SenML = "senml"
+	JSON  = "json"
+)
+
 // Transformer specifies API form Message transformer.
 type Transformer interface {
 	// Transform Mainflux message to any other format.
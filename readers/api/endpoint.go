@@ -18,7 +18,7 @@ func ListChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoin
 			return nil, err
 		}
 
-		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+		if err := authorize(ctx, req.token, req.key, req.chanID, req.pageMeta.Subtopic); err != nil {
 			return nil, errors.Wrap(errors.ErrAuthorization, err)
 		}
 
@@ -35,6 +35,30 @@ func ListChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoin
 	}
 }
 
+func listLatestMessagesEndpoint(cache readers.LatestMessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listLatestMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID, req.pageMeta.Subtopic); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		page, err := cache.ListLatestMessages(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return listMessagesRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
 func listAllMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listAllMessagesReq)
@@ -59,3 +83,165 @@ func listAllMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func listChannelsMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listChannelsMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeChannels(ctx, req.token, req.key, req.chanIDs, req.pageMeta.Subtopic); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		page, err := svc.ListMessagesByChannels(req.chanIDs, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return listMessagesRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
+func listGroupMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listGroupMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		chanIDs, err := groupChannels(ctx, req.token, req.groupID)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		page, err := svc.ListMessagesByChannels(chanIDs, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return listMessagesRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
+func deleteMessagesEndpoint(svc readers.MessageRepository, cache readers.LatestMessageRepository, audit readers.AuditRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(deleteMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		userID, err := authorizeOwner(ctx, req.token, req.chanID)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		// The deletion is recorded first, so that no messages are removed
+		// without a trace.
+		if err := audit.Save(readers.NewDeletion(req.chanID, userID, req.pageMeta)); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DeleteMessages(req.chanID, req.pageMeta); err != nil {
+			return nil, err
+		}
+
+		// The cache holds only the SenML messages.
+		if req.pageMeta.Format == defFormat {
+			if err := cache.DeleteLatestMessages(req.chanID, req.pageMeta); err != nil {
+				return nil, err
+			}
+		}
+
+		return deleteMessagesRes{}, nil
+	}
+}
+
+func replayMessagesEndpoint(svc readers.Replayer) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		target := req.Channel
+		if target == "" {
+			target = req.chanID
+		}
+
+		// The channel owner may replay the messages only to the channels they own.
+		if err := authorizeReplay(ctx, req.token, req.chanID, target); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		r := readers.Replay{
+			Channel:      req.chanID,
+			Target:       target,
+			Subtopic:     req.Subtopic,
+			Rate:         req.Rate,
+			PageMetadata: req.pageMeta,
+		}
+		status, err := svc.Replay(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return replayMessagesRes{ID: status.ID, Total: status.Total}, nil
+	}
+}
+
+func listReplaysEndpoint(svc readers.Replayer) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReplaysReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeReplay(ctx, req.token, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		res := listReplaysRes{Replays: []replayRes{}}
+		for _, s := range svc.ListReplays(req.chanID) {
+			res.Replays = append(res.Replays, replayRes{
+				ID:       s.ID,
+				Channel:  s.Target,
+				Subtopic: s.Subtopic,
+				Rate:     s.Rate,
+				Total:    s.Total,
+				Replayed: s.Replayed,
+				Started:  s.Started,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func cancelReplayEndpoint(svc readers.Replayer) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(cancelReplayReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeReplay(ctx, req.token, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		if err := svc.CancelReplay(req.chanID, req.replayID); err != nil {
+			return nil, err
+		}
+
+		return cancelReplayRes{}, nil
+	}
+}
@@ -7,20 +7,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/users"
 	authmocks "github.com/MainfluxLabs/mainflux/users/mocks"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +33,7 @@ import (
 
 const (
 	svcName       = "test-service"
+	contentType   = "application/json"
 	thingToken    = "1"
 	email         = "admin@example.com"
 	invalid       = "invalid"
@@ -53,9 +58,10 @@ var (
 	user = users.User{Email: email, Password: validPass}
 )
 
-func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient) *httptest.Server {
+func newServer(repo readers.MessageRepository, cache readers.LatestMessageRepository, audit readers.AuditRepository, pub messaging.Publisher, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient) *httptest.Server {
 	logger := logger.NewMock()
-	mux := api.MakeHandler(repo, tc, ac, svcName, logger)
+	replayer := readers.NewReplayer(repo, pub, idProvider, logger)
+	mux := api.MakeHandler(repo, cache, audit, replayer, tc, ac, svcName, logger)
 
 	id, _ := idProvider.ID()
 	user.ID = id
@@ -64,18 +70,23 @@ func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient,
 }
 
 type testRequest struct {
-	client *http.Client
-	method string
-	url    string
-	token  string
-	key    string
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	key         string
+	body        io.Reader
 }
 
 func (tr testRequest) make() (*http.Response, error) {
-	req, err := http.NewRequest(tr.method, tr.url, nil)
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
 	if err != nil {
 		return nil, err
 	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
 	if tr.token != "" {
 		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
 	}
@@ -150,7 +161,8 @@ func TestListChannelMessages(t *testing.T) {
 	userToken := tok.GetValue()
 
 	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
-	ts := newServer(repo, thSvc, authSvc)
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), mocks.NewPublisher(), thSvc, authSvc)
 	defer ts.Close()
 
 	cases := []struct {
@@ -743,6 +755,54 @@ func TestListChannelMessages(t *testing.T) {
 				Messages: messages[5:15],
 			},
 		},
+		{
+			desc:   "read JSON page with payload filters",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&filter=engine.temp:gt:80,engine.state:eq:%%22on%%22,engine.on:eq:true", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "read JSON page with payload fields",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&fields=engine.temp,engine.rpm", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "read SenML page with payload filter",
+			url:    fmt.Sprintf("%s/channels/%s/messages?filter=engine.temp:gt:80", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON page with malformed payload filter",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&filter=engine.temp", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON page with invalid payload filter comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&filter=engine.temp:ne:80", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON page with ordered string payload filter",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&filter=engine.state:gt:on", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON page with invalid payload filter path",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&filter=engine..temp:eq:80", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON page with overlapping payload fields",
+			url:    fmt.Sprintf("%s/channels/%s/messages?format=json&fields=engine,engine.temp", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -824,7 +884,8 @@ func TestListAllMessages(t *testing.T) {
 	userToken := tok.GetValue()
 
 	repo := mocks.NewMessageRepository("", fromSenml(messages))
-	ts := newServer(repo, thSvc, authSvc)
+	cache := mocks.NewLatestMessageRepository("", messages)
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), mocks.NewPublisher(), thSvc, authSvc)
 	defer ts.Close()
 
 	cases := []struct {
@@ -1160,6 +1221,930 @@ func TestListAllMessages(t *testing.T) {
 	}
 }
 
+func TestListLatestMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      "name",
+			Value:     &v,
+		}
+		if i%2 == 1 {
+			msg.Publisher = pubID2
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Name = msgName
+		}
+
+		messages = append(messages, msg)
+	}
+	// The first message of each publisher is the newest one.
+	latest := []senml.Message{messages[0], messages[1]}
+	if pubID2 < pubID {
+		latest = []senml.Message{messages[1], messages[0]}
+	}
+
+	thSvc := thmocks.NewThingsService(map[string]string{user.ID: chanID}, nil)
+	authSvc := newAuthService()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	userToken := tok.GetValue()
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), mocks.NewPublisher(), thSvc, authSvc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		res    pageRes
+	}{
+		{
+			desc:   "read latest messages as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(latest)),
+				Messages: latest,
+			},
+		},
+		{
+			desc:   "read latest messages as user",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(latest)),
+				Messages: latest,
+			},
+		},
+		{
+			desc:   "read latest messages with limit",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?limit=1", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    uint64(len(latest)),
+				Messages: latest[0:1],
+			},
+		},
+		{
+			desc:   "read latest messages with publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?publisher=%s", ts.URL, chanID, pubID2),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    1,
+				Messages: []senml.Message{messages[1]},
+			},
+		},
+		{
+			desc:   "read latest messages with name",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=%s", ts.URL, chanID, msgName),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				Total:    1,
+				Messages: []senml.Message{messages[1]},
+			},
+		},
+		{
+			desc:   "read latest messages with invalid limit",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?limit=invalid", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages with limit above max",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?limit=1001", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest messages with invalid thing key",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			key:    invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read latest messages with invalid user token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read latest messages without credentials",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var page pageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.res.Total, page.Total))
+		assert.Equal(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res.Messages, page.Messages))
+	}
+}
+
+func TestDeleteMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      "name",
+			Value:     &v,
+		}
+		if i%2 == 1 {
+			msg.Publisher = pubID2
+			msg.Subtopic = subtopic
+		}
+
+		messages = append(messages, msg)
+	}
+
+	thSvc := thmocks.NewThingsService(map[string]string{user.ID: chanID}, nil)
+	authSvc := newAuthService()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	userToken := tok.GetValue()
+	userID := user.ID
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	audit := mocks.NewAuditRepository(nil)
+	ts := newServer(repo, cache, audit, mocks.NewPublisher(), thSvc, authSvc)
+	defer ts.Close()
+
+	failingAudit := mocks.NewAuditRepository(readers.ErrSaveDeletion)
+	failingTs := newServer(repo, mocks.NewLatestMessageRepository(chanID, messages), failingAudit, mocks.NewPublisher(), thSvc, authSvc)
+	defer failingTs.Close()
+
+	// The cases are run in order, each one deleting the messages left by
+	// the previous ones.
+	cases := []struct {
+		desc    string
+		url     string
+		token   string
+		key     string
+		status  int
+		total   uint64
+		deleted int
+	}{
+		{
+			desc:   "delete messages as thing",
+			url:    fmt.Sprintf("%s/channels/%s/messages?publisher=%s", ts.URL, chanID, pubID2),
+			key:    thingToken,
+			status: http.StatusUnauthorized,
+			total:  numOfMessages,
+		},
+		{
+			desc:   "delete messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages?publisher=%s", ts.URL, chanID, pubID2),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+			total:  numOfMessages,
+		},
+		{
+			desc:   "delete messages of other user's channel",
+			url:    fmt.Sprintf("%s/channels/%s/messages", ts.URL, otherChanID),
+			token:  userToken,
+			status: http.StatusForbidden,
+			total:  numOfMessages,
+		},
+		{
+			desc:   "delete messages with invalid time range",
+			url:    fmt.Sprintf("%s/channels/%s/messages?from=ABCD", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+			total:  numOfMessages,
+		},
+		{
+			desc:   "delete messages without audit trail",
+			url:    fmt.Sprintf("%s/channels/%s/messages?publisher=%s", failingTs.URL, chanID, pubID2),
+			token:  userToken,
+			status: http.StatusInternalServerError,
+			total:  numOfMessages,
+		},
+		{
+			desc:    "delete messages of publisher",
+			url:     fmt.Sprintf("%s/channels/%s/messages?publisher=%s", ts.URL, chanID, pubID2),
+			token:   userToken,
+			status:  http.StatusNoContent,
+			total:   numOfMessages - numOfMessages/2,
+			deleted: 1,
+		},
+		{
+			desc:    "delete messages of subtopic",
+			url:     fmt.Sprintf("%s/channels/%s/messages?subtopic=%s", ts.URL, chanID, subtopic),
+			token:   userToken,
+			status:  http.StatusNoContent,
+			total:   numOfMessages - numOfMessages/2,
+			deleted: 2,
+		},
+		{
+			desc:    "delete messages within time range",
+			url:     fmt.Sprintf("%s/channels/%s/messages?from=%d&to=%d", ts.URL, chanID, now-19, now+1),
+			token:   userToken,
+			status:  http.StatusNoContent,
+			total:   numOfMessages - numOfMessages/2 - 10,
+			deleted: 3,
+		},
+		{
+			desc:    "delete all messages",
+			url:     fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			token:   userToken,
+			status:  http.StatusNoContent,
+			total:   0,
+			deleted: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		page, err := repo.ListChannelMessages(chanID, readers.PageMetadata{})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d messages left got %d", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.deleted, len(audit.Deletions()), fmt.Sprintf("%s: expected %d deletions got %d", tc.desc, tc.deleted, len(audit.Deletions())))
+	}
+
+	latest, err := cache.ListLatestMessages(chanID, readers.PageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, uint64(0), latest.Total, fmt.Sprintf("expected no latest messages left got %d", latest.Total))
+
+	for _, d := range audit.Deletions() {
+		assert.Equal(t, chanID, d.Channel, fmt.Sprintf("expected deletion of channel %s got %s", chanID, d.Channel))
+		assert.Equal(t, userID, d.Actor, fmt.Sprintf("expected deletion by %s got %s", userID, d.Actor))
+	}
+}
+
+func TestReplayMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      "name",
+			Value:     &v,
+		})
+	}
+
+	// The channel is owned by the user who is not an admin.
+	owner := users.User{ID: ownerID, Email: "owner@example.com", Password: validPass}
+	thSvc := thmocks.NewThingsService(map[string]string{owner.ID: chanID}, nil)
+	authSvc := authmocks.NewAuthService(map[string]users.User{user.Email: user, owner.Email: owner})
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	adminToken := tok.GetValue()
+	tok, err = authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: owner.ID, Email: owner.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	ownerToken := tok.GetValue()
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	pub := mocks.NewPublisher()
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), pub, thSvc, authSvc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		contentType string
+		body        string
+		token       string
+		key         string
+		status      int
+		total       uint64
+	}{
+		{
+			desc:        "replay messages as thing",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{}`,
+			key:         thingToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "replay messages with invalid token",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{}`,
+			token:       invalid,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "replay messages of other user's channel",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, otherChanID),
+			contentType: contentType,
+			body:        `{}`,
+			token:       ownerToken,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "replay messages to other user's channel",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        fmt.Sprintf(`{"channel":"%s"}`, otherChanID),
+			token:       ownerToken,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:   "replay messages without content type",
+			url:    fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			body:   `{}`,
+			token:  ownerToken,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "replay messages with malformed body",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{"rate":"fast"}`,
+			token:       ownerToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay messages with invalid rate",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{"rate":100000}`,
+			token:       ownerToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay messages with wildcard subtopic",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{"subtopic":"a.>"}`,
+			token:       ownerToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay messages with invalid time range",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay?from=ABCD", ts.URL, chanID),
+			contentType: contentType,
+			body:        `{}`,
+			token:       ownerToken,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "replay messages of owned channel",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay?from=%d", ts.URL, chanID, now-4),
+			contentType: contentType,
+			body:        `{}`,
+			token:       ownerToken,
+			status:      http.StatusAccepted,
+			total:       5,
+		},
+		{
+			desc:        "replay messages to other channel as admin",
+			url:         fmt.Sprintf("%s/channels/%s/messages/replay?from=%d", ts.URL, chanID, now-9),
+			contentType: contentType,
+			body:        fmt.Sprintf(`{"channel":"%s","subtopic":"%s","rate":1000}`, otherChanID, subtopic),
+			token:       adminToken,
+			status:      http.StatusAccepted,
+			total:       10,
+		},
+	}
+
+	var published uint64
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         tc.url,
+			contentType: tc.contentType,
+			token:       tc.token,
+			key:         tc.key,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusAccepted {
+			continue
+		}
+
+		var body struct {
+			Total uint64 `json:"total"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected %d replayed messages got %d", tc.desc, tc.total, body.Total))
+
+		// The messages are replayed in the background.
+		published += tc.total
+		for i := 0; i < 100 && uint64(len(pub.Messages())) < published; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.Equal(t, published, uint64(len(pub.Messages())), fmt.Sprintf("%s: expected %d published messages got %d", tc.desc, published, len(pub.Messages())))
+	}
+
+	for i, msg := range pub.Messages() {
+		assert.True(t, msg.Replay, fmt.Sprintf("expected message %d to be marked as replayed", i))
+		assert.Equal(t, pubID, msg.Publisher, fmt.Sprintf("expected message %d publisher %s got %s", i, pubID, msg.Publisher))
+	}
+
+	// The messages are replayed from the oldest one, keeping the time they were created.
+	replayed := pub.Messages()[5:]
+	for i, msg := range replayed {
+		created := (now - 9 + int64(i)) * int64(time.Second)
+		assert.Equal(t, otherChanID, msg.Channel, fmt.Sprintf("expected message %d channel %s got %s", i, otherChanID, msg.Channel))
+		assert.Equal(t, subtopic, msg.Subtopic, fmt.Sprintf("expected message %d subtopic %s got %s", i, subtopic, msg.Subtopic))
+		assert.Equal(t, created, msg.Created, fmt.Sprintf("expected message %d created at %d got %d", i, created, msg.Created))
+	}
+}
+
+func TestListAndCancelReplays(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		messages = append(messages, senml.Message{
+			Channel: chanID,
+			Time:    float64(now - int64(i)),
+			Name:    "name",
+			Value:   &v,
+		})
+	}
+
+	owner := users.User{ID: ownerID, Email: "owner@example.com", Password: validPass}
+	thSvc := thmocks.NewThingsService(map[string]string{owner.ID: chanID}, nil)
+	authSvc := authmocks.NewAuthService(map[string]users.User{user.Email: user, owner.Email: owner})
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: owner.ID, Email: owner.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	ownerToken := tok.GetValue()
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	pub := mocks.NewPublisher()
+	ts := newServer(repo, mocks.NewLatestMessageRepository(chanID, messages), mocks.NewAuditRepository(nil), pub, thSvc, authSvc)
+	defer ts.Close()
+
+	// The replay of a single message per second keeps running during the test.
+	req := testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, chanID),
+		contentType: contentType,
+		token:       ownerToken,
+		body:        strings.NewReader(`{"rate":1}`),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Equal(t, http.StatusAccepted, res.StatusCode, fmt.Sprintf("expected %d got %d", http.StatusAccepted, res.StatusCode))
+
+	var started struct {
+		ID    string `json:"id"`
+		Total uint64 `json:"total"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&started)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	listCases := []struct {
+		desc   string
+		chanID string
+		token  string
+		status int
+		ids    []string
+	}{
+		{
+			desc:   "list replays with invalid token",
+			chanID: chanID,
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list replays of other user's channel",
+			chanID: otherChanID,
+			token:  ownerToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list replays of owned channel",
+			chanID: chanID,
+			token:  ownerToken,
+			status: http.StatusOK,
+			ids:    []string{started.ID},
+		},
+	}
+
+	for _, tc := range listCases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/channels/%s/messages/replay", ts.URL, tc.chanID),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body struct {
+			Replays []struct {
+				ID    string `json:"id"`
+				Total uint64 `json:"total"`
+			} `json:"replays"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var ids []string
+		for _, r := range body.Replays {
+			ids = append(ids, r.ID)
+			assert.Equal(t, started.Total, r.Total, fmt.Sprintf("%s: expected %d replayed messages got %d", tc.desc, started.Total, r.Total))
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected replays %v got %v", tc.desc, tc.ids, ids))
+	}
+
+	cancelCases := []struct {
+		desc   string
+		chanID string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "cancel replay with invalid token",
+			chanID: chanID,
+			id:     started.ID,
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "cancel replay of other user's channel",
+			chanID: otherChanID,
+			id:     started.ID,
+			token:  ownerToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "cancel non-existent replay",
+			chanID: chanID,
+			id:     invalid,
+			token:  ownerToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "cancel replay",
+			chanID: chanID,
+			id:     started.ID,
+			token:  ownerToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "cancel cancelled replay",
+			chanID: chanID,
+			id:     started.ID,
+			token:  ownerToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cancelCases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/channels/%s/messages/replay/%s", ts.URL, tc.chanID, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	assert.Less(t, uint64(len(pub.Messages())), started.Total, "expected cancelled replay to stop publishing")
+}
+
+func TestListChannelsMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	// The messages of the two channels interleave in time.
+	var messages, otherMessages, merged []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      "name",
+			Value:     &v,
+		}
+		if i%2 == 1 {
+			msg.Channel = otherChanID
+			otherMessages = append(otherMessages, msg)
+		} else {
+			messages = append(messages, msg)
+		}
+		merged = append(merged, msg)
+	}
+
+	thSvc := thmocks.NewThingsService(map[string]string{user.ID: chanID}, nil)
+	authSvc := newAuthService()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	userToken := tok.GetValue()
+
+	repo := mocks.NewChannelsMessageRepository(map[string][]readers.Message{
+		chanID:      fromSenml(messages),
+		otherChanID: fromSenml(otherMessages),
+	})
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), mocks.NewPublisher(), thSvc, authSvc)
+	defer ts.Close()
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("%d", i)
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		res    pageRes
+	}{
+		{
+			desc:   "read messages of channels",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s,%s", ts.URL, chanID, otherChanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages"},
+				Total:        uint64(len(merged)),
+				Messages:     merged[0:10],
+			},
+		},
+		{
+			desc:   "read messages of channels with offset",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s,%s&offset=5&limit=20", ts.URL, chanID, otherChanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Offset: 5, Limit: 20, Format: "messages"},
+				Total:        uint64(len(merged)),
+				Messages:     merged[5:25],
+			},
+		},
+		{
+			desc:   "read messages of repeated channel",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s,%s", ts.URL, chanID, chanID),
+			key:    thingToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages"},
+				Total:        uint64(len(messages)),
+				Messages:     messages[0:10],
+			},
+		},
+		{
+			desc:   "read messages of owned channel",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s", ts.URL, chanID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 10, Format: "messages"},
+				Total:        uint64(len(messages)),
+				Messages:     messages[0:10],
+			},
+		},
+		{
+			desc:   "read messages of other user's channel",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s,%s", ts.URL, chanID, otherChanID),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages without channels",
+			url:    fmt.Sprintf("%s/channels/messages", ts.URL),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages of too many channels",
+			url:    fmt.Sprintf("%s/channels/messages?channels=%s", ts.URL, strings.Join(tooMany, ",")),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var page pageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.res.Total, page.Total))
+		assert.ElementsMatch(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: got incorrect body from response", tc.desc))
+	}
+}
+
+func TestListGroupMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherGroupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+
+	var messages []senml.Message
+	for i := 0; i < numOfMessages; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Time:      float64(now - int64(i)),
+			Name:      "name",
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+
+	groups := map[string]things.Group{
+		groupID:      {ID: groupID, OwnerID: user.ID},
+		otherGroupID: {ID: otherGroupID, OwnerID: "other"},
+	}
+	thSvc := thmocks.NewThingsService(map[string]string{user.ID: chanID}, groups)
+	authSvc := newAuthService()
+
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	userToken := tok.GetValue()
+
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	cache := mocks.NewLatestMessageRepository(chanID, messages)
+	ts := newServer(repo, cache, mocks.NewAuditRepository(nil), mocks.NewPublisher(), thSvc, authSvc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		key    string
+		status int
+		res    pageRes
+	}{
+		{
+			desc:   "read messages of group",
+			url:    fmt.Sprintf("%s/groups/%s/messages?limit=20", ts.URL, groupID),
+			token:  userToken,
+			status: http.StatusOK,
+			res: pageRes{
+				PageMetadata: readers.PageMetadata{Limit: 20, Format: "messages"},
+				Total:        uint64(len(messages)),
+				Messages:     messages[0:20],
+			},
+		},
+		{
+			desc:   "read messages of other user's group",
+			url:    fmt.Sprintf("%s/groups/%s/messages", ts.URL, otherGroupID),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read messages of group with invalid token",
+			url:    fmt.Sprintf("%s/groups/%s/messages", ts.URL, groupID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages of group as thing",
+			url:    fmt.Sprintf("%s/groups/%s/messages", ts.URL, groupID),
+			key:    thingToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages of group with invalid limit",
+			url:    fmt.Sprintf("%s/groups/%s/messages?limit=1001", ts.URL, groupID),
+			token:  userToken,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var page pageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.res.Total, page.Total))
+		assert.ElementsMatch(t, tc.res.Messages, page.Messages, fmt.Sprintf("%s: got incorrect body from response", tc.desc))
+	}
+}
+
 type pageRes struct {
 	readers.PageMetadata
 	Total    uint64          `json:"total"`
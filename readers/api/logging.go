@@ -53,3 +53,29 @@ func (lm *loggingMiddleware) ListAllMessages(rpm readers.PageMetadata) (page rea
 
 	return lm.svc.ListAllMessages(rpm)
 }
+
+func (lm *loggingMiddleware) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (page readers.MessagesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_messages_by_channels for channels %v with query %v took %s to complete", chanIDs, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListMessagesByChannels(chanIDs, rpm)
+}
+
+func (lm *loggingMiddleware) DeleteMessages(chanID string, rpm readers.PageMetadata) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method delete_messages for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DeleteMessages(chanID, rpm)
+}
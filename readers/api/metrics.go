@@ -46,3 +46,21 @@ func (mm *metricsMiddleware) ListAllMessages(rpm readers.PageMetadata) (readers.
 
 	return mm.svc.ListAllMessages(rpm)
 }
+
+func (mm *metricsMiddleware) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_messages_by_channels").Add(1)
+		mm.latency.With("method", "list_messages_by_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListMessagesByChannels(chanIDs, rpm)
+}
+
+func (mm *metricsMiddleware) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_messages").Add(1)
+		mm.latency.With("method", "delete_messages").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DeleteMessages(chanID, rpm)
+}
@@ -4,11 +4,19 @@
 package api
 
 import (
+	"strings"
+
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/readers"
 )
 
-const maxLimitSize = 1000
+const (
+	maxLimitSize      = 1000
+	maxChannels       = 100
+	maxPayloadFilters = 10
+	maxPayloadFields  = 10
+	maxReplayRate     = 1000
+)
 
 type listChannelMessagesReq struct {
 	chanID   string
@@ -43,6 +51,29 @@ func (req listChannelMessagesReq) validate() error {
 		return apiutil.ErrInvalidComparator
 	}
 
+	return validatePayloadQuery(req.pageMeta)
+}
+
+type listLatestMessagesReq struct {
+	chanID   string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req listLatestMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
 	return nil
 }
 
@@ -74,5 +105,220 @@ func (req listAllMessagesReq) validate() error {
 		return apiutil.ErrInvalidComparator
 	}
 
+	return validatePayloadQuery(req.pageMeta)
+}
+
+type listChannelsMessagesReq struct {
+	chanIDs  []string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req listChannelsMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.chanIDs) == 0 {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.chanIDs) > maxChannels {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if req.pageMeta.Offset < 0 {
+		return apiutil.ErrOffsetSize
+	}
+
+	if req.pageMeta.Comparator != "" &&
+		req.pageMeta.Comparator != readers.EqualKey &&
+		req.pageMeta.Comparator != readers.LowerThanKey &&
+		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
+		req.pageMeta.Comparator != readers.GreaterThanKey &&
+		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+		return apiutil.ErrInvalidComparator
+	}
+
+	return validatePayloadQuery(req.pageMeta)
+}
+
+type listGroupMessagesReq struct {
+	groupID  string
+	token    string
+	pageMeta readers.PageMetadata
+}
+
+func (req listGroupMessagesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if req.pageMeta.Offset < 0 {
+		return apiutil.ErrOffsetSize
+	}
+
+	if req.pageMeta.Comparator != "" &&
+		req.pageMeta.Comparator != readers.EqualKey &&
+		req.pageMeta.Comparator != readers.LowerThanKey &&
+		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
+		req.pageMeta.Comparator != readers.GreaterThanKey &&
+		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+		return apiutil.ErrInvalidComparator
+	}
+
+	return validatePayloadQuery(req.pageMeta)
+}
+
+type deleteMessagesReq struct {
+	chanID   string
+	token    string
+	pageMeta readers.PageMetadata
+}
+
+func (req deleteMessagesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
 	return nil
 }
+
+type replayMessagesReq struct {
+	chanID   string
+	token    string
+	pageMeta readers.PageMetadata
+	Channel  string  `json:"channel,omitempty"`
+	Subtopic string  `json:"subtopic,omitempty"`
+	Rate     float64 `json:"rate,omitempty"`
+}
+
+func (req replayMessagesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Rate <= 0 || req.Rate > maxReplayRate {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if strings.ContainsAny(req.Subtopic, "*>") {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return validatePayloadQuery(req.pageMeta)
+}
+
+type listReplaysReq struct {
+	chanID string
+	token  string
+}
+
+func (req listReplaysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type cancelReplayReq struct {
+	chanID   string
+	replayID string
+	token    string
+}
+
+func (req cancelReplayReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" || req.replayID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+// validatePayloadQuery checks the payload filters and projection, which apply
+// only to the JSON messages.
+func validatePayloadQuery(pm readers.PageMetadata) error {
+	if len(pm.Filters) == 0 && len(pm.Fields) == 0 {
+		return nil
+	}
+
+	if pm.Format == "" || pm.Format == defFormat {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if len(pm.Filters) > maxPayloadFilters || len(pm.Fields) > maxPayloadFields {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	for _, f := range pm.Filters {
+		if !validPayloadPath(f.Path) {
+			return apiutil.ErrInvalidQueryParams
+		}
+
+		switch f.Comparator {
+		case "", readers.EqualKey:
+		case readers.LowerThanKey, readers.LowerThanEqualKey, readers.GreaterThanKey, readers.GreaterThanEqualKey:
+			// Only the numbers are ordered.
+			if _, ok := f.Value.(float64); !ok {
+				return apiutil.ErrInvalidComparator
+			}
+		default:
+			return apiutil.ErrInvalidComparator
+		}
+	}
+
+	// The projected fields mustn't contain each other.
+	for i, f := range pm.Fields {
+		if !validPayloadPath(f) {
+			return apiutil.ErrInvalidQueryParams
+		}
+		for _, g := range pm.Fields[i+1:] {
+			if f == g || strings.HasPrefix(f, g+".") || strings.HasPrefix(g, f+".") {
+				return apiutil.ErrInvalidQueryParams
+			}
+		}
+	}
+
+	return nil
+}
+
+// validPayloadPath checks that the path consists of non-empty keys. The keys
+// can't contain the separator of the flattened payload fields.
+func validPayloadPath(path string) bool {
+	for _, key := range strings.Split(path, ".") {
+		if key == "" || strings.Contains(key, "/") {
+			return false
+		}
+	}
+	return true
+}
@@ -5,12 +5,19 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/readers"
 )
 
-var _ mainflux.Response = (*listMessagesRes)(nil)
+var (
+	_ mainflux.Response = (*listMessagesRes)(nil)
+	_ mainflux.Response = (*deleteMessagesRes)(nil)
+	_ mainflux.Response = (*replayMessagesRes)(nil)
+	_ mainflux.Response = (*listReplaysRes)(nil)
+	_ mainflux.Response = (*cancelReplayRes)(nil)
+)
 
 type listMessagesRes struct {
 	readers.PageMetadata
@@ -29,3 +36,74 @@ func (res listMessagesRes) Code() int {
 func (res listMessagesRes) Empty() bool {
 	return false
 }
+
+type deleteMessagesRes struct{}
+
+func (res deleteMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deleteMessagesRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res deleteMessagesRes) Empty() bool {
+	return true
+}
+
+type replayMessagesRes struct {
+	ID    string `json:"id"`
+	Total uint64 `json:"total"`
+}
+
+func (res replayMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayMessagesRes) Code() int {
+	return http.StatusAccepted
+}
+
+func (res replayMessagesRes) Empty() bool {
+	return false
+}
+
+type replayRes struct {
+	ID       string    `json:"id"`
+	Channel  string    `json:"channel"`
+	Subtopic string    `json:"subtopic,omitempty"`
+	Rate     float64   `json:"rate"`
+	Total    uint64    `json:"total"`
+	Replayed uint64    `json:"replayed"`
+	Started  time.Time `json:"started"`
+}
+
+type listReplaysRes struct {
+	Replays []replayRes `json:"replays"`
+}
+
+func (res listReplaysRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res listReplaysRes) Code() int {
+	return http.StatusOK
+}
+
+func (res listReplaysRes) Empty() bool {
+	return false
+}
+
+type cancelReplayRes struct{}
+
+func (res cancelReplayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res cancelReplayRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res cancelReplayRes) Empty() bool {
+	return true
+}
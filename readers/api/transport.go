@@ -6,7 +6,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
@@ -25,6 +28,9 @@ const (
 	offsetKey      = "offset"
 	limitKey       = "limit"
 	formatKey      = "format"
+	channelsKey    = "channels"
+	filterKey      = "filter"
+	fieldsKey      = "fields"
 	subtopicKey    = "subtopic"
 	publisherKey   = "publisher"
 	protocolKey    = "protocol"
@@ -37,8 +43,10 @@ const (
 	fromKey        = "from"
 	toKey          = "to"
 	defLimit       = 10
+	defLatestLimit = 100
 	defOffset      = 0
 	defFormat      = "messages"
+	defReplayRate  = 100
 )
 
 var (
@@ -49,7 +57,7 @@ var (
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, svcName string, logger logger.Logger) http.Handler {
+func MakeHandler(svc readers.MessageRepository, cache readers.LatestMessageRepository, audit readers.AuditRepository, replayer readers.Replayer, tc mainflux.ThingsServiceClient, ac mainflux.AuthServiceClient, svcName string, logger logger.Logger) http.Handler {
 	things = tc
 	auth = ac
 
@@ -58,12 +66,54 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 	}
 
 	mux := bone.New()
+	mux.Get("/channels/messages", kithttp.NewServer(
+		listChannelsMessagesEndpoint(svc),
+		decodeListChannelsMessages,
+		encodeResponse,
+		opts...,
+	))
 	mux.Get("/channels/:chanID/messages", kithttp.NewServer(
 		ListChannelMessagesEndpoint(svc),
 		decodeListChannelMessages,
 		encodeResponse,
 		opts...,
 	))
+	mux.Delete("/channels/:chanID/messages", kithttp.NewServer(
+		deleteMessagesEndpoint(svc, cache, audit),
+		decodeDeleteMessages,
+		encodeResponse,
+		opts...,
+	))
+	mux.Post("/channels/:chanID/messages/replay", kithttp.NewServer(
+		replayMessagesEndpoint(replayer),
+		decodeReplayMessages,
+		encodeResponse,
+		opts...,
+	))
+	mux.Get("/channels/:chanID/messages/replay", kithttp.NewServer(
+		listReplaysEndpoint(replayer),
+		decodeListReplays,
+		encodeResponse,
+		opts...,
+	))
+	mux.Delete("/channels/:chanID/messages/replay/:replayID", kithttp.NewServer(
+		cancelReplayEndpoint(replayer),
+		decodeCancelReplay,
+		encodeResponse,
+		opts...,
+	))
+	mux.Get("/channels/:chanID/messages/latest", kithttp.NewServer(
+		listLatestMessagesEndpoint(cache),
+		decodeListLatestMessages,
+		encodeResponse,
+		opts...,
+	))
+	mux.Get("/groups/:groupID/messages", kithttp.NewServer(
+		listGroupMessagesEndpoint(svc),
+		decodeListGroupMessages,
+		encodeResponse,
+		opts...,
+	))
 	mux.Get("/messages", kithttp.NewServer(
 		listAllMessagesEndpoint(svc),
 		decodeListAllMessages,
@@ -77,196 +127,345 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 	return mux
 }
 
-func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{}, error) {
-	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+func decodeListChannelMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
-	if err != nil {
-		return nil, err
+	req := listChannelMessagesReq{
+		chanID:   bone.GetValue(r, "chanID"),
+		token:    apiutil.ExtractBearerToken(r),
+		key:      apiutil.ExtractThingKey(r),
+		pageMeta: pm,
 	}
 
-	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	return req, nil
+}
+
+func decodeListChannelsMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
-	if err != nil {
-		return nil, err
+	req := listChannelsMessagesReq{
+		chanIDs:  readChannels(r),
+		token:    apiutil.ExtractBearerToken(r),
+		key:      apiutil.ExtractThingKey(r),
+		pageMeta: pm,
 	}
 
-	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	return req, nil
+}
+
+func decodeListGroupMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
-	if err != nil {
-		return nil, err
+	req := listGroupMessagesReq{
+		groupID:  bone.GetValue(r, "groupID"),
+		token:    apiutil.ExtractBearerToken(r),
+		pageMeta: pm,
 	}
 
-	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	return req, nil
+}
+
+func decodeReplayMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	pm, err := decodePageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	v, err := apiutil.ReadFloatQuery(r, valueKey, 0)
+	req := replayMessagesReq{
+		chanID:   bone.GetValue(r, "chanID"),
+		token:    apiutil.ExtractBearerToken(r),
+		pageMeta: pm,
+		Rate:     defReplayRate,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListReplays(_ context.Context, r *http.Request) (interface{}, error) {
+	req := listReplaysReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeCancelReplay(_ context.Context, r *http.Request) (interface{}, error) {
+	req := cancelReplayReq{
+		chanID:   bone.GetValue(r, "chanID"),
+		replayID: bone.GetValue(r, "replayID"),
+		token:    apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func decodeListLatestMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
 		return nil, err
 	}
 
-	comparator, err := apiutil.ReadStringQuery(r, comparatorKey, "")
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLatestLimit)
 	if err != nil {
 		return nil, err
 	}
 
-	vs, err := apiutil.ReadStringQuery(r, stringValueKey, "")
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	vd, err := apiutil.ReadStringQuery(r, dataValueKey, "")
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
+	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
 	if err != nil {
 		return nil, err
 	}
 
-	req := listChannelMessagesReq{
+	req := listLatestMessagesReq{
 		chanID: bone.GetValue(r, "chanID"),
 		token:  apiutil.ExtractBearerToken(r),
 		key:    apiutil.ExtractThingKey(r),
 		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			From:        from,
-			To:          to,
+			Offset:    offset,
+			Limit:     limit,
+			Subtopic:  subtopic,
+			Publisher: publisher,
+			Protocol:  protocol,
+			Name:      name,
 		},
 	}
 
-	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
-	if err != nil && err != apiutil.ErrNotFoundParam {
+	return req, nil
+}
+
+func decodeListAllMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := decodePageMetadata(r)
+	if err != nil {
 		return nil, err
 	}
-	if err == nil {
-		req.pageMeta.BoolValue = vb
+
+	req := listAllMessagesReq{
+		token:    apiutil.ExtractBearerToken(r),
+		key:      apiutil.ExtractThingKey(r),
+		pageMeta: pm,
 	}
 
 	return req, nil
 }
 
-func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, error) {
+// decodePageMetadata reads the paging and the filters of the message queries.
+func decodePageMetadata(r *http.Request) (readers.PageMetadata, error) {
 	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	protocol, err := apiutil.ReadStringQuery(r, protocolKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	name, err := apiutil.ReadStringQuery(r, nameKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	v, err := apiutil.ReadFloatQuery(r, valueKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	comparator, err := apiutil.ReadStringQuery(r, comparatorKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vs, err := apiutil.ReadStringQuery(r, stringValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vd, err := apiutil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	req := listAllMessagesReq{
-		token: apiutil.ExtractBearerToken(r),
-		key:   apiutil.ExtractThingKey(r),
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			From:        from,
-			To:          to,
-		},
+	filters, err := readPayloadFilters(r)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Publisher:   publisher,
+		Protocol:    protocol,
+		Name:        name,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		From:        from,
+		To:          to,
+		Filters:     filters,
+		Fields:      bone.GetQuery(r, fieldsKey),
 	}
 
 	vb, err := apiutil.ReadBoolQuery(r, boolValueKey, false)
 	if err != nil && err != apiutil.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 	if err == nil {
-		req.pageMeta.BoolValue = vb
+		pm.BoolValue = vb
+	}
+
+	return pm, nil
+}
+
+// readPayloadFilters reads the payload filters given as <path>:<comparator>:<value>,
+// e.g. engine.temp:gt:80. The value is a number, a bool or, if neither or
+// double quoted, a string.
+func readPayloadFilters(r *http.Request) ([]readers.PayloadFilter, error) {
+	var filters []readers.PayloadFilter
+	for _, f := range bone.GetQuery(r, filterKey) {
+		parts := strings.SplitN(f, ":", 3)
+		if len(parts) != 3 {
+			return nil, apiutil.ErrInvalidQueryParams
+		}
+
+		var value interface{} = parts[2]
+		switch v := parts[2]; {
+		case len(v) > 1 && strings.HasPrefix(v, `"`) && strings.HasSuffix(v, `"`):
+			value = v[1 : len(v)-1]
+		case v == "true", v == "false":
+			value = v == "true"
+		default:
+			if n, err := strconv.ParseFloat(v, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+				value = n
+			}
+		}
+
+		filters = append(filters, readers.PayloadFilter{
+			Path:       parts[0],
+			Comparator: parts[1],
+			Value:      value,
+		})
+	}
+
+	return filters, nil
+}
+
+// readChannels returns the distinct channel IDs of the channels query
+// parameter, given either as a comma separated list or repeated.
+func readChannels(r *http.Request) []string {
+	seen := make(map[string]bool)
+	var chanIDs []string
+	for _, id := range bone.GetQuery(r, channelsKey) {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		chanIDs = append(chanIDs, id)
+	}
+
+	return chanIDs
+}
+
+func decodeDeleteMessages(_ context.Context, r *http.Request) (interface{}, error) {
+	format, err := apiutil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	subtopic, err := apiutil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := apiutil.ReadFloatQuery(r, fromKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := apiutil.ReadFloatQuery(r, toKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	req := deleteMessagesReq{
+		chanID: bone.GetValue(r, "chanID"),
+		token:  apiutil.ExtractBearerToken(r),
+		pageMeta: readers.PageMetadata{
+			Format:    format,
+			Subtopic:  subtopic,
+			Publisher: publisher,
+			From:      from,
+			To:        to,
+		},
 	}
 
 	return req, nil
@@ -298,14 +497,22 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrMissingID,
 		err == apiutil.ErrLimitSize,
 		err == apiutil.ErrOffsetSize,
-		err == apiutil.ErrInvalidComparator:
+		err == apiutil.ErrInvalidComparator,
+		errors.Contains(err, readers.ErrUnsupportedQuery),
+		errors.Contains(err, readers.ErrInvalidFormat):
 		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
 	case errors.Contains(err, errors.ErrAuthorization):
 		w.WriteHeader(http.StatusForbidden)
-	case errors.Contains(err, readers.ErrReadMessages):
+	case errors.Contains(err, readers.ErrReplayNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, readers.ErrReadMessages),
+		errors.Contains(err, readers.ErrDeleteMessages),
+		errors.Contains(err, readers.ErrSaveDeletion):
 		w.WriteHeader(http.StatusInternalServerError)
 
 	default:
@@ -320,31 +527,105 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 }
 
-func authorize(ctx context.Context, token, key, chanID string) (err error) {
+func authorize(ctx context.Context, token, key, chanID, subtopic string) (err error) {
 	switch {
 	case token != "":
-		user, err := auth.Identify(ctx, &mainflux.Token{Value: token})
-		if err != nil {
-			e, ok := status.FromError(err)
-			if ok && e.Code() == codes.PermissionDenied {
-				return errors.Wrap(errUserAccess, err)
-			}
-			return err
+		_, err := authorizeOwner(ctx, token, chanID)
+		return err
+	default:
+		if _, err := things.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: chanID, Subtopic: subtopic}); err != nil {
+			return errors.Wrap(errThingAccess, err)
 		}
-		if _, err = things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.Id, ChanID: chanID}); err != nil {
-			e, ok := status.FromError(err)
-			if ok && e.Code() == codes.PermissionDenied {
-				return errors.Wrap(errUserAccess, err)
+		return nil
+	}
+}
+
+// authorizeChannels checks that all the channels can be read by the user
+// identified by the token, or by the thing identified by the key.
+func authorizeChannels(ctx context.Context, token, key string, chanIDs []string, subtopic string) error {
+	if token == "" {
+		for _, chanID := range chanIDs {
+			if err := authorize(ctx, token, key, chanID, subtopic); err != nil {
+				return err
 			}
-			return err
 		}
 		return nil
-	default:
-		if _, err := things.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: chanID}); err != nil {
-			return errors.Wrap(errThingAccess, err)
+	}
+
+	userID, err := identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	for _, chanID := range chanIDs {
+		if err := isChannelOwner(ctx, userID, chanID); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+// authorizeOwner checks that the user identified by the token owns the
+// channel and returns the user ID.
+func authorizeOwner(ctx context.Context, token, chanID string) (string, error) {
+	userID, err := identify(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if err := isChannelOwner(ctx, userID, chanID); err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+// groupChannels returns the channels of the group owned by the user
+// identified by the token.
+func groupChannels(ctx context.Context, token, groupID string) ([]string, error) {
+	userID, err := identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	res, err := things.GetGroupChannels(ctx, &mainflux.GroupChannelsReq{Owner: userID, GroupID: groupID})
+	if err != nil {
+		e, ok := status.FromError(err)
+		if ok && e.Code() == codes.PermissionDenied {
+			return nil, errors.Wrap(errUserAccess, err)
+		}
+		return nil, err
+	}
+	return res.GetValues(), nil
+}
+
+func identify(ctx context.Context, token string) (string, error) {
+	user, err := auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		e, ok := status.FromError(err)
+		if ok && e.Code() == codes.PermissionDenied {
+			return "", errors.Wrap(errUserAccess, err)
+		}
+		return "", err
+	}
+	return user.Id, nil
+}
+
+func isChannelOwner(ctx context.Context, userID, chanID string) error {
+	if _, err := things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: userID, ChanID: chanID}); err != nil {
+		e, ok := status.FromError(err)
+		if ok && e.Code() == codes.PermissionDenied {
+			return errors.Wrap(errUserAccess, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// authorizeReplay checks that the user identified by the token is the admin,
+// or owns all the channels of the replay.
+func authorizeReplay(ctx context.Context, token string, chanIDs ...string) error {
+	if err := authorizeAdmin(ctx, "authorities", "member", token); err == nil {
 		return nil
 	}
+	return authorizeChannels(ctx, token, "", chanIDs, "")
 }
 
 func authorizeAdmin(ctx context.Context, object, relation, token string) error {
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/gocql/gocql"
+)
+
+var _ readers.AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	session *gocql.Session
+}
+
+// NewAuditRepository returns new Cassandra audit trail of the message
+// deletions.
+func NewAuditRepository(session *gocql.Session) readers.AuditRepository {
+	return auditRepository{
+		session: session,
+	}
+}
+
+func (ar auditRepository) Save(d readers.Deletion) error {
+	q := `INSERT INTO deletions (channel, time, id, actor, subtopic, publisher, format, from_time, to_time)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	id := gocql.UUIDFromTime(d.Time)
+	if err := ar.session.Query(q, d.Channel, d.Time, id, d.Actor, d.Subtopic, d.Publisher, d.Format, d.From, d.To).Exec(); err != nil {
+		return errors.Wrap(readers.ErrSaveDeletion, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cassandra contains repository implementations using Cassandra as
+// the underlying database.
+package cassandra
@@ -0,0 +1,467 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/gocql/gocql"
+)
+
+const (
+	// Table for SenML messages
+	defTable = "messages"
+
+	// SenML time is expressed in seconds and JSON created in nanoseconds,
+	// so the partitions of both formats span a single day.
+	senmlBucket = 24 * 60 * 60
+	jsonBucket  = senmlBucket * 1e9
+
+	// Maximum number of rows removed by a single batch.
+	deleteBatchSize = 100
+)
+
+// formatTable matches the formats that are valid unquoted CQL table names.
+var formatTable = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,47}$`)
+
+var _ readers.MessageRepository = (*cassandraRepository)(nil)
+
+type cassandraRepository struct {
+	session *gocql.Session
+}
+
+// New instantiates Cassandra message repository.
+func New(session *gocql.Session) readers.MessageRepository {
+	return cassandraRepository{
+		session: session,
+	}
+}
+
+func (cr cassandraRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return cr.readAll(nil, rpm)
+}
+
+func (cr cassandraRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return cr.readAll([]string{chanID}, rpm)
+}
+
+func (cr cassandraRepository) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm, Messages: []readers.Message{}}, nil
+	}
+
+	return cr.readAll(chanIDs, rpm)
+}
+
+// readAll walks the day partitions of the given channels, or of all the
+// channels if none are given, from the newest to the oldest one. The matching
+// messages of each partition are counted first, so that only the partitions
+// that contain the requested page are read.
+func (cr cassandraRepository) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	// The payload is stored as text, which isn't queried by its fields.
+	if len(rpm.Filters) > 0 || len(rpm.Fields) > 0 {
+		return readers.MessagesPage{}, readers.ErrUnsupportedQuery
+	}
+
+	table, order, size, err := formatOf(rpm.Format)
+	if err != nil {
+		return readers.MessagesPage{}, err
+	}
+
+	days, err := cr.buckets(table, chanIDs, rpm, size)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	condition, values := fmtCondition(rpm, order)
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+
+	skip := rpm.Offset
+	for _, d := range days {
+		counts := make([]uint64, len(d.channels))
+		var total uint64
+		for i, ch := range d.channels {
+			n, err := cr.count(table, ch, d.bucket, condition, values)
+			if err != nil {
+				if isUnknownTable(err) {
+					return readers.MessagesPage{}, nil
+				}
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			counts[i] = n
+			total += n
+		}
+		page.Total += total
+
+		var need uint64
+		if rpm.Limit > 0 {
+			if uint64(len(page.Messages)) >= rpm.Limit {
+				continue
+			}
+			need = rpm.Limit - uint64(len(page.Messages))
+		}
+		if skip >= total {
+			skip -= total
+			continue
+		}
+
+		var msgs []message
+		for i, ch := range d.channels {
+			if counts[i] == 0 {
+				continue
+			}
+			limit := uint64(0)
+			if need > 0 {
+				limit = skip + need
+			}
+			m, err := cr.read(table, ch, d.bucket, condition, values, limit)
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msgs = append(msgs, m...)
+		}
+
+		sort.SliceStable(msgs, func(i, j int) bool {
+			return msgs[i].time > msgs[j].time
+		})
+		msgs = msgs[skip:]
+		if need > 0 && uint64(len(msgs)) > need {
+			msgs = msgs[:need]
+		}
+		skip = 0
+
+		for _, m := range msgs {
+			page.Messages = append(page.Messages, m.msg)
+		}
+	}
+
+	return page, nil
+}
+
+func (cr cassandraRepository) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	table, order, size, err := formatOf(rpm.Format)
+	if err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	days, err := cr.buckets(table, []string{chanID}, rpm, size)
+	if err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	// Only the filters that apply to the deletion are kept.
+	rpm = readers.PageMetadata{
+		Subtopic:  rpm.Subtopic,
+		Publisher: rpm.Publisher,
+		From:      rpm.From,
+		To:        rpm.To,
+	}
+	condition, values := fmtCondition(rpm, order)
+
+	for _, d := range days {
+		if err := cr.deleteBucket(table, order, chanID, d.bucket, condition, values); err != nil {
+			if isUnknownTable(err) {
+				return nil
+			}
+			return errors.Wrap(readers.ErrDeleteMessages, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteBucket removes the matching messages of the day partition. Since the
+// rows can only be deleted by their primary key, the keys of the matching
+// messages are read first. Without a condition, the whole partition is
+// removed at once.
+func (cr cassandraRepository) deleteBucket(table, order, chanID string, bucket int64, condition string, values []interface{}) error {
+	if condition == "" {
+		q := fmt.Sprintf(`DELETE FROM %s WHERE channel = ? AND bucket = ?`, table)
+		if err := cr.session.Query(q, chanID, bucket).Exec(); err != nil {
+			return err
+		}
+		q = `DELETE FROM buckets WHERE table_name = ? AND channel = ? AND bucket = ?`
+		return cr.session.Query(q, table, chanID, bucket).Exec()
+	}
+
+	q := fmt.Sprintf(`SELECT %s, id FROM %s WHERE channel = ? AND bucket = ?%s ALLOW FILTERING`, order, table, condition)
+	args := append([]interface{}{chanID, bucket}, values...)
+	iter := cr.session.Query(q, args...).Iter()
+
+	var (
+		id      gocql.UUID
+		ts      float64
+		created int64
+	)
+	dest := []interface{}{&ts, &id}
+	if order == "created" {
+		dest[0] = &created
+	}
+
+	del := fmt.Sprintf(`DELETE FROM %s WHERE channel = ? AND bucket = ? AND %s = ? AND id = ?`, table, order)
+	batch := cr.session.NewBatch(gocql.UnloggedBatch)
+	for iter.Scan(dest...) {
+		var key interface{} = ts
+		if order == "created" {
+			key = created
+		}
+		batch.Query(del, chanID, bucket, key, id)
+
+		if batch.Size() == deleteBatchSize {
+			if err := cr.session.ExecuteBatch(batch); err != nil {
+				iter.Close()
+				return err
+			}
+			batch = cr.session.NewBatch(gocql.UnloggedBatch)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+
+	if batch.Size() > 0 {
+		return cr.session.ExecuteBatch(batch)
+	}
+
+	return nil
+}
+
+type day struct {
+	bucket   int64
+	channels []string
+}
+
+// buckets returns the day partitions of the given channels, or of all the
+// channels if none are given, within the requested time range, sorted from
+// the newest to the oldest one.
+func (cr cassandraRepository) buckets(table string, chanIDs []string, rpm readers.PageMetadata, size float64) ([]day, error) {
+	from, to := int64(math.MinInt64), int64(math.MaxInt64)
+	if rpm.From != 0 {
+		from = bucket(rpm.From, size)
+	}
+	if rpm.To != 0 {
+		to = bucket(rpm.To, size)
+	}
+
+	var queries []*gocql.Query
+	switch len(chanIDs) {
+	case 0:
+		q := `SELECT channel, bucket FROM buckets WHERE table_name = ? ALLOW FILTERING`
+		queries = append(queries, cr.session.Query(q, table))
+	default:
+		q := `SELECT channel, bucket FROM buckets WHERE table_name = ? AND channel = ? AND bucket >= ? AND bucket <= ?`
+		for _, chanID := range chanIDs {
+			queries = append(queries, cr.session.Query(q, table, chanID, from, to))
+		}
+	}
+
+	idx := map[int64]int{}
+	var days []day
+	var ch string
+	var b int64
+	for _, q := range queries {
+		iter := q.Iter()
+		for iter.Scan(&ch, &b) {
+			if b < from || b > to {
+				continue
+			}
+			i, ok := idx[b]
+			if !ok {
+				i = len(days)
+				idx[b] = i
+				days = append(days, day{bucket: b})
+			}
+			days[i].channels = append(days[i].channels, ch)
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].bucket > days[j].bucket
+	})
+
+	return days, nil
+}
+
+func (cr cassandraRepository) count(table, chanID string, bucket int64, condition string, values []interface{}) (uint64, error) {
+	q := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE channel = ? AND bucket = ?%s ALLOW FILTERING`, table, condition)
+
+	var n int64
+	args := append([]interface{}{chanID, bucket}, values...)
+	if err := cr.session.Query(q, args...).Scan(&n); err != nil {
+		return 0, err
+	}
+
+	return uint64(n), nil
+}
+
+type message struct {
+	time float64
+	msg  readers.Message
+}
+
+func (cr cassandraRepository) read(table, chanID string, bucket int64, condition string, values []interface{}, limit uint64) ([]message, error) {
+	olq := ""
+	if limit > 0 {
+		olq = fmt.Sprintf(" LIMIT %d", limit)
+	}
+	args := append([]interface{}{chanID, bucket}, values...)
+
+	var msgs []message
+	switch table {
+	case defTable:
+		q := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+            value, string_value, bool_value, data_value, sum, time, update_time
+            FROM messages WHERE channel = ? AND bucket = ?%s%s ALLOW FILTERING`, condition, olq)
+		iter := cr.session.Query(q, args...).Iter()
+		for {
+			var msg senml.Message
+			ok := iter.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Name, &msg.Unit,
+				&msg.Value, &msg.StringValue, &msg.BoolValue, &msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+			if !ok {
+				break
+			}
+			msgs = append(msgs, message{time: msg.Time, msg: msg})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		q := fmt.Sprintf(`SELECT channel, created, subtopic, publisher, protocol, payload
+            FROM %s WHERE channel = ? AND bucket = ?%s%s ALLOW FILTERING`, table, condition, olq)
+		iter := cr.session.Query(q, args...).Iter()
+		for {
+			var msg jsonMessage
+			if !iter.Scan(&msg.Channel, &msg.Created, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Payload) {
+				break
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				iter.Close()
+				return nil, err
+			}
+			msgs = append(msgs, message{time: float64(msg.Created), msg: m})
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return msgs, nil
+}
+
+func fmtCondition(rpm readers.PageMetadata, order string) (string, []interface{}) {
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return "", nil
+	}
+	json.Unmarshal(meta, &query)
+
+	condition := ""
+	var values []interface{}
+	for name := range query {
+		switch name {
+		case
+			"subtopic",
+			"publisher",
+			"name",
+			"protocol":
+			condition = fmt.Sprintf(`%s AND %s = ?`, condition, name)
+			values = append(values, query[name])
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s ?`, condition, comparator)
+			values = append(values, rpm.Value)
+		case "vb":
+			condition = fmt.Sprintf(`%s AND bool_value = ?`, condition)
+			values = append(values, rpm.BoolValue)
+		case "vs":
+			condition = fmt.Sprintf(`%s AND string_value = ?`, condition)
+			values = append(values, rpm.StringValue)
+		case "vd":
+			condition = fmt.Sprintf(`%s AND data_value = ?`, condition)
+			values = append(values, rpm.DataValue)
+		case "from":
+			condition = fmt.Sprintf(`%s AND %s >= ?`, condition, order)
+			values = append(values, timeValue(rpm.From, order))
+		case "to":
+			condition = fmt.Sprintf(`%s AND %s < ?`, condition, order)
+			values = append(values, timeValue(rpm.To, order))
+		}
+	}
+
+	return condition, values
+}
+
+// timeValue converts the time range bound to the type of the column it's
+// compared with.
+func timeValue(v float64, order string) interface{} {
+	if order == "created" {
+		return int64(v)
+	}
+	return v
+}
+
+type jsonMessage struct {
+	Channel   string
+	Created   int64
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Payload   string
+}
+
+func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}{},
+	}
+	pld := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(msg.Payload), &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+	return ret, nil
+}
+
+// formatOf returns the table, the ordering column and the partition size of
+// the messages of the format. The format is used as the table name, so only
+// the formats that are valid CQL identifiers are accepted.
+func formatOf(format string) (string, string, float64, error) {
+	if format == "" || format == defTable {
+		return defTable, "time", senmlBucket, nil
+	}
+	if !formatTable.MatchString(format) {
+		return "", "", 0, readers.ErrInvalidFormat
+	}
+
+	return format, "created", jsonBucket, nil
+}
+
+func bucket(ts, size float64) int64 {
+	return int64(math.Floor(ts / size))
+}
+
+func isUnknownTable(err error) bool {
+	e, ok := err.(gocql.RequestError)
+	return ok && e.Code() == gocql.ErrCodeInvalid && strings.Contains(e.Message(), "unconfigured table")
+}
@@ -0,0 +1,968 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cassandra_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cwriter "github.com/MainfluxLabs/mainflux/consumers/writers/cassandra"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	creader "github.com/MainfluxLabs/mainflux/readers/cassandra"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic    = "subtopic"
+	msgsNum     = 101
+	limit       = 10
+	valueFields = 5
+	zeroOffset  = 0
+	mqttProt    = "mqtt"
+	httpProt    = "http"
+	msgName     = "temperature"
+	msgFormat   = "messages"
+	format1     = "format1"
+	format2     = "format2"
+	wrongID     = "0"
+	noLimit     = 0
+)
+
+var (
+	v   float64 = 5
+	vs          = "value"
+	vb          = true
+	vd          = "dataValue"
+	sum float64 = 42
+
+	idProvider = uuid.New()
+)
+
+func TestListChannelMessagesSenML(t *testing.T) {
+	writer := cwriter.New(session)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	wrongID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	// Since messages are not saved in natural order,
+	// cases that return subset of messages are only
+	// checking data result set size, but not content.
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read messages page for existing channel": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		"read messages page for non-existent channel": {
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages last page": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages[msgsNum-20 : msgsNum]),
+			},
+		},
+		"read messages with non-existent subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   zeroOffset,
+				Limit:    msgsNum,
+				Subtopic: "not-present",
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages with subtopic": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   zeroOffset,
+				Limit:    uint64(len(queryMsgs)),
+				Subtopic: subtopic,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with publisher": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    zeroOffset,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with wrong format": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Format:    "messagess",
+				Offset:    zeroOffset,
+				Limit:     uint64(len(queryMsgs)),
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    0,
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages with protocol": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:   zeroOffset,
+				Limit:    uint64(len(queryMsgs)),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with name": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  limit,
+				Name:   msgName,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs[0:limit]),
+			},
+		},
+		"read messages with value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  limit,
+				Value:  v,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with value and equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     zeroOffset,
+				Limit:      limit,
+				Value:      v,
+				Comparator: readers.EqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with value and lower-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     zeroOffset,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with value and lower-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     zeroOffset,
+				Limit:      limit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with value and greater-than comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     zeroOffset,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with value and greater-than-or-equal comparator": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:     zeroOffset,
+				Limit:      limit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs[0:limit]),
+			},
+		},
+		"read messages with boolean value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    zeroOffset,
+				Limit:     limit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs[0:limit]),
+			},
+		},
+		"read messages with string value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:      zeroOffset,
+				Limit:       limit,
+				StringValue: vs,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs[0:limit]),
+			},
+		},
+		"read messages with data value": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset:    zeroOffset,
+				Limit:     limit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs[0:limit]),
+			},
+		},
+		"read messages with from": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  uint64(len(messages[0:21])),
+				From:   messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[0:21])),
+				Messages: fromSenml(messages[0:21]),
+			},
+		},
+		"read messages with to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  uint64(len(messages[21:])),
+				To:     messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[21:])),
+				Messages: fromSenml(messages[21:]),
+			},
+		},
+		"read messages with from/to": {
+			chanID: chanID,
+			pageMeta: readers.PageMetadata{
+				Offset: zeroOffset,
+				Limit:  limit,
+				From:   messages[5].Time,
+				To:     messages[0].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    5,
+				Messages: fromSenml(messages[1:6]),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ListChannelMessages(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestListChannelMessagesJSON(t *testing.T) {
+	writer := cwriter.New(session)
+
+	id1, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	messages1 := json.Messages{
+		Format: format1,
+	}
+	msgs1 := []map[string]interface{}{}
+	timeNow := time.Now().UnixMilli()
+	for i := 0; i < msgsNum; i++ {
+
+		m := json.Message{
+			Channel:   id1,
+			Publisher: id1,
+			Created:   timeNow - int64(i),
+			Subtopic:  "subtopic/format/some_json",
+			Protocol:  "coap",
+			Payload: map[string]interface{}{
+				"field_1": 123.0,
+				"field_2": "value",
+				"field_3": false,
+				"field_4": 12.344,
+				"field_5": map[string]interface{}{
+					"field_1": "value",
+					"field_2": 42.0,
+				},
+			},
+		}
+
+		msg := m
+		messages1.Data = append(messages1.Data, msg)
+		mapped := toMap(msg)
+		msgs1 = append(msgs1, mapped)
+	}
+	err = writer.Consume(messages1)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	id2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	messages2 := json.Messages{
+		Format: format2,
+	}
+	msgs2 := []map[string]interface{}{}
+	httpMsgs := []map[string]interface{}{}
+	for i := 0; i < msgsNum; i++ {
+		m := json.Message{
+			Channel:   id2,
+			Publisher: id2,
+			Created:   timeNow - int64(i),
+			Subtopic:  "subtopic/other_format/some_other_json",
+			Protocol:  "udp",
+			Payload: map[string]interface{}{
+				"field_1":     "other_value",
+				"false_value": false,
+				"field_pi":    3.14159265,
+			},
+		}
+
+		msg := m
+		if i%2 == 0 {
+			msg.Protocol = httpProt
+			httpMsgs = append(httpMsgs, toMap(msg))
+		}
+
+		messages2.Data = append(messages2.Data, msg)
+		msgs2 = append(msgs2, toMap(msg))
+	}
+	err = writer.Consume(messages2)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		chanID   string
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read messages page for existing channel": {
+			chanID: id1,
+			pageMeta: readers.PageMetadata{
+				Format: messages1.Format,
+				Offset: zeroOffset,
+				Limit:  limit,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs1[:10]),
+			},
+		},
+		"read messages page for non-existent channel": {
+			chanID: wrongID,
+			pageMeta: readers.PageMetadata{
+				Format: messages1.Format,
+				Offset: zeroOffset,
+				Limit:  limit,
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages last page": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format: messages2.Format,
+				Offset: msgsNum - 20,
+				Limit:  msgsNum,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs2[msgsNum-20 : msgsNum]),
+			},
+		},
+		"read messages with protocol": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format:   messages2.Format,
+				Offset:   zeroOffset,
+				Limit:    uint64(len(httpMsgs)),
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(httpMsgs)),
+				Messages: fromJSON(httpMsgs),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ListChannelMessages(tc.chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestListAllMessagesSenML(t *testing.T) {
+	writer := cwriter.New(session)
+
+	// Partitions missing from the buckets table are not read.
+	err := session.Query("TRUNCATE buckets").Exec()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	m := senml.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+	}
+
+	messages := []senml.Message{}
+	valueMsgs := []senml.Message{}
+	boolMsgs := []senml.Message{}
+	stringMsgs := []senml.Message{}
+	dataMsgs := []senml.Message{}
+	queryMsgs := []senml.Message{}
+
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		msg := m
+		msg.Time = now - float64(i)
+
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+			valueMsgs = append(valueMsgs, msg)
+		case 1:
+			msg.BoolValue = &vb
+			boolMsgs = append(boolMsgs, msg)
+		case 2:
+			msg.StringValue = &vs
+			stringMsgs = append(stringMsgs, msg)
+		case 3:
+			msg.DataValue = &vd
+			dataMsgs = append(dataMsgs, msg)
+		case 4:
+			msg.Sum = &sum
+			msg.Subtopic = subtopic
+			msg.Protocol = httpProt
+			msg.Publisher = pubID2
+			msg.Name = msgName
+			queryMsgs = append(queryMsgs, msg)
+		}
+
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	// Since messages are not saved in natural order,
+	// cases that return subset of messages are only
+	// checking data result set size, but not content.
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read all messages": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromSenml(messages),
+			},
+		},
+		"read messages with non-existent subtopic": {
+			pageMeta: readers.PageMetadata{
+				Limit:    noLimit,
+				Subtopic: "not-present",
+			},
+			page: readers.MessagesPage{
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages with subtopic": {
+			pageMeta: readers.PageMetadata{
+				Limit:    noLimit,
+				Subtopic: subtopic,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with publisher": {
+			pageMeta: readers.PageMetadata{
+				Limit:     noLimit,
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with wrong format": {
+			pageMeta: readers.PageMetadata{
+				Format:    "messagess",
+				Limit:     noLimit,
+				Publisher: pubID2,
+			},
+			page: readers.MessagesPage{
+				Total:    0,
+				Messages: []readers.Message{},
+			},
+		},
+		"read messages with protocol": {
+			pageMeta: readers.PageMetadata{
+				Limit:    noLimit,
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with name": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+				Name:  msgName,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(queryMsgs)),
+				Messages: fromSenml(queryMsgs),
+			},
+		},
+		"read messages with value": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+				Value: v,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with value and equal comparator": {
+			pageMeta: readers.PageMetadata{
+				Limit:      noLimit,
+				Value:      v,
+				Comparator: readers.EqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with value and lower-than comparator": {
+			pageMeta: readers.PageMetadata{
+				Limit:      noLimit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with value and lower-than-or-equal comparator": {
+			pageMeta: readers.PageMetadata{
+				Limit:      noLimit,
+				Value:      v + 1,
+				Comparator: readers.LowerThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with value and greater-than comparator": {
+			pageMeta: readers.PageMetadata{
+				Limit:      noLimit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with value and greater-than-or-equal comparator": {
+			pageMeta: readers.PageMetadata{
+				Limit:      noLimit,
+				Value:      v - 1,
+				Comparator: readers.GreaterThanEqualKey,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(valueMsgs)),
+				Messages: fromSenml(valueMsgs),
+			},
+		},
+		"read messages with boolean value": {
+			pageMeta: readers.PageMetadata{
+				Limit:     noLimit,
+				BoolValue: vb,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(boolMsgs)),
+				Messages: fromSenml(boolMsgs),
+			},
+		},
+		"read messages with string value": {
+			pageMeta: readers.PageMetadata{
+				Limit:       noLimit,
+				StringValue: vs,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(stringMsgs)),
+				Messages: fromSenml(stringMsgs),
+			},
+		},
+		"read messages with data value": {
+			pageMeta: readers.PageMetadata{
+				Limit:     noLimit,
+				DataValue: vd,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(dataMsgs)),
+				Messages: fromSenml(dataMsgs),
+			},
+		},
+		"read messages with from": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+				From:  messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[0:21])),
+				Messages: fromSenml(messages[0:21]),
+			},
+		},
+		"read messages with to": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+				To:    messages[20].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(messages[21:])),
+				Messages: fromSenml(messages[21:]),
+			},
+		},
+		"read messages with from/to": {
+			pageMeta: readers.PageMetadata{
+				Limit: noLimit,
+				From:  messages[5].Time,
+				To:    messages[0].Time,
+			},
+			page: readers.MessagesPage{
+				Total:    5,
+				Messages: fromSenml(messages[1:6]),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ListAllMessages(tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestListAllMessagesJSON(t *testing.T) {
+	writer := cwriter.New(session)
+
+	// Partitions missing from the buckets table are not read.
+	err := session.Query("TRUNCATE buckets").Exec()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	id1, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	messages1 := json.Messages{
+		Format: format1,
+	}
+	msgs1 := []map[string]interface{}{}
+	timeNow := time.Now().UnixMilli()
+	for i := 0; i < msgsNum; i++ {
+
+		m := json.Message{
+			Channel:   id1,
+			Publisher: id1,
+			Created:   timeNow - int64(i),
+			Subtopic:  "subtopic/format/some_json",
+			Protocol:  "coap",
+			Payload: map[string]interface{}{
+				"field_1": 123.0,
+				"field_2": "value",
+				"field_3": false,
+				"field_4": 12.344,
+				"field_5": map[string]interface{}{
+					"field_1": "value",
+					"field_2": 42.0,
+				},
+			},
+		}
+
+		msg := m
+		messages1.Data = append(messages1.Data, msg)
+		mapped := toMap(msg)
+		msgs1 = append(msgs1, mapped)
+	}
+	err = writer.Consume(messages1)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	id2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	messages2 := json.Messages{
+		Format: format2,
+	}
+	msgs2 := []map[string]interface{}{}
+	httpMsgs := []map[string]interface{}{}
+	for i := 0; i < msgsNum; i++ {
+		m := json.Message{
+			Channel:   id2,
+			Publisher: id2,
+			Created:   timeNow - int64(i),
+			Subtopic:  "subtopic/other_format/some_other_json",
+			Protocol:  "udp",
+			Payload: map[string]interface{}{
+				"field_1":     "other_value",
+				"false_value": false,
+				"field_pi":    3.14159265,
+			},
+		}
+
+		msg := m
+		if i%2 == 0 {
+			msg.Protocol = httpProt
+			httpMsgs = append(httpMsgs, toMap(msg))
+		}
+
+		messages2.Data = append(messages2.Data, msg)
+		msgs2 = append(msgs2, toMap(msg))
+	}
+	err = writer.Consume(messages2)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := creader.New(session)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read all messages": {
+			pageMeta: readers.PageMetadata{
+				Format: messages1.Format,
+				Limit:  noLimit,
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs1),
+			},
+		},
+		"read messages with protocol": {
+			pageMeta: readers.PageMetadata{
+				Format:   messages2.Format,
+				Limit:    noLimit,
+				Protocol: httpProt,
+			},
+			page: readers.MessagesPage{
+				Total:    uint64(len(httpMsgs)),
+				Messages: fromJSON(httpMsgs),
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ListAllMessages(tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestListJSONMessagesByPayload(t *testing.T) {
+	reader := creader.New(session)
+
+	pageMeta := readers.PageMetadata{
+		Format:  format1,
+		Limit:   limit,
+		Filters: []readers.PayloadFilter{{Path: "engine.temp", Comparator: readers.GreaterThanKey, Value: 80.0}},
+	}
+	_, err := reader.ListAllMessages(pageMeta)
+	assert.Equal(t, readers.ErrUnsupportedQuery, err, fmt.Sprintf("expected error %s got %s", readers.ErrUnsupportedQuery, err))
+
+	pageMeta = readers.PageMetadata{Format: format1, Limit: limit, Fields: []string{"engine.temp"}}
+	_, err = reader.ListAllMessages(pageMeta)
+	assert.Equal(t, readers.ErrUnsupportedQuery, err, fmt.Sprintf("expected error %s got %s", readers.ErrUnsupportedQuery, err))
+}
+
+func TestListMessagesByChannels(t *testing.T) {
+	readerstest.ListMessagesByChannels(t, cwriter.New(session), creader.New(session))
+}
+
+func TestDeleteMessages(t *testing.T) {
+	audit := creader.NewAuditRepository(session)
+	readerstest.DeleteMessages(t, cwriter.New(session), creader.New(session), audit)
+}
+
+func TestInvalidFormat(t *testing.T) {
+	reader := creader.New(session)
+	pageMeta := readers.PageMetadata{Format: "messages; DROP TABLE messages", Limit: limit}
+
+	_, err := reader.ListAllMessages(pageMeta)
+	assert.True(t, errors.Contains(err, readers.ErrInvalidFormat), fmt.Sprintf("expected error %s got %s", readers.ErrInvalidFormat, err))
+
+	err = reader.DeleteMessages(wrongID, pageMeta)
+	assert.True(t, errors.Contains(err, readers.ErrInvalidFormat), fmt.Sprintf("expected error %s got %s", readers.ErrInvalidFormat, err))
+}
+
+func fromSenml(msg []senml.Message) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func fromJSON(msg []map[string]interface{}) []readers.Message {
+	var ret []readers.Message
+	for _, m := range msg {
+		ret = append(ret, m)
+	}
+	return ret
+}
+
+func toMap(msg json.Message) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}(msg.Payload),
+	}
+}
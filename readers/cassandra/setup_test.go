@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cassandra_test contains tests for Cassandra repository
+// implementations.
+package cassandra_test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/readers/cassandra"
+	"github.com/gocql/gocql"
+	dockertest "github.com/ory/dockertest/v3"
+)
+
+const keyspace = "mainflux"
+
+var session *gocql.Session
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+	pool.MaxWait = 3 * time.Minute
+
+	container, err := pool.Run("cassandra", "4.1", []string{})
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	port, err := strconv.Atoi(container.GetPort("9042/tcp"))
+	if err != nil {
+		log.Fatalf("Could not parse container port: %s", err)
+	}
+
+	if err = pool.Retry(func() error {
+		cluster := gocql.NewCluster("localhost")
+		cluster.Port = port
+		s, err := cluster.CreateSession()
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		q := `CREATE KEYSPACE IF NOT EXISTS mainflux WITH replication = {'class': 'SimpleStrategy', 'replication_factor': 1}`
+		return s.Query(q).Exec()
+	}); err != nil {
+		log.Fatalf("Could not create test keyspace: %s", err)
+	}
+
+	dbConfig := cassandra.Config{
+		Hosts:    []string{"localhost"},
+		Keyspace: keyspace,
+		Port:     port,
+	}
+
+	if session, err = cassandra.Connect(dbConfig); err != nil {
+		log.Fatalf("Could not setup test DB connection: %s", err)
+	}
+
+	code := m.Run()
+
+	session.Close()
+	// Defers will not be run when using os.Exit
+	if err = pool.Purge(container); err != nil {
+		log.Fatalf("Could not purge container: %s", err)
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	deletionsTable = "deletions"
+	timeLayout     = "2006-01-02 15:04:05.000"
+)
+
+var _ readers.AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	client *ch.Client
+}
+
+// NewAuditRepository returns new ClickHouse audit trail of the message
+// deletions.
+func NewAuditRepository(client *ch.Client) readers.AuditRepository {
+	return &auditRepository{
+		client: client,
+	}
+}
+
+func (ar auditRepository) Save(d readers.Deletion) error {
+	rows := []interface{}{toDBDeletion(d)}
+	if err := ar.client.Insert(context.Background(), deletionsTable, rows); err != nil {
+		return errors.Wrap(readers.ErrSaveDeletion, err)
+	}
+
+	return nil
+}
+
+type dbDeletion struct {
+	Channel   string  `json:"channel"`
+	Actor     string  `json:"actor"`
+	Subtopic  string  `json:"subtopic"`
+	Publisher string  `json:"publisher"`
+	Format    string  `json:"format"`
+	From      float64 `json:"from_time"`
+	To        float64 `json:"to_time"`
+	Time      string  `json:"time"`
+}
+
+func toDBDeletion(d readers.Deletion) dbDeletion {
+	return dbDeletion{
+		Channel:   d.Channel,
+		Actor:     d.Actor,
+		Subtopic:  d.Subtopic,
+		Publisher: d.Publisher,
+		Format:    d.Format,
+		From:      d.From,
+		To:        d.To,
+		Time:      d.Time.UTC().Format(timeLayout),
+	}
+}
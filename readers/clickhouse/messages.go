@@ -0,0 +1,244 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+// Table for SenML messages
+const defTable = "messages"
+
+var _ readers.MessageRepository = (*clickhouseRepository)(nil)
+
+type clickhouseRepository struct {
+	client *ch.Client
+}
+
+// New returns new ClickHouse reader.
+func New(client *ch.Client) readers.MessageRepository {
+	return &clickhouseRepository{
+		client: client,
+	}
+}
+
+func (cr clickhouseRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return cr.readAll(nil, rpm)
+}
+
+func (cr clickhouseRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return cr.readAll([]string{chanID}, rpm)
+}
+
+func (cr clickhouseRepository) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm, Messages: []readers.Message{}}, nil
+	}
+
+	return cr.readAll(chanIDs, rpm)
+}
+
+// readAll reads the messages of the given channels, or of all the channels if
+// none are given.
+func (cr clickhouseRepository) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	// The payload is stored as text, which isn't queried by its fields.
+	if len(rpm.Filters) > 0 || len(rpm.Fields) > 0 {
+		return readers.MessagesPage{}, readers.ErrUnsupportedQuery
+	}
+
+	order := "time"
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+	}
+
+	olq := "LIMIT {limit:UInt64} OFFSET {offset:UInt64}"
+	if rpm.Limit == 0 {
+		olq = ""
+	}
+
+	condition := fmtCondition(chanIDs, rpm, order)
+	params := map[string]interface{}{
+		"channels":     chanIDs,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+
+	scan := func(row json.RawMessage) error {
+		var msg senml.Message
+		if err := json.Unmarshal(row, &msg); err != nil {
+			return err
+		}
+		page.Messages = append(page.Messages, msg)
+		return nil
+	}
+	if format != defTable {
+		scan = func(row json.RawMessage) error {
+			var msg jsonMessage
+			if err := json.Unmarshal(row, &msg); err != nil {
+				return err
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				return err
+			}
+			page.Messages = append(page.Messages, m)
+			return nil
+		}
+	}
+
+	ctx := context.Background()
+	q := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY %s DESC %s`, ch.Identifier(format), condition, order, olq)
+	if err := cr.client.Query(ctx, q, params, scan); err != nil {
+		if err == ch.ErrUnknownTable {
+			return readers.MessagesPage{}, nil
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	var total struct {
+		Total uint64 `json:"total"`
+	}
+	q = fmt.Sprintf(`SELECT count() AS total FROM %s %s`, ch.Identifier(format), condition)
+	if err := cr.client.Query(ctx, q, params, func(row json.RawMessage) error {
+		return json.Unmarshal(row, &total)
+	}); err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = total.Total
+
+	return page, nil
+}
+
+func (cr clickhouseRepository) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	table, timeColumn := defTable, "time"
+	if rpm.Format != "" && rpm.Format != defTable {
+		table, timeColumn = rpm.Format, "created"
+	}
+
+	// Only the filters that apply to the deletion are kept.
+	rpm = readers.PageMetadata{
+		Subtopic:  rpm.Subtopic,
+		Publisher: rpm.Publisher,
+		From:      rpm.From,
+		To:        rpm.To,
+	}
+	params := map[string]interface{}{
+		"channels":  []string{chanID},
+		"subtopic":  rpm.Subtopic,
+		"publisher": rpm.Publisher,
+		"from":      rpm.From,
+		"to":        rpm.To,
+	}
+
+	// The lightweight delete marks the rows as deleted before it returns, so
+	// they are no longer read, and removes them with the next merge.
+	q := fmt.Sprintf(`DELETE FROM %s %s`, ch.Identifier(table), fmtCondition([]string{chanID}, rpm, timeColumn))
+	if err := cr.client.Exec(context.Background(), q, params); err != nil {
+		if err == ch.ErrUnknownTable {
+			return nil
+		}
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	return nil
+}
+
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata, timeColumn string) string {
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return ""
+	}
+	json.Unmarshal(meta, &query)
+
+	condition := ""
+	op := "WHERE"
+	if len(chanIDs) > 0 {
+		condition = fmt.Sprintf(`%s has({channels:Array(String)}, channel)`, op)
+		op = "AND"
+	}
+
+	for name := range query {
+		switch name {
+		case
+			"subtopic",
+			"publisher",
+			"name",
+			"protocol":
+			condition = fmt.Sprintf(`%s %s %s = {%s:String}`, condition, op, name, name)
+			op = "AND"
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s %s value %s {value:Float64}`, condition, op, comparator)
+			op = "AND"
+		case "vb":
+			condition = fmt.Sprintf(`%s %s bool_value = {bool_value:Bool}`, condition, op)
+			op = "AND"
+		case "vs":
+			condition = fmt.Sprintf(`%s %s string_value = {string_value:String}`, condition, op)
+			op = "AND"
+		case "vd":
+			condition = fmt.Sprintf(`%s %s data_value = {data_value:String}`, condition, op)
+			op = "AND"
+		case "from":
+			condition = fmt.Sprintf(`%s %s %s >= {from:Float64}`, condition, op, timeColumn)
+			op = "AND"
+		case "to":
+			condition = fmt.Sprintf(`%s %s %s < {to:Float64}`, condition, op, timeColumn)
+			op = "AND"
+		}
+	}
+	return condition
+}
+
+type jsonMessage struct {
+	Channel   string `json:"channel"`
+	Created   int64  `json:"created"`
+	Subtopic  string `json:"subtopic"`
+	Publisher string `json:"publisher"`
+	Protocol  string `json:"protocol"`
+	Payload   string `json:"payload"`
+}
+
+func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}{},
+	}
+	pld := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(msg.Payload), &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+	return ret, nil
+}
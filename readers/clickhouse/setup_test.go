@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clickhouse_test contains tests for ClickHouse repository
+// implementations.
+package clickhouse_test
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	ch "github.com/MainfluxLabs/mainflux/pkg/clickhouse"
+	"github.com/MainfluxLabs/mainflux/readers/clickhouse"
+	dockertest "github.com/ory/dockertest/v3"
+)
+
+var client *ch.Client
+
+func TestMain(m *testing.M) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	cfg := []string{
+		"CLICKHOUSE_USER=test",
+		"CLICKHOUSE_PASSWORD=test",
+		"CLICKHOUSE_DB=test",
+	}
+	container, err := pool.Run("clickhouse/clickhouse-server", "23.3", cfg)
+	if err != nil {
+		log.Fatalf("Could not start container: %s", err)
+	}
+
+	dbConfig := ch.Config{
+		Host: "localhost",
+		Port: container.GetPort("8123/tcp"),
+		User: "test",
+		Pass: "test",
+		Name: "test",
+	}
+
+	if err = pool.Retry(func() error {
+		client, err = clickhouse.Connect(dbConfig)
+		return err
+	}); err != nil {
+		log.Fatalf("Could not setup test DB connection: %s", err)
+	}
+
+	code := m.Run()
+
+	// Defers will not be run when using os.Exit
+	if err = pool.Purge(container); err != nil {
+		log.Fatalf("Could not purge container: %s", err)
+	}
+
+	os.Exit(code)
+}
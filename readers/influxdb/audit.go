@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+)
+
+const deletionsMeasurement = "deletions"
+
+var _ readers.AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	client influxdb2.Client
+	org    string
+	bucket string
+}
+
+// NewAuditRepository returns new InfluxDB audit trail of the message
+// deletions. The deletions are stored in a bucket of their own, named after
+// the messages bucket, so that they aren't removed with the messages. The
+// bucket is created if it doesn't exist.
+func NewAuditRepository(client influxdb2.Client, repoCfg RepoConfig) (readers.AuditRepository, error) {
+	name := fmt.Sprintf("%s-audit", repoCfg.Bucket)
+
+	ctx := context.Background()
+	if _, err := client.BucketsAPI().FindBucketByName(ctx, name); err != nil {
+		o, err := client.OrganizationsAPI().FindOrganizationByName(ctx, repoCfg.Org)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := client.BucketsAPI().CreateBucketWithName(ctx, o, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &auditRepository{
+		client: client,
+		org:    repoCfg.Org,
+		bucket: name,
+	}, nil
+}
+
+func (ar auditRepository) Save(d readers.Deletion) error {
+	tags := map[string]string{"channel": d.Channel}
+	fields := map[string]interface{}{
+		"actor":     d.Actor,
+		"subtopic":  d.Subtopic,
+		"publisher": d.Publisher,
+		"format":    d.Format,
+		"from":      d.From,
+		"to":        d.To,
+	}
+	pt := influxdb2.NewPoint(deletionsMeasurement, tags, fields, d.Time)
+
+	if err := ar.client.WriteAPIBlocking(ar.org, ar.bucket).WritePoint(context.Background(), pt); err != nil {
+		return errors.Wrap(readers.ErrSaveDeletion, err)
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -27,6 +29,10 @@ var _ readers.MessageRepository = (*influxRepository)(nil)
 
 var (
 	errResultTime = errors.New("invalid result time")
+
+	// The bounds of the time range InfluxDB can store.
+	minTime = time.Unix(0, math.MinInt64+2)
+	maxTime = time.Unix(0, math.MaxInt64-1)
 )
 
 type RepoConfig struct {
@@ -47,14 +53,24 @@ func New(client influxdb2.Client, repoCfg RepoConfig) readers.MessageRepository
 }
 
 func (repo *influxRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll("", rpm)
+	return repo.readAll(nil, rpm)
 }
 
 func (repo *influxRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll(chanID, rpm)
+	return repo.readAll([]string{chanID}, rpm)
+}
+
+func (repo *influxRepository) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm}, nil
+	}
+
+	return repo.readAll(chanIDs, rpm)
 }
 
-func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+// readAll reads the messages of the given channels, or of all the channels if
+// none are given.
+func (repo *influxRepository) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defMeasurement
 	if rpm.Format != "" {
 		format = rpm.Format
@@ -63,8 +79,14 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
 	var sb strings.Builder
 
-	condition, timeRange := fmtCondition(chanID, rpm)
+	condition, timeRange := fmtCondition(chanIDs, rpm)
+	if format != defMeasurement {
+		condition += fmtPayloadCondition(rpm.Filters)
+	}
 	sb.WriteString(`import "influxdata/influxdb/v1"`)
+	if format != defMeasurement && len(rpm.Fields) > 0 {
+		sb.WriteString(`import "strings"`)
+	}
 	sb.WriteString(fmt.Sprintf(`from(bucket: "%s")`, repo.cfg.Bucket))
 	// FluxQL syntax requires timeRange filter in this position, do not change.
 	sb.WriteString(timeRange)
@@ -77,6 +99,9 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	if rpm.Limit != noLimit {
 		sb.WriteString(fmt.Sprintf(`|> limit(n:%d,offset:%d)`, rpm.Limit, rpm.Offset))
 	}
+	if format != defMeasurement && len(rpm.Fields) > 0 {
+		sb.WriteString(fmtProjection(rpm.Fields))
+	}
 	sb.WriteString(`|> yield(name: "sort")`)
 	query := sb.String()
 	resp, err := queryAPI.Query(context.Background(), query)
@@ -113,6 +138,38 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
+func (repo *influxRepository) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	format := defMeasurement
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	predicate := fmt.Sprintf(`_measurement=%s AND channel=%s`, strconv.Quote(format), strconv.Quote(chanID))
+	if rpm.Subtopic != "" {
+		predicate = fmt.Sprintf(`%s AND subtopic=%s`, predicate, strconv.Quote(rpm.Subtopic))
+	}
+	if rpm.Publisher != "" {
+		predicate = fmt.Sprintf(`%s AND publisher=%s`, predicate, strconv.Quote(rpm.Publisher))
+	}
+
+	// The deleted time range includes both of its bounds.
+	scale := timeScale(rpm.Format)
+	start, stop := minTime, maxTime
+	if rpm.From != 0 {
+		start = time.Unix(0, int64(rpm.From*scale))
+	}
+	if rpm.To != 0 {
+		stop = time.Unix(0, int64(rpm.To*scale)-1)
+	}
+
+	deleteAPI := repo.client.DeleteAPI()
+	if err := deleteAPI.DeleteWithName(context.Background(), repo.cfg.Org, repo.cfg.Bucket, start, stop, predicate); err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	return nil
+}
+
 func (repo *influxRepository) count(measurement, condition string, timeRange string) (uint64, error) {
 
 	var sb strings.Builder
@@ -151,13 +208,17 @@ func (repo *influxRepository) count(measurement, condition string, timeRange str
 
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata) (string, string) {
 	// TODO: adapt filters to flux
 	var timeRange string
 
 	var sb strings.Builder
-	if chanID != "" {
-		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r["channel"] == "%s" )`, chanID))
+	if len(chanIDs) > 0 {
+		var chans []string
+		for _, id := range chanIDs {
+			chans = append(chans, fmt.Sprintf(`r["channel"] == %s`, strconv.Quote(id)))
+		}
+		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => %s )`, strings.Join(chans, " or ")))
 	}
 
 	var query map[string]interface{}
@@ -171,15 +232,16 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
 	}
 
 	//range(start:...) is a must for FluxQL syntax
+	scale := timeScale(rpm.Format)
 	from := `start: time(v:0)`
 	if value, ok := query["from"]; ok {
-		fromValue := int64(value.(float64)*1e9) - 1
+		fromValue := int64(value.(float64)*scale) - 1
 		from = fmt.Sprintf(`start: time(v: %d )`, fromValue)
 	}
 	//range(...,stop:) is an option for FluxQL syntax
 	to := ""
 	if value, ok := query["to"]; ok {
-		toValue := int64(value.(float64) * 1e9)
+		toValue := int64(value.(float64) * scale)
 		to = fmt.Sprintf(`, stop: time(v: %d )`, toValue)
 	}
 	// timeRange returned seperately because
@@ -219,6 +281,63 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
 	return sb.String(), timeRange
 }
 
+// timeScale returns the number of nanoseconds in the unit of the time range.
+// The time range of the SenML messages is in seconds, and of the JSON messages
+// in nanoseconds, as their created time.
+func timeScale(format string) float64 {
+	if format != "" && format != defMeasurement {
+		return 1
+	}
+	return 1e9
+}
+
+// fmtPayloadCondition filters the flattened payload fields. A field matches
+// the filter only if it exists.
+func fmtPayloadCondition(filters []readers.PayloadFilter) string {
+	var sb strings.Builder
+	for _, f := range filters {
+		field := strconv.Quote(strings.ReplaceAll(f.Path, ".", "/"))
+
+		var value string
+		switch v := f.Value.(type) {
+		case float64:
+			// Flux doesn't compare the floats with the integer literals.
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+			if !strings.Contains(value, ".") {
+				value += ".0"
+			}
+		case bool:
+			value = strconv.FormatBool(v)
+		default:
+			value = strconv.Quote(fmt.Sprint(v))
+		}
+
+		op := f.Operator()
+		if op == "=" {
+			op = "=="
+		}
+		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => exists r[%s] and r[%s] %s %s)`, field, field, op, value))
+	}
+
+	return sb.String()
+}
+
+// fmtProjection keeps the message envelope and the flattened payload fields
+// of the given fields.
+func fmtProjection(fields []string) string {
+	columns := []string{`column == "_time"`, `column == "_measurement"`}
+	for _, c := range []string{"channel", "created", "subtopic", "publisher", "protocol"} {
+		columns = append(columns, fmt.Sprintf(`column == %s`, strconv.Quote(c)))
+	}
+	for _, f := range fields {
+		field := strings.ReplaceAll(f, ".", "/")
+		columns = append(columns, fmt.Sprintf(`column == %s`, strconv.Quote(field)))
+		columns = append(columns, fmt.Sprintf(`strings.hasPrefix(v: column, prefix: %s)`, strconv.Quote(field+"/")))
+	}
+
+	return fmt.Sprintf(`|> keep(fn: (column) => %s)`, strings.Join(columns, " or "))
+}
+
 func parseMessage(measurement string, valueMap map[string]interface{}) (interface{}, error) {
 	switch measurement {
 	case defMeasurement:
@@ -12,6 +12,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	ireader "github.com/MainfluxLabs/mainflux/readers/influxdb"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -885,6 +886,26 @@ func TestListAllMessagesJSON(t *testing.T) {
 	}
 }
 
+func TestListJSONMessagesByPayload(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	readerstest.ListJSONMessagesByPayload(t, iwriter.New(client, repoCfg), ireader.New(client, repoCfg))
+}
+
+func TestListMessagesByChannels(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	readerstest.ListMessagesByChannels(t, iwriter.New(client, repoCfg), ireader.New(client, repoCfg))
+}
+
+func TestDeleteMessages(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	audit, err := ireader.NewAuditRepository(client, repoCfg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	readerstest.DeleteMessages(t, iwriter.New(client, repoCfg), ireader.New(client, repoCfg), audit)
+}
+
 func fromSenml(in []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range in {
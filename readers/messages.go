@@ -3,7 +3,10 @@
 
 package readers
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 const (
 	// EqualKey represents the equal comparison operator key.
@@ -18,8 +21,22 @@ const (
 	GreaterThanEqualKey = "ge"
 )
 
-// ErrReadMessages indicates failure occurred while reading messages from database.
-var ErrReadMessages = errors.New("failed to read messages from database")
+var (
+	// ErrReadMessages indicates failure occurred while reading messages from database.
+	ErrReadMessages = errors.New("failed to read messages from database")
+
+	// ErrDeleteMessages indicates failure occurred while deleting messages from database.
+	ErrDeleteMessages = errors.New("failed to delete messages from database")
+
+	// ErrSaveDeletion indicates failure occurred while recording the deletion to the audit trail.
+	ErrSaveDeletion = errors.New("failed to save deletion to audit trail")
+
+	// ErrUnsupportedQuery indicates that the database can't filter or project the JSON payload.
+	ErrUnsupportedQuery = errors.New("payload filters and projection are not supported by the database")
+
+	// ErrInvalidFormat indicates that the message format can't name a database table.
+	ErrInvalidFormat = errors.New("invalid message format")
+)
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
@@ -28,6 +45,60 @@ type MessageRepository interface {
 	ListChannelMessages(chanID string, pm PageMetadata) (MessagesPage, error)
 	// ListAllMessages retrieves all messages from database.
 	ListAllMessages(rpm PageMetadata) (MessagesPage, error)
+	// ListMessagesByChannels skips given number of the messages sent to any
+	// of the given channels, ordered by time, and returns next limited number
+	// of messages.
+	ListMessagesByChannels(chanIDs []string, rpm PageMetadata) (MessagesPage, error)
+	// DeleteMessages removes the messages of the given channel and format that
+	// match the subtopic, publisher and time range of the page metadata. The
+	// other filters are ignored.
+	DeleteMessages(chanID string, rpm PageMetadata) error
+}
+
+// AuditRepository specifies the audit trail of the message deletions.
+type AuditRepository interface {
+	// Save records the deletion of the channel messages.
+	Save(d Deletion) error
+}
+
+// LatestMessageRepository specifies the latest messages cache API.
+type LatestMessageRepository interface {
+	// ListLatestMessages retrieves the latest message of each publisher and
+	// SenML name of the given channel, filtered by publisher, subtopic,
+	// protocol and name.
+	ListLatestMessages(chanID string, pm PageMetadata) (MessagesPage, error)
+	// DeleteLatestMessages removes the cached latest messages of the given
+	// channel that match the subtopic, publisher and time range of the page
+	// metadata.
+	DeleteLatestMessages(chanID string, pm PageMetadata) error
+}
+
+// Deletion represents the record of the messages removed from a channel and
+// the filters they were selected by.
+type Deletion struct {
+	Channel   string
+	Actor     string
+	Subtopic  string
+	Publisher string
+	Format    string
+	From      float64
+	To        float64
+	Time      time.Time
+}
+
+// NewDeletion returns the record of deleting the messages of the channel that
+// match the page metadata by the given user.
+func NewDeletion(chanID, actor string, rpm PageMetadata) Deletion {
+	return Deletion{
+		Channel:   chanID,
+		Actor:     actor,
+		Subtopic:  rpm.Subtopic,
+		Publisher: rpm.Publisher,
+		Format:    rpm.Format,
+		From:      rpm.From,
+		To:        rpm.To,
+		Time:      time.Now().UTC(),
+	}
 }
 
 // Message represents any message format.
@@ -57,6 +128,33 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	// Filters and Fields apply to the payload of the JSON messages.
+	Filters []PayloadFilter `json:"filters,omitempty"`
+	Fields  []string        `json:"fields,omitempty"`
+}
+
+// PayloadFilter represents the comparison of the JSON payload field, given
+// by the dot separated path of its keys, with the number, string or bool value.
+type PayloadFilter struct {
+	Path       string      `json:"path"`
+	Comparator string      `json:"comparator,omitempty"`
+	Value      interface{} `json:"value"`
+}
+
+// Operator returns the mathematical notation of the filter comparator.
+func (f PayloadFilter) Operator() string {
+	switch f.Comparator {
+	case LowerThanKey:
+		return "<"
+	case LowerThanEqualKey:
+		return "<="
+	case GreaterThanKey:
+		return ">"
+	case GreaterThanEqualKey:
+		return ">="
+	default:
+		return "="
+	}
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation
@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var _ readers.AuditRepository = (*AuditRepositoryMock)(nil)
+
+// AuditRepositoryMock keeps the recorded deletions in memory.
+type AuditRepositoryMock struct {
+	mutex     sync.Mutex
+	err       error
+	deletions []readers.Deletion
+}
+
+// NewAuditRepository returns mock implementation of the audit repository
+// which fails to save deletions with the given error, if any.
+func NewAuditRepository(err error) *AuditRepositoryMock {
+	return &AuditRepositoryMock{err: err}
+}
+
+func (arm *AuditRepositoryMock) Save(d readers.Deletion) error {
+	arm.mutex.Lock()
+	defer arm.mutex.Unlock()
+
+	if arm.err != nil {
+		return arm.err
+	}
+	arm.deletions = append(arm.deletions, d)
+	return nil
+}
+
+// Deletions returns the recorded deletions.
+func (arm *AuditRepositoryMock) Deletions() []readers.Deletion {
+	arm.mutex.Lock()
+	defer arm.mutex.Unlock()
+
+	return append([]readers.Deletion{}, arm.deletions...)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var _ readers.LatestMessageRepository = (*latestRepositoryMock)(nil)
+
+type latestRepositoryMock struct {
+	mutex    sync.Mutex
+	messages map[string]map[string]senml.Message
+}
+
+// NewLatestMessageRepository returns mock implementation of latest messages
+// repository containing the latest of the given messages of the channel.
+func NewLatestMessageRepository(chanID string, messages []senml.Message) readers.LatestMessageRepository {
+	latest := map[string]senml.Message{}
+	for _, msg := range messages {
+		key := msg.Publisher + ":" + msg.Name
+		if cur, ok := latest[key]; !ok || msg.Time >= cur.Time {
+			latest[key] = msg
+		}
+	}
+
+	return &latestRepositoryMock{
+		messages: map[string]map[string]senml.Message{chanID: latest},
+	}
+}
+
+func (repo *latestRepositoryMock) ListLatestMessages(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var msgs []senml.Message
+	for _, msg := range repo.messages[chanID] {
+		if pm.Publisher != "" && msg.Publisher != pm.Publisher ||
+			pm.Subtopic != "" && msg.Subtopic != pm.Subtopic ||
+			pm.Protocol != "" && msg.Protocol != pm.Protocol ||
+			pm.Name != "" && msg.Name != pm.Name {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Publisher != msgs[j].Publisher {
+			return msgs[i].Publisher < msgs[j].Publisher
+		}
+		return msgs[i].Name < msgs[j].Name
+	})
+
+	page := readers.MessagesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(msgs)),
+	}
+
+	start := pm.Offset
+	if start > uint64(len(msgs)) {
+		start = uint64(len(msgs))
+	}
+	end := uint64(len(msgs))
+	if pm.Limit > 0 && start+pm.Limit < end {
+		end = start + pm.Limit
+	}
+	for _, msg := range msgs[start:end] {
+		page.Messages = append(page.Messages, msg)
+	}
+
+	return page, nil
+}
+
+func (repo *latestRepositoryMock) DeleteLatestMessages(chanID string, pm readers.PageMetadata) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for key, msg := range repo.messages[chanID] {
+		if pm.Publisher != "" && msg.Publisher != pm.Publisher ||
+			pm.Subtopic != "" && msg.Subtopic != pm.Subtopic ||
+			pm.From != 0 && msg.Time < pm.From ||
+			pm.To != 0 && msg.Time >= pm.To {
+			continue
+		}
+		delete(repo.messages[chanID], key)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"encoding/json"
+	"sort"
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -22,24 +23,56 @@ type messageRepositoryMock struct {
 
 // NewMessageRepository returns mock implementation of message repository.
 func NewMessageRepository(chanID string, messages []readers.Message) readers.MessageRepository {
-	repo := map[string][]readers.Message{
-		chanID: messages,
-	}
+	return NewChannelsMessageRepository(map[string][]readers.Message{chanID: messages})
+}
 
+// NewChannelsMessageRepository returns mock implementation of message
+// repository containing the messages of each of the given channels.
+func NewChannelsMessageRepository(messages map[string][]readers.Message) readers.MessageRepository {
 	return &messageRepositoryMock{
 		mutex:    sync.Mutex{},
-		messages: repo,
+		messages: messages,
 	}
 }
+
 func (repo *messageRepositoryMock) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll(chanID, rpm)
+	return repo.readAll([]string{chanID}, rpm)
 }
 
 func (repo *messageRepositoryMock) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll("", rpm)
+	return repo.readAll([]string{""}, rpm)
+}
+
+func (repo *messageRepositoryMock) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.readAll(chanIDs, rpm)
+}
+
+func (repo *messageRepositoryMock) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if rpm.Format != "" && rpm.Format != "messages" {
+		return nil
+	}
+
+	var msgs []readers.Message
+	for _, m := range repo.messages[chanID] {
+		msg := m.(senml.Message)
+		if (rpm.Subtopic == "" || rpm.Subtopic == msg.Subtopic) &&
+			(rpm.Publisher == "" || rpm.Publisher == msg.Publisher) &&
+			(rpm.From == 0 || msg.Time >= rpm.From) &&
+			(rpm.To == 0 || msg.Time < rpm.To) {
+			continue
+		}
+		msgs = append(msgs, m)
+	}
+	repo.messages[chanID] = msgs
+
+	return nil
 }
 
-func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+// readAll merges the messages of the given channels, ordered by time.
+func (repo *messageRepositoryMock) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	repo.mutex.Lock()
 	defer repo.mutex.Unlock()
 
@@ -51,8 +84,18 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 	meta, _ := json.Marshal(rpm)
 	json.Unmarshal(meta, &query)
 
+	var all []readers.Message
+	for _, chanID := range chanIDs {
+		all = append(all, repo.messages[chanID]...)
+	}
+	if len(chanIDs) > 1 {
+		sort.SliceStable(all, func(i, j int) bool {
+			return all[i].(senml.Message).Time > all[j].(senml.Message).Time
+		})
+	}
+
 	var msgs []readers.Message
-	for _, m := range repo.messages[chanID] {
+	for _, m := range all {
 		senml := m.(senml.Message)
 
 		ok := true
@@ -0,0 +1,63 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeletionsCollection is the collection of the audit trail of the message
+// deletions.
+const DeletionsCollection = "deletions"
+
+var _ readers.AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	db *mongo.Database
+}
+
+// NewAuditRepository returns new MongoDB audit trail of the message deletions.
+func NewAuditRepository(db *mongo.Database) readers.AuditRepository {
+	return auditRepository{
+		db: db,
+	}
+}
+
+func (ar auditRepository) Save(d readers.Deletion) error {
+	coll := ar.db.Collection(DeletionsCollection)
+	if _, err := coll.InsertOne(context.Background(), toDBDeletion(d)); err != nil {
+		return errors.Wrap(readers.ErrSaveDeletion, err)
+	}
+
+	return nil
+}
+
+type dbDeletion struct {
+	Channel   string    `bson:"channel"`
+	Actor     string    `bson:"actor"`
+	Subtopic  string    `bson:"subtopic,omitempty"`
+	Publisher string    `bson:"publisher,omitempty"`
+	Format    string    `bson:"format,omitempty"`
+	From      float64   `bson:"from,omitempty"`
+	To        float64   `bson:"to,omitempty"`
+	Time      time.Time `bson:"time"`
+}
+
+func toDBDeletion(d readers.Deletion) dbDeletion {
+	return dbDeletion{
+		Channel:   d.Channel,
+		Actor:     d.Actor,
+		Subtopic:  d.Subtopic,
+		Publisher: d.Publisher,
+		Format:    d.Format,
+		From:      d.From,
+		To:        d.To,
+		Time:      d.Time,
+	}
+}
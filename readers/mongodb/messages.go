@@ -36,14 +36,24 @@ func New(db *mongo.Database) readers.MessageRepository {
 }
 
 func (repo mongoRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll("", rpm)
+	return repo.readAll(nil, rpm)
 }
 
 func (repo mongoRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return repo.readAll(chanID, rpm)
+	return repo.readAll([]string{chanID}, rpm)
 }
 
-func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+func (repo mongoRepository) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm}, nil
+	}
+
+	return repo.readAll(chanIDs, rpm)
+}
+
+// readAll reads the messages of the given channels, or of all the channels if
+// none are given.
+func (repo mongoRepository) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	format := defCollection
 	order := "time"
 	if rpm.Format != "" && rpm.Format != defCollection {
@@ -57,14 +67,23 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 		order: -1,
 	}
 	// Remove format filter and format the rest properly.
-	filter := fmtCondition(chanID, rpm)
+	filter := fmtCondition(chanIDs, rpm, order)
+	// The date the writer stores for the retention isn't part of the message.
+	projection := bson.M{"retention_time": 0}
+	if format != defCollection {
+		filter = fmtPayloadCondition(filter, rpm.Filters)
+		if len(rpm.Fields) > 0 {
+			projection = fmtProjection(rpm.Fields)
+		}
+	}
+	opts := options.Find().SetSort(sortMap).SetProjection(projection)
 	var cursor *mongo.Cursor
 	var err error
 	switch rpm.Limit {
 	case noLimit:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap))
+		cursor, err = col.Find(context.Background(), filter, opts)
 	default:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+		cursor, err = col.Find(context.Background(), filter, opts.SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
 	}
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
@@ -108,11 +127,45 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 	return mp, nil
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
+func (repo mongoRepository) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	format, timeField := defCollection, "time"
+	if rpm.Format != "" && rpm.Format != defCollection {
+		format, timeField = rpm.Format, "created"
+	}
+
+	filter := bson.D{{Key: "channel", Value: chanID}}
+	if rpm.Subtopic != "" {
+		filter = append(filter, bson.E{Key: "subtopic", Value: rpm.Subtopic})
+	}
+	if rpm.Publisher != "" {
+		filter = append(filter, bson.E{Key: "publisher", Value: rpm.Publisher})
+	}
+	timeRange := bson.M{}
+	if rpm.From != 0 {
+		timeRange["$gte"] = rpm.From
+	}
+	if rpm.To != 0 {
+		timeRange["$lt"] = rpm.To
+	}
+	if len(timeRange) > 0 {
+		filter = append(filter, bson.E{Key: timeField, Value: timeRange})
+	}
+
+	if _, err := repo.db.Collection(format).DeleteMany(context.Background(), filter); err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	return nil
+}
+
+// fmtCondition matches the messages by the filters of the page metadata. The
+// time range is compared with the given field, which is the created field of
+// the JSON messages.
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata, timeField string) bson.D {
 	filter := bson.D{}
 
-	if chanID != "" {
-		filter = append(filter, bson.E{Key: "channel", Value: chanID})
+	if len(chanIDs) > 0 {
+		filter = append(filter, bson.E{Key: "channel", Value: bson.M{"$in": chanIDs}})
 	}
 
 	var query map[string]interface{}
@@ -156,11 +209,53 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 		case "vd":
 			filter = append(filter, bson.E{Key: "data_value", Value: value})
 		case "from":
-			filter = append(filter, bson.E{Key: "time", Value: bson.M{"$gte": value}})
+			filter = append(filter, bson.E{Key: timeField, Value: bson.M{"$gte": value}})
 		case "to":
-			filter = append(filter, bson.E{Key: "time", Value: bson.M{"$lt": value}})
+			filter = append(filter, bson.E{Key: timeField, Value: bson.M{"$lt": value}})
 		}
 	}
 
 	return filter
 }
+
+// fmtPayloadCondition appends the payload filters to the filter. The filters
+// are joined explicitly, since they can apply to the same field.
+func fmtPayloadCondition(filter bson.D, filters []readers.PayloadFilter) bson.D {
+	if len(filters) == 0 {
+		return filter
+	}
+
+	var conditions bson.A
+	for _, f := range filters {
+		var value interface{} = f.Value
+		switch f.Comparator {
+		case readers.LowerThanKey:
+			value = bson.M{"$lt": f.Value}
+		case readers.LowerThanEqualKey:
+			value = bson.M{"$lte": f.Value}
+		case readers.GreaterThanKey:
+			value = bson.M{"$gt": f.Value}
+		case readers.GreaterThanEqualKey:
+			value = bson.M{"$gte": f.Value}
+		}
+		conditions = append(conditions, bson.D{{Key: "payload." + f.Path, Value: value}})
+	}
+
+	return append(filter, bson.E{Key: "$and", Value: conditions})
+}
+
+// fmtProjection keeps the message envelope and the given payload fields.
+func fmtProjection(fields []string) bson.M {
+	projection := bson.M{
+		"channel":   1,
+		"created":   1,
+		"subtopic":  1,
+		"publisher": 1,
+		"protocol":  1,
+	}
+	for _, f := range fields {
+		projection["payload."+f] = 1
+	}
+
+	return projection
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	mreader "github.com/MainfluxLabs/mainflux/readers/mongodb"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -534,6 +535,20 @@ func TestListChannelMessagesJSON(t *testing.T) {
 				Messages: fromJSON(httpMsgs),
 			},
 		},
+		"read messages within time range": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format: messages2.Format,
+				Offset: zeroOffset,
+				Limit:  msgsNum,
+				From:   float64(m.Created),
+				To:     float64(m.Created + 1),
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs2),
+			},
+		},
 	}
 
 	for desc, tc := range cases {
@@ -939,6 +954,31 @@ func TestListAllMessagesJSON(t *testing.T) {
 		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
 	}
 }
+func TestListJSONMessagesByPayload(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	readerstest.ListJSONMessagesByPayload(t, mwriter.New(db), mreader.New(db))
+}
+
+func TestListMessagesByChannels(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	readerstest.ListMessagesByChannels(t, mwriter.New(db), mreader.New(db))
+}
+
+func TestDeleteMessages(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	audit := mreader.NewAuditRepository(db)
+	readerstest.DeleteMessages(t, mwriter.New(db), mreader.New(db), audit)
+}
+
 func fromSenml(in []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range in {
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ readers.AuditRepository = (*auditRepository)(nil)
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+// NewAuditRepository returns new PostgreSQL audit trail of the message deletions.
+func NewAuditRepository(db *sqlx.DB) readers.AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (ar auditRepository) Save(d readers.Deletion) error {
+	q := `INSERT INTO deletions (channel, actor, subtopic, publisher, format, from_time, to_time, time)
+          VALUES (:channel, :actor, :subtopic, :publisher, :format, :from_time, :to_time, :time);`
+
+	if _, err := ar.db.NamedExec(q, toDBDeletion(d)); err != nil {
+		return errors.Wrap(readers.ErrSaveDeletion, err)
+	}
+
+	return nil
+}
+
+type dbDeletion struct {
+	Channel   string    `db:"channel"`
+	Actor     string    `db:"actor"`
+	Subtopic  string    `db:"subtopic"`
+	Publisher string    `db:"publisher"`
+	Format    string    `db:"format"`
+	From      float64   `db:"from_time"`
+	To        float64   `db:"to_time"`
+	Time      time.Time `db:"time"`
+}
+
+func toDBDeletion(d readers.Deletion) dbDeletion {
+	return dbDeletion{
+		Channel:   d.Channel,
+		Actor:     d.Actor,
+		Subtopic:  d.Subtopic,
+		Publisher: d.Publisher,
+		Format:    d.Format,
+		From:      d.From,
+		To:        d.To,
+		Time:      d.Time,
+	}
+}
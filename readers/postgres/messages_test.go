@@ -14,6 +14,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	preader "github.com/MainfluxLabs/mainflux/readers/postgres"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -525,6 +526,20 @@ func TestListChannelMessagesJSON(t *testing.T) {
 				Messages: fromJSON(httpMsgs),
 			},
 		},
+		"read messages within time range": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format: messages2.Format,
+				Offset: zeroOffset,
+				Limit:  msgsNum,
+				From:   float64(m.Created),
+				To:     float64(m.Created + 1),
+			},
+			page: readers.MessagesPage{
+				Total:    msgsNum,
+				Messages: fromJSON(msgs2),
+			},
+		},
 	}
 
 	for desc, tc := range cases {
@@ -932,6 +947,19 @@ func TestListAllMessagesJSON(t *testing.T) {
 	}
 }
 
+func TestListJSONMessagesByPayload(t *testing.T) {
+	readerstest.ListJSONMessagesByPayload(t, pwriter.New(db), preader.New(db))
+}
+
+func TestListMessagesByChannels(t *testing.T) {
+	readerstest.ListMessagesByChannels(t, pwriter.New(db), preader.New(db))
+}
+
+func TestDeleteMessages(t *testing.T) {
+	audit := preader.NewAuditRepository(db)
+	readerstest.DeleteMessages(t, pwriter.New(db), preader.New(db), audit)
+}
+
 func fromSenml(msg []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range msg {
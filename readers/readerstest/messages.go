@@ -0,0 +1,318 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readerstest contains the message repository tests shared by the
+// readers of all the databases.
+package readerstest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subtopic = "subtopic"
+	msgsNum  = 101
+	limit    = 10
+	noLimit  = 0
+	mqttProt = "mqtt"
+	msgName  = "temperature"
+)
+
+var (
+	v float64 = 5
+
+	idProvider = uuid.New()
+)
+
+// ListJSONMessagesByPayload tests filtering and projection of the JSON
+// messages by their payload fields.
+func ListJSONMessagesByPayload(t *testing.T, writer consumers.Consumer, reader readers.MessageRepository) {
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := json.Messages{Format: "engines"}
+	created := time.Now().UnixNano()
+	for i := 0; i < msgsNum; i++ {
+		state := "off"
+		if i%2 == 0 {
+			state = "on"
+		}
+		messages.Data = append(messages.Data, json.Message{
+			Channel:   chanID,
+			Publisher: chanID,
+			Created:   created + int64(i)*1000,
+			Protocol:  mqttProt,
+			Payload: map[string]interface{}{
+				"name": "engine",
+				"engine": map[string]interface{}{
+					"temp":    float64(i),
+					"rpm":     1000.0,
+					"state":   state,
+					"running": i%2 == 0,
+				},
+			},
+		})
+	}
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	cases := []struct {
+		desc     string
+		pageMeta readers.PageMetadata
+		total    uint64
+		payload  map[string]interface{}
+	}{
+		{
+			desc: "read messages by number",
+			pageMeta: readers.PageMetadata{
+				Filters: []readers.PayloadFilter{{Path: "engine.temp", Comparator: readers.GreaterThanKey, Value: 80.0}},
+			},
+			total: 20,
+		},
+		{
+			desc: "read messages by number and string",
+			pageMeta: readers.PageMetadata{
+				Filters: []readers.PayloadFilter{
+					{Path: "engine.temp", Comparator: readers.GreaterThanEqualKey, Value: 50.0},
+					{Path: "engine.state", Comparator: readers.EqualKey, Value: "on"},
+				},
+			},
+			total: 26,
+		},
+		{
+			desc: "read messages by bool",
+			pageMeta: readers.PageMetadata{
+				Filters: []readers.PayloadFilter{{Path: "engine.running", Value: true}},
+			},
+			total: 51,
+		},
+		{
+			desc: "read messages by missing field",
+			pageMeta: readers.PageMetadata{
+				Filters: []readers.PayloadFilter{{Path: "engine.pressure", Comparator: readers.GreaterThanKey, Value: 0.0}},
+			},
+			total: 0,
+		},
+		{
+			desc: "read projected messages",
+			pageMeta: readers.PageMetadata{
+				Filters: []readers.PayloadFilter{{Path: "engine.temp", Comparator: readers.LowerThanKey, Value: 10.0}},
+				Fields:  []string{"engine.temp", "engine.state"},
+			},
+			total: 10,
+			payload: map[string]interface{}{
+				"engine": map[string]interface{}{
+					"temp":  9.0,
+					"state": "off",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.pageMeta.Format = messages.Format
+		tc.pageMeta.Limit = limit
+		page, err := reader.ListChannelMessages(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d total got %d", tc.desc, tc.total, page.Total))
+		if tc.payload == nil || len(page.Messages) == 0 {
+			continue
+		}
+
+		// The newest message is the first one.
+		msg := page.Messages[0].(map[string]interface{})
+		assert.Equal(t, tc.payload, msg["payload"], fmt.Sprintf("%s: expected payload %v got %v", tc.desc, tc.payload, msg["payload"]))
+		assert.Equal(t, chanID, msg["channel"], fmt.Sprintf("%s: expected channel %s got %v", tc.desc, chanID, msg["channel"]))
+	}
+}
+
+// ListMessagesByChannels tests listing the messages of multiple channels.
+func ListMessagesByChannels(t *testing.T, writer consumers.Consumer, reader readers.MessageRepository) {
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chanID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chanID3, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// The messages of the channels interleave in time.
+	var messages, messages2 []senml.Message
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		switch i % 3 {
+		case 0:
+			messages = append(messages, msg)
+		case 1:
+			msg.Channel = chanID2
+			messages2 = append(messages2, msg)
+		case 2:
+			msg.Channel = chanID3
+		}
+		err = writer.Consume([]senml.Message{msg})
+		require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	}
+
+	cases := []struct {
+		desc     string
+		chanIDs  []string
+		pageMeta readers.PageMetadata
+		total    uint64
+		size     int
+		from     float64
+	}{
+		{
+			desc:     "read messages of one channel",
+			chanIDs:  []string{chanID},
+			pageMeta: readers.PageMetadata{Limit: limit},
+			total:    uint64(len(messages)),
+			size:     limit,
+			from:     now,
+		},
+		{
+			desc:     "read messages of two channels",
+			chanIDs:  []string{chanID, chanID2},
+			pageMeta: readers.PageMetadata{Limit: limit},
+			total:    uint64(len(messages) + len(messages2)),
+			size:     limit,
+			from:     now,
+		},
+		{
+			desc:     "read messages of two channels with offset",
+			chanIDs:  []string{chanID, chanID2},
+			pageMeta: readers.PageMetadata{Offset: limit, Limit: limit},
+			total:    uint64(len(messages) + len(messages2)),
+			size:     limit,
+			from:     now - 15,
+		},
+		{
+			desc:     "read messages of two channels with time range",
+			chanIDs:  []string{chanID, chanID2},
+			pageMeta: readers.PageMetadata{From: now - 5, Limit: noLimit},
+			total:    4,
+			size:     4,
+			from:     now,
+		},
+		{
+			desc:     "read messages of no channels",
+			chanIDs:  []string{},
+			pageMeta: readers.PageMetadata{Limit: limit},
+			total:    0,
+			size:     0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := reader.ListMessagesByChannels(tc.chanIDs, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d total got %d", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.size, len(page.Messages), fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.size, len(page.Messages)))
+		if tc.size == 0 {
+			continue
+		}
+
+		// Messages of the channels are merged from the newest one.
+		first := page.Messages[0].(senml.Message)
+		assert.Equal(t, tc.from, first.Time, fmt.Sprintf("%s: expected first message at %f got %f", tc.desc, tc.from, first.Time))
+		for i := 1; i < len(page.Messages); i++ {
+			prev, cur := page.Messages[i-1].(senml.Message), page.Messages[i].(senml.Message)
+			assert.True(t, prev.Time >= cur.Time, fmt.Sprintf("%s: expected messages ordered by time", tc.desc))
+			assert.NotEqual(t, chanID3, cur.Channel, fmt.Sprintf("%s: got message of channel %s", tc.desc, cur.Channel))
+		}
+	}
+}
+
+// DeleteMessages tests deleting the channel messages and recording the
+// deletions.
+func DeleteMessages(t *testing.T, writer consumers.Consumer, reader readers.MessageRepository, audit readers.AuditRepository) {
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var messages []senml.Message
+	now := float64(time.Now().Unix())
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		if i%2 == 1 {
+			msg.Publisher = pubID2
+			msg.Subtopic = subtopic
+		}
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	// The cases are run in order, each one deleting the messages left by
+	// the previous ones.
+	cases := []struct {
+		desc     string
+		pageMeta readers.PageMetadata
+		total    uint64
+	}{
+		{
+			desc:     "delete messages of publisher",
+			pageMeta: readers.PageMetadata{Publisher: pubID2},
+			total:    msgsNum - msgsNum/2,
+		},
+		{
+			desc:     "delete messages of subtopic without messages",
+			pageMeta: readers.PageMetadata{Subtopic: subtopic},
+			total:    msgsNum - msgsNum/2,
+		},
+		{
+			desc:     "delete messages within time range",
+			pageMeta: readers.PageMetadata{From: now - 19, To: now + 1},
+			total:    msgsNum - msgsNum/2 - 10,
+		},
+		{
+			desc:     "delete all messages",
+			pageMeta: readers.PageMetadata{},
+			total:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := audit.Save(readers.NewDeletion(chanID, pubID, tc.pageMeta))
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+		err = reader.DeleteMessages(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+		page, err := reader.ListChannelMessages(chanID, readers.PageMetadata{Limit: noLimit})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d messages left got %d", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the latest messages repository implementation
+// which reads the cache maintained by the Redis writer.
+package redis
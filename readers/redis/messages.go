@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/go-redis/redis/v8"
+)
+
+// keyPrefix has to match the prefix used by the Redis writer.
+const keyPrefix = "latest"
+
+var _ readers.LatestMessageRepository = (*latestRepository)(nil)
+
+type latestRepository struct {
+	client *redis.Client
+}
+
+// New returns new latest messages repository backed by the Redis cache.
+func New(client *redis.Client) readers.LatestMessageRepository {
+	return &latestRepository{client: client}
+}
+
+func (lr latestRepository) ListLatestMessages(chanID string, pm readers.PageMetadata) (readers.MessagesPage, error) {
+	vals, err := lr.client.HVals(context.Background(), fmt.Sprintf("%s:%s", keyPrefix, chanID)).Result()
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	var msgs []senml.Message
+	for _, v := range vals {
+		var msg senml.Message
+		if err := json.Unmarshal([]byte(v), &msg); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		if matches(msg, pm) {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Publisher != msgs[j].Publisher {
+			return msgs[i].Publisher < msgs[j].Publisher
+		}
+		return msgs[i].Name < msgs[j].Name
+	})
+
+	page := readers.MessagesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(msgs)),
+		Messages:     []readers.Message{},
+	}
+
+	if pm.Offset >= uint64(len(msgs)) {
+		return page, nil
+	}
+	end := uint64(len(msgs))
+	if pm.Limit > 0 && pm.Offset+pm.Limit < end {
+		end = pm.Offset + pm.Limit
+	}
+	for _, msg := range msgs[pm.Offset:end] {
+		page.Messages = append(page.Messages, msg)
+	}
+
+	return page, nil
+}
+
+func (lr latestRepository) DeleteLatestMessages(chanID string, pm readers.PageMetadata) error {
+	ctx := context.Background()
+	key := fmt.Sprintf("%s:%s", keyPrefix, chanID)
+
+	vals, err := lr.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	var fields []string
+	for field, v := range vals {
+		var msg senml.Message
+		if err := json.Unmarshal([]byte(v), &msg); err != nil {
+			return errors.Wrap(readers.ErrDeleteMessages, err)
+		}
+		if deleted(msg, pm) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if err := lr.client.HDel(ctx, key, fields...).Err(); err != nil {
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	return nil
+}
+
+// deleted checks whether the message matches the filters of the deletion.
+func deleted(msg senml.Message, pm readers.PageMetadata) bool {
+	switch {
+	case pm.Publisher != "" && msg.Publisher != pm.Publisher,
+		pm.Subtopic != "" && msg.Subtopic != pm.Subtopic,
+		pm.From != 0 && msg.Time < pm.From,
+		pm.To != 0 && msg.Time >= pm.To:
+		return false
+	default:
+		return true
+	}
+}
+
+func matches(msg senml.Message, pm readers.PageMetadata) bool {
+	switch {
+	case pm.Publisher != "" && msg.Publisher != pm.Publisher,
+		pm.Subtopic != "" && msg.Subtopic != pm.Subtopic,
+		pm.Protocol != "" && msg.Protocol != pm.Protocol,
+		pm.Name != "" && msg.Name != pm.Name:
+		return false
+	default:
+		return true
+	}
+}
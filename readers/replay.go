@@ -0,0 +1,315 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	senmllib "github.com/MainfluxLabs/senml"
+)
+
+// replayBatch is the number of the messages read from the database at once.
+const replayBatch = 100
+
+var (
+	// ErrReplayMessages indicates failure occurred while republishing the messages.
+	ErrReplayMessages = errors.New("failed to replay messages")
+
+	// ErrReplayNotFound indicates that the replay isn't running.
+	ErrReplayNotFound = errors.New("replay not found")
+
+	errUnknownMessage = errors.New("unknown message type")
+)
+
+// Replay represents republishing the messages of the channel onto the message
+// bus, e.g. to backfill a new consumer.
+type Replay struct {
+	// Channel is the channel the messages were sent to.
+	Channel string
+	// Target is the channel the messages are republished to.
+	Target string
+	// Subtopic replaces the subtopic of the messages, unless it's empty.
+	Subtopic string
+	// Rate is the number of the messages republished per second.
+	Rate float64
+	// PageMetadata selects the republished messages. The offset and the
+	// limit are ignored.
+	PageMetadata PageMetadata
+}
+
+// ReplayStatus represents the progress of the running replay.
+type ReplayStatus struct {
+	ID string
+	Replay
+	// Total is the number of the messages being replayed.
+	Total uint64
+	// Replayed is the number of the messages republished so far.
+	Replayed uint64
+	Started  time.Time
+}
+
+// Replayer republishes the stored messages onto the message bus.
+type Replayer interface {
+	// Replay starts republishing the messages in the background, from the
+	// oldest one, and returns the status of the replay. The messages keep
+	// their creation time and are marked as replayed.
+	Replay(r Replay) (ReplayStatus, error)
+
+	// ListReplays returns the status of the running replays of the channel,
+	// ordered by their start. The replays are kept in the memory of the
+	// reader instance that runs them.
+	ListReplays(chanID string) []ReplayStatus
+
+	// CancelReplay stops the running replay of the channel.
+	CancelReplay(chanID, id string) error
+}
+
+var _ Replayer = (*replayer)(nil)
+
+type replayer struct {
+	repo       MessageRepository
+	publisher  messaging.Publisher
+	idProvider mainflux.IDProvider
+	logger     logger.Logger
+	mu         sync.Mutex
+	replays    map[string]*running
+}
+
+// running is the replay run in the background.
+type running struct {
+	// replayed is accessed atomically, so it's kept first to be aligned.
+	replayed uint64
+	status   ReplayStatus
+	cancel   context.CancelFunc
+}
+
+// NewReplayer returns the replayer of the messages read from the repository.
+func NewReplayer(repo MessageRepository, publisher messaging.Publisher, idProvider mainflux.IDProvider, logger logger.Logger) Replayer {
+	return &replayer{
+		repo:       repo,
+		publisher:  publisher,
+		idProvider: idProvider,
+		logger:     logger,
+		replays:    make(map[string]*running),
+	}
+}
+
+func (rp *replayer) Replay(r Replay) (ReplayStatus, error) {
+	// The messages stored during the replay would shift the pages, so the
+	// time range ends at the start of the replay. The time range of the
+	// SenML messages is in seconds, and of the JSON messages in nanoseconds,
+	// as their created time.
+	if r.PageMetadata.To == 0 {
+		now := time.Now().UnixNano()
+		switch r.PageMetadata.Format {
+		case "", "messages":
+			r.PageMetadata.To = float64(now) / 1e9
+		default:
+			r.PageMetadata.To = float64(now)
+		}
+	}
+
+	pm := r.PageMetadata
+	pm.Offset, pm.Limit = 0, 1
+	page, err := rp.repo.ListChannelMessages(r.Channel, pm)
+	if err != nil {
+		return ReplayStatus{}, err
+	}
+
+	id, err := rp.idProvider.ID()
+	if err != nil {
+		return ReplayStatus{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &running{
+		status: ReplayStatus{
+			ID:      id,
+			Replay:  r,
+			Total:   page.Total,
+			Started: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+
+	rp.mu.Lock()
+	rp.replays[id] = run
+	rp.mu.Unlock()
+
+	go func() {
+		defer rp.remove(id)
+
+		n, err := rp.replay(ctx, run)
+		switch {
+		case errors.Is(err, context.Canceled):
+			rp.logger.Info(fmt.Sprintf("Replay of channel %s to channel %s cancelled after %d of %d messages", r.Channel, r.Target, n, page.Total))
+		case err != nil:
+			rp.logger.Warn(fmt.Sprintf("Replay of channel %s to channel %s stopped after %d of %d messages: %s", r.Channel, r.Target, n, page.Total, err))
+		default:
+			rp.logger.Info(fmt.Sprintf("Replayed %d messages of channel %s to channel %s", n, r.Channel, r.Target))
+		}
+	}()
+
+	return run.status, nil
+}
+
+func (rp *replayer) ListReplays(chanID string) []ReplayStatus {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	statuses := []ReplayStatus{}
+	for _, run := range rp.replays {
+		if run.status.Channel != chanID {
+			continue
+		}
+		status := run.status
+		status.Replayed = atomic.LoadUint64(&run.replayed)
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Started.Before(statuses[j].Started)
+	})
+
+	return statuses
+}
+
+func (rp *replayer) CancelReplay(chanID, id string) error {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	run, ok := rp.replays[id]
+	if !ok || run.status.Channel != chanID {
+		return ErrReplayNotFound
+	}
+	run.cancel()
+	delete(rp.replays, id)
+
+	return nil
+}
+
+func (rp *replayer) remove(id string) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	if run, ok := rp.replays[id]; ok {
+		run.cancel()
+		delete(rp.replays, id)
+	}
+}
+
+// replay republishes the messages page by page until it's done or cancelled.
+// The messages are read from the newest one, so the pages are read from the
+// last one.
+func (rp *replayer) replay(ctx context.Context, run *running) (uint64, error) {
+	r := run.status.Replay
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / r.Rate))
+	defer ticker.Stop()
+
+	var n uint64
+	pm := r.PageMetadata
+	for end := run.status.Total; end > 0; {
+		start := uint64(0)
+		if end > replayBatch {
+			start = end - replayBatch
+		}
+
+		pm.Offset, pm.Limit = start, end-start
+		page, err := rp.repo.ListChannelMessages(r.Channel, pm)
+		if err != nil {
+			return n, err
+		}
+
+		for i := len(page.Messages) - 1; i >= 0; i-- {
+			msg, err := replayMessage(page.Messages[i], r)
+			if err != nil {
+				return n, err
+			}
+
+			select {
+			case <-ctx.Done():
+				return n, ctx.Err()
+			case <-ticker.C:
+			}
+			if err := rp.publisher.Publish(msg.Channel, msg); err != nil {
+				return n, fmt.Errorf("%s: %w", ErrReplayMessages, err)
+			}
+			n++
+			atomic.StoreUint64(&run.replayed, n)
+		}
+		end = start
+	}
+
+	return n, nil
+}
+
+// replayMessage converts the stored message into the replayed one. The SenML
+// message is republished as a single record pack.
+func replayMessage(m Message, r Replay) (messaging.Message, error) {
+	msg := messaging.Message{
+		Channel: r.Target,
+		Replay:  true,
+	}
+
+	switch m := m.(type) {
+	case senml.Message:
+		rec := senmllib.Record{
+			Name:        m.Name,
+			Unit:        m.Unit,
+			Time:        m.Time,
+			UpdateTime:  m.UpdateTime,
+			Value:       m.Value,
+			StringValue: m.StringValue,
+			DataValue:   m.DataValue,
+			BoolValue:   m.BoolValue,
+			Sum:         m.Sum,
+		}
+		payload, err := senmllib.Encode(senmllib.Pack{Records: []senmllib.Record{rec}}, senmllib.JSON)
+		if err != nil {
+			return messaging.Message{}, err
+		}
+
+		msg.Subtopic = m.Subtopic
+		msg.Publisher = m.Publisher
+		msg.Protocol = m.Protocol
+		msg.Payload = payload
+		msg.Created = int64(m.Time * 1e9)
+	case map[string]interface{}:
+		payload, err := json.Marshal(m["payload"])
+		if err != nil {
+			return messaging.Message{}, err
+		}
+
+		msg.Subtopic, _ = m["subtopic"].(string)
+		msg.Publisher, _ = m["publisher"].(string)
+		msg.Protocol, _ = m["protocol"].(string)
+		msg.Payload = payload
+		switch created := m["created"].(type) {
+		case int64:
+			msg.Created = created
+		case float64:
+			msg.Created = int64(created)
+		case json.Number:
+			msg.Created, _ = created.Int64()
+		}
+	default:
+		return messaging.Message{}, errUnknownMessage
+	}
+
+	if r.Subtopic != "" {
+		msg.Subtopic = r.Subtopic
+	}
+
+	return msg, nil
+}
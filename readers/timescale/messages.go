@@ -6,11 +6,14 @@ package timescale
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx" // required for DB access
 )
@@ -38,14 +41,24 @@ func New(db *sqlx.DB) readers.MessageRepository {
 	}
 }
 func (tr timescaleRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return tr.readAll("", rpm)
+	return tr.readAll(nil, rpm)
 }
 
 func (tr timescaleRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
-	return tr.readAll(chanID, rpm)
+	return tr.readAll([]string{chanID}, rpm)
 }
 
-func (tr timescaleRepository) readAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+func (tr timescaleRepository) ListMessagesByChannels(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if len(chanIDs) == 0 {
+		return readers.MessagesPage{PageMetadata: rpm, Messages: []readers.Message{}}, nil
+	}
+
+	return tr.readAll(chanIDs, rpm)
+}
+
+// readAll reads the messages of the given channels, or of all the channels if
+// none are given.
+func (tr timescaleRepository) readAll(chanIDs []string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
 	order := "time"
 	format := defTable
 
@@ -59,10 +72,8 @@ func (tr timescaleRepository) readAll(chanID string, rpm readers.PageMetadata) (
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY %s DESC %s;`, format, fmtCondition(chanID, rpm), order, olq)
-
 	params := map[string]interface{}{
-		"channel":      chanID,
+		"channels":     chanIDs,
 		"limit":        rpm.Limit,
 		"offset":       rpm.Offset,
 		"subtopic":     rpm.Subtopic,
@@ -77,6 +88,15 @@ func (tr timescaleRepository) readAll(chanID string, rpm readers.PageMetadata) (
 		"to":           rpm.To,
 	}
 
+	columns, condition := "*", fmtCondition(chanIDs, rpm, order)
+	if format != defTable {
+		condition = fmtPayloadCondition(condition, rpm.Filters, params)
+		if len(rpm.Fields) > 0 {
+			columns = fmt.Sprintf(`channel, created, subtopic, publisher, protocol, %s AS payload`, fmtProjection(rpm.Fields, params))
+		}
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM %s %s ORDER BY %s DESC %s;`, columns, format, condition, order, olq)
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -117,7 +137,7 @@ func (tr timescaleRepository) readAll(chanID string, rpm readers.PageMetadata) (
 
 	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s %s;`, format, fmtCondition(chanID, rpm))
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s %s;`, format, condition)
 	rows, err = tr.db.NamedQuery(q, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
@@ -135,7 +155,54 @@ func (tr timescaleRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
-func fmtCondition(chanID string, rpm readers.PageMetadata) string {
+func (tr timescaleRepository) DeleteMessages(chanID string, rpm readers.PageMetadata) error {
+	table, timeColumn := defTable, "time"
+	if rpm.Format != "" && rpm.Format != defTable {
+		table, timeColumn = rpm.Format, "created"
+	}
+
+	q := fmt.Sprintf(`DELETE FROM %s %s;`, pgx.Identifier{table}.Sanitize(), fmtDeleteCondition(rpm, timeColumn))
+	params := map[string]interface{}{
+		"channel":   chanID,
+		"subtopic":  rpm.Subtopic,
+		"publisher": rpm.Publisher,
+		"from":      rpm.From,
+		"to":        rpm.To,
+	}
+
+	if _, err := tr.db.NamedExec(q, params); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UndefinedTable {
+			return nil
+		}
+		return errors.Wrap(readers.ErrDeleteMessages, err)
+	}
+
+	return nil
+}
+
+// fmtDeleteCondition matches the channel messages by the filters that apply
+// to the deletion.
+func fmtDeleteCondition(rpm readers.PageMetadata, timeColumn string) string {
+	condition := `WHERE channel = :channel`
+	if rpm.Subtopic != "" {
+		condition = fmt.Sprintf(`%s AND subtopic = :subtopic`, condition)
+	}
+	if rpm.Publisher != "" {
+		condition = fmt.Sprintf(`%s AND publisher = :publisher`, condition)
+	}
+	if rpm.From != 0 {
+		condition = fmt.Sprintf(`%s AND %s >= :from`, condition, timeColumn)
+	}
+	if rpm.To != 0 {
+		condition = fmt.Sprintf(`%s AND %s < :to`, condition, timeColumn)
+	}
+	return condition
+}
+
+// fmtCondition matches the messages by the filters of the page metadata. The
+// time range is compared with the given column, which is the created column
+// of the JSON messages.
+func fmtCondition(chanIDs []string, rpm readers.PageMetadata, timeColumn string) string {
 	var query map[string]interface{}
 	meta, err := json.Marshal(rpm)
 	if err != nil {
@@ -145,8 +212,8 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 
 	condition := ""
 	op := "WHERE"
-	if chanID != "" {
-		condition = fmt.Sprintf(`%s channel = :channel`, op)
+	if len(chanIDs) > 0 {
+		condition = fmt.Sprintf(`%s channel = ANY(:channels)`, op)
 		op = "AND"
 	}
 
@@ -173,15 +240,96 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 			condition = fmt.Sprintf(`%s %s data_value = :data_value`, condition, op)
 			op = "AND"
 		case "from":
-			condition = fmt.Sprintf(`%s %s time >= :from`, condition, op)
+			condition = fmt.Sprintf(`%s %s %s >= :from`, condition, op, timeColumn)
 			op = "AND"
 		case "to":
-			condition = fmt.Sprintf(`%s %s time < :to`, condition, op)
+			condition = fmt.Sprintf(`%s %s %s < :to`, condition, op, timeColumn)
 		}
 	}
 	return condition
 }
 
+// fmtPayloadCondition appends the payload filters to the condition and binds
+// their paths and values to the params. The field matches the filter only if
+// it's of the same JSON type as the value.
+func fmtPayloadCondition(condition string, filters []readers.PayloadFilter, params map[string]interface{}) string {
+	op := "AND"
+	if condition == "" {
+		op = "WHERE"
+	}
+
+	for i, f := range filters {
+		path, value := fmt.Sprintf("payload_path_%d", i), fmt.Sprintf("payload_value_%d", i)
+		params[path] = strings.Split(f.Path, ".")
+		params[value] = f.Value
+
+		typ, cast := "string", "text"
+		switch f.Value.(type) {
+		case float64:
+			typ, cast = "number", "double precision"
+		case bool:
+			typ, cast = "boolean", "boolean"
+		}
+		condition = fmt.Sprintf(`%s %s jsonb_typeof(payload #> CAST(:%s AS text[])) = '%s' AND payload #> CAST(:%s AS text[]) %s to_jsonb(CAST(:%s AS %s))`,
+			condition, op, path, typ, path, f.Operator(), value, cast)
+		op = "AND"
+	}
+
+	return condition
+}
+
+// fmtProjection returns the expression building the payload of the given
+// fields, keeping their nesting, and binds their keys and paths to the params.
+// The fields missing from the payload are left out.
+func fmtProjection(fields []string, params map[string]interface{}) string {
+	tree := map[string]interface{}{}
+	for _, f := range fields {
+		keys := strings.Split(f, ".")
+		node := tree
+		for _, k := range keys[:len(keys)-1] {
+			next, ok := node[k].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				node[k] = next
+			}
+			node = next
+		}
+		node[keys[len(keys)-1]] = nil
+	}
+
+	var n int
+	var build func(node map[string]interface{}, path []string) string
+	build = func(node map[string]interface{}, path []string) string {
+		keys := make([]string, 0, len(node))
+		for k := range node {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var args []string
+		for _, k := range keys {
+			p := append(append([]string{}, path...), k)
+			key := fmt.Sprintf("field_%d", n)
+			n++
+			params[key] = k
+
+			var value string
+			switch sub := node[k].(type) {
+			case map[string]interface{}:
+				value = build(sub, p)
+			default:
+				value = fmt.Sprintf("payload #> CAST(:%s_path AS text[])", key)
+				params[key+"_path"] = p
+			}
+			args = append(args, fmt.Sprintf("CAST(:%s AS text), %s", key, value))
+		}
+
+		return fmt.Sprintf("jsonb_build_object(%s)", strings.Join(args, ", "))
+	}
+
+	return fmt.Sprintf("jsonb_strip_nulls(%s)", build(tree, nil))
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
@@ -13,6 +13,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	treader "github.com/MainfluxLabs/mainflux/readers/timescale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -504,6 +505,20 @@ func TestListChannelMessagesJSON(t *testing.T) {
 				Messages: fromJSON(httpMsgs),
 			},
 		},
+		"read messages within time range": {
+			chanID: id2,
+			pageMeta: readers.PageMetadata{
+				Format: messages2.Format,
+				Offset: zeroOffset,
+				Limit:  msgsNum,
+				From:   float64(timeNow - 9),
+				To:     float64(timeNow + 1),
+			},
+			page: readers.MessagesPage{
+				Total:    10,
+				Messages: fromJSON(msgs2[:10]),
+			},
+		},
 	}
 
 	for desc, tc := range cases {
@@ -903,6 +918,19 @@ func TestListAllMessagesJSON(t *testing.T) {
 	}
 }
 
+func TestListJSONMessagesByPayload(t *testing.T) {
+	readerstest.ListJSONMessagesByPayload(t, twriter.New(db), treader.New(db))
+}
+
+func TestListMessagesByChannels(t *testing.T) {
+	readerstest.ListMessagesByChannels(t, twriter.New(db), treader.New(db))
+}
+
+func TestDeleteMessages(t *testing.T) {
+	audit := treader.NewAuditRepository(db)
+	readerstest.DeleteMessages(t, twriter.New(db), treader.New(db), audit)
+}
+
 func fromSenml(msg []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range msg {
@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := svc.CreateSchedule(ctx, req.token, req.schedule())
+		if err != nil {
+			return nil, err
+		}
+
+		return createScheduleRes{ID: s.ID}, nil
+	}
+}
+
+func viewScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := svc.ViewSchedule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toScheduleRes(s), nil
+	}
+}
+
+func listSchedulesEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSchedulesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListSchedules(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := schedulesPageRes{
+			Total:     page.Total,
+			Offset:    page.Offset,
+			Limit:     page.Limit,
+			Schedules: []scheduleRes{},
+		}
+		for _, s := range page.Schedules {
+			res.Schedules = append(res.Schedules, toScheduleRes(s))
+		}
+
+		return res, nil
+	}
+}
+
+func updateScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateSchedule(ctx, req.token, req.schedule()); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusOK}, nil
+	}
+}
+
+func removeScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveSchedule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return emptyRes{code: http.StatusNoContent}, nil
+	}
+}
+
+func listExecutionsEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExecutionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListExecutions(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := executionsPageRes{
+			Total:      page.Total,
+			Offset:     page.Offset,
+			Limit:      page.Limit,
+			Executions: []executionRes{},
+		}
+		for _, e := range page.Executions {
+			res.Executions = append(res.Executions, executionRes{
+				ID:       e.ID,
+				Due:      e.Due,
+				Executed: e.Executed,
+				Status:   e.Status,
+				Error:    e.Error,
+			})
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,533 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/MainfluxLabs/mainflux/scheduler/api"
+	"github.com/MainfluxLabs/mainflux/scheduler/mocks"
+	thmocks "github.com/MainfluxLabs/mainflux/things/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	token       = "token"
+	wrongValue  = "wrong-value"
+	email       = "user@example.com"
+	chanID      = "1"
+	otherChanID = "2"
+)
+
+var payload = []byte(`{"calibrate":true}`)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+type testEnv struct {
+	svc    scheduler.Service
+	runner scheduler.Runner
+	ts     *httptest.Server
+}
+
+func newTestEnv() testEnv {
+	auth := thmocks.NewAuthService(map[string]string{token: email})
+	things := pkgmocks.NewThingsService(map[string]string{email: chanID}, nil)
+	schedules := mocks.NewScheduleRepository()
+	executions := mocks.NewExecutionRepository()
+	idp := uuid.NewMock()
+
+	svc := scheduler.New(auth, things, schedules, executions, idp)
+	mux := api.MakeHandler(svc, mocktracer.New(), logger.NewMock())
+
+	return testEnv{
+		svc:    svc,
+		runner: scheduler.NewRunner(things, mocks.NewPublisher(), schedules, executions, idp, logger.NewMock()),
+		ts:     httptest.NewServer(mux),
+	}
+}
+
+type scheduleRes struct {
+	ID      string          `json:"id"`
+	Cron    string          `json:"cron"`
+	Payload json.RawMessage `json:"payload"`
+	Enabled bool            `json:"enabled"`
+	NextRun *time.Time      `json:"next_run"`
+}
+
+type schedulesPageRes struct {
+	Total     uint          `json:"total"`
+	Schedules []scheduleRes `json:"schedules"`
+}
+
+type executionsPageRes struct {
+	Total      uint `json:"total"`
+	Executions []struct {
+		Status string `json:"status"`
+	} `json:"executions"`
+}
+
+func TestCreateSchedule(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	at := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	cases := []struct {
+		desc        string
+		body        string
+		contentType string
+		token       string
+		status      int
+	}{
+		{
+			desc:        "create recurring schedule",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"0 2 * * *","time_zone":"Europe/Belgrade"}`, chanID, payload),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create one-shot schedule",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"at":"%s"}`, chanID, payload, at),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create schedule with invalid cron",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"every day"}`, chanID, payload),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule without cron and time",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s}`, chanID, payload),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule without channel",
+			body:        fmt.Sprintf(`{"payload":%s,"cron":"@daily"}`, payload),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule without payload",
+			body:        fmt.Sprintf(`{"channel_id":"%s","cron":"@daily"}`, chanID),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule on other user's channel",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@daily"}`, otherChanID, payload),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create schedule with invalid token",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@daily"}`, chanID, payload),
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule with malformed body",
+			body:        "}",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule without content type",
+			body:        fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@daily"}`, chanID, payload),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      env.ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/schedules", env.ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusCreated {
+			location := res.Header.Get("Location")
+			assert.True(t, strings.HasPrefix(location, "/schedules/"), fmt.Sprintf("%s: expected location of the schedule got %s", tc.desc, location))
+		}
+	}
+}
+
+func TestViewSchedule(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view existing schedule",
+			id:     sch.ID,
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view non-existing schedule",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view schedule with invalid token",
+			id:     sch.ID,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view schedule with empty token",
+			id:     sch.ID,
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: env.ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules/%s", env.ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body scheduleRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, sch.ID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, sch.ID, body.ID))
+		assert.Equal(t, string(payload), string(body.Payload), fmt.Sprintf("%s: expected payload %s got %s", tc.desc, payload, body.Payload))
+		assert.NotNil(t, body.NextRun, fmt.Sprintf("%s: expected next run", tc.desc))
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		body   string
+		token  string
+		status int
+	}{
+		{
+			desc:   "update schedule",
+			id:     sch.ID,
+			body:   fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@hourly","enabled":false}`, chanID, payload),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update schedule with invalid cron",
+			id:     sch.ID,
+			body:   fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@sometimes"}`, chanID, payload),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "update non-existing schedule",
+			id:     wrongValue,
+			body:   fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@hourly"}`, chanID, payload),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "update schedule with invalid token",
+			id:     sch.ID,
+			body:   fmt.Sprintf(`{"channel_id":"%s","payload":%s,"cron":"@hourly"}`, chanID, payload),
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      env.ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/schedules/%s", env.ts.URL, tc.id),
+			contentType: contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	saved, err := env.svc.ViewSchedule(context.Background(), token, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "@hourly", saved.Cron, fmt.Sprintf("expected cron @hourly got %s", saved.Cron))
+	assert.False(t, saved.Enabled, "expected disabled schedule")
+}
+
+func TestRemoveSchedule(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove schedule with invalid token",
+			id:     sch.ID,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove existing schedule",
+			id:     sch.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed schedule",
+			id:     sch.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: env.ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/schedules/%s", env.ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListSchedules(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+		total  uint
+	}{
+		{
+			desc:   "list all schedules",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			size:   3,
+			total:  3,
+		},
+		{
+			desc:   "list schedules with limit",
+			query:  "?offset=1&limit=1",
+			token:  token,
+			status: http.StatusOK,
+			size:   1,
+			total:  3,
+		},
+		{
+			desc:   "list schedules of other channel",
+			query:  fmt.Sprintf("?channel=%s", otherChanID),
+			token:  token,
+			status: http.StatusOK,
+			size:   0,
+			total:  0,
+		},
+		{
+			desc:   "list schedules with limit too big",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list schedules with invalid offset",
+			query:  "?offset=first",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list schedules with invalid token",
+			query:  "",
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: env.ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules%s", env.ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var page schedulesPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.size, len(page.Schedules), fmt.Sprintf("%s: expected %d schedules got %d", tc.desc, tc.size, len(page.Schedules)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestListExecutions(t *testing.T) {
+	env := newTestEnv()
+	defer env.ts.Close()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "* * * * *", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	for i := 1; i <= 3; i++ {
+		err := env.runner.RunDue(context.Background(), now.Add(time.Duration(i)*time.Minute))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		id     string
+		query  string
+		token  string
+		status int
+		size   int
+		total  uint
+	}{
+		{
+			desc:   "list all executions",
+			id:     sch.ID,
+			token:  token,
+			status: http.StatusOK,
+			size:   3,
+			total:  3,
+		},
+		{
+			desc:   "list executions with limit",
+			id:     sch.ID,
+			query:  "?limit=2",
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+			total:  3,
+		},
+		{
+			desc:   "list executions of non-existing schedule",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list executions with invalid token",
+			id:     sch.ID,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: env.ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules/%s/executions%s", env.ts.URL, tc.id, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var page executionsPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.size, len(page.Executions), fmt.Sprintf("%s: expected %d executions got %d", tc.desc, tc.size, len(page.Executions)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		for _, e := range page.Executions {
+			assert.Equal(t, scheduler.ExecutionSucceeded, e.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, scheduler.ExecutionSucceeded, e.Status))
+		}
+	}
+}
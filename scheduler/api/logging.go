@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+)
+
+var _ scheduler.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    scheduler.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc scheduler.Service, logger log.Logger) scheduler.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateSchedule(ctx context.Context, token string, s scheduler.Schedule) (sch scheduler.Schedule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_schedule with id %s on channel %s took %s to complete", sch.ID, s.ChannelID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateSchedule(ctx, token, s)
+}
+
+func (lm *loggingMiddleware) ViewSchedule(ctx context.Context, token, id string) (s scheduler.Schedule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_schedule for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewSchedule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListSchedules(ctx context.Context, token string, pm scheduler.PageMetadata) (p scheduler.SchedulesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_schedules took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListSchedules(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) UpdateSchedule(ctx context.Context, token string, s scheduler.Schedule) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_schedule for id %s took %s to complete", s.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateSchedule(ctx, token, s)
+}
+
+func (lm *loggingMiddleware) RemoveSchedule(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_schedule for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveSchedule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListExecutions(ctx context.Context, token, id string, pm scheduler.PageMetadata) (p scheduler.ExecutionsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_executions for schedule %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListExecutions(ctx, token, id, pm)
+}
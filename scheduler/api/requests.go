@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+)
+
+const (
+	maxLimitSize = 100
+	maxNameSize  = 254
+)
+
+type scheduleReq struct {
+	token     string
+	id        string
+	Name      string          `json:"name,omitempty"`
+	ChannelID string          `json:"channel_id"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	Cron      string          `json:"cron,omitempty"`
+	TimeZone  string          `json:"time_zone,omitempty"`
+	At        *time.Time      `json:"at,omitempty"`
+	Enabled   *bool           `json:"enabled,omitempty"`
+}
+
+func (req scheduleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if len(req.Payload) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+func (req scheduleReq) schedule() scheduler.Schedule {
+	s := scheduler.Schedule{
+		ID:        req.id,
+		Name:      req.Name,
+		ChannelID: req.ChannelID,
+		Subtopic:  req.Subtopic,
+		Payload:   req.Payload,
+		Cron:      req.Cron,
+		TimeZone:  req.TimeZone,
+		// Schedules are enabled unless stated otherwise.
+		Enabled: req.Enabled == nil || *req.Enabled,
+	}
+	if req.At != nil {
+		s.At = req.At.UTC()
+	}
+
+	return s
+}
+
+type createScheduleReq struct {
+	scheduleReq
+}
+
+type updateScheduleReq struct {
+	scheduleReq
+}
+
+func (req updateScheduleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.scheduleReq.validate()
+}
+
+type viewScheduleReq struct {
+	token string
+	id    string
+}
+
+func (req viewScheduleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listSchedulesReq struct {
+	token        string
+	pageMetadata scheduler.PageMetadata
+}
+
+func (req listSchedulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type listExecutionsReq struct {
+	token        string
+	id           string
+	pageMetadata scheduler.PageMetadata
+}
+
+func (req listExecutionsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
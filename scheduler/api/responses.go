@@ -0,0 +1,169 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+)
+
+var (
+	_ mainflux.Response = (*createScheduleRes)(nil)
+	_ mainflux.Response = (*scheduleRes)(nil)
+	_ mainflux.Response = (*schedulesPageRes)(nil)
+	_ mainflux.Response = (*executionsPageRes)(nil)
+	_ mainflux.Response = (*emptyRes)(nil)
+)
+
+type createScheduleRes struct {
+	ID string
+}
+
+func (res createScheduleRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res createScheduleRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/schedules/%s", res.ID),
+	}
+}
+
+func (res createScheduleRes) Empty() bool {
+	return true
+}
+
+type scheduleRes struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name,omitempty"`
+	ChannelID string          `json:"channel_id"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	Cron      string          `json:"cron,omitempty"`
+	TimeZone  string          `json:"time_zone,omitempty"`
+	At        *time.Time      `json:"at,omitempty"`
+	Enabled   bool            `json:"enabled"`
+	NextRun   *time.Time      `json:"next_run,omitempty"`
+	Created   time.Time       `json:"created"`
+	Updated   time.Time       `json:"updated"`
+}
+
+func (res scheduleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res scheduleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res scheduleRes) Empty() bool {
+	return false
+}
+
+type schedulesPageRes struct {
+	Total     uint          `json:"total"`
+	Offset    uint          `json:"offset"`
+	Limit     uint          `json:"limit"`
+	Schedules []scheduleRes `json:"schedules"`
+}
+
+func (res schedulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res schedulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res schedulesPageRes) Empty() bool {
+	return false
+}
+
+type executionRes struct {
+	ID       string    `json:"id"`
+	Due      time.Time `json:"due"`
+	Executed time.Time `json:"executed"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type executionsPageRes struct {
+	Total      uint           `json:"total"`
+	Offset     uint           `json:"offset"`
+	Limit      uint           `json:"limit"`
+	Executions []executionRes `json:"executions"`
+}
+
+func (res executionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res executionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res executionsPageRes) Empty() bool {
+	return false
+}
+
+type emptyRes struct {
+	code int
+}
+
+func (res emptyRes) Code() int {
+	return res.code
+}
+
+func (res emptyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res emptyRes) Empty() bool {
+	return true
+}
+
+func toScheduleRes(s scheduler.Schedule) scheduleRes {
+	res := scheduleRes{
+		ID:        s.ID,
+		Name:      s.Name,
+		ChannelID: s.ChannelID,
+		Subtopic:  s.Subtopic,
+		Payload:   toJSON(s.Payload),
+		Cron:      s.Cron,
+		TimeZone:  s.TimeZone,
+		Enabled:   s.Enabled,
+		Created:   s.Created,
+		Updated:   s.Updated,
+	}
+	if !s.At.IsZero() {
+		at := s.At
+		res.At = &at
+	}
+	if !s.NextRun.IsZero() {
+		next := s.NextRun
+		res.NextRun = &next
+	}
+
+	return res
+}
+
+// toJSON returns the JSON payloads as they are, and the other
+// payloads encoded as JSON strings.
+func toJSON(payload []byte) json.RawMessage {
+	if len(payload) == 0 || json.Valid(payload) {
+		return payload
+	}
+
+	data, err := json.Marshal(string(payload))
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
@@ -0,0 +1,224 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	channelKey  = "channel"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc scheduler.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux := bone.New()
+
+	mux.Post("/schedules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_schedule")(createScheduleEndpoint(svc)),
+		decodeCreateSchedule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules/:id/executions", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_executions")(listExecutionsEndpoint(svc)),
+		decodeListExecutions,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_schedule")(viewScheduleEndpoint(svc)),
+		decodeViewSchedule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_schedules")(listSchedulesEndpoint(svc)),
+		decodeListSchedules,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/schedules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_schedule")(updateScheduleEndpoint(svc)),
+		decodeUpdateSchedule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/schedules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_schedule")(removeScheduleEndpoint(svc)),
+		decodeViewSchedule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/health", mainflux.Health("scheduler"))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateSchedule(_ context.Context, r *http.Request) (interface{}, error) {
+	req := createScheduleReq{}
+	if err := decodeSchedule(r, &req.scheduleReq); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeUpdateSchedule(_ context.Context, r *http.Request) (interface{}, error) {
+	req := updateScheduleReq{}
+	if err := decodeSchedule(r, &req.scheduleReq); err != nil {
+		return nil, err
+	}
+	req.id = bone.GetValue(r, "id")
+
+	return req, nil
+}
+
+func decodeSchedule(r *http.Request, req *scheduleReq) error {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return apiutil.ErrUnsupportedContentType
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+	req.token = apiutil.ExtractBearerToken(r)
+
+	return nil
+}
+
+func decodeViewSchedule(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewScheduleReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeListSchedules(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := readPageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+
+	chanID, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+	pm.ChannelID = chanID
+
+	req := listSchedulesReq{
+		token:        apiutil.ExtractBearerToken(r),
+		pageMetadata: pm,
+	}
+
+	return req, nil
+}
+
+func decodeListExecutions(_ context.Context, r *http.Request) (interface{}, error) {
+	pm, err := readPageMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listExecutionsReq{
+		token:        apiutil.ExtractBearerToken(r),
+		id:           bone.GetValue(r, "id"),
+		pageMetadata: pm,
+	}
+
+	return req, nil
+}
+
+func readPageMetadata(r *http.Request) (scheduler.PageMetadata, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return scheduler.PageMetadata{}, err
+	}
+
+	limit, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return scheduler.PageMetadata{}, err
+	}
+
+	return scheduler.PageMetadata{Offset: uint(offset), Limit: uint(limit)}, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		err == apiutil.ErrNameSize,
+		err == apiutil.ErrLimitSize,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, scheduler.ErrInvalidSchedule),
+		errors.Contains(err, scheduler.ErrInvalidCron):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrInvalidCron indicates a malformed cron expression.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// maxSearchYears bounds the search for the next activation of the
+// expressions which can never be activated, such as "0 0 30 2 *".
+const maxSearchYears = 5
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// Cron represents the parsed standard cron expression with the minute, hour,
+// day of month, month and day of week fields.
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// Days of month and week are matched by either of them if both are restricted.
+	domStar, dowStar bool
+}
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+// ParseCron parses the cron expression. Each of the five fields is either
+// "*", a value, a range ("1-5") or a list of those ("1,3-5"), optionally
+// followed by a step ("*/15"). Months and days of week can be given by their
+// three-letter names. The "@yearly", "@monthly", "@weekly", "@daily" and
+// "@hourly" descriptors are supported as well.
+func ParseCron(expr string) (Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Cron{}, errors.Wrap(ErrInvalidCron, fmt.Errorf("expected 5 fields, got %d", len(fields)))
+	}
+
+	var c Cron
+	var err error
+	if c.minute, err = parseField(fields[0], bounds{0, 59, nil}); err != nil {
+		return Cron{}, err
+	}
+	if c.hour, err = parseField(fields[1], bounds{0, 23, nil}); err != nil {
+		return Cron{}, err
+	}
+	if c.dom, err = parseField(fields[2], bounds{1, 31, nil}); err != nil {
+		return Cron{}, err
+	}
+	if c.month, err = parseField(fields[3], bounds{1, 12, monthNames}); err != nil {
+		return Cron{}, err
+	}
+	if c.dow, err = parseField(fields[4], bounds{0, 7, dayNames}); err != nil {
+		return Cron{}, err
+	}
+	// Both 0 and 7 represent Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// Next returns the first activation time strictly after the given time, in
+// the location of the given time. Zero time is returned if the expression
+// can't be activated in the next few years.
+func (c Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c Cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		p, err := parsePart(part, b)
+		if err != nil {
+			return 0, errors.Wrap(ErrInvalidCron, err)
+		}
+		bits |= p
+	}
+
+	return bits, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	rng, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		s, err := strconv.Atoi(part[i+1:])
+		if err != nil || s <= 0 {
+			return 0, fmt.Errorf("invalid step in %q", part)
+		}
+		rng, step = part[:i], s
+	}
+
+	start, end := b.min, b.max
+	switch {
+	case rng == "*":
+	case strings.Contains(rng, "-"):
+		i := strings.Index(rng, "-")
+		var err error
+		if start, err = parseValue(rng[:i], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(rng[i+1:], b); err != nil {
+			return 0, err
+		}
+	default:
+		v, err := parseValue(rng, b)
+		if err != nil {
+			return 0, err
+		}
+		start = v
+		// A single value with a step, e.g. "5/15", runs to the maximum.
+		if step == 1 {
+			end = v
+		}
+	}
+	if start > end {
+		return 0, fmt.Errorf("invalid range in %q", part)
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %q out of range %d-%d", s, b.min, b.max)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		desc string
+		expr string
+		err  error
+	}{
+		{desc: "parse every minute", expr: "* * * * *", err: nil},
+		{desc: "parse lists, ranges and steps", expr: "0,30 8-18/2 1-15 */3 1-5", err: nil},
+		{desc: "parse names", expr: "0 0 * jan-jun MON,fri", err: nil},
+		{desc: "parse descriptor", expr: "@daily", err: nil},
+		{desc: "parse too few fields", expr: "* * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse value out of range", expr: "60 * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse inverted range", expr: "* 18-8 * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse zero step", expr: "*/0 * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse unknown name", expr: "* * * * someday", err: scheduler.ErrInvalidCron},
+		{desc: "parse unknown descriptor", expr: "@sometimes", err: scheduler.ErrInvalidCron},
+	}
+
+	for _, tc := range cases {
+		_, err := scheduler.ParseCron(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	belgrade, err := time.LoadLocation("Europe/Belgrade")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Wednesday.
+	now := time.Date(2023, time.March, 15, 10, 20, 30, 0, time.UTC)
+
+	cases := []struct {
+		desc string
+		expr string
+		now  time.Time
+		next time.Time
+	}{
+		{
+			desc: "next minute",
+			expr: "* * * * *",
+			now:  now,
+			next: time.Date(2023, time.March, 15, 10, 21, 0, 0, time.UTC),
+		},
+		{
+			desc: "next quarter",
+			expr: "*/15 * * * *",
+			now:  now,
+			next: time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "next night",
+			expr: "0 2 * * *",
+			now:  now,
+			next: time.Date(2023, time.March, 16, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next weekday",
+			expr: "0 9 * * mon",
+			now:  now,
+			next: time.Date(2023, time.March, 20, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next Sunday as 7",
+			expr: "0 9 * * 7",
+			now:  now,
+			next: time.Date(2023, time.March, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next month",
+			expr: "@monthly",
+			now:  now,
+			next: time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next day of month or week",
+			expr: "0 0 20 * fri",
+			now:  now,
+			next: time.Date(2023, time.March, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next leap day",
+			expr: "0 0 29 2 *",
+			now:  now,
+			next: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next night in time zone",
+			expr: "0 2 * * *",
+			now:  now.In(belgrade),
+			next: time.Date(2023, time.March, 16, 2, 0, 0, 0, belgrade),
+		},
+		{
+			desc: "never",
+			expr: "0 0 30 2 *",
+			now:  now,
+			next: time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := scheduler.ParseCron(tc.expr)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		next := c.Next(tc.now)
+		assert.True(t, tc.next.Equal(next), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.next, next))
+	}
+}
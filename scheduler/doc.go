@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scheduler contains the domain concept definitions needed to support
+// Mainflux scheduler service functionality. Scheduler service publishes the
+// configured payloads to channels at one-shot times or by cron expressions,
+// on behalf of the channel owners.
+package scheduler
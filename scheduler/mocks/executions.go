@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/scheduler"
+)
+
+var _ scheduler.ExecutionRepository = (*executionRepositoryMock)(nil)
+
+type executionRepositoryMock struct {
+	mu         sync.Mutex
+	executions []scheduler.Execution
+}
+
+// NewExecutionRepository creates in-memory execution repository.
+func NewExecutionRepository() scheduler.ExecutionRepository {
+	return &executionRepositoryMock{}
+}
+
+func (erm *executionRepositoryMock) Save(_ context.Context, e scheduler.Execution) error {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	erm.executions = append(erm.executions, e)
+
+	return nil
+}
+
+func (erm *executionRepositoryMock) RetrieveAll(_ context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	var executions []scheduler.Execution
+	for _, e := range erm.executions {
+		if e.ScheduleID == scheduleID {
+			executions = append(executions, e)
+		}
+	}
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].Executed.After(executions[j].Executed)
+	})
+
+	return scheduler.ExecutionsPage{
+		PageMetadata: pm,
+		Total:        uint(len(executions)),
+		Executions:   executions[start(pm, len(executions)):end(pm, len(executions))],
+	}, nil
+}
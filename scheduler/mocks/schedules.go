@@ -0,0 +1,139 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+)
+
+var _ scheduler.ScheduleRepository = (*scheduleRepositoryMock)(nil)
+
+type scheduleRepositoryMock struct {
+	mu        sync.Mutex
+	schedules map[string]scheduler.Schedule
+}
+
+// NewScheduleRepository creates in-memory schedule repository.
+func NewScheduleRepository() scheduler.ScheduleRepository {
+	return &scheduleRepositoryMock{
+		schedules: make(map[string]scheduler.Schedule),
+	}
+}
+
+func (srm *scheduleRepositoryMock) Save(_ context.Context, s scheduler.Schedule) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if _, ok := srm.schedules[s.ID]; ok {
+		return errors.ErrConflict
+	}
+	srm.schedules[s.ID] = s
+
+	return nil
+}
+
+func (srm *scheduleRepositoryMock) Update(_ context.Context, s scheduler.Schedule) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if c, ok := srm.schedules[s.ID]; !ok || c.OwnerID != s.OwnerID {
+		return errors.ErrNotFound
+	}
+	srm.schedules[s.ID] = s
+
+	return nil
+}
+
+func (srm *scheduleRepositoryMock) RetrieveByID(_ context.Context, owner, id string) (scheduler.Schedule, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	s, ok := srm.schedules[id]
+	if !ok || s.OwnerID != owner {
+		return scheduler.Schedule{}, errors.ErrNotFound
+	}
+
+	return s, nil
+}
+
+func (srm *scheduleRepositoryMock) RetrieveAll(_ context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var schedules []scheduler.Schedule
+	for _, s := range srm.schedules {
+		if s.OwnerID != owner || (pm.ChannelID != "" && s.ChannelID != pm.ChannelID) {
+			continue
+		}
+		schedules = append(schedules, s)
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+
+	return scheduler.SchedulesPage{
+		PageMetadata: pm,
+		Total:        uint(len(schedules)),
+		Schedules:    schedules[start(pm, len(schedules)):end(pm, len(schedules))],
+	}, nil
+}
+
+func (srm *scheduleRepositoryMock) RetrieveDue(_ context.Context, now time.Time, limit uint) ([]scheduler.Schedule, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var due []scheduler.Schedule
+	for _, s := range srm.schedules {
+		if s.Enabled && !s.NextRun.IsZero() && !s.NextRun.After(now) && uint(len(due)) < limit {
+			due = append(due, s)
+		}
+	}
+
+	return due, nil
+}
+
+func (srm *scheduleRepositoryMock) Claim(_ context.Context, id string, due, next time.Time) (bool, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	s, ok := srm.schedules[id]
+	if !ok || !s.Enabled || !s.NextRun.Equal(due) {
+		return false, nil
+	}
+	s.NextRun = next
+	srm.schedules[id] = s
+
+	return true, nil
+}
+
+func (srm *scheduleRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if s, ok := srm.schedules[id]; ok && s.OwnerID == owner {
+		delete(srm.schedules, id)
+	}
+
+	return nil
+}
+
+func start(pm scheduler.PageMetadata, total int) int {
+	if int(pm.Offset) > total {
+		return total
+	}
+	return int(pm.Offset)
+}
+
+func end(pm scheduler.PageMetadata, total int) int {
+	if pm.Limit == 0 || int(pm.Offset+pm.Limit) > total {
+		return total
+	}
+	return int(pm.Offset + pm.Limit)
+}
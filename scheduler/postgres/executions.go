@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ scheduler.ExecutionRepository = (*executionRepository)(nil)
+
+type executionRepository struct {
+	db Database
+}
+
+// NewExecutionRepository instantiates a PostgreSQL implementation of
+// execution repository.
+func NewExecutionRepository(db Database) scheduler.ExecutionRepository {
+	return &executionRepository{
+		db: db,
+	}
+}
+
+func (er executionRepository) Save(ctx context.Context, e scheduler.Execution) error {
+	q := `INSERT INTO executions (id, schedule_id, due, executed, status, error)
+		VALUES (:id, :schedule_id, :due, :executed, :status, :error)`
+
+	if _, err := er.db.NamedExecContext(ctx, q, toDBExecution(e)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (er executionRepository) RetrieveAll(ctx context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	params := map[string]interface{}{
+		"schedule_id": scheduleID,
+		"offset":      pm.Offset,
+		"limit":       pm.Limit,
+	}
+
+	limitClause := ""
+	if pm.Limit > 0 {
+		limitClause = "LIMIT :limit"
+	}
+
+	q := fmt.Sprintf(`SELECT id, schedule_id, due, executed, status, error FROM executions
+		WHERE schedule_id = :schedule_id ORDER BY executed DESC, id DESC %s OFFSET :offset`, limitClause)
+
+	rows, err := er.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return scheduler.ExecutionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	executions := []scheduler.Execution{}
+	for rows.Next() {
+		dbe := dbExecution{}
+		if err := rows.StructScan(&dbe); err != nil {
+			return scheduler.ExecutionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		executions = append(executions, toExecution(dbe))
+	}
+
+	cq := `SELECT COUNT(*) FROM executions WHERE schedule_id = :schedule_id`
+	total, err := total(ctx, er.db, cq, params)
+	if err != nil {
+		return scheduler.ExecutionsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := scheduler.ExecutionsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Executions:   executions,
+	}
+
+	return page, nil
+}
+
+type dbExecution struct {
+	ID         string         `db:"id"`
+	ScheduleID string         `db:"schedule_id"`
+	Due        time.Time      `db:"due"`
+	Executed   time.Time      `db:"executed"`
+	Status     string         `db:"status"`
+	Error      sql.NullString `db:"error"`
+}
+
+func toDBExecution(e scheduler.Execution) dbExecution {
+	return dbExecution{
+		ID:         e.ID,
+		ScheduleID: e.ScheduleID,
+		Due:        e.Due,
+		Executed:   e.Executed,
+		Status:     e.Status,
+		Error:      sql.NullString{String: e.Error, Valid: e.Error != ""},
+	}
+}
+
+func toExecution(dbe dbExecution) scheduler.Execution {
+	return scheduler.Execution{
+		ID:         dbe.ID,
+		ScheduleID: dbe.ScheduleID,
+		Due:        dbe.Due,
+		Executed:   dbe.Executed,
+		Status:     dbe.Status,
+		Error:      dbe.Error.String,
+	}
+}
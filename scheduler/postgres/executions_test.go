@@ -0,0 +1,126 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/MainfluxLabs/mainflux/scheduler/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numExecutions = 10
+
+func TestSaveExecution(t *testing.T) {
+	schedules := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	repo := postgres.NewExecutionRepository(postgres.NewDatabase(db))
+
+	sch := newSchedule(t, time.Now().UTC())
+	err := schedules.Save(context.Background(), sch)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	e := scheduler.Execution{ID: id, ScheduleID: sch.ID, Due: sch.NextRun, Executed: time.Now().UTC(), Status: scheduler.ExecutionSucceeded}
+
+	unknown := e
+	unknown.ID = id + "1"
+	unknown.ScheduleID = "non-existing"
+
+	cases := []struct {
+		desc string
+		e    scheduler.Execution
+		err  error
+	}{
+		{
+			desc: "save execution",
+			e:    e,
+			err:  nil,
+		},
+		{
+			desc: "save existing execution",
+			e:    e,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "save execution of non-existing schedule",
+			e:    unknown,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.e)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAllExecutions(t *testing.T) {
+	schedules := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	repo := postgres.NewExecutionRepository(postgres.NewDatabase(db))
+
+	sch := newSchedule(t, time.Now().UTC())
+	err := schedules.Save(context.Background(), sch)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	for i := 0; i < numExecutions; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		executed := now.Add(time.Duration(i) * time.Minute)
+		e := scheduler.Execution{ID: id, ScheduleID: sch.ID, Due: executed, Executed: executed, Status: scheduler.ExecutionSucceeded}
+		err = repo.Save(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc       string
+		scheduleID string
+		pm         scheduler.PageMetadata
+		size       int
+		total      uint
+	}{
+		{
+			desc:       "retrieve all executions",
+			scheduleID: sch.ID,
+			pm:         scheduler.PageMetadata{},
+			size:       numExecutions,
+			total:      numExecutions,
+		},
+		{
+			desc:       "retrieve executions page",
+			scheduleID: sch.ID,
+			pm:         scheduler.PageMetadata{Offset: 2, Limit: 5},
+			size:       5,
+			total:      numExecutions,
+		},
+		{
+			desc:       "retrieve executions of non-existing schedule",
+			scheduleID: "non-existing",
+			pm:         scheduler.PageMetadata{},
+			size:       0,
+			total:      0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.scheduleID, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Executions), fmt.Sprintf("%s: expected %d executions got %d\n", tc.desc, tc.size, len(page.Executions)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+
+	// Executions are removed together with their schedule.
+	err = schedules.Remove(context.Background(), owner, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	page, err := repo.RetrieveAll(context.Background(), sch.ID, scheduler.PageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 0, len(page.Executions), fmt.Sprintf("expected no executions got %d\n", len(page.Executions)))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "scheduler_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS schedules (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        owner_id    VARCHAR(254) NOT NULL,
+                        name        VARCHAR(254),
+                        channel_id  VARCHAR(254) NOT NULL,
+                        subtopic    VARCHAR(254),
+                        payload     BYTEA,
+                        cron        VARCHAR(254),
+                        time_zone   VARCHAR(64),
+                        at          TIMESTAMPTZ,
+                        enabled     BOOLEAN NOT NULL,
+                        next_run    TIMESTAMPTZ,
+                        created     TIMESTAMPTZ NOT NULL,
+                        updated     TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_schedules_owner ON schedules (owner_id)`,
+					`CREATE INDEX IF NOT EXISTS idx_schedules_next_run ON schedules (next_run) WHERE enabled`,
+					`CREATE TABLE IF NOT EXISTS executions (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        schedule_id VARCHAR(254) NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+                        due         TIMESTAMPTZ NOT NULL,
+                        executed    TIMESTAMPTZ NOT NULL,
+                        status      VARCHAR(16) NOT NULL,
+                        error       TEXT
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_executions_schedule_executed ON executions (schedule_id, executed DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS executions",
+					"DROP TABLE IF EXISTS schedules",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,269 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const scheduleColumns = `id, owner_id, name, channel_id, subtopic, payload, cron, time_zone, at, enabled, next_run, created, updated`
+
+var _ scheduler.ScheduleRepository = (*scheduleRepository)(nil)
+
+type scheduleRepository struct {
+	db Database
+}
+
+// NewScheduleRepository instantiates a PostgreSQL implementation of schedule
+// repository.
+func NewScheduleRepository(db Database) scheduler.ScheduleRepository {
+	return &scheduleRepository{
+		db: db,
+	}
+}
+
+func (sr scheduleRepository) Save(ctx context.Context, s scheduler.Schedule) error {
+	q := `INSERT INTO schedules (id, owner_id, name, channel_id, subtopic, payload, cron, time_zone, at, enabled, next_run, created, updated)
+		VALUES (:id, :owner_id, :name, :channel_id, :subtopic, :payload, :cron, :time_zone, :at, :enabled, :next_run, :created, :updated)`
+
+	if _, err := sr.db.NamedExecContext(ctx, q, toDBSchedule(s)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (sr scheduleRepository) Update(ctx context.Context, s scheduler.Schedule) error {
+	q := `UPDATE schedules SET name = :name, channel_id = :channel_id, subtopic = :subtopic, payload = :payload, cron = :cron,
+		time_zone = :time_zone, at = :at, enabled = :enabled, next_run = :next_run, updated = :updated
+		WHERE id = :id AND owner_id = :owner_id`
+
+	res, err := sr.db.NamedExecContext(ctx, q, toDBSchedule(s))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (sr scheduleRepository) RetrieveByID(ctx context.Context, owner, id string) (scheduler.Schedule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM schedules WHERE id = $1 AND owner_id = $2`, scheduleColumns)
+
+	dbs := dbSchedule{}
+	if err := sr.db.QueryRowxContext(ctx, q, id, owner).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return scheduler.Schedule{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return scheduler.Schedule{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toSchedule(dbs), nil
+}
+
+func (sr scheduleRepository) RetrieveAll(ctx context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	conditions := []string{"owner_id = :owner_id"}
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"offset":   pm.Offset,
+		"limit":    pm.Limit,
+	}
+	if pm.ChannelID != "" {
+		conditions = append(conditions, "channel_id = :channel_id")
+		params["channel_id"] = pm.ChannelID
+	}
+	whereClause := fmt.Sprintf("WHERE %s", strings.Join(conditions, " AND "))
+
+	limitClause := ""
+	if pm.Limit > 0 {
+		limitClause = "LIMIT :limit"
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM schedules %s ORDER BY id %s OFFSET :offset`, scheduleColumns, whereClause, limitClause)
+
+	schedules, err := sr.retrieve(ctx, q, params)
+	if err != nil {
+		return scheduler.SchedulesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM schedules %s`, whereClause)
+	total, err := total(ctx, sr.db, cq, params)
+	if err != nil {
+		return scheduler.SchedulesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := scheduler.SchedulesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Schedules:    schedules,
+	}
+
+	return page, nil
+}
+
+func (sr scheduleRepository) RetrieveDue(ctx context.Context, now time.Time, limit uint) ([]scheduler.Schedule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM schedules WHERE enabled AND next_run IS NOT NULL AND next_run <= :now
+		ORDER BY next_run LIMIT :limit`, scheduleColumns)
+
+	params := map[string]interface{}{
+		"now":   now,
+		"limit": limit,
+	}
+
+	return sr.retrieve(ctx, q, params)
+}
+
+func (sr scheduleRepository) Claim(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	// The conditional update succeeds for a single replica only, as the
+	// others no longer find the schedule due at the same time.
+	q := `UPDATE schedules SET next_run = :next WHERE id = :id AND enabled AND next_run = :due`
+
+	params := map[string]interface{}{
+		"id":   id,
+		"due":  due,
+		"next": nullTime(next),
+	}
+
+	res, err := sr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return false, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return cnt == 1, nil
+}
+
+func (sr scheduleRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM schedules WHERE id = :id AND owner_id = :owner_id`
+
+	params := map[string]interface{}{
+		"id":       id,
+		"owner_id": owner,
+	}
+
+	if _, err := sr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (sr scheduleRepository) retrieve(ctx context.Context, query string, params interface{}) ([]scheduler.Schedule, error) {
+	rows, err := sr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	schedules := []scheduler.Schedule{}
+	for rows.Next() {
+		dbs := dbSchedule{}
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		schedules = append(schedules, toSchedule(dbs))
+	}
+
+	return schedules, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var total uint
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+type dbSchedule struct {
+	ID        string         `db:"id"`
+	OwnerID   string         `db:"owner_id"`
+	Name      sql.NullString `db:"name"`
+	ChannelID string         `db:"channel_id"`
+	Subtopic  sql.NullString `db:"subtopic"`
+	Payload   []byte         `db:"payload"`
+	Cron      sql.NullString `db:"cron"`
+	TimeZone  sql.NullString `db:"time_zone"`
+	At        sql.NullTime   `db:"at"`
+	Enabled   bool           `db:"enabled"`
+	NextRun   sql.NullTime   `db:"next_run"`
+	Created   time.Time      `db:"created"`
+	Updated   time.Time      `db:"updated"`
+}
+
+func toDBSchedule(s scheduler.Schedule) dbSchedule {
+	return dbSchedule{
+		ID:        s.ID,
+		OwnerID:   s.OwnerID,
+		Name:      sql.NullString{String: s.Name, Valid: s.Name != ""},
+		ChannelID: s.ChannelID,
+		Subtopic:  sql.NullString{String: s.Subtopic, Valid: s.Subtopic != ""},
+		Payload:   s.Payload,
+		Cron:      sql.NullString{String: s.Cron, Valid: s.Cron != ""},
+		TimeZone:  sql.NullString{String: s.TimeZone, Valid: s.TimeZone != ""},
+		At:        nullTime(s.At),
+		Enabled:   s.Enabled,
+		NextRun:   nullTime(s.NextRun),
+		Created:   s.Created,
+		Updated:   s.Updated,
+	}
+}
+
+func toSchedule(dbs dbSchedule) scheduler.Schedule {
+	s := scheduler.Schedule{
+		ID:        dbs.ID,
+		OwnerID:   dbs.OwnerID,
+		Name:      dbs.Name.String,
+		ChannelID: dbs.ChannelID,
+		Subtopic:  dbs.Subtopic.String,
+		Payload:   dbs.Payload,
+		Cron:      dbs.Cron.String,
+		TimeZone:  dbs.TimeZone.String,
+		Enabled:   dbs.Enabled,
+		Created:   dbs.Created,
+		Updated:   dbs.Updated,
+	}
+	if dbs.At.Valid {
+		s.At = dbs.At.Time
+	}
+	if dbs.NextRun.Valid {
+		s.NextRun = dbs.NextRun.Time
+	}
+
+	return s
+}
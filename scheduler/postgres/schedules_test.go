@@ -0,0 +1,263 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/MainfluxLabs/mainflux/scheduler/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	owner        = "owner"
+	chanID       = "channel"
+	numSchedules = 10
+)
+
+func newSchedule(t *testing.T, next time.Time) scheduler.Schedule {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	return scheduler.Schedule{
+		ID:        id,
+		OwnerID:   owner,
+		Name:      "calibration",
+		ChannelID: chanID,
+		Payload:   []byte(`{"calibrate":true}`),
+		Cron:      "*/5 * * * *",
+		Enabled:   true,
+		NextRun:   next,
+		Created:   now,
+		Updated:   now,
+	}
+}
+
+func TestSaveSchedule(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	sch := newSchedule(t, time.Now().UTC())
+
+	cases := []struct {
+		desc string
+		sch  scheduler.Schedule
+		err  error
+	}{
+		{
+			desc: "save schedule",
+			sch:  sch,
+			err:  nil,
+		},
+		{
+			desc: "save existing schedule",
+			sch:  sch,
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.sch)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	sch := newSchedule(t, time.Now().UTC())
+	err := repo.Save(context.Background(), sch)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	oneShot := sch
+	oneShot.Cron = ""
+	oneShot.At = time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	oneShot.NextRun = oneShot.At
+
+	otherOwner := sch
+	otherOwner.OwnerID = "other"
+
+	cases := []struct {
+		desc string
+		sch  scheduler.Schedule
+		err  error
+	}{
+		{
+			desc: "update schedule",
+			sch:  oneShot,
+			err:  nil,
+		},
+		{
+			desc: "update schedule of other owner",
+			sch:  otherOwner,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "update non-existing schedule",
+			sch:  newSchedule(t, time.Now().UTC()),
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.sch)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.RetrieveByID(context.Background(), owner, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, "", saved.Cron, fmt.Sprintf("expected no cron got %s\n", saved.Cron))
+	assert.True(t, oneShot.At.Equal(saved.At), fmt.Sprintf("expected time %s got %s\n", oneShot.At, saved.At))
+}
+
+func TestRetrieveScheduleByID(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	sch := newSchedule(t, time.Now().UTC())
+	err := repo.Save(context.Background(), sch)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve existing schedule",
+			owner: owner,
+			id:    sch.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve schedule of other owner",
+			owner: "other",
+			id:    sch.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing schedule",
+			owner: owner,
+			id:    "non-existing",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.RetrieveByID(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAllSchedules(t *testing.T) {
+	_, err := db.Exec("DELETE FROM schedules")
+	require.Nil(t, err, fmt.Sprintf("cleanup must not fail: %s", err))
+
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	for i := 0; i < numSchedules; i++ {
+		sch := newSchedule(t, time.Now().UTC())
+		if i%2 == 0 {
+			sch.ChannelID = "other"
+		}
+		err := repo.Save(context.Background(), sch)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		owner string
+		pm    scheduler.PageMetadata
+		size  int
+		total uint
+	}{
+		{
+			desc:  "retrieve all schedules",
+			owner: owner,
+			pm:    scheduler.PageMetadata{},
+			size:  numSchedules,
+			total: numSchedules,
+		},
+		{
+			desc:  "retrieve schedules page",
+			owner: owner,
+			pm:    scheduler.PageMetadata{Offset: 2, Limit: 5},
+			size:  5,
+			total: numSchedules,
+		},
+		{
+			desc:  "retrieve schedules of channel",
+			owner: owner,
+			pm:    scheduler.PageMetadata{ChannelID: chanID},
+			size:  numSchedules / 2,
+			total: numSchedules / 2,
+		},
+		{
+			desc:  "retrieve schedules of other owner",
+			owner: "other",
+			pm:    scheduler.PageMetadata{},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Schedules), fmt.Sprintf("%s: expected %d schedules got %d\n", tc.desc, tc.size, len(page.Schedules)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRetrieveDueAndClaim(t *testing.T) {
+	_, err := db.Exec("DELETE FROM schedules")
+	require.Nil(t, err, fmt.Sprintf("cleanup must not fail: %s", err))
+
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	now := time.Now().UTC()
+
+	due := newSchedule(t, now.Add(-time.Minute))
+	future := newSchedule(t, now.Add(time.Hour))
+	done := newSchedule(t, time.Time{})
+	disabled := newSchedule(t, now.Add(-time.Minute))
+	disabled.Enabled = false
+	for _, sch := range []scheduler.Schedule{due, future, done, disabled} {
+		err := repo.Save(context.Background(), sch)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	schedules, err := repo.RetrieveDue(context.Background(), now, 10)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	require.Equal(t, 1, len(schedules), fmt.Sprintf("expected 1 due schedule got %d\n", len(schedules)))
+	assert.Equal(t, due.ID, schedules[0].ID, fmt.Sprintf("expected due schedule %s got %s\n", due.ID, schedules[0].ID))
+
+	next := now.Add(5 * time.Minute)
+	claimed, err := repo.Claim(context.Background(), due.ID, schedules[0].NextRun, next)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.True(t, claimed, "expected the first claim to succeed")
+
+	claimed, err = repo.Claim(context.Background(), due.ID, schedules[0].NextRun, next)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.False(t, claimed, "expected the second claim to fail")
+
+	schedules, err = repo.RetrieveDue(context.Background(), now, 10)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 0, len(schedules), fmt.Sprintf("expected no due schedules got %d\n", len(schedules)))
+}
+
+func TestRemoveSchedule(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+	sch := newSchedule(t, time.Now().UTC())
+	err := repo.Save(context.Background(), sch)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), owner, sch.ID)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = repo.RetrieveByID(context.Background(), owner, sch.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s\n", errors.ErrNotFound, err))
+}
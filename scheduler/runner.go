@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	// Protocol is the protocol of the scheduled messages.
+	Protocol = "scheduler"
+
+	batchSize = 100
+)
+
+// Runner publishes the payloads of the due schedules.
+type Runner interface {
+	// Run runs the due schedules every interval, until the context is done.
+	Run(ctx context.Context, interval time.Duration) error
+
+	// RunDue runs the schedules due at the given time. Each schedule is run
+	// once, even if it missed several runs while the service was down.
+	RunDue(ctx context.Context, now time.Time) error
+}
+
+var _ Runner = (*runner)(nil)
+
+type runner struct {
+	things     mainflux.ThingsServiceClient
+	publisher  messaging.Publisher
+	schedules  ScheduleRepository
+	executions ExecutionRepository
+	idp        mainflux.IDProvider
+	logger     logger.Logger
+}
+
+// NewRunner instantiates the schedules runner.
+func NewRunner(things mainflux.ThingsServiceClient, publisher messaging.Publisher, schedules ScheduleRepository, executions ExecutionRepository, idp mainflux.IDProvider, logger logger.Logger) Runner {
+	return &runner{
+		things:     things,
+		publisher:  publisher,
+		schedules:  schedules,
+		executions: executions,
+		idp:        idp,
+		logger:     logger,
+	}
+}
+
+func (r *runner) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case t := <-ticker.C:
+			if err := r.RunDue(ctx, t.UTC()); err != nil {
+				r.logger.Warn(fmt.Sprintf("Failed to run due schedules: %s", err))
+			}
+		}
+	}
+}
+
+func (r *runner) RunDue(ctx context.Context, now time.Time) error {
+	due, err := r.schedules.RetrieveDue(ctx, now, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range due {
+		next, err := nextRun(s, now)
+		if err != nil {
+			r.logger.Warn(fmt.Sprintf("Failed to reschedule schedule %s: %s", s.ID, err))
+			continue
+		}
+
+		claimed, err := r.schedules.Claim(ctx, s.ID, s.NextRun, next)
+		if err != nil {
+			r.logger.Warn(fmt.Sprintf("Failed to claim schedule %s: %s", s.ID, err))
+			continue
+		}
+		// The schedule is run by the other replica, or is changed meanwhile.
+		if !claimed {
+			continue
+		}
+
+		if err := r.execute(ctx, s, now); err != nil {
+			r.logger.Warn(fmt.Sprintf("Failed to save execution of schedule %s: %s", s.ID, err))
+		}
+	}
+
+	return nil
+}
+
+func (r *runner) execute(ctx context.Context, s Schedule, now time.Time) error {
+	id, err := r.idp.ID()
+	if err != nil {
+		return err
+	}
+
+	e := Execution{
+		ID:         id,
+		ScheduleID: s.ID,
+		Due:        s.NextRun,
+		Executed:   now,
+		Status:     ExecutionSucceeded,
+	}
+	if err := r.publish(ctx, s, now); err != nil {
+		e.Status = ExecutionFailed
+		e.Error = err.Error()
+	}
+
+	return r.executions.Save(ctx, e)
+}
+
+func (r *runner) publish(ctx context.Context, s Schedule, now time.Time) error {
+	// The owner could have lost the channel since the schedule was created.
+	if _, err := r.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: s.OwnerID, ChanID: s.ChannelID}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	msg := messaging.Message{
+		Channel:   s.ChannelID,
+		Subtopic:  s.Subtopic,
+		Publisher: s.OwnerID,
+		Protocol:  Protocol,
+		Payload:   s.Payload,
+		Created:   now.UnixNano(),
+	}
+
+	return r.publisher.Publish(s.ChannelID, msg)
+}
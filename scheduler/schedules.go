@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// ExecutionSucceeded represents the execution which published the message.
+	ExecutionSucceeded = "succeeded"
+
+	// ExecutionFailed represents the execution which failed to publish the message.
+	ExecutionFailed = "failed"
+)
+
+// Schedule represents the payload publishing to the channel, either once at
+// the given time, or recurring by the cron expression.
+type Schedule struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	ChannelID string
+	Subtopic  string
+	Payload   []byte
+	Cron      string
+	TimeZone  string
+	At        time.Time
+	Enabled   bool
+	// NextRun is zero if the schedule won't run anymore.
+	NextRun time.Time
+	Created time.Time
+	Updated time.Time
+}
+
+// Execution represents the single run of the schedule.
+type Execution struct {
+	ID         string
+	ScheduleID string
+	Due        time.Time
+	Executed   time.Time
+	Status     string
+	Error      string
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint
+	Limit     uint
+	ChannelID string
+}
+
+// SchedulesPage contains page related metadata as well as list of schedules
+// that belong to this page.
+type SchedulesPage struct {
+	PageMetadata
+	Total     uint
+	Schedules []Schedule
+}
+
+// ExecutionsPage contains page related metadata as well as list of executions
+// that belong to this page.
+type ExecutionsPage struct {
+	PageMetadata
+	Total      uint
+	Executions []Execution
+}
+
+// ScheduleRepository specifies a schedule persistence API.
+type ScheduleRepository interface {
+	// Save persists the schedule.
+	Save(ctx context.Context, s Schedule) error
+
+	// Update updates the owner's schedule, including its next run time.
+	Update(ctx context.Context, s Schedule) error
+
+	// RetrieveByID retrieves the schedule having the provided ID and owner.
+	RetrieveByID(ctx context.Context, owner, id string) (Schedule, error)
+
+	// RetrieveAll retrieves the subset of owner's schedules.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (SchedulesPage, error)
+
+	// RetrieveDue retrieves up to limit enabled schedules whose next run
+	// time isn't after the given time.
+	RetrieveDue(ctx context.Context, now time.Time, limit uint) ([]Schedule, error)
+
+	// Claim sets the next run time of the schedule which is still due at
+	// the given time, and reports whether it did. Schedules are claimed
+	// atomically, so that a due schedule is run by exactly one replica.
+	Claim(ctx context.Context, id string, due, next time.Time) (bool, error)
+
+	// Remove removes the owner's schedule having the provided ID.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// ExecutionRepository specifies an execution history persistence API.
+type ExecutionRepository interface {
+	// Save persists the execution.
+	Save(ctx context.Context, e Execution) error
+
+	// RetrieveAll retrieves the subset of schedule's executions, newest first.
+	RetrieveAll(ctx context.Context, scheduleID string, pm PageMetadata) (ExecutionsPage, error)
+}
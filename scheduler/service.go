@@ -0,0 +1,206 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrInvalidSchedule indicates a schedule without exactly one of the cron
+// expression and one-shot time, with the one-shot time in the past or with
+// an unknown time zone.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateSchedule creates the schedule on the channel owned by the user.
+	CreateSchedule(ctx context.Context, token string, s Schedule) (Schedule, error)
+
+	// ViewSchedule retrieves the user's schedule having the provided ID.
+	ViewSchedule(ctx context.Context, token, id string) (Schedule, error)
+
+	// ListSchedules retrieves the user's schedules matching the page metadata.
+	ListSchedules(ctx context.Context, token string, pm PageMetadata) (SchedulesPage, error)
+
+	// UpdateSchedule updates the user's schedule, and reschedules its next run.
+	UpdateSchedule(ctx context.Context, token string, s Schedule) error
+
+	// RemoveSchedule removes the user's schedule together with its executions.
+	RemoveSchedule(ctx context.Context, token, id string) error
+
+	// ListExecutions retrieves the execution history of the user's schedule.
+	ListExecutions(ctx context.Context, token, id string, pm PageMetadata) (ExecutionsPage, error)
+}
+
+var _ Service = (*schedulerService)(nil)
+
+type schedulerService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	schedules  ScheduleRepository
+	executions ExecutionRepository
+	idp        mainflux.IDProvider
+}
+
+// New instantiates the scheduler service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, schedules ScheduleRepository, executions ExecutionRepository, idp mainflux.IDProvider) Service {
+	return &schedulerService{
+		auth:       auth,
+		things:     things,
+		schedules:  schedules,
+		executions: executions,
+		idp:        idp,
+	}
+}
+
+func (ss *schedulerService) CreateSchedule(ctx context.Context, token string, s Schedule) (Schedule, error) {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	if err := ss.authorize(ctx, owner, s.ChannelID); err != nil {
+		return Schedule{}, err
+	}
+
+	now := time.Now().UTC()
+	if s.Cron == "" && !s.At.After(now) {
+		return Schedule{}, errors.Wrap(ErrInvalidSchedule, fmt.Errorf("one-shot time %s is in the past", s.At))
+	}
+
+	if s.NextRun, err = nextRun(s, now); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.ID, err = ss.idp.ID(); err != nil {
+		return Schedule{}, err
+	}
+	s.OwnerID = owner
+	s.Created = now
+	s.Updated = now
+
+	if err := ss.schedules.Save(ctx, s); err != nil {
+		return Schedule{}, err
+	}
+
+	return s, nil
+}
+
+func (ss *schedulerService) ViewSchedule(ctx context.Context, token, id string) (Schedule, error) {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	return ss.schedules.RetrieveByID(ctx, owner, id)
+}
+
+func (ss *schedulerService) ListSchedules(ctx context.Context, token string, pm PageMetadata) (SchedulesPage, error) {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return SchedulesPage{}, err
+	}
+
+	return ss.schedules.RetrieveAll(ctx, owner, pm)
+}
+
+func (ss *schedulerService) UpdateSchedule(ctx context.Context, token string, s Schedule) error {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	current, err := ss.schedules.RetrieveByID(ctx, owner, s.ID)
+	if err != nil {
+		return err
+	}
+
+	if s.ChannelID != current.ChannelID {
+		if err := ss.authorize(ctx, owner, s.ChannelID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	if s.NextRun, err = nextRun(s, now); err != nil {
+		return err
+	}
+	s.OwnerID = owner
+	s.Created = current.Created
+	s.Updated = now
+
+	return ss.schedules.Update(ctx, s)
+}
+
+func (ss *schedulerService) RemoveSchedule(ctx context.Context, token, id string) error {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return ss.schedules.Remove(ctx, owner, id)
+}
+
+func (ss *schedulerService) ListExecutions(ctx context.Context, token, id string, pm PageMetadata) (ExecutionsPage, error) {
+	owner, err := ss.identify(ctx, token)
+	if err != nil {
+		return ExecutionsPage{}, err
+	}
+
+	if _, err := ss.schedules.RetrieveByID(ctx, owner, id); err != nil {
+		return ExecutionsPage{}, err
+	}
+
+	return ss.executions.RetrieveAll(ctx, id, pm)
+}
+
+func (ss *schedulerService) identify(ctx context.Context, token string) (string, error) {
+	res, err := ss.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return res.GetId(), nil
+}
+
+func (ss *schedulerService) authorize(ctx context.Context, owner, chanID string) error {
+	if _, err := ss.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: chanID}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// nextRun returns the first run of the schedule after the given time, or
+// zero time if the schedule won't run anymore.
+func nextRun(s Schedule, now time.Time) (time.Time, error) {
+	if (s.Cron == "") == s.At.IsZero() {
+		return time.Time{}, errors.Wrap(ErrInvalidSchedule, errors.New("either cron expression or one-shot time is required"))
+	}
+
+	if s.Cron == "" {
+		if s.At.After(now) {
+			return s.At.UTC(), nil
+		}
+		return time.Time{}, nil
+	}
+
+	c, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrInvalidSchedule, err)
+	}
+
+	return c.Next(now.In(loc)).UTC(), nil
+}
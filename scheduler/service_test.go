@@ -0,0 +1,351 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	"github.com/MainfluxLabs/mainflux/scheduler/mocks"
+	thmocks "github.com/MainfluxLabs/mainflux/things/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	otherToken  = "other-token"
+	wrongValue  = "wrong-value"
+	email       = "user@example.com"
+	otherEmail  = "other@example.com"
+	chanID      = "1"
+	otherChanID = "2"
+	unavailable = "unavailable"
+)
+
+var payload = []byte(`{"calibrate":true}`)
+
+type testEnv struct {
+	svc       scheduler.Service
+	runner    scheduler.Runner
+	publisher mocks.Publisher
+}
+
+func newTestEnv() testEnv {
+	auth := thmocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := pkgmocks.NewThingsService(map[string]string{email: chanID, otherEmail: unavailable}, nil)
+	schedules := mocks.NewScheduleRepository()
+	executions := mocks.NewExecutionRepository()
+	idp := uuid.NewMock()
+	publisher := mocks.NewPublisher()
+
+	return testEnv{
+		svc:       scheduler.New(auth, things, schedules, executions, idp),
+		runner:    scheduler.NewRunner(things, publisher, schedules, executions, idp, logger.NewMock()),
+		publisher: publisher,
+	}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	env := newTestEnv()
+	at := time.Now().Add(time.Hour).UTC()
+
+	cases := []struct {
+		desc  string
+		token string
+		sch   scheduler.Schedule
+		err   error
+	}{
+		{
+			desc:  "create recurring schedule",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "0 2 * * *", TimeZone: "Europe/Belgrade", Enabled: true},
+			err:   nil,
+		},
+		{
+			desc:  "create one-shot schedule",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, At: at, Enabled: true},
+			err:   nil,
+		},
+		{
+			desc:  "create one-shot schedule in the past",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, At: at.Add(-2 * time.Hour), Enabled: true},
+			err:   scheduler.ErrInvalidSchedule,
+		},
+		{
+			desc:  "create schedule with both cron and time",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", At: at, Enabled: true},
+			err:   scheduler.ErrInvalidSchedule,
+		},
+		{
+			desc:  "create schedule with invalid cron",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "0 25 * * *", Enabled: true},
+			err:   scheduler.ErrInvalidCron,
+		},
+		{
+			desc:  "create schedule with unknown time zone",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", TimeZone: "Mars/Olympus", Enabled: true},
+			err:   scheduler.ErrInvalidSchedule,
+		},
+		{
+			desc:  "create schedule on other user's channel",
+			token: token,
+			sch:   scheduler.Schedule{ChannelID: otherChanID, Payload: payload, Cron: "@daily", Enabled: true},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create schedule with invalid token",
+			token: wrongValue,
+			sch:   scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		sch, err := env.svc.CreateSchedule(context.Background(), tc.token, tc.sch)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.False(t, sch.NextRun.IsZero(), fmt.Sprintf("%s: expected next run to be set", tc.desc))
+		}
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	env := newTestEnv()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	hourly := sch
+	hourly.Cron = "@hourly"
+
+	otherChan := sch
+	otherChan.ChannelID = otherChanID
+
+	invalid := sch
+	invalid.Cron = ""
+
+	unknown := sch
+	unknown.ID = wrongValue
+
+	cases := []struct {
+		desc  string
+		token string
+		sch   scheduler.Schedule
+		err   error
+	}{
+		{
+			desc:  "update schedule",
+			token: token,
+			sch:   hourly,
+			err:   nil,
+		},
+		{
+			desc:  "update schedule to other user's channel",
+			token: token,
+			sch:   otherChan,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update schedule without cron and time",
+			token: token,
+			sch:   invalid,
+			err:   scheduler.ErrInvalidSchedule,
+		},
+		{
+			desc:  "update non-existing schedule",
+			token: token,
+			sch:   unknown,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "update schedule with invalid token",
+			token: wrongValue,
+			sch:   hourly,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := env.svc.UpdateSchedule(context.Background(), tc.token, tc.sch)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := env.svc.ViewSchedule(context.Background(), token, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, hourly.Cron, saved.Cron, fmt.Sprintf("expected cron %s got %s", hourly.Cron, saved.Cron))
+	assert.True(t, saved.NextRun.Before(sch.NextRun) || saved.NextRun.Equal(sch.NextRun), "expected hourly next run not after the daily one")
+}
+
+func TestViewAndRemoveSchedule(t *testing.T) {
+	env := newTestEnv()
+
+	sch, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = env.svc.ViewSchedule(context.Background(), otherToken, sch.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view other user's schedule: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = env.svc.RemoveSchedule(context.Background(), token, sch.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove schedule: unexpected error: %s", err))
+
+	_, err = env.svc.ViewSchedule(context.Background(), token, sch.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view removed schedule: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestListSchedules(t *testing.T) {
+	env := newTestEnv()
+
+	for i := 0; i < 5; i++ {
+		_, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "@daily", Enabled: true})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    scheduler.PageMetadata
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list all schedules",
+			token: token,
+			pm:    scheduler.PageMetadata{},
+			size:  5,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules page",
+			token: token,
+			pm:    scheduler.PageMetadata{Offset: 3, Limit: 5},
+			size:  2,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules of other channel",
+			token: token,
+			pm:    scheduler.PageMetadata{ChannelID: otherChanID},
+			size:  0,
+			err:   nil,
+		},
+		{
+			desc:  "list other user's schedules",
+			token: otherToken,
+			pm:    scheduler.PageMetadata{},
+			size:  0,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules with invalid token",
+			token: wrongValue,
+			pm:    scheduler.PageMetadata{},
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := env.svc.ListSchedules(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Schedules), fmt.Sprintf("%s: expected %d schedules got %d\n", tc.desc, tc.size, len(page.Schedules)))
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	env := newTestEnv()
+	now := time.Now().UTC()
+
+	recurring, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Subtopic: "calibration", Payload: payload, Cron: "*/5 * * * *", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	oneShot, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, At: now.Add(time.Minute), Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	disabled, err := env.svc.CreateSchedule(context.Background(), token, scheduler.Schedule{ChannelID: chanID, Payload: payload, Cron: "* * * * *", Enabled: false})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	failing, err := env.svc.CreateSchedule(context.Background(), otherToken, scheduler.Schedule{ChannelID: unavailable, Payload: payload, Cron: "*/5 * * * *", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Runs all the schedules, and the next run reruns the recurring ones only.
+	later := now.Add(6 * time.Minute)
+	err = env.runner.RunDue(context.Background(), later)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = env.runner.RunDue(context.Background(), later)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = env.runner.RunDue(context.Background(), later.Add(5*time.Minute))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	msgs := env.publisher.Messages()
+	assert.Equal(t, 3, len(msgs), fmt.Sprintf("expected 3 published messages got %d", len(msgs)))
+	for _, msg := range msgs {
+		assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("expected channel %s got %s", chanID, msg.Channel))
+		assert.Equal(t, payload, msg.Payload, fmt.Sprintf("expected payload %s got %s", payload, msg.Payload))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		size   int
+		status string
+	}{
+		{
+			desc:   "list executions of recurring schedule",
+			token:  token,
+			id:     recurring.ID,
+			size:   2,
+			status: scheduler.ExecutionSucceeded,
+		},
+		{
+			desc:   "list executions of one-shot schedule",
+			token:  token,
+			id:     oneShot.ID,
+			size:   1,
+			status: scheduler.ExecutionSucceeded,
+		},
+		{
+			desc:  "list executions of disabled schedule",
+			token: token,
+			id:    disabled.ID,
+			size:  0,
+		},
+		{
+			desc:   "list executions of failing schedule",
+			token:  otherToken,
+			id:     failing.ID,
+			size:   2,
+			status: scheduler.ExecutionFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := env.svc.ListExecutions(context.Background(), tc.token, tc.id, scheduler.PageMetadata{})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Executions), fmt.Sprintf("%s: expected %d executions got %d\n", tc.desc, tc.size, len(page.Executions)))
+		for _, e := range page.Executions {
+			assert.Equal(t, tc.status, e.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, e.Status))
+		}
+	}
+
+	sch, err := env.svc.ViewSchedule(context.Background(), token, oneShot.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, sch.NextRun.IsZero(), fmt.Sprintf("expected no next run of one-shot schedule got %s", sch.NextRun))
+
+	_, err = env.svc.ListExecutions(context.Background(), otherToken, recurring.ID, scheduler.PageMetadata{})
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("list other user's executions: expected %s got %s\n", errors.ErrNotFound, err))
+}
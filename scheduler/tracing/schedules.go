@@ -0,0 +1,141 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/scheduler"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveScheduleOp         = "save_schedule"
+	updateScheduleOp       = "update_schedule"
+	retrieveScheduleOp     = "retrieve_schedule_by_id"
+	retrieveAllScheduleOp  = "retrieve_all_schedules"
+	retrieveDueScheduleOp  = "retrieve_due_schedules"
+	claimScheduleOp        = "claim_schedule"
+	removeScheduleOp       = "remove_schedule"
+	saveExecutionOp        = "save_execution"
+	retrieveAllExecutionOp = "retrieve_all_executions"
+)
+
+var (
+	_ scheduler.ScheduleRepository  = (*scheduleRepositoryMiddleware)(nil)
+	_ scheduler.ExecutionRepository = (*executionRepositoryMiddleware)(nil)
+)
+
+type scheduleRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   scheduler.ScheduleRepository
+}
+
+// ScheduleRepositoryMiddleware instantiates a new schedule repository that
+// tracks request and their latency, and adds spans to context.
+func ScheduleRepositoryMiddleware(repo scheduler.ScheduleRepository, tracer opentracing.Tracer) scheduler.ScheduleRepository {
+	return scheduleRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (srm scheduleRepositoryMiddleware) Save(ctx context.Context, s scheduler.Schedule) error {
+	span := createSpan(ctx, srm.tracer, saveScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Save(ctx, s)
+}
+
+func (srm scheduleRepositoryMiddleware) Update(ctx context.Context, s scheduler.Schedule) error {
+	span := createSpan(ctx, srm.tracer, updateScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Update(ctx, s)
+}
+
+func (srm scheduleRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (scheduler.Schedule, error) {
+	span := createSpan(ctx, srm.tracer, retrieveScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveByID(ctx, owner, id)
+}
+
+func (srm scheduleRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	span := createSpan(ctx, srm.tracer, retrieveAllScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (srm scheduleRepositoryMiddleware) RetrieveDue(ctx context.Context, now time.Time, limit uint) ([]scheduler.Schedule, error) {
+	span := createSpan(ctx, srm.tracer, retrieveDueScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveDue(ctx, now, limit)
+}
+
+func (srm scheduleRepositoryMiddleware) Claim(ctx context.Context, id string, due, next time.Time) (bool, error) {
+	span := createSpan(ctx, srm.tracer, claimScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Claim(ctx, id, due, next)
+}
+
+func (srm scheduleRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+	span := createSpan(ctx, srm.tracer, removeScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Remove(ctx, owner, id)
+}
+
+type executionRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   scheduler.ExecutionRepository
+}
+
+// ExecutionRepositoryMiddleware instantiates a new execution repository that
+// tracks request and their latency, and adds spans to context.
+func ExecutionRepositoryMiddleware(repo scheduler.ExecutionRepository, tracer opentracing.Tracer) scheduler.ExecutionRepository {
+	return executionRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (erm executionRepositoryMiddleware) Save(ctx context.Context, e scheduler.Execution) error {
+	span := createSpan(ctx, erm.tracer, saveExecutionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.Save(ctx, e)
+}
+
+func (erm executionRepositoryMiddleware) RetrieveAll(ctx context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	span := createSpan(ctx, erm.tracer, retrieveAllExecutionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.RetrieveAll(ctx, scheduleID, pm)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
@@ -25,6 +25,9 @@ type grpcClient struct {
 	isChannelOwner endpoint.Endpoint
 	identify       endpoint.Endpoint
 	getGroupsByIDs endpoint.Endpoint
+	getSchema      endpoint.Endpoint
+	getCodec       endpoint.Endpoint
+	getGroupChans  endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -73,6 +76,30 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeGetGroupsByIDsResponse,
 			mainflux.GroupsRes{},
 		).Endpoint()),
+		getSchema: kitot.TraceClient(tracer, "get_channel_schema")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannelSchema",
+			encodeGetChannelSchemaRequest,
+			decodeGetChannelSchemaResponse,
+			mainflux.ChannelSchema{},
+		).Endpoint()),
+		getCodec: kitot.TraceClient(tracer, "get_thing_codec")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetThingCodec",
+			encodeGetThingCodecRequest,
+			decodeGetThingCodecResponse,
+			mainflux.ThingCodec{},
+		).Endpoint()),
+		getGroupChans: kitot.TraceClient(tracer, "get_group_channels")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetGroupChannels",
+			encodeGetGroupChannelsRequest,
+			decodeGetGroupChannelsResponse,
+			mainflux.ChannelIDs{},
+		).Endpoint()),
 	}
 }
 
@@ -83,6 +110,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 	ar := accessByKeyReq{
 		thingKey: req.GetToken(),
 		chanID:   req.GetChanID(),
+		subtopic: req.GetSubtopic(),
 	}
 	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
@@ -94,7 +122,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 }
 
 func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
-	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}
+	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), subtopic: req.GetSubtopic()}
 	res, err := client.canAccessByID(ctx, ar)
 	if err != nil {
 		return nil, err
@@ -141,14 +169,53 @@ func (client grpcClient) GetGroupsByIDs(ctx context.Context, req *mainflux.Group
 	return &mainflux.GroupsRes{Groups: ir.groups}, nil
 }
 
+func (client grpcClient) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelSchema, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getSchema(ctx, channelSchemaReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(channelSchemaRes)
+	return &mainflux.ChannelSchema{Value: sr.value}, nil
+}
+
+func (client grpcClient) GetThingCodec(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.ThingCodec, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getCodec(ctx, thingCodecReq{thingID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	cr := res.(thingCodecRes)
+	return &mainflux.ThingCodec{Value: cr.value, ContentType: cr.contentType, Transformer: cr.transformer}, nil
+}
+
+func (client grpcClient) GetGroupChannels(ctx context.Context, req *mainflux.GroupChannelsReq, _ ...grpc.CallOption) (*mainflux.ChannelIDs, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getGroupChans(ctx, groupChannelsReq{owner: req.GetOwner(), groupID: req.GetGroupID()})
+	if err != nil {
+		return nil, err
+	}
+
+	gr := res.(groupChannelsRes)
+	return &mainflux.ChannelIDs{Values: gr.chIDs}, nil
+}
+
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByKeyReq)
-	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
+	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID, Subtopic: req.subtopic}, nil
 }
 
 func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByIDReq)
-	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
+	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID, Subtopic: req.subtopic}, nil
 }
 
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -166,6 +233,21 @@ func encodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return &mainflux.GroupsReq{Ids: req.ids}, nil
 }
 
+func encodeGetChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelSchemaReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
+func encodeGetThingCodecRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(thingCodecReq)
+	return &mainflux.ThingID{Value: req.thingID}, nil
+}
+
+func encodeGetGroupChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(groupChannelsReq)
+	return &mainflux.GroupChannelsReq{Owner: req.owner, GroupID: req.groupID}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue()}, nil
@@ -179,3 +261,18 @@ func decodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	res := grpcRes.(*mainflux.GroupsRes)
 	return getGroupsByIDsRes{groups: res.GetGroups()}, nil
 }
+
+func decodeGetChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelSchema)
+	return channelSchemaRes{value: res.GetValue()}, nil
+}
+
+func decodeGetThingCodecResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ThingCodec)
+	return thingCodecRes{value: res.GetValue(), contentType: res.GetContentType(), transformer: res.GetTransformer()}, nil
+}
+
+func decodeGetGroupChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelIDs)
+	return groupChannelsRes{chIDs: res.GetValues()}, nil
+}
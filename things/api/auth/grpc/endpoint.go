@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -18,7 +20,7 @@ func canAccessEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey, req.subtopic)
 		if err != nil {
 			return identityRes{}, err
 		}
@@ -33,7 +35,7 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.CanAccessByID(ctx, req.chanID, req.thingID)
+		err := svc.CanAccessByID(ctx, req.chanID, req.thingID, req.subtopic)
 		return emptyRes{err: err}, err
 	}
 }
@@ -93,3 +95,69 @@ func listGroupsByIDsEndpoint(svc things.Service) endpoint.Endpoint {
 		return getGroupsByIDsRes{groups: mgr}, nil
 	}
 }
+
+func getChannelSchemaEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelSchemaReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		sch, err := svc.GetChannelSchema(ctx, req.chanID)
+		if err != nil {
+			return channelSchemaRes{}, err
+		}
+
+		value, err := schema.Marshal(sch)
+		if err != nil {
+			return channelSchemaRes{}, err
+		}
+
+		return channelSchemaRes{value: value}, nil
+	}
+}
+
+func getThingCodecEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(thingCodecReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p, err := svc.GetThingCodec(ctx, req.thingID)
+		if err != nil {
+			return thingCodecRes{}, err
+		}
+
+		value, err := codec.Marshal(p.Codec)
+		if err != nil {
+			return thingCodecRes{}, err
+		}
+
+		res := thingCodecRes{
+			value:       value,
+			contentType: p.ContentType,
+			transformer: p.Transformer,
+		}
+		return res, nil
+	}
+}
+
+func getGroupChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		chIDs, err := svc.GetGroupChannels(ctx, req.owner, req.groupID)
+		if err != nil {
+			return groupChannelsRes{}, err
+		}
+
+		return groupChannelsRes{chIDs: chIDs}, nil
+	}
+}
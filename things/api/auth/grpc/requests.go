@@ -8,6 +8,7 @@ import "github.com/MainfluxLabs/mainflux/internal/apiutil"
 type accessByKeyReq struct {
 	thingKey string
 	chanID   string
+	subtopic string
 }
 
 func (req accessByKeyReq) validate() error {
@@ -23,8 +24,9 @@ func (req accessByKeyReq) validate() error {
 }
 
 type accessByIDReq struct {
-	thingID string
-	chanID  string
+	thingID  string
+	chanID   string
+	subtopic string
 }
 
 func (req accessByIDReq) validate() error {
@@ -71,3 +73,40 @@ func (req getGroupsByIDsReq) validate() error {
 
 	return nil
 }
+
+type channelSchemaReq struct {
+	chanID string
+}
+
+func (req channelSchemaReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type thingCodecReq struct {
+	thingID string
+}
+
+func (req thingCodecReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type groupChannelsReq struct {
+	owner   string
+	groupID string
+}
+
+func (req groupChannelsReq) validate() error {
+	if req.owner == "" || req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -16,3 +16,17 @@ type emptyRes struct {
 type getGroupsByIDsRes struct {
 	groups []*mainflux.Group
 }
+
+type channelSchemaRes struct {
+	value []byte
+}
+
+type thingCodecRes struct {
+	value       []byte
+	contentType string
+	transformer string
+}
+
+type groupChannelsRes struct {
+	chIDs []string
+}
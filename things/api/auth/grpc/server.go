@@ -26,6 +26,9 @@ type grpcServer struct {
 	isChannelOwner kitgrpc.Handler
 	identify       kitgrpc.Handler
 	getGroupsByIDs kitgrpc.Handler
+	getSchema      kitgrpc.Handler
+	getCodec       kitgrpc.Handler
+	getGroupChans  kitgrpc.Handler
 }
 
 // NewServer returns new ThingsServiceServer instance.
@@ -56,6 +59,21 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeGetGroupsByIDsRequest,
 			encodeGetGroupsByIDsResponse,
 		),
+		getSchema: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel_schema")(getChannelSchemaEndpoint(svc)),
+			decodeGetChannelSchemaRequest,
+			encodeGetChannelSchemaResponse,
+		),
+		getCodec: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_thing_codec")(getThingCodecEndpoint(svc)),
+			decodeGetThingCodecRequest,
+			encodeGetThingCodecResponse,
+		),
+		getGroupChans: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_group_channels")(getGroupChannelsEndpoint(svc)),
+			decodeGetGroupChannelsRequest,
+			encodeGetGroupChannelsResponse,
+		),
 	}
 }
 
@@ -104,14 +122,41 @@ func (gs *grpcServer) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsRe
 	return res.(*mainflux.GroupsRes), nil
 }
 
+func (gs *grpcServer) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID) (*mainflux.ChannelSchema, error) {
+	_, res, err := gs.getSchema.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelSchema), nil
+}
+
+func (gs *grpcServer) GetThingCodec(ctx context.Context, req *mainflux.ThingID) (*mainflux.ThingCodec, error) {
+	_, res, err := gs.getCodec.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ThingCodec), nil
+}
+
+func (gs *grpcServer) GetGroupChannels(ctx context.Context, req *mainflux.GroupChannelsReq) (*mainflux.ChannelIDs, error) {
+	_, res, err := gs.getGroupChans.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelIDs), nil
+}
+
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
-	return accessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
+	return accessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID(), subtopic: req.GetSubtopic()}, nil
 }
 
 func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByIDReq)
-	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
+	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), subtopic: req.GetSubtopic()}, nil
 }
 
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -129,6 +174,21 @@ func decodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return getGroupsByIDsReq{ids: req.GetIds()}, nil
 }
 
+func decodeGetChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelSchemaReq{chanID: req.GetValue()}, nil
+}
+
+func decodeGetThingCodecRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return thingCodecReq{thingID: req.GetValue()}, nil
+}
+
+func decodeGetGroupChannelsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.GroupChannelsReq)
+	return groupChannelsReq{owner: req.GetOwner(), groupID: req.GetGroupID()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, nil
@@ -144,6 +204,21 @@ func encodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	return &mainflux.GroupsRes{Groups: res.groups}, nil
 }
 
+func encodeGetChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelSchemaRes)
+	return &mainflux.ChannelSchema{Value: res.value}, nil
+}
+
+func encodeGetThingCodecResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(thingCodecRes)
+	return &mainflux.ThingCodec{Value: res.value, ContentType: res.contentType, Transformer: res.transformer}, nil
+}
+
+func encodeGetGroupChannelsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(groupChannelsRes)
+	return &mainflux.ChannelIDs{Values: res.chIDs}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case err == nil:
@@ -18,8 +18,9 @@ func (req identifyReq) validate() error {
 }
 
 type canAccessByKeyReq struct {
-	chanID string
-	Token  string `json:"token"`
+	chanID   string
+	Token    string `json:"token"`
+	Subtopic string `json:"subtopic,omitempty"`
 }
 
 func (req canAccessByKeyReq) validate() error {
@@ -35,8 +36,9 @@ func (req canAccessByKeyReq) validate() error {
 }
 
 type canAccessByIDReq struct {
-	chanID  string
-	ThingID string `json:"thing_id"`
+	chanID   string
+	ThingID  string `json:"thing_id"`
+	Subtopic string `json:"subtopic,omitempty"`
 }
 
 func (req canAccessByIDReq) validate() error {
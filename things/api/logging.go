@@ -11,6 +11,8 @@ import (
 	"time"
 
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -78,6 +80,45 @@ func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return lm.svc.ViewThing(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewThingStatus(ctx context.Context, token, id string) (p things.Presence, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_thing_status for token %s and thing %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewThingStatus(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) UpdatePresence(ctx context.Context, p things.Presence) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_presence for thing %s took %s to complete", p.ThingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdatePresence(ctx, p)
+}
+
+func (lm *loggingMiddleware) UpdateLastSeen(ctx context.Context, p things.Presence) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_last_seen for thing %s took %s to complete", p.ThingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateLastSeen(ctx, p)
+}
+
 func (lm *loggingMiddleware) ListThings(ctx context.Context, token string, pm things.PageMetadata) (_ things.Page, err error) {
 	defer func(begin time.Time) {
 		nlog := ""
@@ -240,9 +281,22 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token string, chIDs
 	return lm.svc.Disconnect(ctx, token, chIDs, thIDs)
 }
 
-func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string) (thing string, err error) {
+func (lm *loggingMiddleware) UpdateSubtopics(ctx context.Context, token, chanID, thingID string, subtopics []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_subtopics for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateSubtopics(ctx, token, chanID, thingID, subtopics)
+}
+
+func (lm *loggingMiddleware) ViewSubtopics(ctx context.Context, token, chanID, thingID string) (_ []string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for channel %s and thing %s took %s to complete", id, thing, time.Since(begin))
+		message := fmt.Sprintf("Method view_subtopics for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -250,12 +304,12 @@ func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string)
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByKey(ctx, id, key)
+	return lm.svc.ViewSubtopics(ctx, token, chanID, thingID)
 }
 
-func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) (err error) {
+func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key, subtopic string) (thing string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access_by_id for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		message := fmt.Sprintf("Method can_access for channel %s, subtopic %s and thing %s took %s to complete", id, subtopic, thing, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -263,7 +317,20 @@ func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByID(ctx, chanID, thingID)
+	return lm.svc.CanAccessByKey(ctx, id, key, subtopic)
+}
+
+func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, subtopic string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method can_access_by_id for channel %s, subtopic %s and thing %s took %s to complete", chanID, subtopic, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CanAccessByID(ctx, chanID, thingID, subtopic)
 }
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
@@ -292,6 +359,45 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 	return lm.svc.Identify(ctx, key)
 }
 
+func (lm *loggingMiddleware) GetChannelSchema(ctx context.Context, chanID string) (s schema.Schema, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_channel_schema for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) GetThingCodec(ctx context.Context, thingID string) (p codec.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_thing_codec for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetThingCodec(ctx, thingID)
+}
+
+func (lm *loggingMiddleware) GetGroupChannels(ctx context.Context, owner, groupID string) (chIDs []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_group_channels for group %s took %s to complete", groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetGroupChannels(ctx, owner, groupID)
+}
+
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (bk things.Backup, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
@@ -422,6 +528,84 @@ func (lm *loggingMiddleware) RemoveGroup(ctx context.Context, token, id string)
 	return lm.svc.RemoveGroup(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ListDescendants(ctx context.Context, token, groupID string, pm things.PageMetadata) (gp things.GroupPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_descendants for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDescendants(ctx, token, groupID, pm)
+}
+
+func (lm *loggingMiddleware) ListDescendantMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (mp things.MemberPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_descendant_members for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDescendantMembers(ctx, token, groupID, pm)
+}
+
+func (lm *loggingMiddleware) MoveGroup(ctx context.Context, token, groupID, parentID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method move_group for token %s, group %s and parent %s took %s to complete", token, groupID, parentID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.MoveGroup(ctx, token, groupID, parentID)
+}
+
+func (lm *loggingMiddleware) ConnectGroup(ctx context.Context, token, groupID string, chIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method connect_group for token %s, group %s and channels %s took %s to complete", token, groupID, chIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (lm *loggingMiddleware) DisconnectGroup(ctx context.Context, token, groupID string, chIDs []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method disconnect_group for token %s, group %s and channels %s took %s to complete", token, groupID, chIDs, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisconnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (lm *loggingMiddleware) ListChannelsByGroup(ctx context.Context, token, groupID string, pm things.PageMetadata) (cp things.ChannelsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_channels_by_group for token %s and group %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListChannelsByGroup(ctx, token, groupID, pm)
+}
+
 func (lm *loggingMiddleware) Assign(ctx context.Context, token, groupID string, memberIDs ...string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method assign for token %s took %s to complete", token, time.Since(begin))
@@ -447,3 +631,94 @@ func (lm *loggingMiddleware) Unassign(ctx context.Context, token, groupID string
 
 	return lm.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (lm *loggingMiddleware) CreateProfile(ctx context.Context, token string, p things.Profile) (pr things.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_profile for profile %s and token %s took %s to complete", pr.ID, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateProfile(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) UpdateProfile(ctx context.Context, token string, p things.Profile) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_profile for profile %s and token %s took %s to complete", p.ID, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateProfile(ctx, token, p)
+}
+
+func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token, id string) (pr things.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_profile for profile %s and token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewProfile(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (pp things.ProfilesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_profiles for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListProfiles(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RemoveProfile(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_profile for profile %s and token %s took %s to complete", id, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveProfile(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (tp things.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_things_by_profile for profile %s and token %s took %s to complete", profileID, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (lm *loggingMiddleware) UpdateThingsByProfile(ctx context.Context, token, profileID string, metadata things.Metadata) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_things_by_profile for profile %s and token %s took %s to complete", profileID, token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateThingsByProfile(ctx, token, profileID, metadata)
+}
@@ -9,6 +9,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/metrics"
 )
@@ -66,6 +68,33 @@ func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (t
 	return ms.svc.ViewThing(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewThingStatus(ctx context.Context, token, id string) (things.Presence, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_thing_status").Add(1)
+		ms.latency.With("method", "view_thing_status").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewThingStatus(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) UpdatePresence(ctx context.Context, p things.Presence) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_presence").Add(1)
+		ms.latency.With("method", "update_presence").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdatePresence(ctx, p)
+}
+
+func (ms *metricsMiddleware) UpdateLastSeen(ctx context.Context, p things.Presence) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_last_seen").Add(1)
+		ms.latency.With("method", "update_last_seen").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateLastSeen(ctx, p)
+}
+
 func (ms *metricsMiddleware) ListThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_things").Add(1)
@@ -174,22 +203,40 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token string, chIDs
 	return ms.svc.Disconnect(ctx, token, chIDs, thIDs)
 }
 
-func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
+func (ms *metricsMiddleware) UpdateSubtopics(ctx context.Context, token, chanID, thingID string, subtopics []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_subtopics").Add(1)
+		ms.latency.With("method", "update_subtopics").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateSubtopics(ctx, token, chanID, thingID, subtopics)
+}
+
+func (ms *metricsMiddleware) ViewSubtopics(ctx context.Context, token, chanID, thingID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_subtopics").Add(1)
+		ms.latency.With("method", "view_subtopics").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewSubtopics(ctx, token, chanID, thingID)
+}
+
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key, subtopic string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
 		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByKey(ctx, id, key)
+	return ms.svc.CanAccessByKey(ctx, id, key, subtopic)
 }
 
-func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, subtopic string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_id").Add(1)
 		ms.latency.With("method", "can_access_by_id").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByID(ctx, chanID, thingID)
+	return ms.svc.CanAccessByID(ctx, chanID, thingID, subtopic)
 }
 
 func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -210,6 +257,33 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 	return ms.svc.Identify(ctx, key)
 }
 
+func (ms *metricsMiddleware) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_channel_schema").Add(1)
+		ms.latency.With("method", "get_channel_schema").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) GetThingCodec(ctx context.Context, thingID string) (codec.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_thing_codec").Add(1)
+		ms.latency.With("method", "get_thing_codec").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetThingCodec(ctx, thingID)
+}
+
+func (ms *metricsMiddleware) GetGroupChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_group_channels").Add(1)
+		ms.latency.With("method", "get_group_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetGroupChannels(ctx, owner, groupID)
+}
+
 func (ms *metricsMiddleware) Backup(ctx context.Context, token string) (bk things.Backup, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "backup").Add(1)
@@ -300,6 +374,60 @@ func (ms *metricsMiddleware) RemoveGroup(ctx context.Context, token, id string)
 	return ms.svc.RemoveGroup(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ListDescendants(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_descendants").Add(1)
+		ms.latency.With("method", "list_descendants").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDescendants(ctx, token, groupID, pm)
+}
+
+func (ms *metricsMiddleware) ListDescendantMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_descendant_members").Add(1)
+		ms.latency.With("method", "list_descendant_members").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDescendantMembers(ctx, token, groupID, pm)
+}
+
+func (ms *metricsMiddleware) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_group").Add(1)
+		ms.latency.With("method", "move_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.MoveGroup(ctx, token, groupID, parentID)
+}
+
+func (ms *metricsMiddleware) ConnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "connect_group").Add(1)
+		ms.latency.With("method", "connect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (ms *metricsMiddleware) DisconnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disconnect_group").Add(1)
+		ms.latency.With("method", "disconnect_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DisconnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (ms *metricsMiddleware) ListChannelsByGroup(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_channels_by_group").Add(1)
+		ms.latency.With("method", "list_channels_by_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListChannelsByGroup(ctx, token, groupID, pm)
+}
+
 func (ms *metricsMiddleware) Assign(ctx context.Context, token, groupID string, memberIDs ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign").Add(1)
@@ -317,3 +445,66 @@ func (ms *metricsMiddleware) Unassign(ctx context.Context, token, groupID string
 
 	return ms.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (ms *metricsMiddleware) CreateProfile(ctx context.Context, token string, p things.Profile) (things.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_profile").Add(1)
+		ms.latency.With("method", "create_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateProfile(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) UpdateProfile(ctx context.Context, token string, p things.Profile) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_profile").Add(1)
+		ms.latency.With("method", "update_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateProfile(ctx, token, p)
+}
+
+func (ms *metricsMiddleware) ViewProfile(ctx context.Context, token, id string) (things.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_profile").Add(1)
+		ms.latency.With("method", "view_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewProfile(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_profiles").Add(1)
+		ms.latency.With("method", "list_profiles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListProfiles(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RemoveProfile(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_profile").Add(1)
+		ms.latency.With("method", "remove_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveProfile(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (things.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_things_by_profile").Add(1)
+		ms.latency.With("method", "list_things_by_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (ms *metricsMiddleware) UpdateThingsByProfile(ctx context.Context, token, profileID string, metadata things.Metadata) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_things_by_profile").Add(1)
+		ms.latency.With("method", "update_things_by_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateThingsByProfile(ctx, token, profileID, metadata)
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -22,10 +24,11 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		ths := []things.Thing{}
 		for _, tReq := range req.Things {
 			th := things.Thing{
-				Name:     tReq.Name,
-				Key:      tReq.Key,
-				ID:       tReq.ID,
-				Metadata: tReq.Metadata,
+				Name:      tReq.Name,
+				Key:       tReq.Key,
+				ID:        tReq.ID,
+				Metadata:  tReq.Metadata,
+				ProfileID: tReq.ProfileID,
 			}
 			ths = append(ths, th)
 		}
@@ -42,10 +45,11 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, th := range saved {
 			tRes := thingRes{
-				ID:       th.ID,
-				Name:     th.Name,
-				Key:      th.Key,
-				Metadata: th.Metadata,
+				ID:        th.ID,
+				Name:      th.Name,
+				Key:       th.Key,
+				Metadata:  th.Metadata,
+				ProfileID: th.ProfileID,
 			}
 			res.Things = append(res.Things, tRes)
 		}
@@ -108,16 +112,47 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewThingRes{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:        thing.ID,
+			Owner:     thing.Owner,
+			Name:      thing.Name,
+			Key:       thing.Key,
+			Metadata:  thing.Metadata,
+			ProfileID: thing.ProfileID,
 		}
 		return res, nil
 	}
 }
 
+func viewThingStatusEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		p, err := svc.ViewThingStatus(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := thingStatusRes{
+			ThingID:    p.ThingID,
+			Status:     things.StatusOffline,
+			Protocol:   p.Protocol,
+			RemoteAddr: p.RemoteAddr,
+		}
+		if p.Online {
+			res.Status = things.StatusOnline
+		}
+		if !p.LastSeen.IsZero() {
+			res.LastSeen = &p.LastSeen
+		}
+
+		return res, nil
+	}
+}
+
 func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listResourcesReq)
@@ -143,11 +178,12 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Name:     thing.Name,
-				Key:      thing.Key,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Name:      thing.Name,
+				Key:       thing.Key,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -179,11 +215,12 @@ func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Key:      thing.Key,
-				Name:     thing.Name,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Key:       thing.Key,
+				Name:      thing.Name,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -227,6 +264,7 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 				Metadata: cReq.Metadata,
 				Name:     cReq.Name,
 				ID:       cReq.ID,
+				Schema:   cReq.Schema,
 			}
 			chs = append(chs, ch)
 		}
@@ -246,6 +284,7 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 				ID:       ch.ID,
 				Name:     ch.Name,
 				Metadata: ch.Metadata,
+				Schema:   toSchemaRes(ch.Schema),
 			}
 			res.Channels = append(res.Channels, cRes)
 		}
@@ -266,6 +305,7 @@ func updateChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			ID:       req.id,
 			Name:     req.Name,
 			Metadata: req.Metadata,
+			Schema:   req.Schema,
 		}
 		if err := svc.UpdateChannel(ctx, req.token, channel); err != nil {
 			return nil, err
@@ -297,6 +337,7 @@ func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 			Owner:    channel.Owner,
 			Name:     channel.Name,
 			Metadata: channel.Metadata,
+			Schema:   toSchemaRes(channel.Schema),
 		}
 
 		return res, nil
@@ -333,6 +374,7 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 				Owner:    channel.Owner,
 				Name:     channel.Name,
 				Metadata: channel.Metadata,
+				Schema:   toSchemaRes(channel.Schema),
 			}
 
 			res.Channels = append(res.Channels, view)
@@ -412,6 +454,43 @@ func connectThingEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func updateSubtopicsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subtopicsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateSubtopics(ctx, req.token, req.chanID, req.thingID, req.Subtopics); err != nil {
+			return nil, err
+		}
+
+		return subtopicsRes{updated: true}, nil
+	}
+}
+
+func viewSubtopicsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subtopicsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		subtopics, err := svc.ViewSubtopics(ctx, req.token, req.chanID, req.thingID)
+		if err != nil {
+			return nil, err
+		}
+
+		if subtopics == nil {
+			subtopics = []string{}
+		}
+
+		return subtopicsRes{Subtopics: subtopics}, nil
+	}
+}
+
 func connectEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		cr := request.(connectReq)
@@ -472,11 +551,13 @@ func backupEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		return backupRes{
-			Things:         backup.Things,
-			Channels:       backup.Channels,
-			Connections:    backup.Connections,
-			Groups:         backup.Groups,
-			GroupRelations: backup.GroupRelations,
+			Things:           backup.Things,
+			Channels:         backup.Channels,
+			Connections:      backup.Connections,
+			Groups:           backup.Groups,
+			GroupRelations:   backup.GroupRelations,
+			GroupConnections: backup.GroupConnections,
+			Profiles:         backup.Profiles,
 		}, nil
 	}
 }
@@ -489,11 +570,13 @@ func restoreEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		backup := things.Backup{
-			Things:         req.Things,
-			Channels:       req.Channels,
-			Connections:    req.Connections,
-			Groups:         req.Groups,
-			GroupRelations: req.GroupRelations,
+			Things:           req.Things,
+			Channels:         req.Channels,
+			Connections:      req.Connections,
+			Groups:           req.Groups,
+			GroupRelations:   req.GroupRelations,
+			GroupConnections: req.GroupConnections,
+			Profiles:         req.Profiles,
 		}
 
 		if err := svc.Restore(ctx, req.token, backup); err != nil {
@@ -513,6 +596,7 @@ func createGroupEndpoint(svc things.Service) endpoint.Endpoint {
 
 		group := things.Group{
 			Name:        req.Name,
+			ParentID:    req.ParentID,
 			Description: req.Description,
 			Metadata:    req.Metadata,
 		}
@@ -544,6 +628,8 @@ func viewGroupEndpoint(svc things.Service) endpoint.Endpoint {
 			Description: group.Description,
 			Metadata:    group.Metadata,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -618,7 +704,12 @@ func listMembersEndpoint(svc things.Service) endpoint.Endpoint {
 			Limit:    req.limit,
 			Metadata: req.metadata,
 		}
-		page, err := svc.ListMembers(ctx, req.token, req.id, pm)
+		listMembers := svc.ListMembers
+		if req.recursive {
+			listMembers = svc.ListDescendantMembers
+		}
+
+		page, err := listMembers(ctx, req.token, req.id, pm)
 		if err != nil {
 			return nil, err
 		}
@@ -627,6 +718,101 @@ func listMembersEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func listDescendantsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listGroupsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDescendants(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildGroupsResponse(page), nil
+	}
+}
+
+func moveGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.MoveGroup(ctx, req.token, req.id, req.ParentID); err != nil {
+			return nil, err
+		}
+
+		return groupRes{created: false}, nil
+	}
+}
+
+func connectGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ConnectGroup(ctx, req.token, req.groupID, req.Channels); err != nil {
+			return nil, err
+		}
+
+		return connectRes{}, nil
+	}
+}
+
+func disconnectGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupChannelsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DisconnectGroup(ctx, req.token, req.groupID, req.Channels); err != nil {
+			return nil, err
+		}
+
+		return disconnectThingRes{}, nil
+	}
+}
+
+func listChannelsByGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listGroupsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListChannelsByGroup(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := channelsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Channels: []viewChannelRes{},
+		}
+		for _, channel := range page.Channels {
+			view := viewChannelRes{
+				ID:       channel.ID,
+				Owner:    channel.Owner,
+				Name:     channel.Name,
+				Metadata: channel.Metadata,
+			}
+			res.Channels = append(res.Channels, view)
+		}
+
+		return res, nil
+	}
+}
+
 func listMemberships(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listMembersReq)
@@ -693,6 +879,8 @@ func buildGroupsResponse(gp things.GroupPage) groupPageRes {
 		view := viewGroupRes{
 			ID:          group.ID,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
@@ -728,3 +916,196 @@ func buildUsersResponse(mp things.MemberPage) memberPageRes {
 
 	return res
 }
+
+func toSchemaRes(s schema.Schema) *schema.Schema {
+	if s.Empty() {
+		return nil
+	}
+
+	return &s
+}
+
+func createProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(profileReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		profile := things.Profile{
+			Name:        req.Name,
+			ContentType: req.ContentType,
+			Transformer: req.Transformer,
+			Channels:    req.Channels,
+			Schema:      req.Schema,
+			Codec:       req.Codec,
+			Metadata:    req.Metadata,
+		}
+
+		saved, err := svc.CreateProfile(ctx, req.token, profile)
+		if err != nil {
+			return nil, err
+		}
+
+		return profileRes{created: true, id: saved.ID}, nil
+	}
+}
+
+func updateProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		profile := things.Profile{
+			ID:          req.id,
+			Name:        req.Name,
+			ContentType: req.ContentType,
+			Transformer: req.Transformer,
+			Channels:    req.Channels,
+			Schema:      req.Schema,
+			Codec:       req.Codec,
+			Metadata:    req.Metadata,
+		}
+
+		if err := svc.UpdateProfile(ctx, req.token, profile); err != nil {
+			return nil, err
+		}
+
+		return profileRes{created: false, id: req.id}, nil
+	}
+}
+
+func viewProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		profile, err := svc.ViewProfile(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewProfileRes(profile), nil
+	}
+}
+
+func listProfilesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListProfiles(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := profilesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Profiles: []viewProfileRes{},
+		}
+		for _, profile := range page.Profiles {
+			res.Profiles = append(res.Profiles, toViewProfileRes(profile))
+		}
+
+		return res, nil
+	}
+}
+
+func removeProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveProfile(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func listThingsByProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listByConnectionReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListThingsByProfile(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := thingsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Things: []viewThingRes{},
+		}
+		for _, thing := range page.Things {
+			view := viewThingRes{
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Key:       thing.Key,
+				Name:      thing.Name,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
+			}
+			res.Things = append(res.Things, view)
+		}
+
+		return res, nil
+	}
+}
+
+func updateThingsByProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateThingsByProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateThingsByProfile(ctx, req.token, req.id, req.Metadata); err != nil {
+			return nil, err
+		}
+
+		return updateThingsByProfileRes{}, nil
+	}
+}
+
+func toViewProfileRes(p things.Profile) viewProfileRes {
+	return viewProfileRes{
+		ID:          p.ID,
+		Name:        p.Name,
+		ContentType: p.ContentType,
+		Transformer: p.Transformer,
+		Channels:    p.Channels,
+		Schema:      p.Schema,
+		Codec:       toCodecRes(p.Codec),
+		Metadata:    p.Metadata,
+	}
+}
+
+func toCodecRes(c codec.Codec) *codec.Codec {
+	if c.Empty() {
+		return nil
+	}
+
+	return &c
+}
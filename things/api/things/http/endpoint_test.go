@@ -91,12 +91,13 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := mocks.NewGroupRepository()
+	groupsRepo := mocks.NewGroupRepository(channelsRepo)
+	profilesRepo := mocks.NewProfileRepository()
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -535,6 +536,81 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestViewThingStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th, seen := ths[0], ths[1]
+
+	lastSeen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := things.Presence{ThingID: seen.ID, Online: true, LastSeen: lastSeen, Protocol: "mqtt"}
+	err = svc.UpdatePresence(context.Background(), p)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    string
+	}{
+		{
+			desc:   "view status of thing never seen",
+			id:     th.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`{"thing_id":"%s","status":"offline"}`, th.ID),
+		},
+		{
+			desc:   "view status of online thing",
+			id:     seen.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    fmt.Sprintf(`{"thing_id":"%s","status":"online","last_seen":"2024-01-02T03:04:05Z","protocol":"mqtt"}`, seen.ID),
+		},
+		{
+			desc:   "view status of non-existent thing",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+			res:    notFoundRes,
+		},
+		{
+			desc:   "view status of thing by passing invalid token",
+			id:     th.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			res:    unauthRes,
+		},
+		{
+			desc:   "view status of thing by passing empty token",
+			id:     th.ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+			res:    missingTokRes,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/status", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -606,6 +682,20 @@ func TestListThings(t *testing.T) {
 			url:    fmt.Sprintf("%s?offset=%d&limit=%d&order=name&dir=wrong", thingURL, 0, 5),
 			res:    nil,
 		},
+		{
+			desc:   "get a list of things with invalid query",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&query=%s", thingURL, 0, 5, "firmware%3C"),
+			res:    nil,
+		},
+		{
+			desc:   "get a list of things with invalid status",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d&status=%s", thingURL, 0, 5, "sleeping"),
+			res:    nil,
+		},
 		{
 			desc:   "get a list of things with invalid token",
 			auth:   wrongValue,
@@ -2535,6 +2625,362 @@ func TestDisconnnect(t *testing.T) {
 	}
 }
 
+func TestCreateProfile(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	data := `{"name": "sensor", "transformer": "senml", "schema": {"type": "object", "required": ["serial"]}}`
+	invalidSchema := `{"name": "invalid", "schema": {"type": 1}}`
+	invalidTransformer := `{"name": "invalid", "transformer": "xml"}`
+	invalidNameData := fmt.Sprintf(`{"name": "%s"}`, invalidName)
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		auth        string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create valid profile",
+			data:        data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/profiles/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create profile with existing name",
+			data:        data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+			location:    "",
+		},
+		{
+			desc:        "create profile with invalid metadata schema",
+			data:        invalidSchema,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create profile with invalid transformer",
+			data:        invalidTransformer,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create profile with invalid name",
+			data:        invalidNameData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create profile with invalid request format",
+			data:        "}",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "create profile with invalid auth token",
+			data:        data,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+			location:    "",
+		},
+		{
+			desc:        "create profile without content type",
+			data:        data,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+			location:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/profiles", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestUpdateThingsByProfile(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	pr := things.Profile{
+		Name: "sensor",
+		Schema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"firmware": map[string]interface{}{"type": "string"}},
+		},
+	}
+	pr, err := svc.CreateProfile(context.Background(), token, pr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	th := thing
+	th.ProfileID = pr.ID
+	_, err = svc.CreateThings(context.Background(), token, th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		id          string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "update things of profile",
+			id:          pr.ID,
+			data:        `{"metadata": {"firmware": "2.0.0"}}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update things with metadata that doesn't conform to the profile schema",
+			id:          pr.ID,
+			data:        `{"metadata": {"firmware": 2}}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update things without metadata",
+			id:          pr.ID,
+			data:        `{}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update things of non-existing profile",
+			id:          strconv.FormatUint(wrongID, 10),
+			data:        `{"metadata": {"firmware": "2.0.0"}}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update things with invalid auth token",
+			id:          pr.ID,
+			data:        `{"metadata": {"firmware": "2.0.0"}}`,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/profiles/%s/things", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	region, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	building, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building", ParentID: region.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	region2, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region2"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		id          string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "move group under another group",
+			id:          building.ID,
+			data:        fmt.Sprintf(`{"parent_id": "%s"}`, region2.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "move group under its descendant",
+			id:          region2.ID,
+			data:        fmt.Sprintf(`{"parent_id": "%s"}`, building.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "move group to root",
+			id:          building.ID,
+			data:        `{}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "move group under parent with invalid id",
+			id:          building.ID,
+			data:        fmt.Sprintf(`{"parent_id": "%s"}`, wrongValue),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "move group with invalid content type",
+			id:          building.ID,
+			data:        fmt.Sprintf(`{"parent_id": "%s"}`, region.ID),
+			contentType: "application/xml",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "move group with invalid auth token",
+			id:          building.ID,
+			data:        fmt.Sprintf(`{"parent_id": "%s"}`, region.ID),
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/groups/%s/parent", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestConnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	gr, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chs[0]
+
+	cases := []struct {
+		desc        string
+		id          string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "connect group to channel",
+			id:          gr.ID,
+			data:        fmt.Sprintf(`{"channels": ["%s"]}`, ch.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "connect group to already connected channel",
+			id:          gr.ID,
+			data:        fmt.Sprintf(`{"channels": ["%s"]}`, ch.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "connect group to non-existing channel",
+			id:          gr.ID,
+			data:        fmt.Sprintf(`{"channels": ["%s"]}`, wrongValue),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "connect group without channels",
+			id:          gr.ID,
+			data:        `{"channels": []}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "connect group with invalid content type",
+			id:          gr.ID,
+			data:        fmt.Sprintf(`{"channels": ["%s"]}`, ch.ID),
+			contentType: "application/xml",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "connect group with invalid auth token",
+			id:          gr.ID,
+			data:        fmt.Sprintf(`{"channels": ["%s"]}`, ch.ID),
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/groups/%s/channels", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestBackup(t *testing.T) {
 	svc := newService(map[string]string{token: adminEmail})
 	ts := newServer(svc)
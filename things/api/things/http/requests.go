@@ -5,6 +5,11 @@ package http
 
 import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/query"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 )
@@ -12,6 +17,7 @@ import (
 const (
 	maxLimitSize = 100
 	maxNameSize  = 1024
+	maxSilentFor = 100 * 365 * 24 * 60 * 60 // seconds in 100 years
 	nameOrder    = "name"
 	idOrder      = "id"
 	ascDir       = "asc"
@@ -19,10 +25,11 @@ const (
 )
 
 type createThingReq struct {
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	ID       string                 `json:"id,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
 }
 
 type createThingsReq struct {
@@ -46,6 +53,12 @@ func (req createThingsReq) validate() error {
 			}
 		}
 
+		if thing.ProfileID != "" {
+			if err := validateUUID(thing.ProfileID); err != nil {
+				return err
+			}
+		}
+
 		if len(thing.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
@@ -103,6 +116,7 @@ type createChannelReq struct {
 	Name     string                 `json:"name,omitempty"`
 	ID       string                 `json:"id,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Schema   schema.Schema          `json:"schema,omitempty"`
 }
 
 type createChannelsReq struct {
@@ -129,6 +143,10 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if err := channel.Schema.Validate(); err != nil {
+			return apiutil.ErrMalformedEntity
+		}
 	}
 
 	return nil
@@ -139,6 +157,7 @@ type updateChannelReq struct {
 	id       string
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Schema   schema.Schema          `json:"schema,omitempty"`
 }
 
 func (req updateChannelReq) validate() error {
@@ -154,6 +173,10 @@ func (req updateChannelReq) validate() error {
 		return apiutil.ErrNameSize
 	}
 
+	if err := req.Schema.Validate(); err != nil {
+		return apiutil.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -202,6 +225,21 @@ func (req *listResourcesReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
+	if req.pageMetadata.Query != "" {
+		if _, err := query.Parse(req.pageMetadata.Query); err != nil {
+			return errors.Wrap(apiutil.ErrMalformedEntity, err)
+		}
+	}
+
+	if req.pageMetadata.Status != "" &&
+		req.pageMetadata.Status != things.StatusOnline && req.pageMetadata.Status != things.StatusOffline {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	if req.pageMetadata.SilentFor > maxSilentFor {
+		return apiutil.ErrInvalidQueryParams
+	}
+
 	return nil
 }
 
@@ -255,6 +293,31 @@ func (req connectThingReq) validate() error {
 	return nil
 }
 
+type subtopicsReq struct {
+	token     string
+	chanID    string
+	thingID   string
+	Subtopics []string `json:"subtopics"`
+}
+
+func (req subtopicsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" || req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	for _, s := range req.Subtopics {
+		if _, err := messaging.NormalizeSubtopicPattern(s); err != nil {
+			return apiutil.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
 type connectReq struct {
 	token      string
 	ChannelIDs []string `json:"channel_ids,omitempty"`
@@ -297,12 +360,14 @@ func (req backupReq) validate() error {
 }
 
 type restoreReq struct {
-	token          string
-	Things         []things.Thing         `json:"things"`
-	Channels       []things.Channel       `json:"channels"`
-	Connections    []things.Connection    `json:"connections"`
-	Groups         []things.Group         `json:"groups"`
-	GroupRelations []things.GroupRelation `json:"group_relations"`
+	token            string
+	Things           []things.Thing           `json:"things"`
+	Channels         []things.Channel         `json:"channels"`
+	Connections      []things.Connection      `json:"connections"`
+	Groups           []things.Group           `json:"groups"`
+	GroupRelations   []things.GroupRelation   `json:"group_relations"`
+	GroupConnections []things.GroupConnection `json:"group_connections"`
+	Profiles         []things.Profile         `json:"profiles"`
 }
 
 func (req restoreReq) validate() error {
@@ -310,7 +375,7 @@ func (req restoreReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if len(req.Groups) == 0 && len(req.Things) == 0 && len(req.Channels) == 0 && len(req.Connections) == 0 && len(req.GroupRelations) == 0 {
+	if len(req.Groups) == 0 && len(req.Things) == 0 && len(req.Channels) == 0 && len(req.Connections) == 0 && len(req.GroupRelations) == 0 && len(req.GroupConnections) == 0 {
 		return apiutil.ErrEmptyList
 	}
 
@@ -320,6 +385,7 @@ func (req restoreReq) validate() error {
 type createGroupReq struct {
 	token       string
 	Name        string                 `json:"name,omitempty"`
+	ParentID    string                 `json:"parent_id,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -332,6 +398,10 @@ func (req createGroupReq) validate() error {
 		return apiutil.ErrNameSize
 	}
 
+	if req.ParentID != "" {
+		return validateUUID(req.ParentID)
+	}
+
 	return nil
 }
 
@@ -374,11 +444,12 @@ func (req listGroupsReq) validate() error {
 }
 
 type listMembersReq struct {
-	token    string
-	id       string
-	offset   uint64
-	limit    uint64
-	metadata things.GroupMetadata
+	token     string
+	id        string
+	offset    uint64
+	limit     uint64
+	recursive bool
+	metadata  things.GroupMetadata
 }
 
 func (req listMembersReq) validate() error {
@@ -415,6 +486,34 @@ func (req assignReq) validate() error {
 	return nil
 }
 
+type groupChannelsReq struct {
+	token    string
+	groupID  string
+	Channels []string `json:"channels"`
+}
+
+func (req groupChannelsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Channels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, chID := range req.Channels {
+		if chID == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
 type unassignReq struct {
 	assignReq
 }
@@ -435,6 +534,28 @@ func (req unassignReq) validate() error {
 	return nil
 }
 
+type moveGroupReq struct {
+	token    string
+	id       string
+	ParentID string `json:"parent_id"`
+}
+
+func (req moveGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.ParentID != "" {
+		return validateUUID(req.ParentID)
+	}
+
+	return nil
+}
+
 type groupReq struct {
 	token string
 	id    string
@@ -460,3 +581,67 @@ func validateUUID(extID string) (err error) {
 
 	return nil
 }
+
+type profileReq struct {
+	token       string
+	id          string
+	Name        string                 `json:"name,omitempty"`
+	ContentType string                 `json:"content_type,omitempty"`
+	Transformer string                 `json:"transformer,omitempty"`
+	Channels    []string               `json:"channels,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Codec       codec.Codec            `json:"codec,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (req profileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.Name) > maxNameSize || req.Name == "" {
+		return apiutil.ErrNameSize
+	}
+
+	for _, chID := range req.Channels {
+		if chID == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+type updateProfileReq struct {
+	profileReq
+}
+
+func (req updateProfileReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.profileReq.validate()
+}
+
+type updateThingsByProfileReq struct {
+	token    string
+	id       string
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func (req updateThingsByProfileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Metadata) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
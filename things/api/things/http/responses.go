@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -28,6 +30,10 @@ var (
 	_ mainflux.Response = (*removeRes)(nil)
 	_ mainflux.Response = (*assignRes)(nil)
 	_ mainflux.Response = (*unassignRes)(nil)
+	_ mainflux.Response = (*profileRes)(nil)
+	_ mainflux.Response = (*viewProfileRes)(nil)
+	_ mainflux.Response = (*profilesPageRes)(nil)
+	_ mainflux.Response = (*updateThingsByProfileRes)(nil)
 )
 
 type removeRes struct{}
@@ -59,11 +65,12 @@ func (res shareThingRes) Empty() bool {
 }
 
 type thingRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	created   bool
 }
 
 type thingsRes struct {
@@ -88,11 +95,12 @@ func (res thingsRes) Empty() bool {
 }
 
 type viewThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"-"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
 }
 
 func (res viewThingRes) Code() int {
@@ -107,6 +115,26 @@ func (res viewThingRes) Empty() bool {
 	return false
 }
 
+type thingStatusRes struct {
+	ThingID    string     `json:"thing_id"`
+	Status     string     `json:"status"`
+	LastSeen   *time.Time `json:"last_seen,omitempty"`
+	Protocol   string     `json:"protocol,omitempty"`
+	RemoteAddr string     `json:"remote_addr,omitempty"`
+}
+
+func (res thingStatusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res thingStatusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res thingStatusRes) Empty() bool {
+	return false
+}
+
 type thingsPageRes struct {
 	pageRes
 	Things []viewThingRes `json:"things"`
@@ -128,6 +156,7 @@ type channelRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Schema   *schema.Schema         `json:"schema,omitempty"`
 	created  bool
 }
 
@@ -158,6 +187,7 @@ type viewChannelRes struct {
 	Name     string                 `json:"name,omitempty"`
 	Things   []viewThingRes         `json:"connected,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Schema   *schema.Schema         `json:"schema,omitempty"`
 }
 
 func (res viewChannelRes) Code() int {
@@ -247,12 +277,31 @@ func (res disconnectThingRes) Empty() bool {
 	return true
 }
 
+type subtopicsRes struct {
+	Subtopics []string `json:"subtopics"`
+	updated   bool
+}
+
+func (res subtopicsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res subtopicsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res subtopicsRes) Empty() bool {
+	return res.updated
+}
+
 type backupRes struct {
-	Things         []things.Thing         `json:"things"`
-	Channels       []things.Channel       `json:"channels"`
-	Connections    []things.Connection    `json:"connections"`
-	Groups         []things.Group         `json:"groups"`
-	GroupRelations []things.GroupRelation `json:"group_relations"`
+	Things           []things.Thing           `json:"things"`
+	Channels         []things.Channel         `json:"channels"`
+	Connections      []things.Connection      `json:"connections"`
+	Groups           []things.Group           `json:"groups"`
+	GroupRelations   []things.GroupRelation   `json:"group_relations"`
+	GroupConnections []things.GroupConnection `json:"group_connections"`
+	Profiles         []things.Profile         `json:"profiles"`
 }
 
 func (res backupRes) Code() int {
@@ -311,6 +360,8 @@ type viewGroupRes struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name"`
 	OwnerID     string                 `json:"owner_id"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Path        string                 `json:"path,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
@@ -400,3 +451,84 @@ func (res unassignRes) Headers() map[string]string {
 func (res unassignRes) Empty() bool {
 	return true
 }
+
+type profileRes struct {
+	id      string
+	created bool
+}
+
+func (res profileRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res profileRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/profiles/%s", res.id),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res profileRes) Empty() bool {
+	return true
+}
+
+type viewProfileRes struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	ContentType string                 `json:"content_type,omitempty"`
+	Transformer string                 `json:"transformer,omitempty"`
+	Channels    []string               `json:"channels,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Codec       *codec.Codec           `json:"codec,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (res viewProfileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewProfileRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewProfileRes) Empty() bool {
+	return false
+}
+
+type profilesPageRes struct {
+	pageRes
+	Profiles []viewProfileRes `json:"profiles"`
+}
+
+func (res profilesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res profilesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res profilesPageRes) Empty() bool {
+	return false
+}
+
+type updateThingsByProfileRes struct{}
+
+func (res updateThingsByProfileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateThingsByProfileRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateThingsByProfileRes) Empty() bool {
+	return true
+}
@@ -23,17 +23,21 @@ import (
 )
 
 const (
-	contentType = "application/json"
-	offsetKey   = "offset"
-	limitKey    = "limit"
-	nameKey     = "name"
-	orderKey    = "order"
-	dirKey      = "dir"
-	metadataKey = "metadata"
-	disconnKey  = "disconnected"
-	groupIDKey  = "groupID"
-	defOffset   = 0
-	defLimit    = 10
+	contentType  = "application/json"
+	offsetKey    = "offset"
+	limitKey     = "limit"
+	nameKey      = "name"
+	orderKey     = "order"
+	dirKey       = "dir"
+	metadataKey  = "metadata"
+	queryKey     = "query"
+	statusKey    = "status"
+	silentForKey = "silent_for"
+	disconnKey   = "disconnected"
+	groupIDKey   = "groupID"
+	recursiveKey = "recursive"
+	defOffset    = 0
+	defLimit     = 10
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -79,6 +83,13 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/things/:id/status", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_thing_status")(viewThingStatusEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:id/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_channels_by_thing")(listChannelsByThingEndpoint(svc)),
 		decodeListByConnection,
@@ -170,6 +181,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Put("/channels/:chanId/things/:thingId/subtopics", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_subtopics")(updateSubtopicsEndpoint(svc)),
+		decodeSubtopicsUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/channels/:chanId/things/:thingId/subtopics", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_subtopics")(viewSubtopicsEndpoint(svc)),
+		decodeSubtopics,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Post("/groups", kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_group")(createGroupEndpoint(svc)),
 		decodeGroupCreate,
@@ -226,6 +251,41 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/groups/:groupID/descendants", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_descendants")(listDescendantsEndpoint(svc)),
+		decodeListGroupsRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/groups/:groupID/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "connect_group")(connectGroupEndpoint(svc)),
+		decodeGroupChannelsRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/groups/:groupID/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "disconnect_group")(disconnectGroupEndpoint(svc)),
+		decodeGroupChannelsRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:groupID/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_channels_by_group")(listChannelsByGroupEndpoint(svc)),
+		decodeListGroupsRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/groups/:groupID/parent", kithttp.NewServer(
+		kitot.TraceServer(tracer, "move_group")(moveGroupEndpoint(svc)),
+		decodeMoveGroup,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/things/:memberID/groups", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_memberships")(listMemberships(svc)),
 		decodeListMembershipsRequest,
@@ -233,6 +293,55 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/profiles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_profile")(createProfileEndpoint(svc)),
+		decodeProfileCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_profiles")(listProfilesEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_profile")(viewProfileEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_profile")(updateProfileEndpoint(svc)),
+		decodeProfileUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_profile")(removeProfileEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_things_by_profile")(listThingsByProfileEndpoint(svc)),
+		decodeListByConnection,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/profiles/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_things_by_profile")(updateThingsByProfileEndpoint(svc)),
+		decodeThingsByProfileUpdate,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/backup", kithttp.NewServer(
 		kitot.TraceServer(tracer, "backup")(backupEndpoint(svc)),
 		decodeBackup,
@@ -327,6 +436,53 @@ func decodeChannelUpdate(_ context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+func decodeProfileCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := profileReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeProfileUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateProfileReq{
+		profileReq{
+			token: apiutil.ExtractBearerToken(r),
+			id:    bone.GetValue(r, "id"),
+		},
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeThingsByProfileUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateThingsByProfileReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
 	req := viewResourceReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -367,15 +523,33 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	q, err := apiutil.ReadStringQuery(r, queryKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := apiutil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sf, err := apiutil.ReadUintQuery(r, silentForKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: apiutil.ExtractBearerToken(r),
 		pageMetadata: things.PageMetadata{
-			Offset:   o,
-			Limit:    l,
-			Name:     n,
-			Order:    or,
-			Dir:      d,
-			Metadata: m,
+			Offset:    o,
+			Limit:     l,
+			Name:      n,
+			Order:     or,
+			Dir:       d,
+			Metadata:  m,
+			Query:     q,
+			Status:    st,
+			SilentFor: sf,
 		},
 	}
 
@@ -442,6 +616,33 @@ func decodeConnectThing(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeSubtopicsUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := subtopicsReq{
+		token:   apiutil.ExtractBearerToken(r),
+		chanID:  bone.GetValue(r, "chanId"),
+		thingID: bone.GetValue(r, "thingId"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeSubtopics(_ context.Context, r *http.Request) (interface{}, error) {
+	req := subtopicsReq{
+		token:   apiutil.ExtractBearerToken(r),
+		chanID:  bone.GetValue(r, "chanId"),
+		thingID: bone.GetValue(r, "thingId"),
+	}
+
+	return req, nil
+}
+
 func decodeConnectList(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -471,12 +672,18 @@ func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{},
 		return nil, err
 	}
 
+	rec, err := apiutil.ReadBoolQuery(r, recursiveKey, false)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listMembersReq{
-		token:    apiutil.ExtractBearerToken(r),
-		id:       bone.GetValue(r, groupIDKey),
-		offset:   o,
-		limit:    l,
-		metadata: m,
+		token:     apiutil.ExtractBearerToken(r),
+		id:        bone.GetValue(r, groupIDKey),
+		offset:    o,
+		limit:     l,
+		recursive: rec,
+		metadata:  m,
 	}
 	return req, nil
 }
@@ -538,6 +745,22 @@ func decodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeMoveGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := moveGroupReq{
+		id:    bone.GetValue(r, groupIDKey),
+		token: apiutil.ExtractBearerToken(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeGroupRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := groupReq{
 		token: apiutil.ExtractBearerToken(r),
@@ -560,6 +783,23 @@ func decodeAssignRequest(_ context.Context, r *http.Request) (interface{}, error
 	return req, nil
 }
 
+func decodeGroupChannelsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := groupChannelsReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: bone.GetValue(r, groupIDKey),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeUnassignRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := unassignReq{
 		assignReq{
@@ -653,6 +893,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, errors.ErrMalformedEntity),
+		errors.Contains(err, things.ErrGroupCycle),
 		err == apiutil.ErrNameSize,
 		err == apiutil.ErrEmptyList,
 		err == apiutil.ErrMissingID,
@@ -662,6 +904,7 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrOffsetSize,
 		err == apiutil.ErrInvalidOrder,
 		err == apiutil.ErrInvalidDirection,
+		err == apiutil.ErrInvalidQueryParams,
 		err == apiutil.ErrInvalidIDFormat:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrConflict):
@@ -5,15 +5,19 @@ package things
 
 import (
 	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 // Channel represents a Mainflux "communication group". This group contains the
-// things that can exchange messages between each other.
+// things that can exchange messages between each other. Schema describes the
+// payloads that can be published to the channel.
 type Channel struct {
 	ID       string
 	Owner    string
 	Name     string
 	Metadata map[string]interface{}
+	Schema   schema.Schema
 }
 
 // ChannelsPage contains page related metadata as well as list of channels that
@@ -24,11 +28,15 @@ type ChannelsPage struct {
 }
 
 // Connection represents a connection between a channel and a thing.
+// Subtopics restricts the connection to the subtopics matching at least
+// one of the MQTT-style patterns. An empty list grants access to every
+// subtopic of the channel.
 type Connection struct {
 	ChannelID    string
 	ChannelOwner string
 	ThingID      string
 	ThingOwner   string
+	Subtopics    []string
 }
 
 // ChannelRepository specifies a channel persistence API.
@@ -78,6 +86,14 @@ type ChannelRepository interface {
 	// returned error will be nil.
 	HasThingByID(ctx context.Context, chanID, thingID string) error
 
+	// UpdateSubtopics replaces subtopic ACL patterns of the connection
+	// between the specified channel and thing.
+	UpdateSubtopics(ctx context.Context, owner, chanID, thingID string, subtopics []string) error
+
+	// RetrieveSubtopics retrieves subtopic ACL patterns of the connection
+	// between the specified channel and thing.
+	RetrieveSubtopics(ctx context.Context, chanID, thingID string) ([]string, error)
+
 	// RetrieveAll retrieves all channels for all users.
 	RetrieveAll(ctx context.Context) ([]Channel, error)
 
@@ -90,15 +106,27 @@ type ChannelRepository interface {
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect channel thing connection together with its subtopic ACL patterns.
+	Connect(ctx context.Context, chanID, thingID string, subtopics []string) error
 
 	// HasThing checks if thing is connected to channel.
 	HasThing(context.Context, string, string) bool
 
+	// Subtopics returns cached subtopic ACL patterns of the connection.
+	Subtopics(ctx context.Context, chanID, thingID string) ([]string, error)
+
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
 
+	// SaveSchema stores the payload schema of the channel.
+	SaveSchema(ctx context.Context, chanID string, s schema.Schema) error
+
+	// Schema returns the cached payload schema of the channel.
+	Schema(ctx context.Context, chanID string) (schema.Schema, error)
+
+	// RemoveSchema removes the payload schema of the channel from cache.
+	RemoveSchema(ctx context.Context, chanID string) error
+
 	// Removes channel from cache.
 	Remove(context.Context, string) error
 }
@@ -3,6 +3,7 @@ package things
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -21,8 +22,14 @@ var (
 
 	// ErrFailedToRetrieveMembership failed to retrieve memberships
 	ErrFailedToRetrieveMembership = errors.New("failed to retrieve memberships")
+
+	// ErrGroupCycle indicates that a group would become its own ancestor.
+	ErrGroupCycle = errors.New("group cannot be moved under itself or its descendant")
 )
 
+// GroupPathSeparator separates group IDs in a group path.
+const GroupPathSeparator = "/"
+
 // Identity contains ID and Email.
 type Identity struct {
 	ID    string
@@ -32,10 +39,14 @@ type Identity struct {
 // GroupMetadata defines the Metadata type.
 type GroupMetadata map[string]interface{}
 
-// Group represents the group information.
+// Group represents the group information. Groups form a tree: ParentID
+// references the enclosing group, while Path holds the IDs of all the
+// ancestors, starting from the root and ending with the group ID itself.
 type Group struct {
 	ID          string
 	OwnerID     string
+	ParentID    string
+	Path        string
 	Name        string
 	Description string
 	Metadata    GroupMetadata
@@ -51,6 +62,14 @@ type GroupRelation struct {
 	UpdatedAt time.Time
 }
 
+// GroupConnection represents a connection between a group and a channel.
+// All the things assigned to the group, or to any of its descendants, are
+// allowed to access the channel.
+type GroupConnection struct {
+	GroupID   string
+	ChannelID string
+}
+
 // GroupPage contains page related metadata as well as list of groups that
 // belong to this page.
 type GroupPage struct {
@@ -102,4 +121,53 @@ type GroupRepository interface {
 
 	// RetrieveAllGroupRelations retrieves all group relations.
 	RetrieveAllGroupRelations(ctx context.Context) ([]GroupRelation, error)
+
+	// RetrieveDescendants retrieves all groups placed below the group
+	// identified by groupID, at any depth.
+	RetrieveDescendants(ctx context.Context, groupID string, pm PageMetadata) (GroupPage, error)
+
+	// RetrieveDescendantMembers retrieves members assigned to the group
+	// identified by groupID or to any of its descendants.
+	RetrieveDescendantMembers(ctx context.Context, groupID string, pm PageMetadata) (MemberPage, error)
+
+	// RetrieveDescendantChannels retrieves IDs of the channels owned by the
+	// owner and connected to the group identified by groupID or to any of its
+	// descendants, or to the things assigned to them.
+	RetrieveDescendantChannels(ctx context.Context, owner, groupID string) ([]string, error)
+
+	// Move places the group identified by groupID, together with its whole
+	// subtree, under the group identified by parentID. An empty parentID
+	// turns the group into a root group.
+	Move(ctx context.Context, groupID, parentID string) error
+
+	// ConnectChannels connects channels to a group.
+	ConnectChannels(ctx context.Context, groupID string, chIDs ...string) error
+
+	// DisconnectChannels disconnects channels from a group.
+	DisconnectChannels(ctx context.Context, groupID string, chIDs ...string) error
+
+	// RetrieveChannels retrieves channels connected to the group identified by groupID.
+	RetrieveChannels(ctx context.Context, groupID string, pm PageMetadata) (ChannelsPage, error)
+
+	// HasChannelAccess determines whether the member is assigned to a group,
+	// or to a descendant of a group, connected to the specified channel.
+	HasChannelAccess(ctx context.Context, chanID, memberID string) error
+
+	// RetrieveAllConnections retrieves all connections between groups and channels.
+	RetrieveAllConnections(ctx context.Context) ([]GroupConnection, error)
+}
+
+// Ancestors returns IDs of the group ancestors, nearest first.
+func (g Group) Ancestors() []string {
+	if g.Path == "" {
+		return nil
+	}
+
+	ids := strings.Split(g.Path, GroupPathSeparator)
+	var ancestors []string
+	for i := len(ids) - 2; i >= 0; i-- {
+		ancestors = append(ancestors, ids[i])
+	}
+
+	return ancestors
 }
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -24,21 +25,23 @@ type Connection struct {
 var _ things.ChannelRepository = (*channelRepositoryMock)(nil)
 
 type channelRepositoryMock struct {
-	mu       sync.Mutex
-	counter  uint64
-	channels map[string]things.Channel
-	tconns   chan Connection                      // used for synchronization with thing repo
-	cconns   map[string]map[string]things.Channel // used to track connections
-	things   things.ThingRepository
+	mu        sync.Mutex
+	counter   uint64
+	channels  map[string]things.Channel
+	tconns    chan Connection                      // used for synchronization with thing repo
+	cconns    map[string]map[string]things.Channel // used to track connections
+	subtopics map[string][]string                  // used to track connection subtopic patterns
+	things    things.ThingRepository
 }
 
 // NewChannelRepository creates in-memory channel repository.
 func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) things.ChannelRepository {
 	return &channelRepositoryMock{
-		channels: make(map[string]things.Channel),
-		tconns:   tconns,
-		cconns:   make(map[string]map[string]things.Channel),
-		things:   repo,
+		channels:  make(map[string]things.Channel),
+		tconns:    tconns,
+		cconns:    make(map[string]map[string]things.Channel),
+		subtopics: make(map[string][]string),
+		things:    repo,
 	}
 }
 
@@ -251,6 +254,7 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner string, ch
 				connected: false,
 			}
 			delete(crm.cconns[thID], chID)
+			delete(crm.subtopics, key(chID, thID))
 		}
 	}
 
@@ -288,6 +292,29 @@ func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingI
 	return nil
 }
 
+func (crm *channelRepositoryMock) UpdateSubtopics(_ context.Context, owner, chanID, thingID string, subtopics []string) error {
+	if err := crm.HasThingByID(context.Background(), chanID, thingID); err != nil {
+		return errors.ErrNotFound
+	}
+
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	crm.subtopics[key(chanID, thingID)] = subtopics
+	return nil
+}
+
+func (crm *channelRepositoryMock) RetrieveSubtopics(_ context.Context, chanID, thingID string) ([]string, error) {
+	if err := crm.HasThingByID(context.Background(), chanID, thingID); err != nil {
+		return nil, errors.ErrNotFound
+	}
+
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	return crm.subtopics[key(chanID, thingID)], nil
+}
+
 func (crm *channelRepositoryMock) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
@@ -312,6 +339,7 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 				ChannelOwner: v.Owner,
 				ThingID:      thingID,
 				ThingOwner:   v.Owner,
+				Subtopics:    crm.subtopics[key(v.ID, thingID)],
 			}
 			conns = append(conns, con)
 		}
@@ -322,25 +350,37 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 }
 
 type channelCacheMock struct {
-	mu       sync.Mutex
-	channels map[string]string
+	mu        sync.Mutex
+	channels  map[string]string
+	subtopics map[string][]string
+	schemas   map[string]schema.Schema
 }
 
 // NewChannelCache returns mock cache instance.
 func NewChannelCache() things.ChannelCache {
 	return &channelCacheMock{
-		channels: make(map[string]string),
+		channels:  make(map[string]string),
+		subtopics: make(map[string][]string),
+		schemas:   make(map[string]schema.Schema),
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string, subtopics []string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
 	ccm.channels[chanID] = thingID
+	ccm.subtopics[key(chanID, thingID)] = subtopics
 	return nil
 }
 
+func (ccm *channelCacheMock) Subtopics(_ context.Context, chanID, thingID string) ([]string, error) {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+
+	return ccm.subtopics[key(chanID, thingID)], nil
+}
+
 func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
@@ -353,6 +393,34 @@ func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID strin
 	defer ccm.mu.Unlock()
 
 	delete(ccm.channels, chanID)
+	delete(ccm.subtopics, key(chanID, thingID))
+	return nil
+}
+
+func (ccm *channelCacheMock) SaveSchema(_ context.Context, chanID string, s schema.Schema) error {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+
+	ccm.schemas[chanID] = s
+	return nil
+}
+
+func (ccm *channelCacheMock) Schema(_ context.Context, chanID string) (schema.Schema, error) {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+
+	s, ok := ccm.schemas[chanID]
+	if !ok {
+		return schema.Schema{}, errors.ErrNotFound
+	}
+	return s, nil
+}
+
+func (ccm *channelCacheMock) RemoveSchema(_ context.Context, chanID string) error {
+	ccm.mu.Lock()
+	defer ccm.mu.Unlock()
+
+	delete(ccm.schemas, chanID)
 	return nil
 }
 
@@ -361,5 +429,6 @@ func (ccm *channelCacheMock) Remove(_ context.Context, chanID string) error {
 	defer ccm.mu.Unlock()
 
 	delete(ccm.channels, chanID)
+	delete(ccm.schemas, chanID)
 	return nil
 }
@@ -5,6 +5,8 @@ package mocks
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,14 +30,20 @@ type groupRepositoryMock struct {
 	// is an element in the map members where group id is a key.
 	// members     map[type][GroupID]map[MemberID]MemberID
 	members map[string]map[string]map[string]string
+	// Map of channels connected to the group, group id as a key.
+	channels map[string]map[string]bool
+	// Repository of the channels connected to the group members.
+	chanRepo things.ChannelRepository
 }
 
 // NewGroupRepository creates in-memory user repository
-func NewGroupRepository() things.GroupRepository {
+func NewGroupRepository(chanRepo things.ChannelRepository) things.GroupRepository {
 	return &groupRepositoryMock{
 		groups:      make(map[string]things.Group),
 		memberships: make(map[string]map[string]things.Group),
 		members:     make(map[string]map[string]map[string]string),
+		channels:    make(map[string]map[string]bool),
+		chanRepo:    chanRepo,
 	}
 }
 
@@ -76,6 +84,12 @@ func (grm *groupRepositoryMock) Remove(ctx context.Context, id string) error {
 	if len(grm.members[id]) > 0 {
 		return things.ErrGroupNotEmpty
 	}
+
+	for _, g := range grm.groups {
+		if g.ParentID == id {
+			return things.ErrGroupNotEmpty
+		}
+	}
 	// This is not quite exact, it should go in depth
 	delete(grm.groups, id)
 
@@ -242,3 +256,254 @@ func (grm *groupRepositoryMock) RetrieveAllGroupRelations(ctx context.Context) (
 
 	return groupRelations, nil
 }
+
+func (grm *groupRepositoryMock) RetrieveDescendants(ctx context.Context, groupID string, pm things.PageMetadata) (things.GroupPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	group, ok := grm.groups[groupID]
+	if !ok {
+		return things.GroupPage{}, errors.ErrNotFound
+	}
+
+	descendants := grm.descendants(group)
+	sort.SliceStable(descendants, func(i, j int) bool {
+		return descendants[i].Path < descendants[j].Path
+	})
+
+	return things.GroupPage{
+		Groups: pageGroups(descendants, pm),
+		PageMetadata: things.PageMetadata{
+			Total:  uint64(len(descendants)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (grm *groupRepositoryMock) RetrieveDescendantMembers(ctx context.Context, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	group, ok := grm.groups[groupID]
+	if !ok {
+		return things.MemberPage{}, errors.ErrNotFound
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, g := range append([]things.Group{group}, grm.descendants(group)...) {
+		for _, memberID := range grm.members[g.ID][g.ID] {
+			if !seen[memberID] {
+				seen[memberID] = true
+				ids = append(ids, memberID)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	var items []things.Thing
+	for i, id := range ids {
+		if uint64(i) >= pm.Offset && (pm.Limit == 0 || uint64(i) < pm.Offset+pm.Limit) {
+			items = append(items, things.Thing{ID: id})
+		}
+	}
+
+	return things.MemberPage{
+		Members: items,
+		PageMetadata: things.PageMetadata{
+			Total:  uint64(len(ids)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (grm *groupRepositoryMock) Move(ctx context.Context, groupID, parentID string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	group, ok := grm.groups[groupID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	newPath := groupID
+	if parentID != "" {
+		parent, ok := grm.groups[parentID]
+		if !ok {
+			return errors.ErrNotFound
+		}
+
+		if parent.Path == group.Path || strings.HasPrefix(parent.Path, group.Path+things.GroupPathSeparator) {
+			return things.ErrGroupCycle
+		}
+		newPath = parent.Path + things.GroupPathSeparator + groupID
+	}
+
+	for _, d := range grm.descendants(group) {
+		d.Path = newPath + strings.TrimPrefix(d.Path, group.Path)
+		grm.groups[d.ID] = d
+	}
+
+	group.ParentID = parentID
+	group.Path = newPath
+	group.UpdatedAt = time.Now()
+	grm.groups[groupID] = group
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) RetrieveDescendantChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	group, ok := grm.groups[groupID]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	seen := make(map[string]bool)
+	var chIDs []string
+	add := func(ch things.Channel) {
+		if ch.Owner == owner && !seen[ch.ID] {
+			seen[ch.ID] = true
+			chIDs = append(chIDs, ch.ID)
+		}
+	}
+
+	for _, g := range append([]things.Group{group}, grm.descendants(group)...) {
+		for chID := range grm.channels[g.ID] {
+			if ch, err := grm.chanRepo.RetrieveByID(ctx, chID); err == nil {
+				add(ch)
+			}
+		}
+
+		for _, memberID := range grm.members[g.ID][g.ID] {
+			cp, err := grm.chanRepo.RetrieveByThing(ctx, owner, memberID, things.PageMetadata{})
+			if err != nil {
+				return nil, err
+			}
+			for _, ch := range cp.Channels {
+				add(ch)
+			}
+		}
+	}
+
+	return chIDs, nil
+}
+
+func (grm *groupRepositoryMock) descendants(group things.Group) []things.Group {
+	var items []things.Group
+	for _, g := range grm.groups {
+		if strings.HasPrefix(g.Path, group.Path+things.GroupPathSeparator) {
+			items = append(items, g)
+		}
+	}
+
+	return items
+}
+
+func pageGroups(groups []things.Group, pm things.PageMetadata) []things.Group {
+	var items []things.Group
+	for i, g := range groups {
+		if uint64(i) >= pm.Offset && (pm.Limit == 0 || uint64(i) < pm.Offset+pm.Limit) {
+			items = append(items, g)
+		}
+	}
+
+	return items
+}
+
+func (grm *groupRepositoryMock) ConnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	if _, ok := grm.groups[groupID]; !ok {
+		return errors.ErrNotFound
+	}
+
+	if _, ok := grm.channels[groupID]; !ok {
+		grm.channels[groupID] = make(map[string]bool)
+	}
+
+	for _, chID := range chIDs {
+		if grm.channels[groupID][chID] {
+			return errors.ErrConflict
+		}
+		grm.channels[groupID][chID] = true
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) DisconnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for _, chID := range chIDs {
+		if !grm.channels[groupID][chID] {
+			return errors.ErrNotFound
+		}
+		delete(grm.channels[groupID], chID)
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) RetrieveChannels(ctx context.Context, groupID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var ids []string
+	for chID := range grm.channels[groupID] {
+		ids = append(ids, chID)
+	}
+	sort.Strings(ids)
+
+	items := []things.Channel{}
+	for i, id := range ids {
+		if uint64(i) >= pm.Offset && (pm.Limit == 0 || uint64(i) < pm.Offset+pm.Limit) {
+			items = append(items, things.Channel{ID: id})
+		}
+	}
+
+	return things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  uint64(len(ids)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (grm *groupRepositoryMock) HasChannelAccess(ctx context.Context, chanID, memberID string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for groupID := range grm.memberships[memberID] {
+		group := grm.groups[groupID]
+		for _, id := range append([]string{group.ID}, group.Ancestors()...) {
+			if grm.channels[id][chanID] {
+				return nil
+			}
+		}
+	}
+
+	return errors.ErrNotFound
+}
+
+func (grm *groupRepositoryMock) RetrieveAllConnections(ctx context.Context) ([]things.GroupConnection, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var conns []things.GroupConnection
+	for groupID, chs := range grm.channels {
+		for chID := range chs {
+			conns = append(conns, things.GroupConnection{GroupID: groupID, ChannelID: chID})
+		}
+	}
+
+	return conns, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.ProfileRepository = (*profileRepositoryMock)(nil)
+
+type profileRepositoryMock struct {
+	mu       sync.Mutex
+	profiles map[string]things.Profile
+}
+
+// NewProfileRepository creates in-memory profile repository.
+func NewProfileRepository() things.ProfileRepository {
+	return &profileRepositoryMock{
+		profiles: make(map[string]things.Profile),
+	}
+}
+
+func (prm *profileRepositoryMock) Save(_ context.Context, p things.Profile) (things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, pr := range prm.profiles {
+		if pr.ID == p.ID || pr.Owner == p.Owner && pr.Name == p.Name {
+			return things.Profile{}, errors.ErrConflict
+		}
+	}
+
+	prm.profiles[p.ID] = p
+	return p, nil
+}
+
+func (prm *profileRepositoryMock) Update(_ context.Context, p things.Profile) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	pr, ok := prm.profiles[p.ID]
+	if !ok || pr.Owner != p.Owner {
+		return errors.ErrNotFound
+	}
+
+	prm.profiles[p.ID] = p
+	return nil
+}
+
+func (prm *profileRepositoryMock) RetrieveByID(_ context.Context, id string) (things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	pr, ok := prm.profiles[id]
+	if !ok {
+		return things.Profile{}, errors.ErrNotFound
+	}
+
+	return pr, nil
+}
+
+func (prm *profileRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var prs []things.Profile
+	for _, pr := range prm.profiles {
+		if pr.Owner == owner {
+			prs = append(prs, pr)
+		}
+	}
+
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].ID < prs[j].ID
+	})
+
+	total := uint64(len(prs))
+	if pm.Limit > 0 {
+		first := uint64(pm.Offset)
+		last := first + uint64(pm.Limit)
+		if first > total {
+			first = total
+		}
+		if last > total {
+			last = total
+		}
+		prs = prs[first:last]
+	}
+
+	page := things.ProfilesPage{
+		Profiles: prs,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (prm *profileRepositoryMock) RetrieveAll(_ context.Context) ([]things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var prs []things.Profile
+	for _, pr := range prm.profiles {
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+func (prm *profileRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	if pr, ok := prm.profiles[id]; ok && pr.Owner == owner {
+		delete(prm.profiles, id)
+	}
+
+	return nil
+}
@@ -10,25 +10,28 @@ import (
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
 var _ things.ThingRepository = (*thingRepositoryMock)(nil)
 
 type thingRepositoryMock struct {
-	mu      sync.Mutex
-	counter uint64
-	conns   chan Connection
-	tconns  map[string]map[string]things.Thing
-	things  map[string]things.Thing
+	mu       sync.Mutex
+	counter  uint64
+	conns    chan Connection
+	tconns   map[string]map[string]things.Thing
+	things   map[string]things.Thing
+	presence map[string]things.Presence
 }
 
 // NewThingRepository creates in-memory thing repository.
 func NewThingRepository(conns chan Connection) things.ThingRepository {
 	repo := &thingRepositoryMock{
-		conns:  conns,
-		things: make(map[string]things.Thing),
-		tconns: make(map[string]map[string]things.Thing),
+		conns:    conns,
+		things:   make(map[string]things.Thing),
+		tconns:   make(map[string]map[string]things.Thing),
+		presence: make(map[string]things.Presence),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
 		for conn := range conns {
@@ -79,6 +82,26 @@ func (trm *thingRepositoryMock) Update(_ context.Context, thing things.Thing) er
 	return nil
 }
 
+func (trm *thingRepositoryMock) UpdateMetadata(_ context.Context, ths ...things.Thing) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, th := range ths {
+		if _, ok := trm.things[key(th.Owner, th.ID)]; !ok {
+			return errors.ErrNotFound
+		}
+	}
+
+	for _, th := range ths {
+		dbKey := key(th.Owner, th.ID)
+		stored := trm.things[dbKey]
+		stored.Metadata = th.Metadata
+		trm.things[dbKey] = stored
+	}
+
+	return nil
+}
+
 func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val string) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -252,6 +275,48 @@ func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chID
 	return page, nil
 }
 
+func (trm *thingRepositoryMock) RetrieveByProfile(_ context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if pm.Limit < 0 {
+		return things.Page{}, nil
+	}
+
+	var ths []things.Thing
+	for _, th := range trm.things {
+		if th.Owner == owner && th.ProfileID == profileID {
+			ths = append(ths, th)
+		}
+	}
+
+	ths = sortThings(pm, ths)
+	total := uint64(len(ths))
+
+	if pm.Limit > 0 {
+		first := uint64(pm.Offset)
+		last := first + uint64(pm.Limit)
+		if first > total {
+			first = total
+		}
+		if last > total {
+			last = total
+		}
+		ths = ths[first:last]
+	}
+
+	page := things.Page{
+		Things: ths,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) error {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -260,6 +325,7 @@ func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) erro
 		return errors.ErrNotFound
 	}
 	delete(trm.things, key(owner, id))
+	delete(trm.presence, id)
 	return nil
 }
 
@@ -313,15 +379,73 @@ func (trm *thingRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.P
 	panic("not implemented")
 }
 
+func (trm *thingRepositoryMock) SavePresence(_ context.Context, p things.Presence) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if !trm.exists(p.ThingID) {
+		return errors.ErrNotFound
+	}
+
+	if old, ok := trm.presence[p.ThingID]; ok && old.LastSeen.After(p.LastSeen) {
+		p.LastSeen = old.LastSeen
+	}
+	trm.presence[p.ThingID] = p
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) UpdateLastSeen(_ context.Context, p things.Presence) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if !trm.exists(p.ThingID) {
+		return errors.ErrNotFound
+	}
+
+	old := trm.presence[p.ThingID]
+	if old.LastSeen.After(p.LastSeen) {
+		p.LastSeen = old.LastSeen
+	}
+	p.Online = old.Online
+	trm.presence[p.ThingID] = p
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) RetrievePresence(_ context.Context, thingID string) (things.Presence, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	p, ok := trm.presence[thingID]
+	if !ok {
+		return things.Presence{}, errors.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (trm *thingRepositoryMock) exists(id string) bool {
+	for _, th := range trm.things {
+		if th.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
 type thingCacheMock struct {
 	mu     sync.Mutex
 	things map[string]string
+	codecs map[string]codec.Profile
 }
 
 // NewThingCache returns mock cache instance.
 func NewThingCache() things.ThingCache {
 	return &thingCacheMock{
 		things: make(map[string]string),
+		codecs: make(map[string]codec.Profile),
 	}
 }
 
@@ -345,10 +469,40 @@ func (tcm *thingCacheMock) ID(_ context.Context, key string) (string, error) {
 	return id, nil
 }
 
+func (tcm *thingCacheMock) SaveCodec(_ context.Context, id string, p codec.Profile) error {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	tcm.codecs[id] = p
+	return nil
+}
+
+func (tcm *thingCacheMock) Codec(_ context.Context, id string) (codec.Profile, error) {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	p, ok := tcm.codecs[id]
+	if !ok {
+		return codec.Profile{}, errors.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (tcm *thingCacheMock) RemoveCodec(_ context.Context, id string) error {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	delete(tcm.codecs, id)
+	return nil
+}
+
 func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 	tcm.mu.Lock()
 	defer tcm.mu.Unlock()
 
+	delete(tcm.codecs, id)
+
 	for key, val := range tcm.things {
 		if val == id {
 			delete(tcm.things, key)
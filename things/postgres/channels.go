@@ -12,9 +12,11 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -44,8 +46,8 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 		return nil, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO channels (id, owner, name, metadata)
-		  VALUES (:id, :owner, :name, :metadata);`
+	q := `INSERT INTO channels (id, owner, name, metadata, schema)
+		  VALUES (:id, :owner, :name, :metadata, :schema);`
 
 	for _, channel := range channels {
 		dbch := toDBChannel(channel)
@@ -76,7 +78,7 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 }
 
 func (cr channelRepository) Update(ctx context.Context, channel things.Channel) error {
-	q := `UPDATE channels SET name = :name, metadata = :metadata WHERE owner = :owner AND id = :id;`
+	q := `UPDATE channels SET name = :name, metadata = :metadata, schema = :schema WHERE owner = :owner AND id = :id;`
 
 	dbch := toDBChannel(channel)
 
@@ -108,7 +110,7 @@ func (cr channelRepository) Update(ctx context.Context, channel things.Channel)
 }
 
 func (cr channelRepository) RetrieveByID(ctx context.Context, id string) (things.Channel, error) {
-	q := `SELECT name, metadata, owner FROM channels WHERE id = $1;`
+	q := `SELECT name, metadata, schema, owner FROM channels WHERE id = $1;`
 
 	dbch := dbChannel{
 		ID: id,
@@ -436,8 +438,59 @@ func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID string
 	return nil
 }
 
+func (cr channelRepository) UpdateSubtopics(ctx context.Context, owner, chanID, thingID string, subtopics []string) error {
+	dbco := toDBConnection(things.Connection{
+		ChannelID:    chanID,
+		ChannelOwner: owner,
+		ThingID:      thingID,
+		Subtopics:    subtopics,
+	})
+
+	q := `UPDATE connections SET subtopics = :subtopics
+	      WHERE channel_id = :channel_id AND channel_owner = :channel_owner AND thing_id = :thing_id;`
+
+	res, err := cr.db.NamedExecContext(ctx, q, dbco)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr channelRepository) RetrieveSubtopics(ctx context.Context, chanID, thingID string) ([]string, error) {
+	q := `SELECT subtopics FROM connections WHERE channel_id = $1 AND thing_id = $2;`
+
+	var subs pgtype.TextArray
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).Scan(&subs); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var subtopics []string
+	if err := subs.AssignTo(&subtopics); err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return subtopics, nil
+}
+
 func (cr channelRepository) RetrieveAllConnections(ctx context.Context) ([]things.Connection, error) {
-	q := `SELECT channel_id, channel_owner, thing_id, thing_owner FROM connections;`
+	q := `SELECT channel_id, channel_owner, thing_id, thing_owner, subtopics FROM connections;`
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
 	if err != nil {
@@ -468,6 +521,19 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, pm thing
 		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
+	params := map[string]interface{}{
+		"owner":    owner,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+		"name":     name,
+		"metadata": meta,
+	}
+
+	sq, err := getSearchQuery(pm.Query, params)
+	if err != nil {
+		return things.ChannelsPage{}, err
+	}
+
 	var whereClause string
 	var query []string
 	if ownq != "" {
@@ -479,6 +545,9 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, pm thing
 	if nq != "" {
 		query = append(query, nq)
 	}
+	if sq != "" {
+		query = append(query, sq)
+	}
 	if len(query) > 0 {
 		whereClause = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
 	}
@@ -488,15 +557,8 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, pm thing
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, name, metadata, schema FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
-	params := map[string]interface{}{
-		"owner":    owner,
-		"limit":    pm.Limit,
-		"offset":   pm.Offset,
-		"name":     name,
-		"metadata": meta,
-	}
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
@@ -573,11 +635,38 @@ func (m dbMetadata) Value() (driver.Value, error) {
 	return b, err
 }
 
+// dbSchema type for handling channel payload schema properly in database/sql.
+type dbSchema schema.Schema
+
+// Scan implements the database/sql scanner interface.
+func (s *dbSchema) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.ErrScanMetadata
+	}
+
+	return json.Unmarshal(b, s)
+}
+
+// Value implements database/sql valuer interface.
+func (s dbSchema) Value() (driver.Value, error) {
+	if schema.Schema(s).Empty() {
+		return nil, nil
+	}
+
+	return json.Marshal(s)
+}
+
 type dbChannel struct {
 	ID       string     `db:"id"`
 	Owner    string     `db:"owner"`
 	Name     string     `db:"name"`
 	Metadata dbMetadata `db:"metadata"`
+	Schema   dbSchema   `db:"schema"`
 }
 
 func toDBChannel(ch things.Channel) dbChannel {
@@ -586,6 +675,7 @@ func toDBChannel(ch things.Channel) dbChannel {
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Schema:   dbSchema(ch.Schema),
 	}
 }
 
@@ -595,31 +685,43 @@ func toChannel(ch dbChannel) things.Channel {
 		Owner:    ch.Owner,
 		Name:     ch.Name,
 		Metadata: ch.Metadata,
+		Schema:   schema.Schema(ch.Schema),
 	}
 }
 
 type dbConn struct {
-	ChannelID    string `db:"channel_id"`
-	ChannelOwner string `db:"channel_owner"`
-	ThingID      string `db:"thing_id"`
-	ThingOwner   string `db:"thing_owner"`
+	ChannelID    string           `db:"channel_id"`
+	ChannelOwner string           `db:"channel_owner"`
+	ThingID      string           `db:"thing_id"`
+	ThingOwner   string           `db:"thing_owner"`
+	Subtopics    pgtype.TextArray `db:"subtopics"`
 }
 
 func toConnection(co dbConn) things.Connection {
+	var subtopics []string
+	co.Subtopics.AssignTo(&subtopics)
+
 	return things.Connection{
 		ChannelID:    co.ChannelID,
 		ChannelOwner: co.ChannelOwner,
 		ThingID:      co.ThingID,
 		ThingOwner:   co.ThingOwner,
+		Subtopics:    subtopics,
 	}
 }
 
 func toDBConnection(co things.Connection) dbConn {
+	subtopics := pgtype.TextArray{Status: pgtype.Null}
+	if len(co.Subtopics) > 0 {
+		subtopics.Set(co.Subtopics)
+	}
+
 	return dbConn{
 		ChannelID:    co.ChannelID,
 		ChannelOwner: co.ChannelOwner,
 		ThingID:      co.ThingID,
 		ThingOwner:   co.ThingOwner,
+		Subtopics:    subtopics,
 	}
 }
 
@@ -630,6 +732,13 @@ func getOwnerQuery(owner string) string {
 	return "owner = :owner"
 }
 
+func getProfileQuery(profileID string) string {
+	if profileID == "" {
+		return ""
+	}
+	return "profile_id = :profile"
+}
+
 func getNameQuery(name string) (string, string) {
 	if name == "" {
 		return "", ""
@@ -22,6 +22,7 @@ import (
 var (
 	errCreateMetadataQuery = errors.New("failed to create query for metadata")
 	groupIDFkeyy           = "group_relations_group_id_fkey"
+	groupParentIDFkey      = "groups_parent_id_fkey"
 )
 
 var _ things.GroupRepository = (*groupRepository)(nil)
@@ -39,9 +40,9 @@ func NewGroupRepo(db Database) things.GroupRepository {
 }
 
 func (gr groupRepository) Save(ctx context.Context, g things.Group) (things.Group, error) {
-	q := `INSERT INTO groups (name, description, id, owner_id, metadata, created_at, updated_at)
-		  VALUES (:name, :description, :id, :owner_id, :metadata, :created_at, :updated_at)
-		  RETURNING id, name, owner_id, description, metadata, created_at, updated_at`
+	q := `INSERT INTO groups (name, description, id, owner_id, parent_id, path, metadata, created_at, updated_at)
+		  VALUES (:name, :description, :id, :owner_id, :parent_id, :path, :metadata, :created_at, :updated_at)
+		  RETURNING id, name, owner_id, parent_id, path, description, metadata, created_at, updated_at`
 
 	dbg, err := toDBGroup(g)
 	if err != nil {
@@ -79,7 +80,7 @@ func (gr groupRepository) Save(ctx context.Context, g things.Group) (things.Grou
 
 func (gr groupRepository) Update(ctx context.Context, g things.Group) (things.Group, error) {
 	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata, updated_at = :updated_at WHERE id = :id
-		  RETURNING id, name, owner_id, description, metadata, created_at, updated_at`
+		  RETURNING id, name, owner_id, parent_id, path, description, metadata, created_at, updated_at`
 
 	dbu, err := toDBGroup(g)
 	if err != nil {
@@ -131,7 +132,7 @@ func (gr groupRepository) Remove(ctx context.Context, groupID string) error {
 				return errors.Wrap(errors.ErrMalformedEntity, err)
 			case pgerrcode.ForeignKeyViolation:
 				switch pqErr.ConstraintName {
-				case groupIDFkeyy:
+				case groupIDFkeyy, groupParentIDFkey:
 					return errors.Wrap(things.ErrGroupNotEmpty, err)
 				}
 				return errors.Wrap(errors.ErrConflict, err)
@@ -152,7 +153,7 @@ func (gr groupRepository) Remove(ctx context.Context, groupID string) error {
 }
 
 func (gr groupRepository) RetrieveAll(ctx context.Context) ([]things.Group, error) {
-	q := `SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups`
+	q := `SELECT id, name, owner_id, parent_id, path, description, metadata, created_at, updated_at FROM groups`
 
 	rows, err := gr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
 	if err != nil {
@@ -181,7 +182,7 @@ func (gr groupRepository) RetrieveByID(ctx context.Context, id string) (things.G
 	dbu := dbGroup{
 		ID: id,
 	}
-	q := `SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups WHERE id = $1`
+	q := `SELECT id, name, owner_id, parent_id, path, description, metadata, created_at, updated_at FROM groups WHERE id = $1`
 	if err := gr.db.QueryRowxContext(ctx, q, id).StructScan(&dbu); err != nil {
 		if err == sql.ErrNoRows {
 			return things.Group{}, errors.Wrap(errors.ErrNotFound, err)
@@ -198,7 +199,7 @@ func (gr groupRepository) RetrieveByIDs(ctx context.Context, groupIDs []string)
 	}
 
 	idq := fmt.Sprintf("WHERE id IN ('%s') ", strings.Join(groupIDs, "','"))
-	q := fmt.Sprintf(`SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups %s;`, idq)
+	q := fmt.Sprintf(`SELECT id, name, owner_id, parent_id, path, description, metadata, created_at, updated_at FROM groups %s;`, idq)
 
 	rows, err := gr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
 	if err != nil {
@@ -253,7 +254,7 @@ func (gr groupRepository) RetrieveByOwner(ctx context.Context, ownerID string, p
 		whereq = fmt.Sprintf("%s AND %s", whereq, strings.Join(query, " AND "))
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner_id, name, description, metadata, created_at, updated_at FROM groups %s;`, whereq)
+	q := fmt.Sprintf(`SELECT id, owner_id, parent_id, path, name, description, metadata, created_at, updated_at FROM groups %s;`, whereq)
 
 	dbPage, err := toDBGroupPage(ownerID, "", pm)
 	if err != nil {
@@ -368,7 +369,7 @@ func (gr groupRepository) RetrieveMemberships(ctx context.Context, memberID stri
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT g.id, g.owner_id, g.name, g.description, g.metadata
+	q := fmt.Sprintf(`SELECT g.id, g.owner_id, g.parent_id, g.path, g.name, g.description, g.metadata
 		FROM group_relations gr, groups g
 		WHERE gr.group_id = g.id and gr.member_id = :member_id
 		%s ORDER BY id %s;`, mq, olq)
@@ -523,6 +524,466 @@ func (gr groupRepository) UnassignMember(ctx context.Context, groupID string, id
 	return nil
 }
 
+func (gr groupRepository) RetrieveDescendants(ctx context.Context, groupID string, pm things.PageMetadata) (things.GroupPage, error) {
+	_, mq, err := getGroupsMetadataQuery("g", pm.Metadata)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	if mq != "" {
+		mq = fmt.Sprintf("AND %s", mq)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT g.id, g.owner_id, g.parent_id, g.path, g.name, g.description, g.metadata, g.created_at, g.updated_at
+		FROM groups g, groups p
+		WHERE p.id = :id AND g.path LIKE p.path || '/%%' %s
+		ORDER BY g.path %s;`, mq, olq)
+
+	params, err := toDBGroupPage("", groupID, pm)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := gr.processRows(rows)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM groups g, groups p
+		WHERE p.id = :id AND g.path LIKE p.path || '/%%' %s;`, mq)
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.GroupPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gr groupRepository) RetrieveDescendantMembers(ctx context.Context, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
+	_, mq, err := getGroupsMetadataQuery("t", pm.Metadata)
+	if err != nil {
+		return things.MemberPage{}, errors.Wrap(things.ErrFailedToRetrieveMembers, err)
+	}
+
+	if mq != "" {
+		mq = fmt.Sprintf("AND %s", mq)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	subtreeq := `FROM group_relations gr, groups g, groups p, things t
+		WHERE p.id = :group_id AND (g.id = p.id OR g.path LIKE p.path || '/%')
+		AND gr.group_id = g.id AND gr.member_id = t.id`
+
+	q := fmt.Sprintf(`SELECT DISTINCT t.id, t.owner, t.name, t.metadata, t.key
+		%s %s ORDER BY t.id %s;`, subtreeq, mq, olq)
+
+	params, err := toDBMemberPage("", groupID, pm)
+	if err != nil {
+		return things.MemberPage{}, err
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.MemberPage{}, errors.Wrap(things.ErrFailedToRetrieveMembers, err)
+	}
+	defer rows.Close()
+
+	var items []things.Thing
+	for rows.Next() {
+		dbmem := dbThing{}
+		if err := rows.StructScan(&dbmem); err != nil {
+			return things.MemberPage{}, errors.Wrap(things.ErrFailedToRetrieveMembers, err)
+		}
+
+		th, err := toThing(dbmem)
+		if err != nil {
+			return things.MemberPage{}, err
+		}
+
+		items = append(items, th)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(DISTINCT t.id) %s %s;`, subtreeq, mq)
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.MemberPage{}, errors.Wrap(things.ErrFailedToRetrieveMembers, err)
+	}
+
+	page := things.MemberPage{
+		Members: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gr groupRepository) RetrieveDescendantChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	q := `WITH subtree AS (
+			SELECT g.id FROM groups g, groups p
+			WHERE p.id = :group_id AND (g.id = p.id OR g.path LIKE p.path || '/%')
+		)
+		SELECT c.channel_id FROM connections c
+		INNER JOIN group_relations gr ON gr.member_id = c.thing_id
+		WHERE gr.group_id IN (SELECT id FROM subtree) AND c.channel_owner = :owner
+		UNION
+		SELECT gc.channel_id FROM group_channels gc
+		WHERE gc.group_id IN (SELECT id FROM subtree) AND gc.channel_owner = :owner;`
+
+	params := map[string]interface{}{
+		"group_id": groupID,
+		"owner":    owner,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return nil, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var chIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		chIDs = append(chIDs, id)
+	}
+
+	return chIDs, nil
+}
+
+func (gr groupRepository) Move(ctx context.Context, groupID, parentID string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	// Both the moved group and the new parent are locked, in a fixed order to
+	// avoid deadlocks, so that neither of them is moved concurrently.
+	ids := []string{groupID}
+	if parentID != "" {
+		ids = append(ids, parentID)
+	}
+	paths, err := lockPaths(ctx, tx, ids)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	oldPath, ok := paths[groupID]
+	if !ok {
+		tx.Rollback()
+		return errors.ErrNotFound
+	}
+
+	newPath := groupID
+	if parentID != "" {
+		parentPath, ok := paths[parentID]
+		if !ok {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+
+		if parentPath == oldPath || strings.HasPrefix(parentPath, oldPath+things.GroupPathSeparator) {
+			tx.Rollback()
+			return things.ErrGroupCycle
+		}
+		newPath = parentPath + things.GroupPathSeparator + groupID
+	}
+
+	params := map[string]interface{}{
+		"id":         groupID,
+		"parent_id":  sql.NullString{String: parentID, Valid: parentID != ""},
+		"old_path":   oldPath,
+		"new_path":   newPath,
+		"updated_at": time.Now(),
+	}
+
+	qParent := `UPDATE groups SET parent_id = :parent_id, updated_at = :updated_at WHERE id = :id`
+	if _, err := tx.NamedExecContext(ctx, qParent, params); err != nil {
+		tx.Rollback()
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	qPath := `UPDATE groups SET path = :new_path || substring(path FROM char_length(:old_path) + 1)
+		WHERE path = :old_path OR path LIKE :old_path || '/%'`
+	if _, err := tx.NamedExecContext(ctx, qPath, params); err != nil {
+		tx.Rollback()
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// lockPaths locks the groups in the order of their IDs and returns their
+// paths, group ID as a key.
+func lockPaths(ctx context.Context, tx *sqlx.Tx, ids []string) (map[string]string, error) {
+	q := `SELECT id, path FROM groups WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+
+	rows, err := tx.QueryxContext(ctx, q, ids)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return nil, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	paths := make(map[string]string)
+	for rows.Next() {
+		var id, path string
+		if err := rows.Scan(&id, &path); err != nil {
+			return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+		paths[id] = path
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return paths, nil
+}
+
+func (gr groupRepository) ConnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrConnect, err)
+	}
+
+	// Channel owner is a part of the channel primary key, so it's resolved
+	// from the channels table instead of being passed by the caller.
+	q := `INSERT INTO group_channels (group_id, channel_id, channel_owner)
+		SELECT :group_id, id, owner FROM channels WHERE id = :channel_id`
+
+	for _, chID := range chIDs {
+		dbgc := dbGroupConnection{GroupID: groupID, ChannelID: chID}
+
+		res, err := tx.NamedExecContext(ctx, q, dbgc)
+		if err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return errors.Wrap(errors.ErrNotFound, err)
+				case pgerrcode.UniqueViolation:
+					return errors.Wrap(errors.ErrConflict, err)
+				}
+			}
+
+			return errors.Wrap(things.ErrConnect, err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(things.ErrConnect, err)
+		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrConnect, err)
+	}
+
+	return nil
+}
+
+func (gr groupRepository) DisconnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(things.ErrDisconnect, err)
+	}
+
+	q := `DELETE FROM group_channels WHERE group_id = :group_id AND channel_id = :channel_id`
+
+	for _, chID := range chIDs {
+		dbgc := dbGroupConnection{GroupID: groupID, ChannelID: chID}
+
+		res, err := tx.NamedExecContext(ctx, q, dbgc)
+		if err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+
+			return errors.Wrap(things.ErrDisconnect, err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(things.ErrDisconnect, err)
+		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(things.ErrDisconnect, err)
+	}
+
+	return nil
+}
+
+func (gr groupRepository) RetrieveChannels(ctx context.Context, groupID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT ch.id, ch.owner, ch.name, ch.metadata, ch.schema
+		FROM channels ch, group_channels gc
+		WHERE gc.group_id = :group_id AND gc.channel_id = ch.id AND gc.channel_owner = ch.owner
+		ORDER BY ch.id %s;`, olq)
+
+	params := map[string]interface{}{
+		"group_id": groupID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Channel{}
+	for rows.Next() {
+		dbch := dbChannel{}
+		if err := rows.StructScan(&dbch); err != nil {
+			return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, toChannel(dbch))
+	}
+
+	cq := `SELECT COUNT(*) FROM group_channels WHERE group_id = :group_id;`
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (gr groupRepository) HasChannelAccess(ctx context.Context, chanID, memberID string) error {
+	// Connected group grants access to the members of the whole subtree
+	// which are owned by the channel owner.
+	q := `SELECT EXISTS (
+		SELECT 1 FROM group_channels gc
+		INNER JOIN channels ch ON ch.id = gc.channel_id
+		INNER JOIN groups cg ON cg.id = gc.group_id
+		INNER JOIN groups g ON g.id = cg.id OR g.path LIKE cg.path || '/%'
+		INNER JOIN group_relations gr ON gr.group_id = g.id
+		INNER JOIN things t ON t.id = gr.member_id
+		WHERE gc.channel_id = $1 AND gr.member_id = $2 AND t.owner = ch.owner);`
+
+	exists := false
+	if err := gr.db.QueryRowxContext(ctx, q, chanID, memberID).Scan(&exists); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrNotFound, err)
+		}
+		return errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	if !exists {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (gr groupRepository) RetrieveAllConnections(ctx context.Context) ([]things.GroupConnection, error) {
+	q := `SELECT group_id, channel_id FROM group_channels`
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	conns := []things.GroupConnection{}
+	for rows.Next() {
+		dbgc := dbGroupConnection{}
+		if err := rows.StructScan(&dbgc); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		conns = append(conns, things.GroupConnection{GroupID: dbgc.GroupID, ChannelID: dbgc.ChannelID})
+	}
+
+	return conns, nil
+}
+
+type dbGroupConnection struct {
+	GroupID   string `db:"group_id"`
+	ChannelID string `db:"channel_id"`
+}
+
 type dbMember struct {
 	MemberID  string    `db:"member_id"`
 	GroupID   string    `db:"group_id"`
@@ -531,13 +992,15 @@ type dbMember struct {
 }
 
 type dbGroup struct {
-	ID          string     `db:"id"`
-	OwnerID     string     `db:"owner_id"`
-	Name        string     `db:"name"`
-	Description string     `db:"description"`
-	Metadata    dbMetadata `db:"metadata"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
+	ID          string         `db:"id"`
+	OwnerID     string         `db:"owner_id"`
+	ParentID    sql.NullString `db:"parent_id"`
+	Path        sql.NullString `db:"path"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	Metadata    dbMetadata     `db:"metadata"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
 }
 
 type dbGroupPage struct {
@@ -562,6 +1025,8 @@ func toDBGroup(g things.Group) (dbGroup, error) {
 		ID:          g.ID,
 		Name:        g.Name,
 		OwnerID:     g.OwnerID,
+		ParentID:    sql.NullString{String: g.ParentID, Valid: g.ParentID != ""},
+		Path:        sql.NullString{String: g.Path, Valid: g.Path != ""},
 		Description: g.Description,
 		Metadata:    dbMetadata(g.Metadata),
 		CreatedAt:   g.CreatedAt,
@@ -595,6 +1060,8 @@ func toGroup(dbu dbGroup) (things.Group, error) {
 		ID:          dbu.ID,
 		Name:        dbu.Name,
 		OwnerID:     dbu.OwnerID,
+		ParentID:    dbu.ParentID.String,
+		Path:        dbu.Path.String,
 		Description: dbu.Description,
 		Metadata:    things.GroupMetadata(dbu.Metadata),
 		UpdatedAt:   dbu.UpdatedAt,
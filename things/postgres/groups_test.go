@@ -603,6 +603,260 @@ func TestRetrieveAllGroupRelations(t *testing.T) {
 	}
 }
 
+func TestGroupMove(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	creationTime := time.Now().UTC()
+	var groups []things.Group
+	for i := 0; i < 2; i++ {
+		id := generateGroupID(t)
+		path := id
+		parentID := ""
+		if i > 0 {
+			parentID = groups[i-1].ID
+			path = groups[i-1].Path + things.GroupPathSeparator + id
+		}
+		g, err := groupRepo.Save(context.Background(), things.Group{
+			ID:        id,
+			Name:      fmt.Sprintf("%s-%d", groupName, i),
+			OwnerID:   uid,
+			ParentID:  parentID,
+			Path:      path,
+			CreatedAt: creationTime,
+			UpdatedAt: creationTime,
+		})
+		require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+		groups = append(groups, g)
+	}
+	other := generateGroupID(t)
+	_, err = groupRepo.Save(context.Background(), things.Group{
+		ID:        other,
+		Name:      groupName,
+		OwnerID:   uid,
+		Path:      other,
+		CreatedAt: creationTime,
+		UpdatedAt: creationTime,
+	})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		groupID  string
+		parentID string
+		path     string
+		err      error
+	}{
+		{
+			desc:     "move group under its descendant",
+			groupID:  groups[0].ID,
+			parentID: groups[1].ID,
+			err:      things.ErrGroupCycle,
+		},
+		{
+			desc:     "move group under non-existing group",
+			groupID:  groups[0].ID,
+			parentID: generateGroupID(t),
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "move non-existing group",
+			groupID:  generateGroupID(t),
+			parentID: other,
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "move group under another group",
+			groupID:  groups[0].ID,
+			parentID: other,
+			path:     other + things.GroupPathSeparator + groups[1].Path,
+			err:      nil,
+		},
+		{
+			desc:    "move group to root",
+			groupID: groups[0].ID,
+			path:    groups[1].Path,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := groupRepo.Move(context.Background(), tc.groupID, tc.parentID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		g, err := groupRepo.RetrieveByID(context.Background(), groups[1].ID)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.path, g.Path, fmt.Sprintf("%s: expected descendant path %s got %s\n", tc.desc, tc.path, g.Path))
+	}
+}
+
+func TestHasChannelAccess(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	uid2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	creationTime := time.Now().UTC()
+	group, err := groupRepo.Save(context.Background(), things.Group{
+		ID:        generateGroupID(t),
+		Name:      groupName,
+		OwnerID:   uid,
+		CreatedAt: creationTime,
+		UpdatedAt: creationTime,
+	})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	chID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = chanRepo.Save(context.Background(), things.Channel{ID: chID, Owner: uid})
+	require.Nil(t, err, fmt.Sprintf("channel save got unexpected error: %s", err))
+	err = groupRepo.ConnectChannels(context.Background(), group.ID, chID)
+	require.Nil(t, err, fmt.Sprintf("group connect got unexpected error: %s", err))
+
+	var thIDs []string
+	for _, owner := range []string{uid, uid2} {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		_, err = thingRepo.Save(context.Background(), things.Thing{ID: id, Owner: owner, Key: key})
+		require.Nil(t, err, fmt.Sprintf("thing save got unexpected error: %s", err))
+		err = groupRepo.AssignMember(context.Background(), group.ID, id)
+		require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+		thIDs = append(thIDs, id)
+	}
+
+	nonMemberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		memberID string
+		err      error
+	}{
+		"check access of group member": {
+			memberID: thIDs[0],
+			err:      nil,
+		},
+		"check access of group member owned by another user": {
+			memberID: thIDs[1],
+			err:      errors.ErrNotFound,
+		},
+		"check access of non-member": {
+			memberID: nonMemberID,
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := groupRepo.HasChannelAccess(context.Background(), chID, tc.memberID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestRetrieveDescendantChannels(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	uid2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	creationTime := time.Now().UTC()
+	rootID := generateGroupID(t)
+	root, err := groupRepo.Save(context.Background(), things.Group{
+		ID:        rootID,
+		Name:      groupName,
+		OwnerID:   uid,
+		Path:      rootID,
+		CreatedAt: creationTime,
+		UpdatedAt: creationTime,
+	})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	childID := generateGroupID(t)
+	child, err := groupRepo.Save(context.Background(), things.Group{
+		ID:        childID,
+		Name:      groupName,
+		OwnerID:   uid,
+		ParentID:  root.ID,
+		Path:      root.Path + things.GroupPathSeparator + childID,
+		CreatedAt: creationTime,
+		UpdatedAt: creationTime,
+	})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	var chIDs []string
+	for _, owner := range []string{uid, uid, uid, uid2} {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		_, err = chanRepo.Save(context.Background(), things.Channel{ID: id, Owner: owner})
+		require.Nil(t, err, fmt.Sprintf("channel save got unexpected error: %s", err))
+		chIDs = append(chIDs, id)
+	}
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: thID, Owner: uid, Key: key})
+	require.Nil(t, err, fmt.Sprintf("thing save got unexpected error: %s", err))
+	err = groupRepo.AssignMember(context.Background(), child.ID, thID)
+	require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+
+	// The thing is connected to the first channel, the child group to the
+	// first two channels and the root group to the third one.
+	err = chanRepo.Connect(context.Background(), uid, []string{chIDs[0]}, []string{thID})
+	require.Nil(t, err, fmt.Sprintf("thing connect got unexpected error: %s", err))
+	err = groupRepo.ConnectChannels(context.Background(), child.ID, chIDs[0], chIDs[1])
+	require.Nil(t, err, fmt.Sprintf("group connect got unexpected error: %s", err))
+	err = groupRepo.ConnectChannels(context.Background(), root.ID, chIDs[2], chIDs[3])
+	require.Nil(t, err, fmt.Sprintf("group connect got unexpected error: %s", err))
+
+	cases := map[string]struct {
+		owner   string
+		groupID string
+		chIDs   []string
+	}{
+		"retrieve channels of root group": {
+			owner:   uid,
+			groupID: root.ID,
+			chIDs:   chIDs[:3],
+		},
+		"retrieve channels of child group": {
+			owner:   uid,
+			groupID: child.ID,
+			chIDs:   chIDs[:2],
+		},
+		"retrieve channels of root group owned by another user": {
+			owner:   uid2,
+			groupID: root.ID,
+			chIDs:   chIDs[3:],
+		},
+	}
+
+	for desc, tc := range cases {
+		chIDs, err := groupRepo.RetrieveDescendantChannels(context.Background(), tc.owner, tc.groupID)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", desc, err))
+		assert.ElementsMatch(t, tc.chIDs, chIDs, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.chIDs, chIDs))
+	}
+}
+
 func cleanUp(t *testing.T) {
 	_, err := db.Exec("delete from group_relations")
 	require.Nil(t, err, fmt.Sprintf("clean relations unexpected error: %s", err))
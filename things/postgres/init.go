@@ -126,6 +126,103 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE group_relations",
 				},
 			},
+			{
+				Id: "things_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS subtopics TEXT[]`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS subtopics`,
+				},
+			},
+			{
+				Id: "things_7",
+				Up: []string{
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS schema JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS schema`,
+				},
+			},
+			{
+				Id: "things_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS profiles (
+						id           UUID,
+						owner        VARCHAR(254),
+						name         VARCHAR(1024) NOT NULL,
+						content_type VARCHAR(254),
+						transformer  VARCHAR(32),
+						channels     TEXT[],
+						schema       JSONB,
+						metadata     JSONB,
+						PRIMARY KEY (id),
+						UNIQUE      (owner, name)
+					)`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS profile_id UUID REFERENCES profiles (id) ON DELETE SET NULL`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS profile_id`,
+					`DROP TABLE IF EXISTS profiles`,
+				},
+			},
+			{
+				Id: "things_9",
+				Up: []string{
+					`ALTER TABLE IF EXISTS groups ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES groups (id)`,
+					`ALTER TABLE IF EXISTS groups ADD COLUMN IF NOT EXISTS path TEXT`,
+					`UPDATE groups SET path = id::text WHERE path IS NULL`,
+					`CREATE INDEX IF NOT EXISTS groups_path_idx ON groups (path text_pattern_ops)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS groups_path_idx`,
+					`ALTER TABLE IF EXISTS groups DROP COLUMN IF EXISTS path`,
+					`ALTER TABLE IF EXISTS groups DROP COLUMN IF EXISTS parent_id`,
+				},
+			},
+			{
+				Id: "things_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS group_channels (
+						group_id      UUID NOT NULL,
+						channel_id    UUID NOT NULL,
+						channel_owner VARCHAR(254) NOT NULL,
+						FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (channel_id, channel_owner) REFERENCES channels (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (group_id, channel_id, channel_owner)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS group_channels`,
+				},
+			},
+			{
+				Id: "things_11",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS thing_presence (
+						thing_id    UUID NOT NULL,
+						thing_owner VARCHAR(254) NOT NULL,
+						online      BOOLEAN NOT NULL DEFAULT FALSE,
+						last_seen   TIMESTAMPTZ,
+						protocol    VARCHAR(64),
+						remote_addr VARCHAR(254),
+						FOREIGN KEY (thing_id, thing_owner) REFERENCES things (id, owner) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (thing_id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS thing_presence`,
+				},
+			},
+			{
+				Id: "things_12",
+				Up: []string{
+					`ALTER TABLE IF EXISTS profiles ADD COLUMN IF NOT EXISTS codec JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS profiles DROP COLUMN IF EXISTS codec`,
+				},
+			},
 		},
 	}
 
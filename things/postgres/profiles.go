@@ -0,0 +1,275 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ things.ProfileRepository = (*profileRepository)(nil)
+
+type profileRepository struct {
+	db Database
+}
+
+// NewProfileRepository instantiates a PostgreSQL implementation of profile
+// repository.
+func NewProfileRepository(db Database) things.ProfileRepository {
+	return &profileRepository{
+		db: db,
+	}
+}
+
+func (pr profileRepository) Save(ctx context.Context, p things.Profile) (things.Profile, error) {
+	q := `INSERT INTO profiles (id, owner, name, content_type, transformer, channels, schema, codec, metadata)
+		  VALUES (:id, :owner, :name, :content_type, :transformer, :channels, :schema, :codec, :metadata);`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, toDBProfile(p)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return things.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return things.Profile{}, errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return things.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return things.Profile{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return p, nil
+}
+
+func (pr profileRepository) Update(ctx context.Context, p things.Profile) error {
+	q := `UPDATE profiles SET name = :name, content_type = :content_type, transformer = :transformer,
+		  channels = :channels, schema = :schema, codec = :codec, metadata = :metadata WHERE owner = :owner AND id = :id;`
+
+	res, err := pr.db.NamedExecContext(ctx, q, toDBProfile(p))
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr profileRepository) RetrieveByID(ctx context.Context, id string) (things.Profile, error) {
+	q := `SELECT id, owner, name, content_type, transformer, channels, schema, codec, metadata FROM profiles WHERE id = $1;`
+
+	var dbp dbProfile
+	if err := pr.db.QueryRowxContext(ctx, q, id).StructScan(&dbp); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		//  If there is no result or ID is in an invalid format, return ErrNotFound.
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.Profile{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.Profile{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toProfile(dbp), nil
+}
+
+func (pr profileRepository) RetrieveByOwner(ctx context.Context, owner string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	nq, name := getNameQuery(pm.Name)
+	oq := getOrderQuery(pm.Order)
+	dq := getDirQuery(pm.Dir)
+
+	if nq != "" {
+		nq = fmt.Sprintf("AND %s", nq)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, name, content_type, transformer, channels, schema, codec, metadata
+		  FROM profiles WHERE owner = :owner %s ORDER BY %s %s %s;`, nq, oq, dq, olq)
+
+	params := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.ProfilesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.Profile
+	for rows.Next() {
+		var dbp dbProfile
+		if err := rows.StructScan(&dbp); err != nil {
+			return things.ProfilesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, toProfile(dbp))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM profiles WHERE owner = :owner %s;`, nq)
+
+	total, err := total(ctx, pr.db, cq, params)
+	if err != nil {
+		return things.ProfilesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.ProfilesPage{
+		Profiles: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}
+
+	return page, nil
+}
+
+func (pr profileRepository) RetrieveAll(ctx context.Context) ([]things.Profile, error) {
+	q := `SELECT id, owner, name, content_type, transformer, channels, schema, codec, metadata FROM profiles;`
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.Profile
+	for rows.Next() {
+		var dbp dbProfile
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, toProfile(dbp))
+	}
+
+	return items, nil
+}
+
+func (pr profileRepository) Remove(ctx context.Context, owner, id string) error {
+	dbp := dbProfile{
+		ID:    id,
+		Owner: owner,
+	}
+	q := `DELETE FROM profiles WHERE id = :id AND owner = :owner;`
+	if _, err := pr.db.NamedExecContext(ctx, q, dbp); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// dbCodec type for handling profile payload codec properly in database/sql.
+type dbCodec codec.Codec
+
+// Scan implements the database/sql scanner interface.
+func (c *dbCodec) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.ErrScanMetadata
+	}
+
+	return json.Unmarshal(b, c)
+}
+
+// Value implements database/sql valuer interface.
+func (c dbCodec) Value() (driver.Value, error) {
+	if codec.Codec(c).Empty() {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
+}
+
+type dbProfile struct {
+	ID          string           `db:"id"`
+	Owner       string           `db:"owner"`
+	Name        string           `db:"name"`
+	ContentType sql.NullString   `db:"content_type"`
+	Transformer sql.NullString   `db:"transformer"`
+	Channels    pgtype.TextArray `db:"channels"`
+	Schema      dbMetadata       `db:"schema"`
+	Codec       dbCodec          `db:"codec"`
+	Metadata    dbMetadata       `db:"metadata"`
+}
+
+func toDBProfile(p things.Profile) dbProfile {
+	channels := pgtype.TextArray{Status: pgtype.Null}
+	if len(p.Channels) > 0 {
+		channels.Set(p.Channels)
+	}
+
+	return dbProfile{
+		ID:          p.ID,
+		Owner:       p.Owner,
+		Name:        p.Name,
+		ContentType: sql.NullString{String: p.ContentType, Valid: p.ContentType != ""},
+		Transformer: sql.NullString{String: p.Transformer, Valid: p.Transformer != ""},
+		Channels:    channels,
+		Schema:      p.Schema,
+		Codec:       dbCodec(p.Codec),
+		Metadata:    dbMetadata(p.Metadata),
+	}
+}
+
+func toProfile(dbp dbProfile) things.Profile {
+	var channels []string
+	dbp.Channels.AssignTo(&channels)
+
+	return things.Profile{
+		ID:          dbp.ID,
+		Owner:       dbp.Owner,
+		Name:        dbp.Name,
+		ContentType: dbp.ContentType.String,
+		Transformer: dbp.Transformer.String,
+		Channels:    channels,
+		Schema:      dbp.Schema,
+		Codec:       codec.Codec(dbp.Codec),
+		Metadata:    things.Metadata(dbp.Metadata),
+	}
+}
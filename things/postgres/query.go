@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/query"
+	"github.com/jackc/pgtype"
+)
+
+// getSearchQuery translates the query to the SQL condition over the name
+// and metadata columns. Query values are bound as named parameters which
+// are added to params.
+func getSearchQuery(q string, params map[string]interface{}) (string, error) {
+	if q == "" {
+		return "", nil
+	}
+
+	expr, err := query.Parse(q)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	b := queryBuilder{params: params}
+	return b.build(expr)
+}
+
+type queryBuilder struct {
+	params map[string]interface{}
+	count  int
+}
+
+// bind adds the value to params and returns its placeholder.
+func (b *queryBuilder) bind(v interface{}) string {
+	name := fmt.Sprintf("q%d", b.count)
+	b.count++
+	b.params[name] = v
+	return ":" + name
+}
+
+func (b *queryBuilder) build(expr query.Expr) (string, error) {
+	switch e := expr.(type) {
+	case query.And:
+		return b.binary(e.Left, e.Right, "AND")
+	case query.Or:
+		return b.binary(e.Left, e.Right, "OR")
+	case query.Not:
+		s, err := b.build(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("NOT %s", s), nil
+	case query.Condition:
+		return b.condition(e)
+	default:
+		return "", errors.ErrMalformedEntity
+	}
+}
+
+func (b *queryBuilder) binary(left, right query.Expr, op string) (string, error) {
+	l, err := b.build(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := b.build(right)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
+
+// condition translates the condition to SQL. Conditions over missing
+// fields or values of different type evaluate to false rather than NULL,
+// so negated conditions match them.
+func (b *queryBuilder) condition(c query.Condition) (string, error) {
+	// Name is converted to JSON so it is compared the same way as metadata values.
+	jsonExpr, textExpr := "to_jsonb(name)", "name"
+	if c.Field.Metadata {
+		var path pgtype.TextArray
+		if err := path.Set(c.Field.Path); err != nil {
+			return "", errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		p := b.bind(path)
+		jsonExpr = fmt.Sprintf("metadata #> CAST(%s AS text[])", p)
+		textExpr = fmt.Sprintf("metadata #>> CAST(%s AS text[])", p)
+	}
+
+	var cond string
+	switch c.Operator {
+	case query.Exists:
+		cond = fmt.Sprintf("%s IS NOT NULL", jsonExpr)
+	case query.Eq, query.Neq:
+		v, err := b.bindJSON(c.Values[0])
+		if err != nil {
+			return "", err
+		}
+		cond = fmt.Sprintf("%s %s %s", jsonExpr, c.Operator, v)
+	case query.In:
+		var values []string
+		for _, val := range c.Values {
+			v, err := b.bindJSON(val)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, v)
+		}
+		cond = fmt.Sprintf("%s IN (%s)", jsonExpr, strings.Join(values, ", "))
+	case query.Lt, query.Lte, query.Gt, query.Gte:
+		switch v := c.Values[0].(type) {
+		case float64:
+			cond = fmt.Sprintf("CASE WHEN jsonb_typeof(%s) = 'number' THEN CAST(%s AS numeric) END %s CAST(%s AS numeric)", jsonExpr, textExpr, c.Operator, b.bind(v))
+		case string:
+			cond = fmt.Sprintf("CASE WHEN jsonb_typeof(%s) = 'string' THEN %s END %s %s", jsonExpr, textExpr, c.Operator, b.bind(v))
+		default:
+			return "", errors.ErrMalformedEntity
+		}
+	case query.Prefix:
+		v, ok := c.Values[0].(string)
+		if !ok {
+			return "", errors.ErrMalformedEntity
+		}
+		cond = fmt.Sprintf("%s LIKE %s", textExpr, b.bind(escapeLike(v)+"%"))
+	case query.Match:
+		v, ok := c.Values[0].(string)
+		if !ok {
+			return "", errors.ErrMalformedEntity
+		}
+		cond = fmt.Sprintf("to_tsvector('simple', %s) @@ plainto_tsquery('simple', %s)", textExpr, b.bind(v))
+	default:
+		return "", errors.ErrMalformedEntity
+	}
+
+	return fmt.Sprintf("COALESCE(%s, FALSE)", cond), nil
+}
+
+func (b *queryBuilder) bindJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS jsonb)", b.bind(string(data))), nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
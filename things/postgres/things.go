@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -38,8 +39,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 		return []things.Thing{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO things (id, owner, name, key, metadata)
-		  VALUES (:id, :owner, :name, :key, :metadata);`
+	q := `INSERT INTO things (id, owner, name, key, metadata, profile_id)
+		  VALUES (:id, :owner, :name, :key, :metadata, :profile_id);`
 
 	for _, thing := range ths {
 		dbth, err := toDBThing(thing)
@@ -52,7 +53,7 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				switch pgErr.Code {
-				case pgerrcode.InvalidTextRepresentation:
+				case pgerrcode.InvalidTextRepresentation, pgerrcode.ForeignKeyViolation:
 					return []things.Thing{}, errors.Wrap(errors.ErrMalformedEntity, err)
 				case pgerrcode.UniqueViolation:
 					return []things.Thing{}, errors.Wrap(errors.ErrConflict, err)
@@ -107,6 +108,49 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 	return nil
 }
 
+func (tr thingRepository) UpdateMetadata(ctx context.Context, ths ...things.Thing) error {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	q := `UPDATE things SET metadata = :metadata WHERE id = :id;`
+
+	for _, th := range ths {
+		dbth, err := toDBThing(th)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+
+		res, err := tx.NamedExecContext(ctx, q, dbth)
+		if err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
 	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
 
@@ -146,7 +190,7 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 }
 
 func (tr thingRepository) RetrieveByID(ctx context.Context, id string) (things.Thing, error) {
-	q := `SELECT name, owner, key, metadata FROM things WHERE id = $1;`
+	q := `SELECT name, owner, key, metadata, profile_id FROM things WHERE id = $1;`
 
 	dbth := dbThing{ID: id}
 
@@ -191,7 +235,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata, profile_id FROM things
 					   %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, oq, dq)
 
 	params := map[string]interface{}{
@@ -248,11 +292,24 @@ func (tr thingRepository) RetrieveByOwner(ctx context.Context, owner string, pm
 		return things.Page{}, errors.ErrRetrieveEntity
 	}
 
-	return tr.retrieve(ctx, owner, pm)
+	return tr.retrieve(ctx, owner, "", pm)
+}
+
+func (tr thingRepository) RetrieveByProfile(ctx context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	if owner == "" {
+		return things.Page{}, errors.ErrRetrieveEntity
+	}
+
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(profileID); err != nil {
+		return things.Page{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return tr.retrieve(ctx, owner, profileID, pm)
 }
 
 func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, error) {
-	thPage, err := tr.retrieve(ctx, "", things.PageMetadata{})
+	thPage, err := tr.retrieve(ctx, "", "", things.PageMetadata{})
 	if err != nil {
 		return []things.Thing{}, err
 	}
@@ -261,7 +318,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, erro
 }
 
 func (tr thingRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
-	return tr.retrieve(ctx, "", pm)
+	return tr.retrieve(ctx, "", "", pm)
 }
 
 func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID string, pm things.PageMetadata) (things.Page, error) {
@@ -369,8 +426,86 @@ func (tr thingRepository) Remove(ctx context.Context, owner, id string) error {
 	return nil
 }
 
-func (tr thingRepository) retrieve(ctx context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (tr thingRepository) SavePresence(ctx context.Context, p things.Presence) error {
+	q := `INSERT INTO thing_presence (thing_id, thing_owner, online, last_seen, protocol, remote_addr)
+		  SELECT id, owner, :online, :last_seen, :protocol, :remote_addr FROM things WHERE id = :thing_id
+		  ON CONFLICT (thing_id) DO UPDATE SET online = EXCLUDED.online,
+		  last_seen = EXCLUDED.last_seen,
+		  protocol = EXCLUDED.protocol, remote_addr = EXCLUDED.remote_addr
+		  WHERE thing_presence.last_seen <= EXCLUDED.last_seen;`
+
+	return tr.savePresence(ctx, q, p)
+}
+
+func (tr thingRepository) UpdateLastSeen(ctx context.Context, p things.Presence) error {
+	q := `INSERT INTO thing_presence (thing_id, thing_owner, last_seen, protocol, remote_addr)
+		  SELECT id, owner, :last_seen, :protocol, :remote_addr FROM things WHERE id = :thing_id
+		  ON CONFLICT (thing_id) DO UPDATE SET
+		  last_seen = GREATEST(thing_presence.last_seen, EXCLUDED.last_seen),
+		  protocol = EXCLUDED.protocol, remote_addr = EXCLUDED.remote_addr;`
+
+	return tr.savePresence(ctx, q, p)
+}
+
+func (tr thingRepository) savePresence(ctx context.Context, q string, p things.Presence) error {
+	if _, err := uuid.FromString(p.ThingID); err != nil {
+		return errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	res, err := tr.db.NamedExecContext(ctx, q, toDBPresence(p))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return tr.presenceConflict(ctx, p.ThingID)
+	}
+
+	return nil
+}
+
+// presenceConflict distinguishes a presence older than the saved one, which
+// is ignored, from the presence of a non-existing thing.
+func (tr thingRepository) presenceConflict(ctx context.Context, thingID string) error {
+	q := `SELECT EXISTS (SELECT 1 FROM thing_presence WHERE thing_id = $1);`
+
+	var exists bool
+	if err := tr.db.QueryRowxContext(ctx, q, thingID).Scan(&exists); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if !exists {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr thingRepository) RetrievePresence(ctx context.Context, thingID string) (things.Presence, error) {
+	if _, err := uuid.FromString(thingID); err != nil {
+		return things.Presence{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	q := `SELECT thing_id, online, last_seen, protocol, remote_addr FROM thing_presence WHERE thing_id = $1;`
+
+	dbp := dbPresence{}
+	if err := tr.db.QueryRowxContext(ctx, q, thingID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return things.Presence{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.Presence{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toPresence(dbp), nil
+}
+
+func (tr thingRepository) retrieve(ctx context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
 	ownq := getOwnerQuery(owner)
+	pq := getProfileQuery(profileID)
 	nq, name := getNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -379,16 +514,46 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, pm things.
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
+	params := map[string]interface{}{
+		"owner":    owner,
+		"profile":  profileID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+		"name":     name,
+		"metadata": m,
+	}
+
+	sq, err := getSearchQuery(pm.Query, params)
+	if err != nil {
+		return things.Page{}, err
+	}
+
+	stq := getStatusQuery(pm.Status)
+	slq, seen := getSilentQuery(pm.SilentFor)
+	params["seen"] = seen
+
 	var query []string
 	if ownq != "" {
 		query = append(query, ownq)
 	}
+	if pq != "" {
+		query = append(query, pq)
+	}
 	if mq != "" {
 		query = append(query, mq)
 	}
 	if nq != "" {
 		query = append(query, nq)
 	}
+	if sq != "" {
+		query = append(query, sq)
+	}
+	if stq != "" {
+		query = append(query, stq)
+	}
+	if slq != "" {
+		query = append(query, slq)
+	}
 
 	var whereClause string
 	if len(query) > 0 {
@@ -400,15 +565,7 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, pm things.
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
-
-	params := map[string]interface{}{
-		"owner":    owner,
-		"limit":    pm.Limit,
-		"offset":   pm.Offset,
-		"name":     name,
-		"metadata": m,
-	}
+	q := fmt.Sprintf(`SELECT id, name, key, metadata, profile_id FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -453,11 +610,12 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, pm things.
 }
 
 type dbThing struct {
-	ID       string `db:"id"`
-	Owner    string `db:"owner"`
-	Name     string `db:"name"`
-	Key      string `db:"key"`
-	Metadata []byte `db:"metadata"`
+	ID        string         `db:"id"`
+	Owner     string         `db:"owner"`
+	Name      string         `db:"name"`
+	Key       string         `db:"key"`
+	Metadata  []byte         `db:"metadata"`
+	ProfileID sql.NullString `db:"profile_id"`
 }
 
 func toDBThing(th things.Thing) (dbThing, error) {
@@ -471,11 +629,12 @@ func toDBThing(th things.Thing) (dbThing, error) {
 	}
 
 	return dbThing{
-		ID:       th.ID,
-		Owner:    th.Owner,
-		Name:     th.Name,
-		Key:      th.Key,
-		Metadata: data,
+		ID:        th.ID,
+		Owner:     th.Owner,
+		Name:      th.Name,
+		Key:       th.Key,
+		Metadata:  data,
+		ProfileID: sql.NullString{String: th.ProfileID, Valid: th.ProfileID != ""},
 	}, nil
 }
 
@@ -486,10 +645,61 @@ func toThing(dbth dbThing) (things.Thing, error) {
 	}
 
 	return things.Thing{
-		ID:       dbth.ID,
-		Owner:    dbth.Owner,
-		Name:     dbth.Name,
-		Key:      dbth.Key,
-		Metadata: metadata,
+		ID:        dbth.ID,
+		Owner:     dbth.Owner,
+		Name:      dbth.Name,
+		Key:       dbth.Key,
+		Metadata:  metadata,
+		ProfileID: dbth.ProfileID.String,
 	}, nil
 }
+
+type dbPresence struct {
+	ThingID    string         `db:"thing_id"`
+	Online     bool           `db:"online"`
+	LastSeen   sql.NullTime   `db:"last_seen"`
+	Protocol   sql.NullString `db:"protocol"`
+	RemoteAddr sql.NullString `db:"remote_addr"`
+}
+
+func toDBPresence(p things.Presence) dbPresence {
+	return dbPresence{
+		ThingID:    p.ThingID,
+		Online:     p.Online,
+		LastSeen:   sql.NullTime{Time: p.LastSeen, Valid: !p.LastSeen.IsZero()},
+		Protocol:   sql.NullString{String: p.Protocol, Valid: p.Protocol != ""},
+		RemoteAddr: sql.NullString{String: p.RemoteAddr, Valid: p.RemoteAddr != ""},
+	}
+}
+
+func toPresence(dbp dbPresence) things.Presence {
+	return things.Presence{
+		ThingID:    dbp.ThingID,
+		Online:     dbp.Online,
+		LastSeen:   dbp.LastSeen.Time,
+		Protocol:   dbp.Protocol.String,
+		RemoteAddr: dbp.RemoteAddr.String,
+	}
+}
+
+func getStatusQuery(status string) string {
+	switch status {
+	case things.StatusOnline:
+		return "id IN (SELECT thing_id FROM thing_presence WHERE online)"
+	case things.StatusOffline:
+		return "id NOT IN (SELECT thing_id FROM thing_presence WHERE online)"
+	default:
+		return ""
+	}
+}
+
+// getSilentQuery returns the query matching things not seen in the given
+// number of seconds, including the ones which have never been seen.
+func getSilentQuery(silentFor uint64) (string, time.Time) {
+	if silentFor == 0 {
+		return "", time.Time{}
+	}
+
+	seen := time.Now().Add(-time.Duration(silentFor) * time.Second)
+	return "id NOT IN (SELECT thing_id FROM thing_presence WHERE last_seen > :seen)", seen
+}
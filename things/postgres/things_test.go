@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -188,6 +189,76 @@ func TestThingUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateMetadata(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	email := "thing-update-metadata@example.com"
+
+	var ths []things.Thing
+	for i := 0; i < 2; i++ {
+		thID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		thkey, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		ths = append(ths, things.Thing{
+			ID:       thID,
+			Owner:    email,
+			Key:      thkey,
+			Metadata: things.Metadata{"model": "v1"},
+		})
+	}
+
+	_, err := thingRepo.Save(context.Background(), ths...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonexistentThingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := []things.Thing{ths[0], ths[1]}
+	for i := range updated {
+		updated[i].Metadata = things.Metadata{"model": "v2"}
+	}
+	nonexistent := things.Thing{
+		ID:       nonexistentThingID,
+		Owner:    email,
+		Metadata: things.Metadata{"model": "v3"},
+	}
+
+	cases := []struct {
+		desc     string
+		things   []things.Thing
+		metadata things.Metadata
+		err      error
+	}{
+		{
+			desc:     "update metadata of existing things",
+			things:   updated,
+			metadata: things.Metadata{"model": "v2"},
+			err:      nil,
+		},
+		{
+			desc:     "update metadata of existing and non-existing things",
+			things:   []things.Thing{nonexistent, updated[0]},
+			metadata: things.Metadata{"model": "v2"},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.UpdateMetadata(context.Background(), tc.things...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		// None of the things is updated if any of them fails.
+		for _, th := range ths {
+			saved, err := thingRepo.RetrieveByID(context.Background(), th.ID)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.metadata, saved.Metadata, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.metadata, saved.Metadata))
+		}
+	}
+}
+
 func TestUpdateKey(t *testing.T) {
 	email := "thing-update=key@example.com"
 	newKey := "new-key"
@@ -511,6 +582,26 @@ func TestMultiThingRetrieval(t *testing.T) {
 			},
 			size: nameMetaNum,
 		},
+		"retrieve things with query": {
+			owner: email,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  nameMetaNum,
+				Query:  fmt.Sprintf("field2.subfield12.subfield121 = value3 and name startswith %s", name),
+			},
+			size: nameMetaNum,
+		},
+		"retrieve things with negated query": {
+			owner: email,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n - metaNum - nameMetaNum,
+				Query:  "not field1 exists",
+			},
+			size: n - metaNum - nameMetaNum,
+		},
 		"retrieve things sorted by name ascendent": {
 			owner: email,
 			pageMetadata: things.PageMetadata{
@@ -843,6 +934,64 @@ func TestThingRemoval(t *testing.T) {
 	}
 }
 
+func TestSavePresence(t *testing.T) {
+	email := "thing-presence@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: id, Owner: email, Key: key})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc     string
+		presence things.Presence
+		online   bool
+		err      error
+	}{
+		{
+			desc:     "save presence of connected thing",
+			presence: things.Presence{ThingID: id, Online: true, LastSeen: now, Protocol: "mqtt"},
+			online:   true,
+			err:      nil,
+		},
+		{
+			desc:     "save presence of disconnected thing",
+			presence: things.Presence{ThingID: id, Online: false, LastSeen: now.Add(time.Second), Protocol: "mqtt"},
+			online:   false,
+			err:      nil,
+		},
+		{
+			desc:     "save presence older than the saved one",
+			presence: things.Presence{ThingID: id, Online: true, LastSeen: now, Protocol: "mqtt"},
+			online:   false,
+			err:      nil,
+		},
+		{
+			desc:     "save presence of non-existing thing",
+			presence: things.Presence{ThingID: wrongValue, Online: true, LastSeen: now, Protocol: "mqtt"},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.SavePresence(context.Background(), tc.presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		p, err := thingRepo.RetrievePresence(context.Background(), id)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.online, p.Online, fmt.Sprintf("%s: expected online %t got %t\n", tc.desc, tc.online, p.Online))
+	}
+}
+
 func testSortThings(t *testing.T, pm things.PageMetadata, ths []things.Thing) {
 	if len(ths) < 1 {
 		return
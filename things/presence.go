@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	// StatusOnline represents the status of things having an open session.
+	StatusOnline = "online"
+
+	// StatusOffline represents the status of things without an open session.
+	StatusOffline = "offline"
+)
+
+// Presence represents the connection state and the last activity of a
+// thing. Online is set while the thing has an open session with one of
+// the session based adapters (e.g. MQTT), while LastSeen, Protocol and
+// RemoteAddr are updated on each session change and published message.
+type Presence struct {
+	ThingID    string
+	Online     bool
+	LastSeen   time.Time
+	Protocol   string
+	RemoteAddr string
+}
+
+var _ messaging.MessageHandler = (*presenceHandler)(nil)
+
+type presenceHandler struct {
+	svc      Service
+	interval time.Duration
+	mu       sync.Mutex
+	seen     map[string]time.Time
+	pruned   time.Time
+}
+
+// NewPresenceHandler returns the message handler which records the last
+// activity of the things publishing messages. In order to limit the number
+// of writes, activity of the same thing is recorded at most once per interval.
+func NewPresenceHandler(svc Service, interval time.Duration) messaging.MessageHandler {
+	return &presenceHandler{
+		svc:      svc,
+		interval: interval,
+		seen:     make(map[string]time.Time),
+	}
+}
+
+func (ph *presenceHandler) Handle(msg messaging.Message) error {
+	if msg.Publisher == "" {
+		return nil
+	}
+
+	seen := time.Now()
+	if msg.Created > 0 {
+		seen = time.Unix(0, msg.Created)
+	}
+
+	if !ph.record(msg.Publisher, seen) {
+		return nil
+	}
+
+	p := Presence{
+		ThingID:    msg.Publisher,
+		LastSeen:   seen,
+		Protocol:   msg.Protocol,
+		RemoteAddr: msg.RemoteAddr,
+	}
+
+	return ph.svc.UpdateLastSeen(context.Background(), p)
+}
+
+func (ph *presenceHandler) Cancel() error {
+	return nil
+}
+
+// record reports whether the activity should be recorded, and removes
+// the things whose activity was recorded more than interval ago.
+func (ph *presenceHandler) record(thingID string, seen time.Time) bool {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	if seen.Sub(ph.pruned) > ph.interval {
+		for id, t := range ph.seen {
+			if seen.Sub(t) >= ph.interval {
+				delete(ph.seen, id)
+			}
+		}
+		ph.pruned = seen
+	}
+
+	if t, ok := ph.seen[thingID]; ok && seen.Sub(t) < ph.interval {
+		return false
+	}
+	ph.seen[thingID] = seen
+
+	return true
+}
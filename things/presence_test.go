@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresenceHandler(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	interval := time.Minute
+	handler := things.NewPresenceHandler(svc, interval)
+	first := time.Now().Add(-time.Hour).Round(0)
+
+	cases := []struct {
+		desc     string
+		msg      messaging.Message
+		lastSeen time.Time
+		protocol string
+		err      error
+	}{
+		{
+			desc:     "handle message of thing never seen",
+			msg:      messaging.Message{Publisher: th.ID, Protocol: "http", Created: first.UnixNano()},
+			lastSeen: first,
+			protocol: "http",
+			err:      nil,
+		},
+		{
+			desc:     "handle message within interval",
+			msg:      messaging.Message{Publisher: th.ID, Protocol: "coap", Created: first.Add(interval / 2).UnixNano()},
+			lastSeen: first,
+			protocol: "http",
+			err:      nil,
+		},
+		{
+			desc:     "handle message after interval",
+			msg:      messaging.Message{Publisher: th.ID, Protocol: "coap", Created: first.Add(interval).UnixNano()},
+			lastSeen: first.Add(interval),
+			protocol: "coap",
+			err:      nil,
+		},
+		{
+			desc:     "handle message without publisher",
+			msg:      messaging.Message{Protocol: "http", Created: first.Add(2 * interval).UnixNano()},
+			lastSeen: first.Add(interval),
+			protocol: "coap",
+			err:      nil,
+		},
+		{
+			desc:     "handle message of non-existing thing",
+			msg:      messaging.Message{Publisher: wrongValue, Protocol: "http", Created: first.UnixNano()},
+			lastSeen: first.Add(interval),
+			protocol: "coap",
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := handler.Handle(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		p, err := svc.ViewThingStatus(context.Background(), token, th.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.True(t, tc.lastSeen.Equal(p.LastSeen), fmt.Sprintf("%s: expected last seen %s got %s\n", tc.desc, tc.lastSeen, p.LastSeen))
+		assert.Equal(t, tc.protocol, p.Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, tc.protocol, p.Protocol))
+	}
+}
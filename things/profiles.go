@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+)
+
+// ErrInvalidMetadata indicates that thing metadata doesn't conform to the
+// metadata schema of the thing's profile.
+var ErrInvalidMetadata = errors.New("metadata doesn't conform to the profile schema")
+
+// Profile represents a template shared by things of the same device model.
+// Things created from the profile have their metadata validated against
+// the metadata schema and are connected to the profile channels. Their
+// binary payloads are decoded using the profile codec.
+type Profile struct {
+	ID          string
+	Owner       string
+	Name        string
+	ContentType string
+	Transformer string
+	Channels    []string
+	Schema      map[string]interface{}
+	Codec       codec.Codec
+	Metadata    Metadata
+}
+
+// ProfilesPage contains page related metadata as well as list of profiles that
+// belong to this page.
+type ProfilesPage struct {
+	PageMetadata
+	Profiles []Profile
+}
+
+// ProfileRepository specifies a profile persistence API.
+type ProfileRepository interface {
+	// Save persists the profile. Successful operation is indicated by non-nil
+	// error response.
+	Save(ctx context.Context, p Profile) (Profile, error)
+
+	// Update performs an update to the existing profile. A non-nil error is
+	// returned to indicate operation failure.
+	Update(ctx context.Context, p Profile) error
+
+	// RetrieveByID retrieves the profile having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Profile, error)
+
+	// RetrieveByOwner retrieves the subset of profiles owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (ProfilesPage, error)
+
+	// RetrieveAll retrieves all profiles for all users.
+	RetrieveAll(ctx context.Context) ([]Profile, error)
+
+	// Remove removes the profile having the provided identifier, that is owned
+	// by the specified user.
+	Remove(ctx context.Context, owner, id string) error
+}
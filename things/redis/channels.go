@@ -8,11 +8,16 @@ import (
 	"fmt"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-redis/redis/v8"
 )
 
-const chanPrefix = "channel"
+const (
+	chanPrefix      = "channel"
+	subtopicsSuffix = "subtopics"
+	schemaSuffix    = "schema"
+)
 
 var _ things.ChannelCache = (*channelCache)(nil)
 
@@ -25,9 +30,21 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(ctx context.Context, chanID, thingID string) error {
+func (cc channelCache) Connect(ctx context.Context, chanID, thingID string, subtopics []string) error {
 	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SAdd(ctx, cid, tid).Err(); err != nil {
+	sid := subtopicsKey(chanID, thingID)
+
+	// Subtopic patterns are stored before the connection itself, so that
+	// connection can't be observed without its subtopic restrictions.
+	_, err := cc.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sid)
+		if len(subtopics) > 0 {
+			pipe.SAdd(ctx, sid, toInterfaces(subtopics)...)
+		}
+		pipe.SAdd(ctx, cid, tid)
+		return nil
+	})
+	if err != nil {
 		return errors.Wrap(errors.ErrCreateEntity, err)
 	}
 	return nil
@@ -38,9 +55,55 @@ func (cc channelCache) HasThing(ctx context.Context, chanID, thingID string) boo
 	return cc.client.SIsMember(ctx, cid, tid).Val()
 }
 
+func (cc channelCache) Subtopics(ctx context.Context, chanID, thingID string) ([]string, error) {
+	subtopics, err := cc.client.SMembers(ctx, subtopicsKey(chanID, thingID)).Result()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	return subtopics, nil
+}
+
 func (cc channelCache) Disconnect(ctx context.Context, chanID, thingID string) error {
 	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(ctx, cid, tid).Err(); err != nil {
+	_, err := cc.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SRem(ctx, cid, tid)
+		pipe.Del(ctx, subtopicsKey(chanID, thingID))
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+func (cc channelCache) SaveSchema(ctx context.Context, chanID string, s schema.Schema) error {
+	data, err := schema.Marshal(s)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	// The empty schema is cached as the empty value, so that the channels
+	// without schema are cached as well.
+	if err := cc.client.Set(ctx, schemaKey(chanID), data, 0).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return nil
+}
+
+func (cc channelCache) Schema(ctx context.Context, chanID string) (schema.Schema, error) {
+	data, err := cc.client.Get(ctx, schemaKey(chanID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return schema.Schema{}, errors.ErrNotFound
+		}
+		return schema.Schema{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return schema.Unmarshal(data)
+}
+
+func (cc channelCache) RemoveSchema(ctx context.Context, chanID string) error {
+	if err := cc.client.Del(ctx, schemaKey(chanID)).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 	return nil
@@ -48,7 +111,7 @@ func (cc channelCache) Disconnect(ctx context.Context, chanID, thingID string) e
 
 func (cc channelCache) Remove(ctx context.Context, chanID string) error {
 	cid, _ := kv(chanID, "0")
-	if err := cc.client.Del(ctx, cid).Err(); err != nil {
+	if err := cc.client.Del(ctx, cid, schemaKey(chanID)).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 	return nil
@@ -59,3 +122,21 @@ func kv(chanID, thingID string) (string, string) {
 	cid := fmt.Sprintf("%s:%s", chanPrefix, chanID)
 	return cid, thingID
 }
+
+// Generates key of the connection subtopic patterns set
+func subtopicsKey(chanID, thingID string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", chanPrefix, chanID, thingID, subtopicsSuffix)
+}
+
+// Generates key of the channel payload schema
+func schemaKey(chanID string) string {
+	return fmt.Sprintf("%s:%s:%s", chanPrefix, chanID, schemaSuffix)
+}
+
+func toInterfaces(vals []string) []interface{} {
+	res := make([]interface{}, len(vals))
+	for i, v := range vals {
+		res[i] = v
+	}
+	return res
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,7 +38,7 @@ func TestConnect(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		err := channelCache.Connect(context.Background(), cid, tid)
+		err := channelCache.Connect(context.Background(), cid, tid, nil)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail to connect due to: %s\n", tc.desc, err))
 	}
 }
@@ -47,7 +49,7 @@ func TestHasThing(t *testing.T) {
 	cid := "123"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, nil)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := map[string]struct {
@@ -84,7 +86,7 @@ func TestDisconnect(t *testing.T) {
 	tid := "321"
 	tid2 := "322"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, nil)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -122,7 +124,7 @@ func TestRemove(t *testing.T) {
 	cid2 := "124"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, nil)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -155,3 +157,59 @@ func TestRemove(t *testing.T) {
 		assert.Equal(t, tc.hasAccess, hasAcces, "%s - check access after removing channel: expected %t got %t\n", tc.desc, tc.hasAccess, hasAcces)
 	}
 }
+
+func TestSchema(t *testing.T) {
+	channelCache := redis.NewChannelCache(redisClient)
+
+	cid := "125"
+	cid2 := "126"
+	cid3 := "127"
+	senml := schema.Schema{SenML: []schema.SenMLRecord{{Name: "current", Unit: "A"}}}
+
+	err := channelCache.SaveSchema(context.Background(), cid, senml)
+	require.Nil(t, err, fmt.Sprintf("save schema: unexpected error: %s\n", err))
+	err = channelCache.SaveSchema(context.Background(), cid2, schema.Schema{})
+	require.Nil(t, err, fmt.Sprintf("save schema: unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		cid    string
+		schema schema.Schema
+		err    error
+	}{
+		{
+			desc:   "retrieve cached schema",
+			cid:    cid,
+			schema: senml,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve cached empty schema",
+			cid:    cid2,
+			schema: schema.Schema{},
+			err:    nil,
+		},
+		{
+			desc:   "retrieve non-cached schema",
+			cid:    cid3,
+			schema: schema.Schema{},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := channelCache.Schema(context.Background(), tc.cid)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.schema, s, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.schema, s))
+	}
+
+	err = channelCache.RemoveSchema(context.Background(), cid)
+	require.Nil(t, err, fmt.Sprintf("remove schema: unexpected error: %s\n", err))
+	_, err = channelCache.Schema(context.Background(), cid)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed schema: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = channelCache.Remove(context.Background(), cid2)
+	require.Nil(t, err, fmt.Sprintf("remove channel: unexpected error: %s\n", err))
+	_, err = channelCache.Schema(context.Background(), cid2)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve schema of removed channel: expected %s got %s\n", errors.ErrNotFound, err))
+}
@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import "time"
+
+type sessionEvent struct {
+	thingID   string
+	eventType string
+	timestamp time.Time
+}
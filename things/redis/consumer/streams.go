@@ -0,0 +1,183 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	stream = "mainflux.mqtt"
+	group  = "mainflux.things"
+
+	protocol   = "mqtt"
+	connect    = "connect"
+	disconnect = "disconnect"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+
+	// reclaimIdle is the time after which the events left pending by a
+	// failed handling are claimed and handled again.
+	reclaimIdle = 30 * time.Second
+	// maxDeliveries is the number of handling attempts after which a
+	// pending event is acknowledged and dropped.
+	maxDeliveries = 10
+)
+
+// Subscriber represents event source for thing sessions.
+type Subscriber interface {
+	// Subscribes to given subject and receives events.
+	Subscribe(context.Context, string) error
+}
+
+type eventStore struct {
+	svc      things.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc things.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+func (es eventStore) Subscribe(ctx context.Context, subject string) error {
+	err := es.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	reclaimed := time.Now()
+	for {
+		if time.Since(reclaimed) >= reclaimIdle {
+			es.reclaim(ctx)
+			reclaimed = time.Now()
+		}
+
+		streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			es.handle(ctx, msg)
+		}
+	}
+}
+
+// reclaim claims the events left pending for longer than reclaimIdle, by
+// this or any other consumer of the group, and handles them again. Events
+// that failed maxDeliveries times are acknowledged and dropped.
+func (es eventStore) reclaim(ctx context.Context) {
+	pending, err := es.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Idle:   reclaimIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  100,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return
+	}
+
+	var ids []string
+	for _, p := range pending {
+		if p.RetryCount >= maxDeliveries {
+			es.logger.Error(fmt.Sprintf("Dropping event %s after %d failed deliveries", p.ID, p.RetryCount))
+			es.client.XAck(ctx, stream, group, p.ID)
+			continue
+		}
+		ids = append(ids, p.ID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	msgs, err := es.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: es.consumer,
+		MinIdle:  reclaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		es.logger.Warn(fmt.Sprintf("Failed to claim pending events: %s", err.Error()))
+		return
+	}
+
+	for _, msg := range msgs {
+		es.handle(ctx, msg)
+	}
+}
+
+// handle handles the event and acknowledges it once handled. The events that
+// failed to be handled are left pending, to be reclaimed later.
+func (es eventStore) handle(ctx context.Context, msg redis.XMessage) {
+	se := decodeSessionEvent(msg.Values)
+
+	var err error
+	switch se.eventType {
+	case connect, disconnect:
+		err = es.handleSession(ctx, se)
+	}
+	// Events of removed things are acknowledged and dropped.
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		es.logger.Warn(fmt.Sprintf("Failed to handle event sourcing: %s", err.Error()))
+		return
+	}
+	es.client.XAck(ctx, stream, group, msg.ID)
+}
+
+func decodeSessionEvent(event map[string]interface{}) sessionEvent {
+	timestamp := time.Now()
+	if ts, err := strconv.ParseInt(read(event, "timestamp", ""), 10, 64); err == nil {
+		timestamp = time.Unix(ts, 0)
+	}
+
+	return sessionEvent{
+		thingID:   read(event, "thing_id", ""),
+		eventType: read(event, "event_type", ""),
+		timestamp: timestamp,
+	}
+}
+
+func (es eventStore) handleSession(ctx context.Context, se sessionEvent) error {
+	p := things.Presence{
+		ThingID:  se.thingID,
+		Online:   se.eventType == connect,
+		LastSeen: se.timestamp,
+		Protocol: protocol,
+	}
+
+	return es.svc.UpdatePresence(ctx, p)
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
@@ -6,6 +6,8 @@ package redis
 import (
 	"context"
 
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-redis/redis/v8"
 )
@@ -86,6 +88,18 @@ func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Th
 	return es.svc.ViewThing(ctx, token, id)
 }
 
+func (es eventStore) ViewThingStatus(ctx context.Context, token, id string) (things.Presence, error) {
+	return es.svc.ViewThingStatus(ctx, token, id)
+}
+
+func (es eventStore) UpdatePresence(ctx context.Context, p things.Presence) error {
+	return es.svc.UpdatePresence(ctx, p)
+}
+
+func (es eventStore) UpdateLastSeen(ctx context.Context, p things.Presence) error {
+	return es.svc.UpdateLastSeen(ctx, p)
+}
+
 func (es eventStore) ListThings(ctx context.Context, token string, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListThings(ctx, token, pm)
 }
@@ -244,12 +258,20 @@ func (es eventStore) Disconnect(ctx context.Context, token string, chIDs, thIDs
 	return nil
 }
 
-func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
-	return es.svc.CanAccessByKey(ctx, chanID, key)
+func (es eventStore) UpdateSubtopics(ctx context.Context, token, chanID, thingID string, subtopics []string) error {
+	return es.svc.UpdateSubtopics(ctx, token, chanID, thingID, subtopics)
 }
 
-func (es eventStore) CanAccessByID(ctx context.Context, chanID string, thingID string) error {
-	return es.svc.CanAccessByID(ctx, chanID, thingID)
+func (es eventStore) ViewSubtopics(ctx context.Context, token, chanID, thingID string) ([]string, error) {
+	return es.svc.ViewSubtopics(ctx, token, chanID, thingID)
+}
+
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, subtopic string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, subtopic)
+}
+
+func (es eventStore) CanAccessByID(ctx context.Context, chanID, thingID, subtopic string) error {
+	return es.svc.CanAccessByID(ctx, chanID, thingID, subtopic)
 }
 
 func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -260,6 +282,18 @@ func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
 
+func (es eventStore) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	return es.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (es eventStore) GetThingCodec(ctx context.Context, thingID string) (codec.Profile, error) {
+	return es.svc.GetThingCodec(ctx, thingID)
+}
+
+func (es eventStore) GetGroupChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	return es.svc.GetGroupChannels(ctx, owner, groupID)
+}
+
 func (es eventStore) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
 	return es.svc.ListMembers(ctx, token, groupID, pm)
 }
@@ -280,6 +314,30 @@ func (es eventStore) RemoveGroup(ctx context.Context, token, id string) error {
 	return es.svc.RemoveGroup(ctx, token, id)
 }
 
+func (es eventStore) ListDescendants(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupPage, error) {
+	return es.svc.ListDescendants(ctx, token, groupID, pm)
+}
+
+func (es eventStore) ListDescendantMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
+	return es.svc.ListDescendantMembers(ctx, token, groupID, pm)
+}
+
+func (es eventStore) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	return es.svc.MoveGroup(ctx, token, groupID, parentID)
+}
+
+func (es eventStore) ConnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	return es.svc.ConnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (es eventStore) DisconnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	return es.svc.DisconnectGroup(ctx, token, groupID, chIDs)
+}
+
+func (es eventStore) ListChannelsByGroup(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	return es.svc.ListChannelsByGroup(ctx, token, groupID, pm)
+}
+
 func (es eventStore) UpdateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
 	return es.svc.UpdateGroup(ctx, token, group)
 }
@@ -299,3 +357,31 @@ func (es eventStore) Unassign(ctx context.Context, token string, groupID string,
 func (es eventStore) ListMemberships(ctx context.Context, token string, memberID string, pm things.PageMetadata) (things.GroupPage, error) {
 	return es.svc.ListMemberships(ctx, token, memberID, pm)
 }
+
+func (es eventStore) CreateProfile(ctx context.Context, token string, p things.Profile) (things.Profile, error) {
+	return es.svc.CreateProfile(ctx, token, p)
+}
+
+func (es eventStore) UpdateProfile(ctx context.Context, token string, p things.Profile) error {
+	return es.svc.UpdateProfile(ctx, token, p)
+}
+
+func (es eventStore) ViewProfile(ctx context.Context, token, id string) (things.Profile, error) {
+	return es.svc.ViewProfile(ctx, token, id)
+}
+
+func (es eventStore) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	return es.svc.ListProfiles(ctx, token, pm)
+}
+
+func (es eventStore) RemoveProfile(ctx context.Context, token, id string) error {
+	return es.svc.RemoveProfile(ctx, token, id)
+}
+
+func (es eventStore) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (things.Page, error) {
+	return es.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (es eventStore) UpdateThingsByProfile(ctx context.Context, token, profileID string, metadata things.Metadata) error {
+	return es.svc.UpdateThingsByProfile(ctx, token, profileID, metadata)
+}
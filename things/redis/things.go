@@ -5,16 +5,19 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-redis/redis/v8"
 )
 
 const (
-	keyPrefix = "thing_key"
-	idPrefix  = "thing"
+	keyPrefix   = "thing_key"
+	idPrefix    = "thing"
+	codecSuffix = "codec"
 )
 
 var _ things.ThingCache = (*thingCache)(nil)
@@ -53,7 +56,49 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
+func (tc *thingCache) SaveCodec(ctx context.Context, thingID string, p codec.Profile) error {
+	// The empty profile is cached as well, so that the things without
+	// profile aren't retrieved from the database on every message.
+	data, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if err := tc.client.Set(ctx, codecKey(thingID), data, 0).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	return nil
+}
+
+func (tc *thingCache) Codec(ctx context.Context, thingID string) (codec.Profile, error) {
+	data, err := tc.client.Get(ctx, codecKey(thingID)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return codec.Profile{}, errors.ErrNotFound
+		}
+		return codec.Profile{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var p codec.Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return codec.Profile{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return p, nil
+}
+
+func (tc *thingCache) RemoveCodec(ctx context.Context, thingID string) error {
+	if err := tc.client.Del(ctx, codecKey(thingID)).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+	return nil
+}
+
 func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
+	if err := tc.RemoveCodec(ctx, thingID); err != nil {
+		return err
+	}
+
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
 	key, err := tc.client.Get(ctx, tid).Result()
 	// Redis returns Nil Reply when key does not exist.
@@ -70,3 +115,8 @@ func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
 	}
 	return nil
 }
+
+// Generates key of the thing payload codec
+func codecKey(thingID string) string {
+	return fmt.Sprintf("%s:%s:%s", idPrefix, thingID, codecSuffix)
+}
@@ -10,6 +10,7 @@ import (
 
 	r "github.com/go-redis/redis/v8"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
@@ -120,3 +121,63 @@ func TestThingRemove(t *testing.T) {
 	}
 
 }
+
+func TestThingCodec(t *testing.T) {
+	thingCache := redis.NewThingCache(redisClient)
+
+	id := "456"
+	id2 := "457"
+	id3 := "458"
+	p := codec.Profile{
+		Codec:       codec.Codec{Fields: []codec.Field{{Name: "temperature", Unit: "Cel", Type: codec.Int16, Scale: 0.01}}},
+		ContentType: "application/senml+json",
+		Transformer: "senml",
+	}
+
+	err := thingCache.SaveCodec(context.Background(), id, p)
+	require.Nil(t, err, fmt.Sprintf("save codec: unexpected error: %s", err))
+	err = thingCache.SaveCodec(context.Background(), id2, codec.Profile{})
+	require.Nil(t, err, fmt.Sprintf("save codec: unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		id      string
+		profile codec.Profile
+		err     error
+	}{
+		{
+			desc:    "retrieve cached codec",
+			id:      id,
+			profile: p,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve cached empty codec",
+			id:      id2,
+			profile: codec.Profile{},
+			err:     nil,
+		},
+		{
+			desc:    "retrieve non-cached codec",
+			id:      id3,
+			profile: codec.Profile{},
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := thingCache.Codec(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.profile, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.profile, p))
+	}
+
+	err = thingCache.RemoveCodec(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("remove codec: unexpected error: %s", err))
+	_, err = thingCache.Codec(context.Background(), id)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed codec: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = thingCache.Remove(context.Background(), id2)
+	require.Nil(t, err, fmt.Sprintf("remove thing: unexpected error: %s", err))
+	_, err = thingCache.Codec(context.Background(), id2)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve codec of removed thing: expected %s got %s\n", errors.ErrNotFound, err))
+}
@@ -5,9 +5,18 @@ package things
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 
 	"github.com/MainfluxLabs/mainflux"
 )
@@ -30,6 +39,17 @@ type Service interface {
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
 
+	// ViewThingStatus retrieves the presence of the thing identified with
+	// the provided ID, that belongs to the user identified by the provided key.
+	ViewThingStatus(ctx context.Context, token, id string) (Presence, error)
+
+	// UpdatePresence stores the session state of the thing reported by
+	// the session based adapters.
+	UpdatePresence(ctx context.Context, p Presence) error
+
+	// UpdateLastSeen stores the last activity of the thing.
+	UpdateLastSeen(ctx context.Context, p Presence) error
+
 	// ListThings retrieves data about subset of things that belongs to the
 	// user identified by the provided key.
 	ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error)
@@ -77,13 +97,21 @@ type Service interface {
 	// things.
 	Disconnect(ctx context.Context, token string, chIDs, thIDs []string) error
 
-	// CanAccessByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
+	// UpdateSubtopics replaces subtopic ACL patterns of the connection between
+	// the channel and the thing identified by the provided IDs.
+	UpdateSubtopics(ctx context.Context, token, chanID, thingID string, subtopics []string) error
+
+	// ViewSubtopics retrieves subtopic ACL patterns of the connection between
+	// the channel and the thing identified by the provided IDs.
+	ViewSubtopics(ctx context.Context, token, chanID, thingID string) ([]string, error)
 
-	// CanAccessByID determines whether the channel can be accessed by
+	// CanAccessByKey determines whether the channel subtopic can be accessed
+	// using the provided key and returns thing's id if access is allowed.
+	CanAccessByKey(ctx context.Context, chanID, key, subtopic string) (string, error)
+
+	// CanAccessByID determines whether the channel subtopic can be accessed by
 	// the given thing and returns error if it cannot.
-	CanAccessByID(ctx context.Context, chanID, thingID string) error
+	CanAccessByID(ctx context.Context, chanID, thingID, subtopic string) error
 
 	// IsChannelOwner determines whether the channel can be accessed by
 	// the given user and returns error if it cannot.
@@ -92,6 +120,20 @@ type Service interface {
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 
+	// GetChannelSchema retrieves the payload schema of the channel identified
+	// by the provided ID.
+	GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error)
+
+	// GetThingCodec retrieves the payload codec, and the default content
+	// type and transformer, of the profile the thing identified by the
+	// provided ID was created from.
+	GetThingCodec(ctx context.Context, thingID string) (codec.Profile, error)
+
+	// GetGroupChannels retrieves IDs of the channels owned by the given user
+	// and connected to the group or to any of its descendants, or to the
+	// things assigned to them. The group has to be owned by the user.
+	GetGroupChannels(ctx context.Context, owner, groupID string) ([]string, error)
+
 	// Backup retrieves all things, channels and connections for all users. Only accessible by admin.
 	Backup(ctx context.Context, token string) (Backup, error)
 
@@ -122,11 +164,62 @@ type Service interface {
 	// RemoveGroup removes the group identified with the provided ID.
 	RemoveGroup(ctx context.Context, token, id string) error
 
+	// ListDescendants retrieves all groups placed below the group identified by groupID.
+	ListDescendants(ctx context.Context, token, groupID string, pm PageMetadata) (GroupPage, error)
+
+	// ListDescendantMembers retrieves things assigned to the group identified
+	// by groupID or to any of its descendants.
+	ListDescendantMembers(ctx context.Context, token, groupID string, pm PageMetadata) (MemberPage, error)
+
+	// MoveGroup moves the group identified by groupID, together with its
+	// subtree, under the group identified by parentID. An empty parentID
+	// turns the group into a root group.
+	MoveGroup(ctx context.Context, token, groupID, parentID string) error
+
+	// ConnectGroup connects channels to the group identified by groupID. All
+	// current and future members of the group and its descendants are
+	// allowed to access the channels.
+	ConnectGroup(ctx context.Context, token, groupID string, chIDs []string) error
+
+	// DisconnectGroup disconnects channels from the group identified by groupID.
+	DisconnectGroup(ctx context.Context, token, groupID string, chIDs []string) error
+
+	// ListChannelsByGroup retrieves channels connected to the group identified by groupID.
+	ListChannelsByGroup(ctx context.Context, token, groupID string, pm PageMetadata) (ChannelsPage, error)
+
 	// Assign adds a member with memberID into the group identified by groupID.
 	Assign(ctx context.Context, token, groupID string, memberIDs ...string) error
 
 	// Unassign removes member with memberID from group identified by groupID.
 	Unassign(ctx context.Context, token, groupID string, memberIDs ...string) error
+
+	// CreateProfile adds the profile to the user identified by the provided key.
+	CreateProfile(ctx context.Context, token string, p Profile) (Profile, error)
+
+	// UpdateProfile updates the profile identified by the provided ID, that
+	// belongs to the user identified by the provided key.
+	UpdateProfile(ctx context.Context, token string, p Profile) error
+
+	// ViewProfile retrieves data about the profile identified by the provided
+	// ID, that belongs to the user identified by the provided key.
+	ViewProfile(ctx context.Context, token, id string) (Profile, error)
+
+	// ListProfiles retrieves data about subset of profiles that belongs to the
+	// user identified by the provided key.
+	ListProfiles(ctx context.Context, token string, pm PageMetadata) (ProfilesPage, error)
+
+	// RemoveProfile removes the profile identified by the provided ID, that
+	// belongs to the user identified by the provided key.
+	RemoveProfile(ctx context.Context, token, id string) error
+
+	// ListThingsByProfile retrieves data about subset of things created from
+	// the profile identified by the provided ID.
+	ListThingsByProfile(ctx context.Context, token, profileID string, pm PageMetadata) (Page, error)
+
+	// UpdateThingsByProfile merges the provided metadata into the metadata of
+	// all things created from the profile identified by the provided ID. If
+	// the metadata of any of the things can't be updated, none is updated.
+	UpdateThingsByProfile(ctx context.Context, token, profileID string, metadata Metadata) error
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -138,15 +231,20 @@ type PageMetadata struct {
 	Order        string                 `json:"order,omitempty"`
 	Dir          string                 `json:"dir,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Query        string                 `json:"query,omitempty"`      // Used for filtering by name and metadata, see pkg/query
+	Status       string                 `json:"status,omitempty"`     // Used for filtering things by presence status
+	SilentFor    uint64                 `json:"silent_for,omitempty"` // Used for filtering things not seen in given number of seconds
 	Disconnected bool                   // Used for connected or disconnected lists
 }
 
 type Backup struct {
-	Things         []Thing
-	Channels       []Channel
-	Connections    []Connection
-	Groups         []Group
-	GroupRelations []GroupRelation
+	Things           []Thing
+	Channels         []Channel
+	Connections      []Connection
+	Groups           []Group
+	GroupRelations   []GroupRelation
+	GroupConnections []GroupConnection
+	Profiles         []Profile
 }
 
 var _ Service = (*thingsService)(nil)
@@ -156,18 +254,20 @@ type thingsService struct {
 	things       ThingRepository
 	channels     ChannelRepository
 	groups       GroupRepository
+	profiles     ProfileRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
 	idProvider   mainflux.IDProvider
 }
 
 // New instantiates the things service implementation.
-func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
+func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, profiles ProfileRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
 	return &thingsService{
 		auth:         auth,
 		things:       things,
 		channels:     channels,
 		groups:       groups,
+		profiles:     profiles,
 		channelCache: ccache,
 		thingCache:   tcache,
 		idProvider:   idp,
@@ -214,6 +314,15 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 		thing.Key = key
 	}
 
+	var profile Profile
+	if thing.ProfileID != "" {
+		p, err := ts.applyProfile(ctx, thing)
+		if err != nil {
+			return Thing{}, err
+		}
+		profile = p
+	}
+
 	ths, err := ts.things.Save(ctx, *thing)
 	if err != nil {
 		return Thing{}, err
@@ -222,22 +331,78 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 		return Thing{}, errors.ErrCreateEntity
 	}
 
+	if len(profile.Channels) > 0 {
+		if err := ts.channels.Connect(ctx, thing.Owner, profile.Channels, []string{ths[0].ID}); err != nil {
+			return Thing{}, err
+		}
+	}
+
 	return ths[0], nil
 }
 
+// applyProfile fills in the default metadata of the thing profile and
+// validates the resulting metadata against the profile schema.
+func (ts *thingsService) applyProfile(ctx context.Context, thing *Thing) (Profile, error) {
+	profile, err := ts.profiles.RetrieveByID(ctx, thing.ProfileID)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if profile.Owner != thing.Owner {
+		return Profile{}, errors.ErrNotFound
+	}
+
+	metadata := Metadata{}
+	for k, v := range profile.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range thing.Metadata {
+		metadata[k] = v
+	}
+
+	if err := validateMetadata(profile, metadata); err != nil {
+		return Profile{}, err
+	}
+	thing.Metadata = metadata
+
+	return profile, nil
+}
+
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return err
 	}
 
-	if err := ts.isThingOwner(ctx, res.GetId(), thing.ID); err != nil {
+	current, err := ts.things.RetrieveByID(ctx, thing.ID)
+	if err != nil {
 		return err
 	}
 
+	if current.Owner != res.GetId() {
+		return errors.ErrNotFound
+	}
+
 	thing.Owner = res.GetId()
+	// The profile of a thing is set on creation and can't be changed.
+	thing.ProfileID = current.ProfileID
+
+	if thing.ProfileID != "" {
+		profile, err := ts.profiles.RetrieveByID(ctx, thing.ProfileID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateMetadata(profile, thing.Metadata); err != nil {
+			return err
+		}
+	}
+
+	if err := ts.things.Update(ctx, thing); err != nil {
+		return err
+	}
 
-	return ts.things.Update(ctx, thing)
+	return ts.thingCache.RemoveCodec(ctx, thing.ID)
 }
 
 func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
@@ -272,8 +437,7 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 	}
 
 	for _, group := range mpg.Groups {
-		_, err = ts.auth.CanAccessGroup(ctx, &mainflux.AccessGroupReq{Token: token, GroupID: group.ID})
-		if err == nil {
+		if ts.canAccessGroup(ctx, token, group) {
 			return thing, nil
 		}
 	}
@@ -281,6 +445,31 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 	return Thing{}, errors.ErrAuthorization
 }
 
+func (ts *thingsService) ViewThingStatus(ctx context.Context, token, id string) (Presence, error) {
+	if _, err := ts.ViewThing(ctx, token, id); err != nil {
+		return Presence{}, err
+	}
+
+	p, err := ts.things.RetrievePresence(ctx, id)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			// Thing has never been seen.
+			return Presence{ThingID: id}, nil
+		}
+		return Presence{}, err
+	}
+
+	return p, nil
+}
+
+func (ts *thingsService) UpdatePresence(ctx context.Context, p Presence) error {
+	return ts.things.SavePresence(ctx, p)
+}
+
+func (ts *thingsService) UpdateLastSeen(ctx context.Context, p Presence) error {
+	return ts.things.UpdateLastSeen(ctx, p)
+}
+
 func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error) {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -347,6 +536,10 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 }
 
 func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, identity *mainflux.UserIdentity) (Channel, error) {
+	if err := channel.Schema.Validate(); err != nil {
+		return Channel{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
 	if channel.ID == "" {
 		chID, err := ts.idProvider.ID()
 		if err != nil {
@@ -373,8 +566,16 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := channel.Schema.Validate(); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
 	channel.Owner = res.GetId()
-	return ts.channels.Update(ctx, channel)
+	if err := ts.channels.Update(ctx, channel); err != nil {
+		return err
+	}
+
+	return ts.channelCache.RemoveSchema(ctx, channel.ID)
 }
 
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
@@ -429,8 +630,7 @@ func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID st
 	}
 
 	for _, group := range mpg.Groups {
-		_, err = ts.auth.CanAccessGroup(ctx, &mainflux.AccessGroupReq{Token: token, GroupID: group.ID})
-		if err == nil {
+		if ts.canAccessGroup(ctx, token, group) {
 			return ts.channels.RetrieveConns(ctx, thID, pm)
 		}
 	}
@@ -493,13 +693,55 @@ func (ts *thingsService) Disconnect(ctx context.Context, token string, chIDs, th
 	return ts.channels.Disconnect(ctx, res.GetId(), chIDs, thIDs)
 }
 
-func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
+func (ts *thingsService) UpdateSubtopics(ctx context.Context, token, chanID, thingID string, subtopics []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ts.IsChannelOwner(ctx, res.GetId(), chanID); err != nil {
+		return err
+	}
+
+	patterns := []string{}
+	for _, s := range subtopics {
+		p, err := messaging.NormalizeSubtopicPattern(s)
+		if err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		patterns = append(patterns, p)
+	}
+
+	if err := ts.channels.UpdateSubtopics(ctx, res.GetId(), chanID, thingID, patterns); err != nil {
+		return err
+	}
+
+	return ts.channelCache.Disconnect(ctx, chanID, thingID)
+}
+
+func (ts *thingsService) ViewSubtopics(ctx context.Context, token, chanID, thingID string) ([]string, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ts.IsChannelOwner(ctx, res.GetId(), chanID); err != nil {
+		return nil, err
+	}
+
+	return ts.channels.RetrieveSubtopics(ctx, chanID, thingID)
+}
+
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey, subtopic string) (string, error) {
 	thingID, err := ts.hasThing(ctx, chanID, thingKey)
 	if err == nil {
+		if err := ts.canAccessSubtopic(ctx, chanID, thingID, subtopic); err != nil {
+			return "", err
+		}
 		return thingID, nil
 	}
 
-	thingID, err = ts.channels.HasThing(ctx, chanID, thingKey)
+	thingID, err = ts.things.RetrieveByKey(ctx, thingKey)
 	if err != nil {
 		return "", err
 	}
@@ -507,24 +749,33 @@ func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey st
 	if err := ts.thingCache.Save(ctx, thingKey, thingID); err != nil {
 		return "", err
 	}
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
+
+	subtopics, err := ts.resolveConnection(ctx, chanID, thingID)
+	if err != nil {
 		return "", err
 	}
+
+	if !messaging.MatchSubtopic(subtopics, subtopic) {
+		return "", errors.ErrAuthorization
+	}
+
 	return thingID, nil
 }
 
-func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID, subtopic string) error {
 	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); connected {
-		return nil
+		return ts.canAccessSubtopic(ctx, chanID, thingID, subtopic)
 	}
 
-	if err := ts.channels.HasThingByID(ctx, chanID, thingID); err != nil {
+	subtopics, err := ts.resolveConnection(ctx, chanID, thingID)
+	if err != nil {
 		return err
 	}
 
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
-		return err
+	if !messaging.MatchSubtopic(subtopics, subtopic) {
+		return errors.ErrAuthorization
 	}
+
 	return nil
 }
 
@@ -558,6 +809,61 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
+func (ts *thingsService) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	if s, err := ts.channelCache.Schema(ctx, chanID); err == nil {
+		return s, nil
+	}
+
+	channel, err := ts.channels.RetrieveByID(ctx, chanID)
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	if err := ts.channelCache.SaveSchema(ctx, chanID, channel.Schema); err != nil {
+		return schema.Schema{}, err
+	}
+
+	return channel.Schema, nil
+}
+
+func (ts *thingsService) GetThingCodec(ctx context.Context, thingID string) (codec.Profile, error) {
+	if p, err := ts.thingCache.Codec(ctx, thingID); err == nil {
+		return p, nil
+	}
+
+	thing, err := ts.things.RetrieveByID(ctx, thingID)
+	if err != nil {
+		return codec.Profile{}, err
+	}
+
+	var p codec.Profile
+	if thing.ProfileID != "" {
+		profile, err := ts.profiles.RetrieveByID(ctx, thing.ProfileID)
+		if err != nil {
+			return codec.Profile{}, err
+		}
+		p = codec.Profile{
+			Codec:       profile.Codec,
+			ContentType: profile.ContentType,
+			Transformer: profile.Transformer,
+		}
+	}
+
+	if err := ts.thingCache.SaveCodec(ctx, thingID, p); err != nil {
+		return codec.Profile{}, err
+	}
+
+	return p, nil
+}
+
+func (ts *thingsService) GetGroupChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	if err := ts.isGroupOwner(ctx, owner, groupID); err != nil {
+		return nil, err
+	}
+
+	return ts.groups.RetrieveDescendantChannels(ctx, owner, groupID)
+}
+
 func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
 	thingID, err := ts.thingCache.ID(ctx, thingKey)
 	if err != nil {
@@ -570,6 +876,59 @@ func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string)
 	return thingID, nil
 }
 
+// canAccessSubtopic checks the subtopic against the cached subtopic ACL
+// patterns of the connection.
+func (ts *thingsService) canAccessSubtopic(ctx context.Context, chanID, thingID, subtopic string) error {
+	subtopics, err := ts.channelCache.Subtopics(ctx, chanID, thingID)
+	if err != nil {
+		return err
+	}
+
+	if !messaging.MatchSubtopic(subtopics, subtopic) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// resolveConnection checks whether the thing is allowed to access the
+// channel, either through a direct connection or through a group connected
+// to the channel, caches the result and returns subtopic ACL patterns.
+// The direct connection is checked first, so that its subtopic restrictions
+// apply even if the thing is in a connected group as well. Access granted by
+// a group only isn't restricted to subtopics.
+func (ts *thingsService) resolveConnection(ctx context.Context, chanID, thingID string) ([]string, error) {
+	err := ts.channels.HasThingByID(ctx, chanID, thingID)
+	if err == nil {
+		return ts.cacheConnection(ctx, chanID, thingID)
+	}
+
+	if gerr := ts.groups.HasChannelAccess(ctx, chanID, thingID); gerr != nil {
+		return nil, err
+	}
+
+	if err := ts.channelCache.Connect(ctx, chanID, thingID, nil); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// cacheConnection stores the connection together with its subtopic ACL
+// patterns to the cache and returns the patterns.
+func (ts *thingsService) cacheConnection(ctx context.Context, chanID, thingID string) ([]string, error) {
+	subtopics, err := ts.channels.RetrieveSubtopics(ctx, chanID, thingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ts.channelCache.Connect(ctx, chanID, thingID, subtopics); err != nil {
+		return nil, err
+	}
+
+	return subtopics, nil
+}
+
 func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, error) {
 	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
@@ -590,6 +949,16 @@ func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, erro
 		return Backup{}, err
 	}
 
+	groupConnections, err := ts.groups.RetrieveAllConnections(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	profiles, err := ts.profiles.RetrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
 	things, err := ts.things.RetrieveAll(ctx)
 	if err != nil {
 		return Backup{}, err
@@ -606,11 +975,13 @@ func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, erro
 	}
 
 	return Backup{
-		Things:         things,
-		Channels:       channels,
-		Connections:    connections,
-		Groups:         groups,
-		GroupRelations: groupRelations,
+		Things:           things,
+		Channels:         channels,
+		Connections:      connections,
+		Groups:           groups,
+		GroupRelations:   groupRelations,
+		GroupConnections: groupConnections,
+		Profiles:         profiles,
 	}, nil
 }
 
@@ -624,7 +995,13 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		return err
 	}
 
-	for _, group := range backup.Groups {
+	// Parents have to be restored before their children.
+	groups := append([]Group{}, backup.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Ancestors()) < len(groups[j].Ancestors())
+	})
+
+	for _, group := range groups {
 		_, err = ts.groups.Save(ctx, group)
 		if err != nil {
 			return err
@@ -638,6 +1015,12 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		}
 	}
 
+	for _, profile := range backup.Profiles {
+		if _, err := ts.profiles.Save(ctx, profile); err != nil {
+			return err
+		}
+	}
+
 	_, err = ts.things.Save(ctx, backup.Things...)
 	if err != nil {
 		return err
@@ -653,6 +1036,18 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		if err != nil {
 			return err
 		}
+
+		if len(conn.Subtopics) > 0 {
+			if err := ts.channels.UpdateSubtopics(ctx, conn.ChannelOwner, conn.ChannelID, conn.ThingID, conn.Subtopics); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, conn := range backup.GroupConnections {
+		if err := ts.groups.ConnectChannels(ctx, conn.GroupID, conn.ChannelID); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -675,6 +1070,19 @@ func (ts *thingsService) CreateGroup(ctx context.Context, token string, group Gr
 
 	group.ID = id
 	group.OwnerID = user.Id
+	group.Path = id
+
+	if group.ParentID != "" {
+		parent, err := ts.groups.RetrieveByID(ctx, group.ParentID)
+		if err != nil {
+			return Group{}, err
+		}
+
+		if parent.OwnerID != user.Id {
+			return Group{}, errors.ErrAuthorization
+		}
+		group.Path = parent.Path + GroupPathSeparator + id
+	}
 
 	group, err = ts.groups.Save(ctx, group)
 	if err != nil {
@@ -734,16 +1142,226 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 		return Group{}, errors.ErrNotFound
 	}
 
-	_, err = ts.auth.CanAccessGroup(ctx, &mainflux.AccessGroupReq{Token: token, GroupID: id})
-	if user.GetId() != gr.OwnerID && err != nil {
-		return Group{}, err
+	if user.GetId() != gr.OwnerID && !ts.canAccessGroup(ctx, token, gr) {
+		return Group{}, errors.ErrAuthorization
 	}
 
 	return gr, nil
 }
 
+func (ts *thingsService) ListDescendants(ctx context.Context, token, groupID string, pm PageMetadata) (GroupPage, error) {
+	if _, err := ts.ViewGroup(ctx, token, groupID); err != nil {
+		return GroupPage{}, err
+	}
+
+	return ts.groups.RetrieveDescendants(ctx, groupID, pm)
+}
+
+func (ts *thingsService) ListDescendantMembers(ctx context.Context, token, groupID string, pm PageMetadata) (MemberPage, error) {
+	if _, err := ts.ViewGroup(ctx, token, groupID); err != nil {
+		return MemberPage{}, err
+	}
+
+	mp, err := ts.groups.RetrieveDescendantMembers(ctx, groupID, pm)
+	if err != nil {
+		return MemberPage{}, errors.Wrap(ErrFailedToRetrieveMembers, err)
+	}
+
+	return mp, nil
+}
+
+func (ts *thingsService) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	group, err := ts.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if group.OwnerID != user.GetId() {
+		return errors.ErrAuthorization
+	}
+
+	if parentID != "" {
+		parent, err := ts.groups.RetrieveByID(ctx, parentID)
+		if err != nil {
+			return err
+		}
+
+		if parent.OwnerID != user.GetId() {
+			return errors.ErrAuthorization
+		}
+
+		if parent.ID == group.ID || strings.HasPrefix(parent.Path, group.Path+GroupPathSeparator) {
+			return ErrGroupCycle
+		}
+	}
+
+	// Subtree members lose the channels connected to the former ancestors,
+	// which are collected before the move, while the cached connections are
+	// invalidated only after it, so that they can't be cached again from the
+	// former ancestors in the meantime.
+	chIDs, err := ts.groupChannels(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.groups.Move(ctx, groupID, parentID); err != nil {
+		return err
+	}
+
+	return ts.invalidateConnections(ctx, groupID, chIDs, nil)
+}
+
+func (ts *thingsService) ConnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+		return err
+	}
+
+	for _, chID := range chIDs {
+		if err := ts.IsChannelOwner(ctx, user.GetId(), chID); err != nil {
+			return err
+		}
+	}
+
+	if err := ts.groups.ConnectChannels(ctx, groupID, chIDs...); err != nil {
+		return err
+	}
+
+	return ts.invalidateConnections(ctx, groupID, chIDs, nil)
+}
+
+func (ts *thingsService) DisconnectGroup(ctx context.Context, token, groupID string, chIDs []string) error {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+		return err
+	}
+
+	if err := ts.groups.DisconnectChannels(ctx, groupID, chIDs...); err != nil {
+		return err
+	}
+
+	return ts.invalidateConnections(ctx, groupID, chIDs, nil)
+}
+
+func (ts *thingsService) ListChannelsByGroup(ctx context.Context, token, groupID string, pm PageMetadata) (ChannelsPage, error) {
+	if _, err := ts.ViewGroup(ctx, token, groupID); err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return ts.groups.RetrieveChannels(ctx, groupID, pm)
+}
+
+func (ts *thingsService) isGroupOwner(ctx context.Context, owner, groupID string) error {
+	group, err := ts.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if group.OwnerID != owner {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// invalidateGroupConnections removes cached connections between the members
+// and the channels connected to the group or to any of its ancestors. If no
+// members are provided, members of the whole group subtree are used.
+func (ts *thingsService) invalidateGroupConnections(ctx context.Context, groupID string, memberIDs []string) error {
+	group, err := ts.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	chIDs, err := ts.groupChannels(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	return ts.invalidateConnections(ctx, groupID, chIDs, memberIDs)
+}
+
+// groupChannels retrieves IDs of the channels connected to the group or to
+// any of its ancestors.
+func (ts *thingsService) groupChannels(ctx context.Context, group Group) ([]string, error) {
+	var chIDs []string
+	for _, id := range append([]string{group.ID}, group.Ancestors()...) {
+		cp, err := ts.groups.RetrieveChannels(ctx, id, PageMetadata{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ch := range cp.Channels {
+			chIDs = append(chIDs, ch.ID)
+		}
+	}
+
+	return chIDs, nil
+}
+
+// invalidateConnections removes cached connections between the channels and
+// the members. If no members are provided, members of the whole group
+// subtree are used.
+func (ts *thingsService) invalidateConnections(ctx context.Context, groupID string, chIDs, memberIDs []string) error {
+	if len(chIDs) == 0 {
+		return nil
+	}
+
+	if memberIDs == nil {
+		mp, err := ts.groups.RetrieveDescendantMembers(ctx, groupID, PageMetadata{})
+		if err != nil {
+			return err
+		}
+
+		for _, m := range mp.Members {
+			memberIDs = append(memberIDs, m.ID)
+		}
+	}
+
+	for _, chID := range chIDs {
+		for _, thID := range memberIDs {
+			if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// canAccessGroup reports whether the user identified by the token has access
+// to the group. Access to a group assigned to an org is inherited by all of
+// its descendants, so the group's ancestors are checked as well.
+func (ts *thingsService) canAccessGroup(ctx context.Context, token string, group Group) bool {
+	for _, id := range append([]string{group.ID}, group.Ancestors()...) {
+		if _, err := ts.auth.CanAccessGroup(ctx, &mainflux.AccessGroupReq{Token: token, GroupID: id}); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (ts *thingsService) Assign(ctx context.Context, token string, groupID string, memberIDs ...string) error {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canManageMembers(ctx, user.GetId(), groupID, memberIDs); err != nil {
 		return err
 	}
 
@@ -751,15 +1369,48 @@ func (ts *thingsService) Assign(ctx context.Context, token string, groupID strin
 		return err
 	}
 
-	return nil
+	// Cached subtopic restrictions of direct connections don't apply to
+	// the channels the new members gain through the group.
+	return ts.invalidateGroupConnections(ctx, groupID, memberIDs)
 }
 
 func (ts *thingsService) Unassign(ctx context.Context, token string, groupID string, memberIDs ...string) error {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canManageMembers(ctx, user.GetId(), groupID, memberIDs); err != nil {
+		return err
+	}
+
+	if err := ts.groups.UnassignMember(ctx, groupID, memberIDs...); err != nil {
+		return err
+	}
+
+	return ts.invalidateGroupConnections(ctx, groupID, memberIDs)
+}
+
+// canManageMembers checks that the user owns the group and all the things
+// being assigned to or unassigned from it, since the group members gain access
+// to the channels connected to the group.
+func (ts *thingsService) canManageMembers(ctx context.Context, owner, groupID string, memberIDs []string) error {
+	if err := ts.isGroupOwner(ctx, owner, groupID); err != nil {
 		return err
 	}
 
-	return ts.groups.UnassignMember(ctx, groupID, memberIDs...)
+	for _, id := range memberIDs {
+		th, err := ts.things.RetrieveByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if th.Owner != owner {
+			return errors.ErrAuthorization
+		}
+	}
+
+	return nil
 }
 
 func getTimestmap() time.Time {
@@ -786,6 +1437,208 @@ func (ts *thingsService) ListMemberships(ctx context.Context, token string, memb
 	return ts.groups.RetrieveMemberships(ctx, memberID, pm)
 }
 
+func (ts *thingsService) CreateProfile(ctx context.Context, token string, p Profile) (Profile, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Profile{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	p.Owner = res.GetId()
+
+	if err := ts.validateProfile(ctx, p); err != nil {
+		return Profile{}, err
+	}
+
+	if p.ID == "" {
+		id, err := ts.idProvider.ID()
+		if err != nil {
+			return Profile{}, err
+		}
+		p.ID = id
+	}
+
+	return ts.profiles.Save(ctx, p)
+}
+
+func (ts *thingsService) UpdateProfile(ctx context.Context, token string, p Profile) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+	p.Owner = res.GetId()
+
+	if err := ts.validateProfile(ctx, p); err != nil {
+		return err
+	}
+
+	if err := ts.profiles.Update(ctx, p); err != nil {
+		return err
+	}
+
+	return ts.removeProfileCodecs(ctx, p.Owner, p.ID)
+}
+
+func (ts *thingsService) ViewProfile(ctx context.Context, token, id string) (Profile, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Profile{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.retrieveProfile(ctx, res.GetId(), id)
+}
+
+func (ts *thingsService) ListProfiles(ctx context.Context, token string, pm PageMetadata) (ProfilesPage, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ProfilesPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return ts.profiles.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (ts *thingsService) RemoveProfile(ctx context.Context, token, id string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	// The codecs are invalidated before the profile is removed, while its
+	// things can still be found by the profile.
+	if err := ts.removeProfileCodecs(ctx, res.GetId(), id); err != nil {
+		return err
+	}
+
+	return ts.profiles.Remove(ctx, res.GetId(), id)
+}
+
+func (ts *thingsService) ListThingsByProfile(ctx context.Context, token, profileID string, pm PageMetadata) (Page, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := ts.retrieveProfile(ctx, res.GetId(), profileID); err != nil {
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByProfile(ctx, res.GetId(), profileID, pm)
+}
+
+func (ts *thingsService) UpdateThingsByProfile(ctx context.Context, token, profileID string, metadata Metadata) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	profile, err := ts.retrieveProfile(ctx, res.GetId(), profileID)
+	if err != nil {
+		return err
+	}
+
+	page, err := ts.things.RetrieveByProfile(ctx, res.GetId(), profileID, PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	// Validate all the things before updating any of them.
+	for i, th := range page.Things {
+		md := Metadata{}
+		for k, v := range th.Metadata {
+			md[k] = v
+		}
+		for k, v := range metadata {
+			md[k] = v
+		}
+
+		if err := validateMetadata(profile, md); err != nil {
+			return err
+		}
+		page.Things[i].Metadata = md
+	}
+
+	return ts.things.UpdateMetadata(ctx, page.Things...)
+}
+
+// removeProfileCodecs removes the cached codecs of the things created from
+// the profile.
+func (ts *thingsService) removeProfileCodecs(ctx context.Context, owner, profileID string) error {
+	page, err := ts.things.RetrieveByProfile(ctx, owner, profileID, PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	for _, th := range page.Things {
+		if err := ts.thingCache.RemoveCodec(ctx, th.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ts *thingsService) retrieveProfile(ctx context.Context, owner, id string) (Profile, error) {
+	profile, err := ts.profiles.RetrieveByID(ctx, id)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if profile.Owner != owner {
+		return Profile{}, errors.ErrNotFound
+	}
+
+	return profile, nil
+}
+
+func (ts *thingsService) validateProfile(ctx context.Context, p Profile) error {
+	switch p.Transformer {
+	case "", transformers.SenML, transformers.JSON:
+	default:
+		return errors.ErrMalformedEntity
+	}
+
+	switch p.ContentType {
+	case "", senml.JSON, senml.CBOR, mfjson.ContentType:
+	default:
+		return errors.ErrMalformedEntity
+	}
+
+	if err := (schema.Schema{JSON: p.Schema}).Validate(); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if err := p.Codec.Validate(); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	for _, chID := range p.Channels {
+		if err := ts.IsChannelOwner(ctx, p.Owner, chID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateMetadata(p Profile, metadata Metadata) error {
+	if len(p.Schema) == 0 {
+		return nil
+	}
+
+	if metadata == nil {
+		metadata = Metadata{}
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if err := (schema.Schema{JSON: p.Schema}).ValidatePayload(data); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, errors.Wrap(ErrInvalidMetadata, err))
+	}
+
+	return nil
+}
+
 func (ts *thingsService) authorize(ctx context.Context, email string) error {
 	req := &mainflux.AuthorizeReq{
 		Email: email,
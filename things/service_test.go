@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/mocks"
@@ -36,6 +39,22 @@ var (
 	channel   = things.Channel{Name: "test"}
 	thsExtID  = []things.Thing{{ID: prefix + "000000000001", Name: "a"}, {ID: prefix + "000000000002", Name: "b"}}
 	chsExtID  = []things.Channel{{ID: prefix + "000000000001", Name: "a"}, {ID: prefix + "000000000002", Name: "b"}}
+
+	senmlSchema = schema.Schema{SenML: []schema.SenMLRecord{{Name: "current", Unit: "A"}}}
+	profile     = things.Profile{
+		Name:        "sensor",
+		ContentType: "application/senml+json",
+		Transformer: "senml",
+		Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"serial"},
+			"properties": map[string]interface{}{
+				"serial":   map[string]interface{}{"type": "string"},
+				"firmware": map[string]interface{}{"type": "string"},
+			},
+		},
+		Metadata: things.Metadata{"firmware": "1.0.0"},
+	}
 )
 
 func newService(tokens map[string]string) things.Service {
@@ -43,12 +62,13 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := mocks.NewGroupRepository()
+	groupsRepo := mocks.NewGroupRepository(channelsRepo)
+	profilesRepo := mocks.NewProfileRepository()
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func TestInit(t *testing.T) {
@@ -216,6 +236,104 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestViewThingStatus(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0], thingList[1])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, seen := ths[0], ths[1]
+
+	connected := time.Now().Add(-time.Minute).Round(0)
+	published := connected.Add(time.Second)
+	err = svc.UpdatePresence(context.Background(), things.Presence{ThingID: seen.ID, Online: true, LastSeen: connected, Protocol: "mqtt"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdateLastSeen(context.Background(), things.Presence{ThingID: seen.ID, LastSeen: published, Protocol: "http", RemoteAddr: "10.0.0.1:5000"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id       string
+		token    string
+		presence things.Presence
+		err      error
+	}{
+		"view status of thing never seen": {
+			id:       th.ID,
+			token:    token,
+			presence: things.Presence{ThingID: th.ID},
+			err:      nil,
+		},
+		"view status of seen thing": {
+			id:       seen.ID,
+			token:    token,
+			presence: things.Presence{ThingID: seen.ID, Online: true, LastSeen: published, Protocol: "http", RemoteAddr: "10.0.0.1:5000"},
+			err:      nil,
+		},
+		"view status of thing with wrong credentials": {
+			id:    th.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		"view status of thing owned by other user": {
+			id:    th.ID,
+			token: token2,
+			err:   errors.ErrAuthorization,
+		},
+		"view status of non-existing thing": {
+			id:    wrongID,
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		p, err := svc.ViewThingStatus(context.Background(), tc.token, tc.id)
+		assert.Equal(t, tc.presence, p, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.presence, p))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestUpdatePresence(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc     string
+		presence things.Presence
+		online   bool
+		err      error
+	}{
+		{
+			desc:     "connect thing",
+			presence: things.Presence{ThingID: th.ID, Online: true, LastSeen: time.Now(), Protocol: "mqtt"},
+			online:   true,
+			err:      nil,
+		},
+		{
+			desc:     "disconnect thing",
+			presence: things.Presence{ThingID: th.ID, Online: false, LastSeen: time.Now(), Protocol: "mqtt"},
+			online:   false,
+			err:      nil,
+		},
+		{
+			desc:     "connect non-existing thing",
+			presence: things.Presence{ThingID: wrongID, Online: true, LastSeen: time.Now(), Protocol: "mqtt"},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdatePresence(context.Background(), tc.presence)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		p, err := svc.ViewThingStatus(context.Background(), token, th.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.online, p.Online, fmt.Sprintf("%s: expected online %t got %t\n", tc.desc, tc.online, p.Online))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
@@ -593,6 +711,18 @@ func TestCreateChannels(t *testing.T) {
 			token:    token,
 			err:      nil,
 		},
+		{
+			desc:     "create new channel with schema",
+			channels: []things.Channel{{Name: "e", Schema: senmlSchema}},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create new channel with malformed schema",
+			channels: []things.Channel{{Name: "f", Schema: schema.Schema{SenML: []schema.SenMLRecord{{Unit: "A"}}}}},
+			token:    token,
+			err:      errors.ErrMalformedEntity,
+		},
 	}
 
 	for _, cc := range cases {
@@ -632,6 +762,12 @@ func TestUpdateChannel(t *testing.T) {
 			token:   token,
 			err:     errors.ErrNotFound,
 		},
+		{
+			desc:    "update channel with malformed schema",
+			channel: things.Channel{ID: ch.ID, Schema: schema.Schema{JSON: map[string]interface{}{"type": 1}}},
+			token:   token,
+			err:     errors.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1171,26 +1307,121 @@ func TestDisconnect(t *testing.T) {
 
 }
 
+func TestUpdateSubtopics(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: "john.doe@email.net"})
+
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0], thingList[1])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		token     string
+		chanID    string
+		thingID   string
+		subtopics []string
+		expected  []string
+		err       error
+	}{
+		"update subtopics of connection": {
+			token:     token,
+			chanID:    chs[0].ID,
+			thingID:   ths[0].ID,
+			subtopics: []string{"tenant1/#", "sensors.+.temp"},
+			expected:  []string{"tenant1.#", "sensors.+.temp"},
+			err:       nil,
+		},
+		"update subtopics with malformed pattern": {
+			token:     token,
+			chanID:    chs[0].ID,
+			thingID:   ths[0].ID,
+			subtopics: []string{"tenant1/#/temp"},
+			err:       errors.ErrMalformedEntity,
+		},
+		"update subtopics of non-existing connection": {
+			token:     token,
+			chanID:    chs[0].ID,
+			thingID:   ths[1].ID,
+			subtopics: []string{"tenant1/#"},
+			err:       errors.ErrNotFound,
+		},
+		"update subtopics with wrong credentials": {
+			token:     wrongValue,
+			chanID:    chs[0].ID,
+			thingID:   ths[0].ID,
+			subtopics: []string{"tenant1/#"},
+			err:       errors.ErrAuthentication,
+		},
+		"update subtopics of channel owned by other user": {
+			token:     token2,
+			chanID:    chs[0].ID,
+			thingID:   ths[0].ID,
+			subtopics: []string{"tenant1/#"},
+			err:       errors.ErrAuthorization,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.UpdateSubtopics(context.Background(), tc.token, tc.chanID, tc.thingID, tc.subtopics)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		subtopics, err := svc.ViewSubtopics(context.Background(), tc.token, tc.chanID, tc.thingID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", desc, err))
+		assert.Equal(t, tc.expected, subtopics, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.expected, subtopics))
+	}
+}
+
 func TestCanAccessByKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
 	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID})
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID, chs[2].ID}, []string{ths[0].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdateSubtopics(context.Background(), token, chs[2].ID, ths[0].ID, []string{"tenant1/#"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
-		token   string
-		channel string
-		err     error
+		token    string
+		channel  string
+		subtopic string
+		err      error
 	}{
 		"allowed access": {
 			token:   ths[0].Key,
 			channel: chs[0].ID,
 			err:     nil,
 		},
+		"allowed access to any subtopic": {
+			token:    ths[0].Key,
+			channel:  chs[0].ID,
+			subtopic: "tenant2.temp",
+			err:      nil,
+		},
+		"allowed access to restricted subtopic": {
+			token:    ths[0].Key,
+			channel:  chs[2].ID,
+			subtopic: "tenant1.temp",
+			err:      nil,
+		},
+		"access to subtopic outside of restriction": {
+			token:    ths[0].Key,
+			channel:  chs[2].ID,
+			subtopic: "tenant2.temp",
+			err:      errors.ErrAuthorization,
+		},
+		"access to restricted channel without subtopic": {
+			token:   ths[0].Key,
+			channel: chs[2].ID,
+			err:     errors.ErrAuthorization,
+		},
 		"non-existing thing": {
 			token:   wrongValue,
 			channel: chs[0].ID,
@@ -1209,7 +1440,7 @@ func TestCanAccessByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token)
+		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token, tc.subtopic)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
 	}
 }
@@ -1220,22 +1451,37 @@ func TestCanAccessByID(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thingList[0], thingList[1])
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID, chs[1].ID}, []string{th.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdateSubtopics(context.Background(), token, chs[1].ID, th.ID, []string{"sensors/+/temp"})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
-		thingID string
-		channel string
-		err     error
+		thingID  string
+		channel  string
+		subtopic string
+		err      error
 	}{
 		"allowed access": {
 			thingID: th.ID,
 			channel: ch.ID,
 			err:     nil,
 		},
+		"allowed access to restricted subtopic": {
+			thingID:  th.ID,
+			channel:  chs[1].ID,
+			subtopic: "sensors.s1.temp",
+			err:      nil,
+		},
+		"access to subtopic outside of restriction": {
+			thingID:  th.ID,
+			channel:  chs[1].ID,
+			subtopic: "sensors.s1.humidity",
+			err:      errors.ErrAuthorization,
+		},
 		"access to non-existing thing": {
 			thingID: wrongValue,
 			channel: ch.ID,
@@ -1254,11 +1500,226 @@ func TestCanAccessByID(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID)
+		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID, tc.subtopic)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
 
+func TestConnectGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	building, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	floor, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "floor", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	chs, err = svc.CreateChannels(context.Background(), token2, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	foreignCh := chs[0]
+
+	cases := []struct {
+		desc    string
+		groupID string
+		chIDs   []string
+		token   string
+		err     error
+	}{
+		{
+			desc:    "connect group to channel",
+			groupID: building.ID,
+			chIDs:   []string{ch.ID},
+			token:   token,
+			err:     nil,
+		},
+		{
+			desc:    "connect group to already connected channel",
+			groupID: building.ID,
+			chIDs:   []string{ch.ID},
+			token:   token,
+			err:     errors.ErrConflict,
+		},
+		{
+			desc:    "connect group to channel owned by another user",
+			groupID: building.ID,
+			chIDs:   []string{foreignCh.ID},
+			token:   token,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "connect non-existing group",
+			groupID: wrongValue,
+			chIDs:   []string{ch.ID},
+			token:   token,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "connect group with wrong credentials",
+			groupID: building.ID,
+			chIDs:   []string{ch.ID},
+			token:   wrongValue,
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ConnectGroup(context.Background(), tc.token, tc.groupID, tc.chIDs)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	cp, err := svc.ListChannelsByGroup(context.Background(), token, building.ID, things.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, uint64(1), cp.Total, fmt.Sprintf("expected %d channels got %d\n", 1, cp.Total))
+
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), token, floor.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[0].ID, "")
+	assert.Nil(t, err, fmt.Sprintf("member of descendant group: unexpected error: %s\n", err))
+	_, err = svc.CanAccessByKey(context.Background(), ch.ID, ths[0].Key, "any.subtopic")
+	assert.Nil(t, err, fmt.Sprintf("member of descendant group by key: unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[1].ID, "")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("non-member: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	err = svc.Assign(context.Background(), token, floor.ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[1].ID, "")
+	assert.Nil(t, err, fmt.Sprintf("newly assigned member: unexpected error: %s\n", err))
+
+	err = svc.Unassign(context.Background(), token, floor.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[0].ID, "")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("unassigned member: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	// The subtopic restrictions of the direct connection apply to the group
+	// members as well.
+	rths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "c"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	restricted := rths[0]
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{restricted.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdateSubtopics(context.Background(), token, ch.ID, restricted.ID, []string{"sensors/+/temp"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), token, floor.ID, restricted.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, restricted.ID, "sensors.s1.humidity")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("connected member outside of subtopic restriction: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, restricted.ID, "sensors.s1.temp")
+	assert.Nil(t, err, fmt.Sprintf("connected member within subtopic restriction: unexpected error: %s\n", err))
+
+	err = svc.DisconnectGroup(context.Background(), token, building.ID, []string{ch.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, ths[1].ID, "")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("member of disconnected group: expected %s got %s\n", errors.ErrAuthorization, err))
+}
+
+func TestAssign(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	gr, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	ths, err = svc.CreateThings(context.Background(), token2, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	foreignTh := ths[0]
+
+	cases := []struct {
+		desc     string
+		token    string
+		groupID  string
+		memberID string
+		err      error
+	}{
+		{
+			desc:     "assign thing to group",
+			token:    token,
+			groupID:  gr.ID,
+			memberID: th.ID,
+			err:      nil,
+		},
+		{
+			desc:     "assign thing to group owned by another user",
+			token:    token2,
+			groupID:  gr.ID,
+			memberID: foreignTh.ID,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "assign thing owned by another user to group",
+			token:    token,
+			groupID:  gr.ID,
+			memberID: foreignTh.ID,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "assign thing with wrong credentials",
+			token:    wrongValue,
+			groupID:  gr.ID,
+			memberID: th.ID,
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Assign(context.Background(), tc.token, tc.groupID, tc.memberID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUnassign(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	gr, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	err = svc.Assign(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		groupID  string
+		memberID string
+		err      error
+	}{
+		{
+			desc:     "unassign thing from group owned by another user",
+			token:    token2,
+			groupID:  gr.ID,
+			memberID: th.ID,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "unassign thing with wrong credentials",
+			token:    wrongValue,
+			groupID:  gr.ID,
+			memberID: th.ID,
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "unassign thing from group",
+			token:    token,
+			groupID:  gr.ID,
+			memberID: th.ID,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Unassign(context.Background(), tc.token, tc.groupID, tc.memberID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestIsChannelOwner(t *testing.T) {
 	svc := newService(map[string]string{token: email, token2: "john.doe@email.net"})
 
@@ -1324,6 +1785,643 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestGetChannelSchema(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	ch := channel
+	ch.Schema = senmlSchema
+	chs, err := svc.CreateChannels(context.Background(), token, ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch = chs[0]
+
+	cases := map[string]struct {
+		chanID string
+		schema schema.Schema
+		err    error
+	}{
+		"get schema of existing channel": {
+			chanID: ch.ID,
+			schema: senmlSchema,
+			err:    nil,
+		},
+		"get schema of non-existing channel": {
+			chanID: wrongID,
+			schema: schema.Schema{},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		sch, err := svc.GetChannelSchema(context.Background(), tc.chanID)
+		assert.Equal(t, tc.schema, sch, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.schema, sch))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	// The cached schema is invalidated once the channel is updated.
+	updated := schema.Schema{SenML: []schema.SenMLRecord{{Name: "voltage", Unit: "V"}}}
+	ch.Schema = updated
+	err = svc.UpdateChannel(context.Background(), token, ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	sch, err := svc.GetChannelSchema(context.Background(), ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, updated, sch, fmt.Sprintf("get schema of updated channel: expected %v got %v\n", updated, sch))
+
+	err = svc.RemoveChannel(context.Background(), token, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.GetChannelSchema(context.Background(), ch.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("get schema of removed channel: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestCreateProfile(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	withChannels := profile
+	withChannels.Name = "with-channels"
+	withChannels.Channels = []string{ch.ID}
+
+	invalidTransformer := profile
+	invalidTransformer.Name = "invalid-transformer"
+	invalidTransformer.Transformer = "xml"
+
+	invalidContentType := profile
+	invalidContentType.Name = "invalid-content-type"
+	invalidContentType.ContentType = "text/plain"
+
+	invalidSchema := profile
+	invalidSchema.Name = "invalid-schema"
+	invalidSchema.Schema = map[string]interface{}{"type": 1}
+
+	invalidCodec := profile
+	invalidCodec.Name = "invalid-codec"
+	invalidCodec.Codec = codec.Codec{Fields: []codec.Field{{Name: "temperature", Type: "int12"}}}
+
+	cases := []struct {
+		desc    string
+		profile things.Profile
+		token   string
+		err     error
+	}{
+		{
+			desc:    "create new profile",
+			profile: profile,
+			token:   token,
+			err:     nil,
+		},
+		{
+			desc:    "create profile with default channels",
+			profile: withChannels,
+			token:   token,
+			err:     nil,
+		},
+		{
+			desc:    "create profile with channels of another user",
+			profile: withChannels,
+			token:   token2,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "create profile with invalid transformer",
+			profile: invalidTransformer,
+			token:   token,
+			err:     errors.ErrMalformedEntity,
+		},
+		{
+			desc:    "create profile with invalid content type",
+			profile: invalidContentType,
+			token:   token,
+			err:     errors.ErrMalformedEntity,
+		},
+		{
+			desc:    "create profile with invalid metadata schema",
+			profile: invalidSchema,
+			token:   token,
+			err:     errors.ErrMalformedEntity,
+		},
+		{
+			desc:    "create profile with invalid codec",
+			profile: invalidCodec,
+			token:   token,
+			err:     errors.ErrMalformedEntity,
+		},
+		{
+			desc:    "create profile with wrong credentials",
+			profile: profile,
+			token:   wrongValue,
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateProfile(context.Background(), tc.token, tc.profile)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestGetThingCodec(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	c := codec.Codec{Fields: []codec.Field{{Name: "temperature", Unit: "Cel", Type: codec.Int16, Scale: 0.01}}}
+	pr := profile
+	pr.Codec = c
+	pr, err := svc.CreateProfile(context.Background(), token, pr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	ths, err := svc.CreateThings(context.Background(), token,
+		things.Thing{Name: "with-profile", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "A1"}},
+		things.Thing{Name: "without-profile"},
+	)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		thingID string
+		profile codec.Profile
+		err     error
+	}{
+		"get codec of thing created from profile": {
+			thingID: ths[0].ID,
+			profile: codec.Profile{Codec: c, ContentType: pr.ContentType, Transformer: pr.Transformer},
+			err:     nil,
+		},
+		"get codec of thing without profile": {
+			thingID: ths[1].ID,
+			profile: codec.Profile{},
+			err:     nil,
+		},
+		"get codec of non-existing thing": {
+			thingID: wrongValue,
+			profile: codec.Profile{},
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		p, err := svc.GetThingCodec(context.Background(), tc.thingID)
+		assert.Equal(t, tc.profile, p, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.profile, p))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	// The cached codecs of the profile things are invalidated once the
+	// profile is updated.
+	updated := codec.Codec{Fields: []codec.Field{{Name: "humidity", Unit: "%RH", Type: codec.Uint8}}}
+	pr.Codec = updated
+	err = svc.UpdateProfile(context.Background(), token, pr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	got, err := svc.GetThingCodec(context.Background(), ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, updated, got.Codec, fmt.Sprintf("get codec after profile update: expected %v got %v\n", updated, got.Codec))
+}
+
+func TestCreateThingsWithProfile(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	pr := profile
+	pr.Channels = []string{ch.ID}
+	pr, err = svc.CreateProfile(context.Background(), token, pr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		thing    things.Thing
+		token    string
+		metadata things.Metadata
+		err      error
+	}{
+		{
+			desc:     "create thing with profile",
+			thing:    things.Thing{Name: "a", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "A1"}},
+			token:    token,
+			metadata: things.Metadata{"serial": "A1", "firmware": "1.0.0"},
+			err:      nil,
+		},
+		{
+			desc:     "create thing with profile overriding default metadata",
+			thing:    things.Thing{Name: "b", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "B1", "firmware": "2.0.0"}},
+			token:    token,
+			metadata: things.Metadata{"serial": "B1", "firmware": "2.0.0"},
+			err:      nil,
+		},
+		{
+			desc:  "create thing with metadata that doesn't conform to the profile schema",
+			thing: things.Thing{Name: "c", ProfileID: pr.ID},
+			token: token,
+			err:   things.ErrInvalidMetadata,
+		},
+		{
+			desc:  "create thing with non-existing profile",
+			thing: things.Thing{Name: "d", ProfileID: wrongValue},
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "create thing with profile of another user",
+			thing: things.Thing{Name: "e", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "E1"}},
+			token: token2,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		ths, err := svc.CreateThings(context.Background(), tc.token, tc.thing)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		th := ths[0]
+		assert.Equal(t, tc.metadata, th.Metadata, fmt.Sprintf("%s: expected metadata %v got %v\n", tc.desc, tc.metadata, th.Metadata))
+
+		err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, "")
+		assert.Nil(t, err, fmt.Sprintf("%s: expected thing to be connected to the profile channel: %s\n", tc.desc, err))
+	}
+}
+
+func TestUpdateThingsByProfile(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+
+	pr, err := svc.CreateProfile(context.Background(), token, profile)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	for i := 0; i < 3; i++ {
+		th := things.Thing{Name: fmt.Sprintf("th-%d", i), ProfileID: pr.ID, Metadata: things.Metadata{"serial": strconv.Itoa(i)}}
+		_, err := svc.CreateThings(context.Background(), token, th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+	_, err = svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	page, err := svc.ListThingsByProfile(context.Background(), token, pr.ID, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, uint64(3), page.Total, fmt.Sprintf("expected %d things got %d\n", 3, page.Total))
+
+	cases := []struct {
+		desc      string
+		profileID string
+		metadata  things.Metadata
+		token     string
+		err       error
+	}{
+		{
+			desc:      "update things with metadata that doesn't conform to the profile schema",
+			profileID: pr.ID,
+			metadata:  things.Metadata{"firmware": 2},
+			token:     token,
+			err:       things.ErrInvalidMetadata,
+		},
+		{
+			desc:      "update things of non-existing profile",
+			profileID: wrongValue,
+			metadata:  things.Metadata{"firmware": "2.0.0"},
+			token:     token,
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "update things with wrong credentials",
+			profileID: pr.ID,
+			metadata:  things.Metadata{"firmware": "2.0.0"},
+			token:     wrongValue,
+			err:       errors.ErrAuthentication,
+		},
+		{
+			desc:      "update things of profile",
+			profileID: pr.ID,
+			metadata:  things.Metadata{"firmware": "2.0.0"},
+			token:     token,
+			err:       nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateThingsByProfile(context.Background(), tc.token, tc.profileID, tc.metadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err = svc.ListThingsByProfile(context.Background(), token, pr.ID, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	for _, th := range page.Things {
+		assert.Equal(t, "2.0.0", th.Metadata["firmware"], fmt.Sprintf("expected updated firmware got %v\n", th.Metadata["firmware"]))
+	}
+}
+
+func TestCreateGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	parent, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, parent.ID, parent.Path, fmt.Sprintf("expected root group path %s got %s\n", parent.ID, parent.Path))
+
+	cases := []struct {
+		desc  string
+		group things.Group
+		token string
+		path  string
+		err   error
+	}{
+		{
+			desc:  "create group under parent",
+			group: things.Group{Name: "building", ParentID: parent.ID},
+			token: token,
+			path:  parent.Path + things.GroupPathSeparator,
+			err:   nil,
+		},
+		{
+			desc:  "create group under non-existing parent",
+			group: things.Group{Name: "building", ParentID: wrongValue},
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "create group under parent owned by another user",
+			group: things.Group{Name: "building", ParentID: parent.ID},
+			token: token2,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create group with wrong credentials",
+			group: things.Group{Name: "building"},
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		gr, err := svc.CreateGroup(context.Background(), tc.token, tc.group)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.path+gr.ID, gr.Path, fmt.Sprintf("%s: expected path %s got %s\n", tc.desc, tc.path+gr.ID, gr.Path))
+		}
+	}
+}
+
+func TestListDescendants(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	region, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	building, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building", ParentID: region.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	floor, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "floor", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"}, things.Thing{Name: "c"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), token, region.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), token, floor.ID, ths[1].ID, ths[2].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		groupID string
+		token   string
+		groups  uint64
+		members uint64
+		err     error
+	}{
+		{
+			desc:    "list descendants of root group",
+			groupID: region.ID,
+			token:   token,
+			groups:  2,
+			members: 3,
+			err:     nil,
+		},
+		{
+			desc:    "list descendants of intermediate group",
+			groupID: building.ID,
+			token:   token,
+			groups:  1,
+			members: 2,
+			err:     nil,
+		},
+		{
+			desc:    "list descendants of leaf group",
+			groupID: floor.ID,
+			token:   token,
+			groups:  0,
+			members: 2,
+			err:     nil,
+		},
+		{
+			desc:    "list descendants of non-existing group",
+			groupID: wrongValue,
+			token:   token,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "list descendants with wrong credentials",
+			groupID: region.ID,
+			token:   wrongValue,
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		gp, err := svc.ListDescendants(context.Background(), tc.token, tc.groupID, things.PageMetadata{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.groups, gp.Total, fmt.Sprintf("%s: expected %d groups got %d\n", tc.desc, tc.groups, gp.Total))
+
+		mp, err := svc.ListDescendantMembers(context.Background(), tc.token, tc.groupID, things.PageMetadata{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.members, mp.Total, fmt.Sprintf("%s: expected %d members got %d\n", tc.desc, tc.members, mp.Total))
+	}
+}
+
+func TestGetGroupChannels(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	building, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	floor1, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "floor1", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	floor2, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "floor2", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"}, things.Thing{Name: "c"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Assign(context.Background(), token, floor1.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Assign(context.Background(), token, floor2.ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID, ths[1].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[1].ID}, []string{ths[1].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[2].ID}, []string{ths[2].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectGroup(context.Background(), token, floor2.ID, []string{chs[3].ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		owner   string
+		groupID string
+		chIDs   []string
+		err     error
+	}{
+		{
+			desc:    "get channels of root group",
+			owner:   email,
+			groupID: building.ID,
+			chIDs:   []string{chs[0].ID, chs[1].ID, chs[3].ID},
+			err:     nil,
+		},
+		{
+			desc:    "get channels of group connected to channel",
+			owner:   email,
+			groupID: floor2.ID,
+			chIDs:   []string{chs[0].ID, chs[1].ID, chs[3].ID},
+			err:     nil,
+		},
+		{
+			desc:    "get channels of leaf group",
+			owner:   email,
+			groupID: floor1.ID,
+			chIDs:   []string{chs[0].ID},
+			err:     nil,
+		},
+		{
+			desc:    "get channels of group owned by another user",
+			owner:   email2,
+			groupID: building.ID,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "get channels of non-existing group",
+			owner:   email,
+			groupID: wrongValue,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		chIDs, err := svc.GetGroupChannels(context.Background(), tc.owner, tc.groupID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.chIDs, chIDs, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.chIDs, chIDs))
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	region, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	building, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "building", ParentID: region.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	floor, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "floor", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	region2, err := svc.CreateGroup(context.Background(), token, things.Group{Name: "region2"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	foreign, err := svc.CreateGroup(context.Background(), token2, things.Group{Name: "foreign"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// The floor member accesses the channel of the region, which caches
+	// the connection.
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	err = svc.ConnectGroup(context.Background(), token, region.ID, []string{ch.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "member"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	err = svc.Assign(context.Background(), token, floor.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		groupID  string
+		parentID string
+		token    string
+		err      error
+	}{
+		{
+			desc:     "move group under itself",
+			groupID:  building.ID,
+			parentID: building.ID,
+			token:    token,
+			err:      things.ErrGroupCycle,
+		},
+		{
+			desc:     "move group under its descendant",
+			groupID:  region.ID,
+			parentID: floor.ID,
+			token:    token,
+			err:      things.ErrGroupCycle,
+		},
+		{
+			desc:     "move group under group owned by another user",
+			groupID:  building.ID,
+			parentID: foreign.ID,
+			token:    token,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "move group under non-existing group",
+			groupID:  building.ID,
+			parentID: wrongValue,
+			token:    token,
+			err:      errors.ErrNotFound,
+		},
+		{
+			desc:     "move group with wrong credentials",
+			groupID:  building.ID,
+			parentID: region2.ID,
+			token:    wrongValue,
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "move subtree under another group",
+			groupID:  building.ID,
+			parentID: region2.ID,
+			token:    token,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.MoveGroup(context.Background(), tc.token, tc.groupID, tc.parentID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	moved, err := svc.ViewGroup(context.Background(), token, floor.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	path := strings.Join([]string{region2.ID, building.ID, floor.ID}, things.GroupPathSeparator)
+	assert.Equal(t, path, moved.Path, fmt.Sprintf("expected path %s got %s\n", path, moved.Path))
+
+	err = svc.CanAccessByID(context.Background(), ch.ID, th.ID, "")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("member of moved subtree: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	gp, err := svc.ListDescendants(context.Background(), token, region.ID, things.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, uint64(0), gp.Total, fmt.Sprintf("expected no descendants got %d\n", gp.Total))
+
+	err = svc.RemoveGroup(context.Background(), token, region2.ID)
+	assert.True(t, errors.Contains(err, things.ErrGroupNotEmpty), fmt.Sprintf("remove group with children: expected %s got %s\n", things.ErrGroupNotEmpty, err))
+
+	err = svc.MoveGroup(context.Background(), token, building.ID, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	moved, err = svc.ViewGroup(context.Background(), token, floor.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	path = strings.Join([]string{building.ID, floor.ID}, things.GroupPathSeparator)
+	assert.Equal(t, path, moved.Path, fmt.Sprintf("expected path %s got %s\n", path, moved.Path))
+}
+
 func TestBackup(t *testing.T) {
 	svc := newService(map[string]string{token: adminEmail})
 
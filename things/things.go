@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 )
 
 var (
@@ -26,12 +27,14 @@ type Metadata map[string]interface{}
 
 // Thing represents a Mainflux thing. Each thing is owned by one user, and
 // it is assigned with the unique identifier and (temporary) access key.
+// ProfileID identifies the profile the thing was created from, if any.
 type Thing struct {
-	ID       string
-	Owner    string
-	Name     string
-	Key      string
-	Metadata Metadata
+	ID        string
+	Owner     string
+	Name      string
+	Key       string
+	Metadata  Metadata
+	ProfileID string
 }
 
 // Page contains page related metadata as well as list of things that
@@ -52,6 +55,10 @@ type ThingRepository interface {
 	// returned to indicate operation failure.
 	Update(ctx context.Context, t Thing) error
 
+	// UpdateMetadata updates the metadata of multiple things using a
+	// transaction. If one thing fails then none will be updated.
+	UpdateMetadata(ctx context.Context, ths ...Thing) error
+
 	// UpdateKey updates key value of the existing thing. A non-nil error is
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, owner, id, key string) error
@@ -73,6 +80,10 @@ type ThingRepository interface {
 	// user and connected or not connected to specified channel.
 	RetrieveByChannel(ctx context.Context, owner, chID string, pm PageMetadata) (Page, error)
 
+	// RetrieveByProfile retrieves the subset of things owned by the specified
+	// user and created from the specified profile.
+	RetrieveByProfile(ctx context.Context, owner, profileID string, pm PageMetadata) (Page, error)
+
 	// Remove removes the thing having the provided identifier, that is owned
 	// by the specified user.
 	Remove(ctx context.Context, owner, id string) error
@@ -82,6 +93,16 @@ type ThingRepository interface {
 
 	// RetrieveByAdmin retrieves all things for all users with pagination.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// SavePresence stores the session state of the thing.
+	SavePresence(ctx context.Context, p Presence) error
+
+	// UpdateLastSeen stores the last activity of the thing, keeping
+	// its session state.
+	UpdateLastSeen(ctx context.Context, p Presence) error
+
+	// RetrievePresence retrieves the presence of the thing with given ID.
+	RetrievePresence(ctx context.Context, thingID string) (Presence, error)
 }
 
 // ThingCache contains thing caching interface.
@@ -92,6 +113,16 @@ type ThingCache interface {
 	// ID returns thing ID for given key.
 	ID(context.Context, string) (string, error)
 
+	// SaveCodec stores the payload codec and defaults of the thing profile.
+	SaveCodec(ctx context.Context, thingID string, p codec.Profile) error
+
+	// Codec returns the cached payload codec and defaults of the thing
+	// profile.
+	Codec(ctx context.Context, thingID string) (codec.Profile, error)
+
+	// RemoveCodec removes the payload codec of the thing from cache.
+	RemoveCodec(ctx context.Context, thingID string) error
+
 	// Removes thing from cache.
 	Remove(context.Context, string) error
 }
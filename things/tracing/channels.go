@@ -6,6 +6,7 @@ package tracing
 import (
 	"context"
 
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 )
@@ -22,8 +23,13 @@ const (
 	disconnectOp              = "disconnect"
 	hasThingOp                = "has_thing"
 	hasThingByIDOp            = "has_thing_by_id"
+	updateSubtopicsOp         = "update_subtopics"
+	retrieveSubtopicsOp       = "retrieve_subtopics"
 	retrieveAllChannelsOp     = "retrieve_all_channels"
 	retrieveAllConnectionsOp  = "retrieve_all_connections"
+	saveSchemaOp              = "save_schema"
+	retrieveSchemaOp          = "retrieve_schema"
+	removeSchemaOp            = "remove_schema"
 )
 
 var (
@@ -133,6 +139,22 @@ func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID,
 	return crm.repo.HasThingByID(ctx, chanID, thingID)
 }
 
+func (crm channelRepositoryMiddleware) UpdateSubtopics(ctx context.Context, owner, chanID, thingID string, subtopics []string) error {
+	span := createSpan(ctx, crm.tracer, updateSubtopicsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.UpdateSubtopics(ctx, owner, chanID, thingID, subtopics)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveSubtopics(ctx context.Context, chanID, thingID string) ([]string, error) {
+	span := createSpan(ctx, crm.tracer, retrieveSubtopicsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveSubtopics(ctx, chanID, thingID)
+}
+
 func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
@@ -171,12 +193,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string, subtopics []string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, subtopics)
+}
+
+func (ccm channelCacheMiddleware) Subtopics(ctx context.Context, chanID, thingID string) ([]string, error) {
+	span := createSpan(ctx, ccm.tracer, retrieveSubtopicsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return ccm.cache.Subtopics(ctx, chanID, thingID)
 }
 
 func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
@@ -195,6 +225,30 @@ func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingI
 	return ccm.cache.Disconnect(ctx, chanID, thingID)
 }
 
+func (ccm channelCacheMiddleware) SaveSchema(ctx context.Context, chanID string, s schema.Schema) error {
+	span := createSpan(ctx, ccm.tracer, saveSchemaOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return ccm.cache.SaveSchema(ctx, chanID, s)
+}
+
+func (ccm channelCacheMiddleware) Schema(ctx context.Context, chanID string) (schema.Schema, error) {
+	span := createSpan(ctx, ccm.tracer, retrieveSchemaOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return ccm.cache.Schema(ctx, chanID)
+}
+
+func (ccm channelCacheMiddleware) RemoveSchema(ctx context.Context, chanID string) error {
+	span := createSpan(ctx, ccm.tracer, removeSchemaOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return ccm.cache.RemoveSchema(ctx, chanID)
+}
+
 func (ccm channelCacheMiddleware) Remove(ctx context.Context, chanID string) error {
 	span := createSpan(ctx, ccm.tracer, removeChannelOp)
 	defer span.Finish()
@@ -20,6 +20,15 @@ const (
 	assignMemberOp              = "assign_member"
 	unassignMemberOp            = "unassign_member"
 	retrieveAllGroupRelationsOp = "retrieve_all_group_relations"
+	retrieveDescendantsOp       = "retrieve_descendants"
+	retrieveDescendantMembersOp = "retrieve_descendant_members"
+	retrieveDescendantChansOp   = "retrieve_descendant_channels"
+	moveGroupOp                 = "move_group"
+	connectChannelsOp           = "connect_channels"
+	disconnectChannelsOp        = "disconnect_channels"
+	retrieveGroupChannelsOp     = "retrieve_group_channels"
+	hasChannelAccessOp          = "has_channel_access"
+	retrieveAllGroupConnsOp     = "retrieve_all_group_connections"
 )
 
 var _ things.GroupRepository = (*groupRepositoryMiddleware)(nil)
@@ -130,3 +139,75 @@ func (grm groupRepositoryMiddleware) UnassignMember(ctx context.Context, groupID
 
 	return grm.repo.UnassignMember(ctx, groupID, memberIDs...)
 }
+
+func (grm groupRepositoryMiddleware) RetrieveDescendants(ctx context.Context, groupID string, pm things.PageMetadata) (things.GroupPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveDescendantsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveDescendants(ctx, groupID, pm)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveDescendantMembers(ctx context.Context, groupID string, pm things.PageMetadata) (things.MemberPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveDescendantMembersOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveDescendantMembers(ctx, groupID, pm)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveDescendantChannels(ctx context.Context, owner, groupID string) ([]string, error) {
+	span := createSpan(ctx, grm.tracer, retrieveDescendantChansOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveDescendantChannels(ctx, owner, groupID)
+}
+
+func (grm groupRepositoryMiddleware) Move(ctx context.Context, groupID, parentID string) error {
+	span := createSpan(ctx, grm.tracer, moveGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Move(ctx, groupID, parentID)
+}
+
+func (grm groupRepositoryMiddleware) ConnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, connectChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.ConnectChannels(ctx, groupID, chIDs...)
+}
+
+func (grm groupRepositoryMiddleware) DisconnectChannels(ctx context.Context, groupID string, chIDs ...string) error {
+	span := createSpan(ctx, grm.tracer, disconnectChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.DisconnectChannels(ctx, groupID, chIDs...)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveChannels(ctx context.Context, groupID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveGroupChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveChannels(ctx, groupID, pm)
+}
+
+func (grm groupRepositoryMiddleware) HasChannelAccess(ctx context.Context, chanID, memberID string) error {
+	span := createSpan(ctx, grm.tracer, hasChannelAccessOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.HasChannelAccess(ctx, chanID, memberID)
+}
+
+func (grm groupRepositoryMiddleware) RetrieveAllConnections(ctx context.Context) ([]things.GroupConnection, error) {
+	span := createSpan(ctx, grm.tracer, retrieveAllGroupConnsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveAllConnections(ctx)
+}
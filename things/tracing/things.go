@@ -6,6 +6,7 @@ package tracing
 import (
 	"context"
 
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/codec"
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 )
@@ -14,16 +15,24 @@ const (
 	saveThingOp               = "save_thing"
 	saveThingsOp              = "save_things"
 	updateThingOp             = "update_thing"
+	updateThingsMetadataOp    = "update_things_metadata"
 	updateThingKeyOp          = "update_thing_by_key"
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingsByIDsOp     = "retrieve_things_by_ids"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
 	retrieveThingsByOwnerOp   = "retrieve_things_by_owner"
 	retrieveThingsByChannelOp = "retrieve_things_by_chan"
+	retrieveThingsByProfileOp = "retrieve_things_by_profile"
 	removeThingOp             = "remove_thing"
 	retrieveThingIDByKeyOp    = "retrieve_id_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
 	restoreThingsOp           = "restore_things"
+	savePresenceOp            = "save_presence"
+	updateLastSeenOp          = "update_last_seen"
+	retrievePresenceOp        = "retrieve_presence"
+	saveCodecOp               = "save_codec"
+	retrieveCodecOp           = "retrieve_codec"
+	removeCodecOp             = "remove_codec"
 )
 
 var (
@@ -61,6 +70,14 @@ func (trm thingRepositoryMiddleware) Update(ctx context.Context, th things.Thing
 	return trm.repo.Update(ctx, th)
 }
 
+func (trm thingRepositoryMiddleware) UpdateMetadata(ctx context.Context, ths ...things.Thing) error {
+	span := createSpan(ctx, trm.tracer, updateThingsMetadataOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UpdateMetadata(ctx, ths...)
+}
+
 func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, key string) error {
 	span := createSpan(ctx, trm.tracer, updateThingKeyOp)
 	defer span.Finish()
@@ -109,6 +126,14 @@ func (trm thingRepositoryMiddleware) RetrieveByChannel(ctx context.Context, owne
 	return trm.repo.RetrieveByChannel(ctx, owner, chID, pm)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveByProfile(ctx context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingsByProfileOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByProfile(ctx, owner, profileID, pm)
+}
+
 func (trm thingRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
 	span := createSpan(ctx, trm.tracer, removeThingOp)
 	defer span.Finish()
@@ -133,6 +158,30 @@ func (trm thingRepositoryMiddleware) RetrieveByAdmin(ctx context.Context, pm thi
 	return trm.repo.RetrieveByAdmin(ctx, pm)
 }
 
+func (trm thingRepositoryMiddleware) SavePresence(ctx context.Context, p things.Presence) error {
+	span := createSpan(ctx, trm.tracer, savePresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SavePresence(ctx, p)
+}
+
+func (trm thingRepositoryMiddleware) UpdateLastSeen(ctx context.Context, p things.Presence) error {
+	span := createSpan(ctx, trm.tracer, updateLastSeenOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UpdateLastSeen(ctx, p)
+}
+
+func (trm thingRepositoryMiddleware) RetrievePresence(ctx context.Context, thingID string) (things.Presence, error) {
+	span := createSpan(ctx, trm.tracer, retrievePresenceOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrievePresence(ctx, thingID)
+}
+
 type thingCacheMiddleware struct {
 	tracer opentracing.Tracer
 	cache  things.ThingCache
@@ -163,6 +212,30 @@ func (tcm thingCacheMiddleware) ID(ctx context.Context, thingKey string) (string
 	return tcm.cache.ID(ctx, thingKey)
 }
 
+func (tcm thingCacheMiddleware) SaveCodec(ctx context.Context, thingID string, p codec.Profile) error {
+	span := createSpan(ctx, tcm.tracer, saveCodecOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return tcm.cache.SaveCodec(ctx, thingID, p)
+}
+
+func (tcm thingCacheMiddleware) Codec(ctx context.Context, thingID string) (codec.Profile, error) {
+	span := createSpan(ctx, tcm.tracer, retrieveCodecOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return tcm.cache.Codec(ctx, thingID)
+}
+
+func (tcm thingCacheMiddleware) RemoveCodec(ctx context.Context, thingID string) error {
+	span := createSpan(ctx, tcm.tracer, removeCodecOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return tcm.cache.RemoveCodec(ctx, thingID)
+}
+
 func (tcm thingCacheMiddleware) Remove(ctx context.Context, thingID string) error {
 	span := createSpan(ctx, tcm.tracer, removeThingOp)
 	defer span.Finish()
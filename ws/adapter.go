@@ -13,6 +13,7 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 const (
@@ -55,26 +56,40 @@ type Service interface {
 
 	// Unsubscribe method is used to stop observing resource.
 	Unsubscribe(ctx context.Context, thingKey, chanID, subtopic string) error
+
+	// SubscribeStream subscribes the user identified by the provided token
+	// to the messages of the channel it owns, and returns the ID of the
+	// stream subscription. Subtopic may contain wildcards.
+	SubscribeStream(ctx context.Context, token, chanID, subtopic string, h messaging.MessageHandler) (string, error)
+
+	// UnsubscribeStream cancels the stream subscription with the given ID.
+	UnsubscribeStream(ctx context.Context, id, chanID, subtopic string) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	things mainflux.ThingsServiceClient
-	pubsub messaging.PubSub
+	things     mainflux.ThingsServiceClient
+	auth       mainflux.AuthServiceClient
+	validator  schema.Validator
+	pubsub     messaging.PubSub
+	idProvider mainflux.IDProvider
 }
 
 // New instantiates the WS adapter implementation
-func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+func New(things mainflux.ThingsServiceClient, auth mainflux.AuthServiceClient, pubsub messaging.PubSub, idp mainflux.IDProvider) Service {
 	return &adapterService{
-		things: things,
-		pubsub: pubsub,
+		things:     things,
+		auth:       auth,
+		validator:  schema.NewValidator(things, schema.DefCacheTTL),
+		pubsub:     pubsub,
+		idProvider: idp,
 	}
 }
 
 // Publish publishes the message using the broker
 func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg messaging.Message) error {
-	thid, err := svc.authorize(ctx, thingKey, msg.GetChannel())
+	thid, err := svc.authorize(ctx, thingKey, msg.GetChannel(), msg.GetSubtopic())
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -85,6 +100,10 @@ func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg mes
 
 	msg.Publisher = thid.GetValue()
 
+	if err := svc.validator.Validate(ctx, msg); err != nil {
+		return err
+	}
+
 	if err := svc.pubsub.Publish(msg.GetChannel(), msg); err != nil {
 		return ErrFailedMessagePublish
 	}
@@ -98,7 +117,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		return ErrUnauthorizedAccess
 	}
 
-	thid, err := svc.authorize(ctx, thingKey, chanID)
+	thid, err := svc.authorize(ctx, thingKey, chanID, subtopic)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -123,7 +142,7 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, thingKey, chanID, su
 		return ErrUnauthorizedAccess
 	}
 
-	thid, err := svc.authorize(ctx, thingKey, chanID)
+	thid, err := svc.authorize(ctx, thingKey, chanID, subtopic)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -136,10 +155,67 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, thingKey, chanID, su
 	return svc.pubsub.Unsubscribe(thid.GetValue(), subject)
 }
 
-func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID string) (*mainflux.ThingID, error) {
+// SubscribeStream subscribes the stream of the channel owner to the topic
+func (svc *adapterService) SubscribeStream(ctx context.Context, token, chanID, subtopic string, h messaging.MessageHandler) (string, error) {
+	if chanID == "" {
+		return "", ErrEmptyID
+	}
+
+	if err := svc.authorizeOwner(ctx, token, chanID); err != nil {
+		return "", err
+	}
+
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return "", err
+	}
+
+	if err := svc.pubsub.Subscribe(id, subject(chanID, subtopic), h); err != nil {
+		return "", ErrFailedSubscription
+	}
+
+	return id, nil
+}
+
+// UnsubscribeStream unsubscribes the stream from the topic.
+func (svc *adapterService) UnsubscribeStream(ctx context.Context, id, chanID, subtopic string) error {
+	if id == "" || chanID == "" {
+		return ErrEmptyID
+	}
+
+	return svc.pubsub.Unsubscribe(id, subject(chanID, subtopic))
+}
+
+func (svc *adapterService) authorizeOwner(ctx context.Context, token, chanID string) error {
+	if token == "" {
+		return errors.ErrAuthentication
+	}
+
+	user, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := svc.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.GetId(), ChanID: chanID}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+func subject(chanID, subtopic string) string {
+	if subtopic == "" {
+		return fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", chansPrefix, chanID, subtopic)
+}
+
+func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID, subtopic string) (*mainflux.ThingID, error) {
 	ar := &mainflux.AccessByKeyReq{
-		Token:  thingKey,
-		ChanID: chanID,
+		Token:    thingKey,
+		ChanID:   chanID,
+		Subtopic: subtopic,
 	}
 	thid, err := svc.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -10,7 +10,11 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	thmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	authmock "github.com/MainfluxLabs/mainflux/users/mocks"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +26,9 @@ const (
 	thingKey = "thing_key"
 	subTopic = "subtopic"
 	protocol = "ws"
+	token    = "token"
+	userID   = "user"
+	email    = "user@example.com"
 )
 
 var msg = messaging.Message{
@@ -34,7 +41,8 @@ var msg = messaging.Message{
 
 func newService(tc mainflux.ThingsServiceClient) (ws.Service, mocks.MockPubSub) {
 	pubsub := mocks.NewPubSub()
-	return ws.New(tc, pubsub), pubsub
+	auth := authmock.NewAuthService(map[string]users.User{token: {ID: userID, Email: email}})
+	return ws.New(tc, auth, pubsub, uuid.NewMock()), pubsub
 }
 
 func TestPublish(t *testing.T) {
@@ -238,3 +246,122 @@ func TestUnsubscribe(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestSubscribeStream(t *testing.T) {
+	thingsClient := thmock.NewThingsService(map[string]string{userID: chanID}, nil)
+	svc, pubsub := newService(thingsClient)
+
+	cases := []struct {
+		desc     string
+		token    string
+		chanID   string
+		subtopic string
+		fail     bool
+		err      error
+	}{
+		{
+			desc:     "subscribe stream to owned channel",
+			token:    token,
+			chanID:   chanID,
+			subtopic: "",
+			err:      nil,
+		},
+		{
+			desc:     "subscribe stream to owned channel with subtopic wildcard",
+			token:    token,
+			chanID:   chanID,
+			subtopic: "room.*",
+			err:      nil,
+		},
+		{
+			desc:     "subscribe stream with subscribe set to fail",
+			token:    token,
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     true,
+			err:      ws.ErrFailedSubscription,
+		},
+		{
+			desc:     "subscribe stream to channel owned by other user",
+			token:    token,
+			chanID:   "2",
+			subtopic: "",
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "subscribe stream with invalid token",
+			token:    "invalid",
+			chanID:   chanID,
+			subtopic: "",
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "subscribe stream with empty token",
+			token:    "",
+			chanID:   chanID,
+			subtopic: "",
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "subscribe stream with empty channel",
+			token:    token,
+			chanID:   "",
+			subtopic: "",
+			err:      ws.ErrEmptyID,
+		},
+	}
+
+	for _, tc := range cases {
+		pubsub.SetFail(tc.fail)
+		id, err := svc.SubscribeStream(context.Background(), tc.token, tc.chanID, tc.subtopic, ws.NewStream(nil, ws.StreamFilter{}))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, id, fmt.Sprintf("%s: expected non-empty stream id", tc.desc))
+		}
+	}
+}
+
+func TestUnsubscribeStream(t *testing.T) {
+	thingsClient := thmock.NewThingsService(map[string]string{userID: chanID}, nil)
+	svc, pubsub := newService(thingsClient)
+
+	cases := []struct {
+		desc   string
+		id     string
+		chanID string
+		fail   bool
+		err    error
+	}{
+		{
+			desc:   "unsubscribe stream",
+			id:     id,
+			chanID: chanID,
+			err:    nil,
+		},
+		{
+			desc:   "unsubscribe stream with unsubscribe set to fail",
+			id:     id,
+			chanID: chanID,
+			fail:   true,
+			err:    ws.ErrFailedUnsubscribe,
+		},
+		{
+			desc:   "unsubscribe stream with empty id",
+			id:     "",
+			chanID: chanID,
+			err:    ws.ErrEmptyID,
+		},
+		{
+			desc:   "unsubscribe stream with empty channel",
+			id:     id,
+			chanID: "",
+			err:    ws.ErrEmptyID,
+		},
+	}
+
+	for _, tc := range cases {
+		pubsub.SetFail(tc.fail)
+		err := svc.UnsubscribeStream(context.Background(), tc.id, tc.chanID, "")
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,8 +12,12 @@ import (
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	authmocks "github.com/MainfluxLabs/mainflux/users/mocks"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
@@ -25,13 +30,17 @@ const (
 	id       = "1"
 	thingKey = "c02ff576-ccd5-40f6-ba5f-c85377aad529"
 	protocol = "ws"
+	token    = "token"
+	userID   = "user"
+	email    = "user@example.com"
 )
 
 var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
 
 func newService(tc mainflux.ThingsServiceClient) (ws.Service, mocks.MockPubSub) {
 	pubsub := mocks.NewPubSub()
-	return ws.New(tc, pubsub), pubsub
+	auth := authmocks.NewAuthService(map[string]users.User{token: {ID: userID, Email: email}})
+	return ws.New(tc, auth, pubsub, uuid.NewMock()), pubsub
 }
 
 func newHTTPServer(svc ws.Service) *httptest.Server {
@@ -75,7 +84,7 @@ func handshake(tsURL, chanID, subtopic, thingKey string, addHeader bool) (*webso
 }
 
 func TestHandshake(t *testing.T) {
-	thingsClient := thmocks.NewThingsService(map[string]string{thingKey: chanID},nil)
+	thingsClient := thmocks.NewThingsService(map[string]string{thingKey: chanID}, nil)
 	svc, _ := newService(thingsClient)
 	ts := newHTTPServer(svc)
 	defer ts.Close()
@@ -185,3 +194,123 @@ func TestHandshake(t *testing.T) {
 		}
 	}
 }
+
+func TestStream(t *testing.T) {
+	thingsClient := thmocks.NewThingsService(map[string]string{userID: chanID}, nil)
+	svc, _ := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+	}{
+		{
+			desc:   "open stream with token in header",
+			url:    fmt.Sprintf("%s/channels/%s/stream", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "open stream with token as query parameter",
+			url:    fmt.Sprintf("%s/channels/%s/stream?authorization=%s", ts.URL, chanID, token),
+			status: http.StatusOK,
+		},
+		{
+			desc:   "open stream of subtopic with filters",
+			url:    fmt.Sprintf("%s/channels/%s/stream/room/*?publisher=%s&name=temp", ts.URL, chanID, id),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "open stream of subtopic with invalid name",
+			url:    fmt.Sprintf("%s/channels/%s/stream/sub/a*b", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "open stream of channel owned by other user",
+			url:    fmt.Sprintf("%s/channels/%s/stream", ts.URL, id),
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "open stream with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/stream", ts.URL, chanID),
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "open stream without token",
+			url:    fmt.Sprintf("%s/channels/%s/stream", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, tc.url, nil)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		if tc.token != "" {
+			req.Header.Set("Authorization", apiutil.BearerPrefix+tc.token)
+		}
+
+		res, err := ts.Client().Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code '%d' got '%d'\n", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			ct := res.Header.Get("Content-Type")
+			assert.Equal(t, "text/event-stream", ct, fmt.Sprintf("%s: expected content type text/event-stream got %s\n", tc.desc, ct))
+		}
+		cancel()
+		res.Body.Close()
+	}
+}
+
+func TestStreamWS(t *testing.T) {
+	thingsClient := thmocks.NewThingsService(map[string]string{userID: chanID}, nil)
+	svc, _ := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	u.Scheme = protocol
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		code   int
+	}{
+		{
+			desc:   "open WS stream of owned channel",
+			chanID: chanID,
+			token:  token,
+			code:   websocket.CloseNormalClosure,
+		},
+		{
+			desc:   "open WS stream of channel owned by other user",
+			chanID: id,
+			token:  token,
+			code:   websocket.ClosePolicyViolation,
+		},
+	}
+
+	for _, tc := range cases {
+		header := http.Header{}
+		header.Set("Authorization", apiutil.BearerPrefix+tc.token)
+		conn, res, err := websocket.DefaultDialer.Dial(fmt.Sprintf("%s/channels/%s/stream", u, tc.chanID), header)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode, fmt.Sprintf("%s: expected status code '%d' got '%d'\n", tc.desc, http.StatusSwitchingProtocols, res.StatusCode))
+
+		if tc.code == websocket.CloseNormalClosure {
+			err = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		}
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, tc.code), fmt.Sprintf("%s: expected close code %d got %s\n", tc.desc, tc.code, err))
+		conn.Close()
+	}
+}
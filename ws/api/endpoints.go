@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/go-zoo/bone"
@@ -139,11 +140,12 @@ func listen(conn *websocket.Conn, msgs chan<- []byte) {
 func process(svc ws.Service, req connReq, msgs <-chan []byte) {
 	for msg := range msgs {
 		m := messaging.Message{
-			Channel:  req.chanID,
-			Subtopic: req.subtopic,
-			Protocol: "websocket",
-			Payload:  msg,
-			Created:  time.Now().UnixNano(),
+			Channel:    req.chanID,
+			Subtopic:   req.subtopic,
+			Protocol:   "websocket",
+			Payload:    msg,
+			Created:    time.Now().UnixNano(),
+			RemoteAddr: req.conn.RemoteAddr().String(),
 		}
 		svc.Publish(context.Background(), req.thingKey, m)
 	}
@@ -155,13 +157,17 @@ func process(svc ws.Service, req connReq, msgs <-chan []byte) {
 func encodeError(w http.ResponseWriter, err error) {
 	statusCode := http.StatusUnauthorized
 
-	switch err {
-	case ws.ErrEmptyID, ws.ErrEmptyTopic:
+	switch {
+	case err == ws.ErrEmptyID, err == ws.ErrEmptyTopic:
 		statusCode = http.StatusBadRequest
-	case errUnauthorizedAccess:
+	case err == errUnauthorizedAccess:
 		statusCode = http.StatusForbidden
-	case errMalformedSubtopic, apiutil.ErrMalformedEntity:
+	case err == errMalformedSubtopic, err == apiutil.ErrMalformedEntity:
 		statusCode = http.StatusBadRequest
+	case err == apiutil.ErrBearerToken, errors.Contains(err, errors.ErrAuthentication):
+		statusCode = http.StatusUnauthorized
+	case errors.Contains(err, errors.ErrAuthorization):
+		statusCode = http.StatusForbidden
 	default:
 		statusCode = http.StatusNotFound
 	}
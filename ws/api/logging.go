@@ -77,3 +77,37 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, thingKey, chanID,
 
 	return lm.svc.Unsubscribe(ctx, thingKey, chanID, subtopic)
 }
+
+func (lm *loggingMiddleware) SubscribeStream(ctx context.Context, token, chanID, subtopic string, h messaging.MessageHandler) (id string, err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method subscribe_stream to channel %s took %s to complete", destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SubscribeStream(ctx, token, chanID, subtopic, h)
+}
+
+func (lm *loggingMiddleware) UnsubscribeStream(ctx context.Context, id, chanID, subtopic string) (err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method unsubscribe_stream %s from channel %s took %s to complete", id, destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnsubscribeStream(ctx, id, chanID, subtopic)
+}
@@ -57,3 +57,21 @@ func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, thingKey, chanID,
 
 	return mm.svc.Unsubscribe(ctx, thingKey, chanID, subtopic)
 }
+
+func (mm *metricsMiddleware) SubscribeStream(ctx context.Context, token, chanID, subtopic string, h messaging.MessageHandler) (string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "subscribe_stream").Add(1)
+		mm.latency.With("method", "subscribe_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SubscribeStream(ctx, token, chanID, subtopic, h)
+}
+
+func (mm *metricsMiddleware) UnsubscribeStream(ctx context.Context, id, chanID, subtopic string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unsubscribe_stream").Add(1)
+		mm.latency.With("method", "unsubscribe_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UnsubscribeStream(ctx, id, chanID, subtopic)
+}
@@ -3,7 +3,10 @@
 
 package api
 
-import "github.com/gorilla/websocket"
+import (
+	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/gorilla/websocket"
+)
 
 type connReq struct {
 	thingKey string
@@ -11,3 +14,10 @@ type connReq struct {
 	subtopic string
 	conn     *websocket.Conn
 }
+
+type streamReq struct {
+	token    string
+	chanID   string
+	subtopic string
+	filter   ws.StreamFilter
+}
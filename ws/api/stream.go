@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	publisherKey = "publisher"
+	nameKey      = "name"
+
+	sseContentType = "text/event-stream"
+)
+
+var streamPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/stream(/[^?]*)?(\?.*)?$`)
+
+// stream serves the live messages of the channel to its owner, using
+// WebSocket if requested by the client and Server-Sent Events otherwise.
+func stream(svc ws.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeStreamRequest(r)
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		if websocket.IsWebSocketUpgrade(r) {
+			streamWS(svc, req, w, r)
+			return
+		}
+		streamSSE(svc, req, w, r)
+	}
+}
+
+func streamSSE(svc ws.Service, req streamReq, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Messages are written only after the response headers.
+	sw := &sseWriter{w: w, flusher: flusher}
+	sw.mu.Lock()
+	id, err := svc.SubscribeStream(r.Context(), req.token, req.chanID, req.subtopic, ws.NewStream(sw, req.filter))
+	if err != nil {
+		sw.closed = true
+		sw.mu.Unlock()
+		encodeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", sseContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	sw.mu.Unlock()
+
+	logger.Debug(fmt.Sprintf("Successfully opened SSE stream on channel %s", req.chanID))
+	<-r.Context().Done()
+
+	if err := svc.UnsubscribeStream(context.Background(), id, req.chanID, req.subtopic); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to unsubscribe SSE stream: %s", err))
+	}
+}
+
+func streamWS(svc ws.Service, req streamReq, w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err.Error()))
+		return
+	}
+
+	sw := &wsWriter{conn: conn}
+	id, err := svc.SubscribeStream(context.Background(), req.token, req.chanID, req.subtopic, ws.NewStream(sw, req.filter))
+	if err != nil {
+		msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error())
+		sw.mu.Lock()
+		conn.WriteMessage(websocket.CloseMessage, msg)
+		sw.mu.Unlock()
+		sw.Close()
+		return
+	}
+
+	logger.Debug(fmt.Sprintf("Successfully upgraded stream to WS on channel %s", req.chanID))
+
+	// The stream is read only, so reading is used to detect the closed connection.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			break
+		}
+	}
+
+	if err := svc.UnsubscribeStream(context.Background(), id, req.chanID, req.subtopic); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to unsubscribe WS stream: %s", err))
+	}
+}
+
+func decodeStreamRequest(r *http.Request) (streamReq, error) {
+	token := apiutil.ExtractBearerToken(r)
+	if token == "" {
+		tokens := bone.GetQuery(r, "authorization")
+		if len(tokens) == 0 {
+			logger.Debug("Missing authorization token.")
+			return streamReq{}, apiutil.ErrBearerToken
+		}
+		token = tokens[0]
+	}
+
+	streamParts := streamPartRegExp.FindStringSubmatch(r.RequestURI)
+	if len(streamParts) < 2 {
+		logger.Warn("Empty channel id or malformed url")
+		return streamReq{}, apiutil.ErrMalformedEntity
+	}
+
+	subtopic, err := parseSubTopic(streamParts[2])
+	if err != nil {
+		return streamReq{}, err
+	}
+
+	publisher, err := apiutil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return streamReq{}, err
+	}
+
+	name, err := apiutil.ReadStringQuery(r, nameKey, "")
+	if err != nil {
+		return streamReq{}, err
+	}
+
+	req := streamReq{
+		token:    token,
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+		filter: ws.StreamFilter{
+			Publisher: publisher,
+			Name:      name,
+		},
+	}
+
+	return req, nil
+}
+
+var _ ws.StreamWriter = (*sseWriter)(nil)
+
+type sseWriter struct {
+	mu      sync.Mutex
+	closed  bool
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// Write writes the payload as a single event, prefixing each of its
+// lines with the data field name.
+func (sw *sseWriter) Write(payload []byte) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if sw.closed {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, line := range bytes.Split(payload, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+
+	if _, err := sw.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	sw.flusher.Flush()
+
+	return nil
+}
+
+// Close stops writing to the response, which is ended by the request
+// context.
+func (sw *sseWriter) Close() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.closed = true
+
+	return nil
+}
+
+var _ ws.StreamWriter = (*wsWriter)(nil)
+
+type wsWriter struct {
+	mu   sync.Mutex
+	once sync.Once
+	conn *websocket.Conn
+}
+
+func (sw *wsWriter) Write(payload []byte) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	return sw.conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+func (sw *wsWriter) Close() error {
+	var err error
+	sw.once.Do(func() {
+		err = sw.conn.Close()
+	})
+
+	return err
+}
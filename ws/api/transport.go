@@ -41,6 +41,8 @@ func MakeHandler(svc ws.Service, l log.Logger) http.Handler {
 	mux := bone.New()
 	mux.GetFunc("/channels/:id/messages", handshake(svc))
 	mux.GetFunc("/channels/:id/messages/*", handshake(svc))
+	mux.GetFunc("/channels/:id/stream", stream(svc))
+	mux.GetFunc("/channels/:id/stream/*", stream(svc))
 	mux.GetFunc("/version", mainflux.Health(protocol))
 	mux.Handle("/metrics", promhttp.Handler())
 
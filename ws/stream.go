@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+// StreamFilter represents the filter applied to the messages of a user stream.
+type StreamFilter struct {
+	Publisher string
+	Name      string
+}
+
+// StreamWriter delivers the messages of a stream to the user.
+type StreamWriter interface {
+	// Write sends the message to the user.
+	Write(payload []byte) error
+
+	// Close terminates the stream.
+	Close() error
+}
+
+var _ messaging.MessageHandler = (*stream)(nil)
+
+type stream struct {
+	writer      StreamWriter
+	filter      StreamFilter
+	transformer transformers.Transformer
+}
+
+// NewStream returns the message handler which writes the messages matching
+// the filter to the stream writer. Messages are written as normalized SenML
+// records, and only the records with the filtered name are kept. Messages
+// which aren't SenML are written as published, unless filtered by name.
+func NewStream(w StreamWriter, f StreamFilter) messaging.MessageHandler {
+	return &stream{
+		writer:      w,
+		filter:      f,
+		transformer: senml.New(senml.JSON),
+	}
+}
+
+func (s *stream) Handle(msg messaging.Message) error {
+	if s.filter.Publisher != "" && msg.GetPublisher() != s.filter.Publisher {
+		return nil
+	}
+
+	m, err := s.transformer.Transform(msg)
+	if err != nil {
+		if s.filter.Name != "" {
+			return nil
+		}
+		return s.writer.Write(msg.GetPayload())
+	}
+
+	var records []senml.Message
+	for _, r := range m.([]senml.Message) {
+		if s.filter.Name == "" || r.Name == s.filter.Name {
+			records = append(records, r)
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	return s.writer.Write(payload)
+}
+
+func (s *stream) Cancel() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamWriter struct {
+	payloads [][]byte
+	closed   bool
+}
+
+func (sw *streamWriter) Write(payload []byte) error {
+	sw.payloads = append(sw.payloads, payload)
+	return nil
+}
+
+func (sw *streamWriter) Close() error {
+	sw.closed = true
+	return nil
+}
+
+func TestStreamHandle(t *testing.T) {
+	senml := messaging.Message{
+		Channel:   chanID,
+		Publisher: id,
+		Payload:   []byte(`[{"bn":"dev:","n":"temp","t":10,"v":21.5},{"n":"hum","t":10,"v":40}]`),
+	}
+	other := senml
+	other.Publisher = "2"
+	raw := messaging.Message{
+		Channel:   chanID,
+		Publisher: id,
+		Payload:   []byte("on\noff"),
+	}
+
+	cases := []struct {
+		desc     string
+		filter   ws.StreamFilter
+		msg      messaging.Message
+		expected []string
+	}{
+		{
+			desc:     "handle SenML message without filter",
+			msg:      senml,
+			expected: []string{`[{"channel":"1","publisher":"1","name":"dev:temp","time":10,"value":21.5},{"channel":"1","publisher":"1","name":"dev:hum","time":10,"value":40}]`},
+		},
+		{
+			desc:     "handle SenML message filtered by name",
+			filter:   ws.StreamFilter{Name: "dev:hum"},
+			msg:      senml,
+			expected: []string{`[{"channel":"1","publisher":"1","name":"dev:hum","time":10,"value":40}]`},
+		},
+		{
+			desc:     "handle SenML message filtered by non-matching name",
+			filter:   ws.StreamFilter{Name: "pressure"},
+			msg:      senml,
+			expected: nil,
+		},
+		{
+			desc:     "handle SenML message filtered by publisher",
+			filter:   ws.StreamFilter{Publisher: id},
+			msg:      other,
+			expected: nil,
+		},
+		{
+			desc:     "handle non-SenML message without filter",
+			msg:      raw,
+			expected: []string{"on\noff"},
+		},
+		{
+			desc:     "handle non-SenML message filtered by name",
+			filter:   ws.StreamFilter{Name: "temp"},
+			msg:      raw,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		w := &streamWriter{}
+		h := ws.NewStream(w, tc.filter)
+		err := h.Handle(tc.msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		var payloads []string
+		for _, p := range w.payloads {
+			payloads = append(payloads, string(p))
+		}
+		assert.Equal(t, tc.expected, payloads, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.expected, payloads))
+
+		err = h.Cancel()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.True(t, w.closed, fmt.Sprintf("%s: expected closed stream writer", tc.desc))
+	}
+}